@@ -0,0 +1,254 @@
+// Command admin assigns plans and overrides limits of users directly in the DB,
+// every change is recorded in the limits audit trail like the admin API does.
+//
+// Usage:
+//
+//	admin plans
+//	admin show -user 42
+//	admin assign-plan -user 42 -plan pro
+//	admin override -user 42 -active-reminders 500 -monthly-sent-reminders none
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"remindme/internal/config"
+	c "remindme/internal/core/domain/common"
+	dl "remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	assignplan "remindme/internal/core/services/assign_plan"
+	getuserplan "remindme/internal/core/services/get_user_plan"
+	listplans "remindme/internal/core/services/list_plans"
+	overridelimits "remindme/internal/core/services/override_limits"
+	"remindme/internal/db"
+	uow "remindme/internal/db/unit_of_work"
+	dbuser "remindme/internal/db/user"
+	"remindme/internal/http/handlers/response"
+	"remindme/internal/implementations/logging"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// NO_LIMIT removes a limit when passed as a value to the override command.
+const NO_LIMIT = "none"
+
+type app struct {
+	log  dl.Logger
+	pool *pgxpool.Pool
+	hash *db.TokenHasher
+	now  func() time.Time
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fail(err)
+	}
+	log, err := logging.NewZapLogger(logging.Options{Level: "warn"})
+	if err != nil {
+		fail(err)
+	}
+	defer log.Sync()
+	pool, err := pgxpool.Connect(context.Background(), cfg.PostgresqlURL)
+	if err != nil {
+		fail(err)
+	}
+	defer pool.Close()
+
+	a := &app{
+		log:  log,
+		pool: pool,
+		hash: db.NewTokenHasher(cfg.Secret),
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "plans":
+		err = a.listPlans(args)
+	case "show":
+		err = a.showUserPlan(args)
+	case "assign-plan":
+		err = a.assignPlan(args)
+	case "override":
+		err = a.overrideLimits(args)
+	default:
+		usage()
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func (a *app) listPlans(args []string) error {
+	flags := flag.NewFlagSet("plans", flag.ExitOnError)
+	flags.Parse(args)
+
+	service := listplans.New(a.log, dbuser.NewPgxPlanRepository(a.pool))
+	result, err := service.Run(context.Background(), listplans.Input{})
+	if err != nil {
+		return err
+	}
+	plans := make([]response.Plan, len(result.Plans))
+	for i, p := range result.Plans {
+		plans[i].FromDomainType(p)
+	}
+	return print(plans)
+}
+
+func (a *app) showUserPlan(args []string) error {
+	flags := flag.NewFlagSet("show", flag.ExitOnError)
+	userID := flags.Int64("user", 0, "ID of the user")
+	flags.Parse(args)
+
+	service := getuserplan.New(
+		a.log,
+		dbuser.NewPgxLimitsRepository(a.pool),
+		dbuser.NewPgxLimitsAuditRepository(a.pool),
+	)
+	result, err := service.Run(context.Background(), getuserplan.Input{UserID: user.ID(*userID)})
+	if err != nil {
+		return err
+	}
+	out := struct {
+		UserPlan response.UserPlan           `json:"user_plan"`
+		Audit    []response.LimitsAuditEntry `json:"audit"`
+	}{Audit: make([]response.LimitsAuditEntry, len(result.Audit))}
+	out.UserPlan.FromDomainType(result.UserPlan)
+	for i, entry := range result.Audit {
+		out.Audit[i].FromDomainType(entry)
+	}
+	return print(out)
+}
+
+func (a *app) assignPlan(args []string) error {
+	flags := flag.NewFlagSet("assign-plan", flag.ExitOnError)
+	userID := flags.Int64("user", 0, "ID of the user")
+	plan := flags.String("plan", "", "name of the plan")
+	actor := flags.String("actor", os.Getenv("USER"), "name recorded in the audit trail")
+	flags.Parse(args)
+	if *plan == "" || *actor == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	service := assignplan.New(
+		a.log,
+		uow.NewPgxUnitOfWork(a.pool, a.hash),
+		dbuser.NewPgxPlanRepository(a.pool),
+		a.now,
+	)
+	result, err := service.Run(context.Background(), assignplan.Input{
+		Actor:  *actor,
+		UserID: user.ID(*userID),
+		Plan:   user.PlanName(*plan),
+	})
+	if err != nil {
+		return err
+	}
+	out := response.UserPlan{}
+	out.FromDomainType(result.UserPlan)
+	return print(out)
+}
+
+func (a *app) overrideLimits(args []string) error {
+	flags := flag.NewFlagSet("override", flag.ExitOnError)
+	userID := flags.Int64("user", 0, "ID of the user")
+	actor := flags.String("actor", os.Getenv("USER"), "name recorded in the audit trail")
+	usage := fmt.Sprintf("limit of %%s, %q removes the limit", NO_LIMIT)
+	email := flags.String("email-channels", "", fmt.Sprintf(usage, "email channels"))
+	telegram := flags.String("telegram-channels", "", fmt.Sprintf(usage, "telegram channels"))
+	active := flags.String("active-reminders", "", fmt.Sprintf(usage, "active reminders"))
+	sent := flags.String("monthly-sent-reminders", "", fmt.Sprintf(usage, "reminders sent a month"))
+	perDay := flags.String("reminder-every-per-day", "", fmt.Sprintf(usage, "reminder repetitions a day"))
+	flags.Parse(args)
+	if *actor == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	input := overridelimits.Input{Actor: *actor, UserID: user.ID(*userID)}
+	var err error
+	if input.DoEmailChannelCountUpdate, input.EmailChannelCount, err = parseCount(*email); err != nil {
+		return err
+	}
+	if input.DoTelegramChannelCountUpdate, input.TelegramChannelCount, err = parseCount(*telegram); err != nil {
+		return err
+	}
+	if input.DoActiveReminderCountUpdate, input.ActiveReminderCount, err = parseCount(*active); err != nil {
+		return err
+	}
+	if input.DoMonthlySentReminderCountUpdate, input.MonthlySentReminderCount, err = parseCount(*sent); err != nil {
+		return err
+	}
+	if input.DoReminderEveryPerDayCountUpdate, input.ReminderEveryPerDayCount, err = parseRate(*perDay); err != nil {
+		return err
+	}
+
+	service := overridelimits.New(a.log, uow.NewPgxUnitOfWork(a.pool, a.hash), a.now)
+	result, err := service.Run(context.Background(), input)
+	if err != nil {
+		return err
+	}
+	out := response.UserPlan{}
+	out.FromDomainType(result.UserPlan)
+	return print(out)
+}
+
+func parseCount(value string) (doUpdate bool, count c.Optional[uint32], err error) {
+	if value == "" {
+		return false, count, nil
+	}
+	if value == NO_LIMIT {
+		return true, count, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return false, count, fmt.Errorf("invalid limit %q: %w", value, err)
+	}
+	return true, c.NewOptional(uint32(n), true), nil
+}
+
+func parseRate(value string) (doUpdate bool, rate c.Optional[float64], err error) {
+	if value == "" {
+		return false, rate, nil
+	}
+	if value == NO_LIMIT {
+		return true, rate, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return false, rate, fmt.Errorf("invalid limit %q", value)
+	}
+	return true, c.NewOptional(n, true), nil
+}
+
+func print(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin plans | show | assign-plan | override [flags]")
+	os.Exit(2)
+}
+
+func fail(err error) {
+	switch {
+	case errors.Is(err, user.ErrPlanDoesNotExist), errors.Is(err, user.ErrLimitsDoNotExist):
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	default:
+		fmt.Fprintf(os.Stderr, "unexpected error: %v\n", err)
+	}
+	os.Exit(1)
+}
@@ -9,7 +9,9 @@ import (
 	"remindme/internal/app"
 	"remindme/internal/app/consumers"
 	"remindme/internal/app/deps"
+	"remindme/internal/app/ops"
 	"remindme/internal/app/services"
+	"remindme/internal/http/handlers/health"
 	"syscall"
 	"time"
 
@@ -19,19 +21,30 @@ import (
 func main() {
 	deps, shutdownDeps := deps.InitDeps()
 	services := services.InitServices(deps)
-	shutdownConsumers := consumers.InitConsumers(deps, services)
+	consumers, shutdownConsumers := consumers.InitConsumers(deps, services)
 	httpServer := app.InitHttpServer(deps, services)
+	opsServer := ops.InitServer(
+		deps,
+		deps.Config.OpsPort,
+		health.NewLiveness(),
+		append(
+			ops.DependencyChecks(deps),
+			ops.ConsumerCheck("reminder_ready_for_sending_consumer", consumers.ReminderReadyForSending),
+		)...,
+	)
 
 	go start(httpServer, deps)
+	go ops.Start(opsServer, deps)
 
 	stopCh, closeCh := createChannel()
 	defer closeCh()
 
 	<-stopCh
 
-	shutdownConsumers()
+	drainConsumers(shutdownConsumers, deps)
 	shutdownDeps()
 	shutdown(context.Background(), httpServer)
+	ops.Shutdown(opsServer)
 
 	deps.Logger.Info(context.Background(), "Shutdown completed.")
 }
@@ -60,6 +73,18 @@ func start(server *http.Server, deps *deps.Deps) {
 	}
 }
 
+func drainConsumers(shutdownConsumers func(ctx context.Context), deps *deps.Deps) {
+	ctx, cancel := context.WithTimeout(context.Background(), deps.Config.RabbitmqConsumerShutdownTimeout)
+	defer cancel()
+
+	deps.Logger.Info(
+		ctx,
+		"Draining RabbitMQ consumers.",
+		dl.Entry("timeout", deps.Config.RabbitmqConsumerShutdownTimeout),
+	)
+	shutdownConsumers(ctx)
+}
+
 func shutdown(ctx context.Context, server *http.Server) {
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
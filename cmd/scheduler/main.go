@@ -5,9 +5,17 @@ import (
 	"os"
 	"os/signal"
 	"remindme/internal/app/deps"
+	"remindme/internal/app/ops"
 	"remindme/internal/app/services"
 	"remindme/internal/core/domain/logging"
+	deleteexpiredsessions "remindme/internal/core/services/delete_expired_sessions"
+	deleteinactiveusers "remindme/internal/core/services/delete_inactive_users"
+	purgeaccounts "remindme/internal/core/services/purge_accounts"
+	reconcileusage "remindme/internal/core/services/reconcile_usage"
 	schedulereminders "remindme/internal/core/services/schedule_reminders"
+	leaderelection "remindme/internal/db/leader_election"
+	"remindme/internal/http/handlers/scheduler/health"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -19,8 +27,33 @@ func main() {
 
 	services := services.InitServices(deps)
 
-	ticker := time.NewTicker(deps.Config.RemindersSchedulingPeriod)
-	defer ticker.Stop()
+	elector := leaderelection.NewPgxAdvisoryLockElector(
+		deps.PackageLogger("internal/db/leader_election"),
+		deps.DB,
+		deps.Config.SchedulerLeaderLockID,
+		deps.Config.InstanceID,
+		deps.Config.SchedulerElectionPeriod,
+	)
+	electionCtx, stopElection := context.WithCancel(context.Background())
+	var electionWg sync.WaitGroup
+	electionWg.Add(1)
+	go func() {
+		elector.Run(electionCtx)
+		electionWg.Done()
+	}()
+	defer func() {
+		stopElection()
+		electionWg.Wait()
+	}()
+
+	opsServer := ops.InitServer(
+		deps,
+		deps.Config.SchedulerHealthPort,
+		health.New(elector),
+		ops.DependencyChecks(deps)...,
+	)
+	go ops.Start(opsServer, deps)
+	defer ops.Shutdown(opsServer)
 
 	stopCh, closeCh := createChannel()
 	defer closeCh()
@@ -29,24 +62,135 @@ func main() {
 		context.Background(),
 		"Starting periodic reminder scheduler.",
 		logging.Entry("periodMinutes", (deps.Config.RemindersSchedulingPeriod).Minutes()),
+		logging.Entry("instanceID", deps.Config.InstanceID),
 	)
 
-loop:
+	// Every job runs on its own goroutine, so a long run of one job does not delay the others.
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobsWg sync.WaitGroup
+	startJob := func(name string, period time.Duration, run func(ctx context.Context)) {
+		jobsWg.Add(1)
+		go func() {
+			runJob(jobsCtx, log, elector, name, period, run)
+			jobsWg.Done()
+		}()
+	}
+
+	startJob("schedule_reminders", deps.Config.RemindersSchedulingPeriod, func(ctx context.Context) {
+		result, err := services.ScheduleReminders.Run(ctx, schedulereminders.Input{})
+		if err != nil {
+			log.Error(
+				ctx,
+				"Scheduling service returned an error.",
+				logging.Entry("err", err),
+				logging.Entry("scheduledCount", result.Scheduled),
+				logging.Entry("failedCount", result.Failed),
+			)
+		}
+	})
+	startJob("purge_accounts", deps.Config.AccountPurgePeriod, func(ctx context.Context) {
+		result, err := services.PurgeAccounts.Run(ctx, purgeaccounts.Input{})
+		if err != nil {
+			log.Error(
+				ctx,
+				"Accounts purge service returned an error.",
+				logging.Entry("err", err),
+				logging.Entry("purgedCount", result.Purged),
+				logging.Entry("failedCount", result.Failed),
+			)
+		}
+	})
+	startJob("delete_inactive_users", deps.Config.InactiveUserCleanupPeriod, func(ctx context.Context) {
+		result, err := services.DeleteInactiveUsers.Run(ctx, deleteinactiveusers.Input{})
+		if err != nil {
+			log.Error(
+				ctx,
+				"Inactive users cleanup service returned an error.",
+				logging.Entry("err", err),
+				logging.Entry("deletedCount", result.Deleted),
+			)
+		}
+	})
+	startJob("delete_expired_sessions", deps.Config.SessionCleanupPeriod, func(ctx context.Context) {
+		result, err := services.DeleteExpiredSessions.Run(ctx, deleteexpiredsessions.Input{})
+		if err != nil {
+			log.Error(
+				ctx,
+				"Expired sessions cleanup service returned an error.",
+				logging.Entry("err", err),
+				logging.Entry("deletedCount", result.Deleted),
+			)
+		}
+	})
+	startJob("reconcile_usage", deps.Config.UsageReconciliationPeriod, func(ctx context.Context) {
+		result, err := services.ReconcileUsage.Run(ctx, reconcileusage.Input{})
+		if err != nil {
+			log.Error(
+				ctx,
+				"Usage reconciliation service returned an error.",
+				logging.Entry("err", err),
+				logging.Entry("userCount", result.Users),
+			)
+		}
+	})
+
+	<-stopCh
+	log.Info(context.Background(), "Stopping periodic reminder scheduler.")
+	stopJobs()
+	jobsWg.Wait()
+}
+
+// runJob runs the job every period until ctx is done. The leadership is verified right before
+// every run and the run is canceled as soon as the instance loses the leadership.
+func runJob(
+	ctx context.Context,
+	log logging.Logger,
+	elector *leaderelection.PgxAdvisoryLockElector,
+	name string,
+	period time.Duration,
+	run func(ctx context.Context),
+) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case <-stopCh:
-			log.Info(context.Background(), "Stopping periodic reminde scheduler.")
-			break loop
+		case <-ctx.Done():
+			return
 		case <-ticker.C:
-			log.Info(context.Background(), "Launching reminders scheduling service.")
-			_, err := services.ScheduleReminders.Run(context.Background(), schedulereminders.Input{})
-			if err != nil {
-				log.Error(context.Background(), "Scheduling service returned an error.", logging.Entry("err", err))
-			}
 		}
+
+		status := elector.VerifyLeadership(ctx)
+		if !status.IsLeader {
+			log.Info(
+				ctx,
+				"Instance is not the leader, skip the job.",
+				logging.Entry("job", name),
+				logging.Entry("instanceID", status.InstanceID),
+				logging.Entry("leader", status.Leader),
+			)
+			continue
+		}
+		log.Info(ctx, "Launching the job.", logging.Entry("job", name), logging.Entry("instanceID", status.InstanceID))
+		runCtx, cancel := withLeadership(ctx, elector.LeadershipContext())
+		run(runCtx)
+		cancel()
 	}
 }
 
+// withLeadership returns a context which is done when either ctx or leadership is done.
+func withLeadership(ctx context.Context, leadership context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-leadership.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
 func createChannel() (chan os.Signal, func()) {
 	stopCh := make(chan os.Signal, 1)
 	signal.Notify(stopCh, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
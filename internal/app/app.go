@@ -6,32 +6,66 @@ import (
 	"remindme/internal/app/deps"
 	"remindme/internal/app/services"
 	"remindme/internal/core/domain/channel"
+	assignplan "remindme/internal/http/handlers/admin/assign_plan"
+	getuserplan "remindme/internal/http/handlers/admin/get_user_plan"
+	listplans "remindme/internal/http/handlers/admin/list_plans"
+	overridelimits "remindme/internal/http/handlers/admin/override_limits"
 	"remindme/internal/http/handlers/auth"
 	activateuser "remindme/internal/http/handlers/auth/activate_user"
+	confirmemailchange "remindme/internal/http/handlers/auth/confirm_email_change"
 	loginwithemail "remindme/internal/http/handlers/auth/log_in_with_email"
+	loginwithoidc "remindme/internal/http/handlers/auth/log_in_with_oidc"
+	loginwithtwofactor "remindme/internal/http/handlers/auth/log_in_with_two_factor"
 	logout "remindme/internal/http/handlers/auth/log_out"
+	resendactivationtoken "remindme/internal/http/handlers/auth/resend_activation_token"
 	resetpassword "remindme/internal/http/handlers/auth/reset_password"
+	revertemailchange "remindme/internal/http/handlers/auth/revert_email_change"
 	sendpasswordresettoken "remindme/internal/http/handlers/auth/send_password_reset_token"
 	signupanonymously "remindme/internal/http/handlers/auth/sign_up_anonymously"
 	signupwithemail "remindme/internal/http/handlers/auth/sign_up_with_email"
+	startoidclogin "remindme/internal/http/handlers/auth/start_oidc_login"
+	getcalendarfeed "remindme/internal/http/handlers/calendar/get_calendar_feed"
 	"remindme/internal/http/handlers/captcha"
 	createemailchannel "remindme/internal/http/handlers/channels/create_email_channel"
 	createtlgchannel "remindme/internal/http/handlers/channels/create_telegram_channel"
 	listuserchannels "remindme/internal/http/handlers/channels/list_user_channels"
 	verifyemailchannel "remindme/internal/http/handlers/channels/verify_email_channel"
+	httplogging "remindme/internal/http/handlers/logging"
+	httpmetrics "remindme/internal/http/handlers/metrics"
+	bulkupdatereminders "remindme/internal/http/handlers/reminders/bulk_update_reminders"
 	cancelreminder "remindme/internal/http/handlers/reminders/cancel_reminder"
 	createreminder "remindme/internal/http/handlers/reminders/create_reminder"
 	createreminderbynlq "remindme/internal/http/handlers/reminders/create_reminder_by_nlq"
+	importreminders "remindme/internal/http/handlers/reminders/import_reminders"
 	listuserreminders "remindme/internal/http/handlers/reminders/list_user_reminders"
+	pausereminder "remindme/internal/http/handlers/reminders/pause_reminder"
+	resumereminder "remindme/internal/http/handlers/reminders/resume_reminder"
 	updatereminder "remindme/internal/http/handlers/reminders/update_reminder"
 	updatereminderchannels "remindme/internal/http/handlers/reminders/update_reminder_channels"
 	telegram "remindme/internal/http/handlers/telegram"
+	httptracing "remindme/internal/http/handlers/tracing"
+	cancelaccountdeletion "remindme/internal/http/handlers/user/cancel_account_deletion"
 	changepassword "remindme/internal/http/handlers/user/change_password"
+	claimaccount "remindme/internal/http/handlers/user/claim_account"
+	confirmtotp "remindme/internal/http/handlers/user/confirm_totp"
+	createapitoken "remindme/internal/http/handlers/user/create_api_token"
+	createcalendarfeed "remindme/internal/http/handlers/user/create_calendar_feed"
+	disabletotp "remindme/internal/http/handlers/user/disable_totp"
+	enabletotp "remindme/internal/http/handlers/user/enable_totp"
 	"remindme/internal/http/handlers/user/events"
+	exportuserdata "remindme/internal/http/handlers/user/export_user_data"
 	limitforactivereminders "remindme/internal/http/handlers/user/limit_for_active_reminders"
 	limitforchannels "remindme/internal/http/handlers/user/limit_for_channels"
 	limitforsentreminders "remindme/internal/http/handlers/user/limit_for_sent_reminders"
+	listapitokens "remindme/internal/http/handlers/user/list_api_tokens"
+	listusersessions "remindme/internal/http/handlers/user/list_user_sessions"
 	me "remindme/internal/http/handlers/user/me"
+	requestaccountdeletion "remindme/internal/http/handlers/user/request_account_deletion"
+	requestemailchange "remindme/internal/http/handlers/user/request_email_change"
+	revokeapitoken "remindme/internal/http/handlers/user/revoke_api_token"
+	revokecalendarfeed "remindme/internal/http/handlers/user/revoke_calendar_feed"
+	revokeothersessions "remindme/internal/http/handlers/user/revoke_other_sessions"
+	revokesession "remindme/internal/http/handlers/user/revoke_session"
 	updateuser "remindme/internal/http/handlers/user/update_user"
 
 	sentryhttp "github.com/getsentry/sentry-go/http"
@@ -48,20 +82,56 @@ func InitHttpServer(deps *deps.Deps, s *services.Services) *http.Server {
 	authRouter.Method(http.MethodPost, "/signup", signupwithemail.New(s.SignUpWithEmail, isTestMode))
 	authRouter.Method(http.MethodPost, "/signup/anonymously", signupanonymously.New(s.SignUpAnonymously))
 	authRouter.Method(http.MethodPost, "/activate", activateuser.New(s.ActivateUser))
+	authRouter.Method(
+		http.MethodPost,
+		"/activate/resend",
+		resendactivationtoken.New(s.ResendActivationToken, isTestMode),
+	)
 	authRouter.Method(http.MethodPost, "/login", loginwithemail.New(s.LogInWithEmail))
+	authRouter.Method(http.MethodPost, "/login/two_factor", loginwithtwofactor.New(s.LogInWithTwoFactor))
 	authRouter.Method(http.MethodPost, "/logout", logout.New(s.LogOut))
+	authRouter.Method(http.MethodPost, "/oidc/{provider}", startoidclogin.New(s.StartOIDCLogin))
+	authRouter.Method(http.MethodPost, "/oidc/{provider}/callback", loginwithoidc.New(s.LogInWithOIDC))
 	authRouter.Method(
 		http.MethodPost,
 		"/password_reset/token",
 		sendpasswordresettoken.New(s.SendPasswordResetToken, isTestMode),
 	)
 	authRouter.Method(http.MethodPut, "/password_reset", resetpassword.New(s.ResetPassword))
+	authRouter.Method(http.MethodPost, "/email/confirm", confirmemailchange.New(s.ConfirmEmailChange))
+	authRouter.Method(http.MethodPost, "/email/revert", revertemailchange.New(s.RevertEmailChange))
 
 	profileRouter := chi.NewRouter()
 	profileRouter.Use(auth.SetAuthTokenToContext)
 	profileRouter.Method(http.MethodGet, "/me", me.New(s.GetUserBySessionToken))
 	profileRouter.Method(http.MethodPatch, "/me", updateuser.New(s.UpdateUser))
 	profileRouter.Method(http.MethodPut, "/password", changepassword.New(s.ChangePassword))
+	profileRouter.Method(http.MethodPut, "/email", requestemailchange.New(s.RequestEmailChange))
+	profileRouter.Method(http.MethodPost, "/claim", claimaccount.New(s.ClaimAccount, isTestMode))
+	profileRouter.Method(http.MethodPost, "/identities/{provider}", startoidclogin.New(s.LinkOIDCIdentity))
+	profileRouter.Method(
+		http.MethodPost,
+		"/identities/{provider}/callback",
+		loginwithoidc.New(s.CompleteOIDCIdentityLink),
+	)
+	profileRouter.Method(http.MethodPost, "/totp", enabletotp.New(s.EnableTOTP))
+	profileRouter.Method(http.MethodPut, "/totp", confirmtotp.New(s.ConfirmTOTP))
+	profileRouter.Method(http.MethodDelete, "/totp", disabletotp.New(s.DisableTOTP))
+	profileRouter.Method(http.MethodGet, "/sessions", listusersessions.New(s.ListUserSessions))
+	profileRouter.Method(http.MethodDelete, "/sessions", revokeothersessions.New(s.RevokeOtherSessions))
+	profileRouter.Method(http.MethodDelete, "/sessions/{sessionID:[0-9]+}", revokesession.New(s.RevokeSession))
+	profileRouter.Method(http.MethodGet, "/tokens", listapitokens.New(s.ListAPITokens))
+	profileRouter.Method(http.MethodPost, "/tokens", createapitoken.New(s.CreateAPIToken))
+	profileRouter.Method(http.MethodDelete, "/tokens/{tokenID:[0-9]+}", revokeapitoken.New(s.RevokeAPIToken))
+	profileRouter.Method(
+		http.MethodPost,
+		"/calendar",
+		createcalendarfeed.New(s.CreateCalendarFeed, deps.Config.CalendarFeedBaseURL),
+	)
+	profileRouter.Method(http.MethodDelete, "/calendar", revokecalendarfeed.New(s.RevokeCalendarFeed))
+	profileRouter.Method(http.MethodPost, "/deletion", requestaccountdeletion.New(s.RequestAccountDeletion))
+	profileRouter.Method(http.MethodDelete, "/deletion", cancelaccountdeletion.New(s.CancelAccountDeletion))
+	profileRouter.Method(http.MethodGet, "/export", exportuserdata.New(s.ExportUserData))
 	profileRouter.Method(
 		http.MethodGet,
 		"/limit/reminders/active",
@@ -94,29 +164,44 @@ func InitHttpServer(deps *deps.Deps, s *services.Services) *http.Server {
 	reminderRouter.Use(auth.SetAuthTokenToContext)
 	reminderRouter.Method(http.MethodPost, "/", createreminder.New(s.CreateReminder))
 	reminderRouter.Method(http.MethodPost, "/nlq", createreminderbynlq.New(s.CreateReminderByNLQ))
+	reminderRouter.Method(http.MethodPost, "/import", importreminders.New(s.ImportReminders))
+	reminderRouter.Method(http.MethodPost, "/bulk", bulkupdatereminders.New(s.BulkUpdateReminders))
 	reminderRouter.Method(http.MethodGet, "/", listuserreminders.New(s.ListUserReminders))
 	reminderRouter.Method(http.MethodDelete, "/{reminderID:[0-9]+}", cancelreminder.New(s.DeleteReminder))
 	reminderRouter.Method(http.MethodPatch, "/{reminderID:[0-9]+}", updatereminder.New(s.UpdateReminder))
+	reminderRouter.Method(http.MethodPost, "/{reminderID:[0-9]+}/pause", pausereminder.New(s.PauseReminder))
+	reminderRouter.Method(http.MethodPost, "/{reminderID:[0-9]+}/resume", resumereminder.New(s.ResumeReminder))
 	reminderRouter.Method(
 		http.MethodPut,
 		"/{reminderID:[0-9]+}/channels",
 		updatereminderchannels.New(s.UpdateReminderChannels),
 	)
 
+	adminRouter := chi.NewRouter()
+	adminRouter.Use(auth.SetAuthTokenToContext)
+	adminRouter.Method(http.MethodGet, "/plans", listplans.New(s.ListPlans))
+	adminRouter.Method(http.MethodGet, "/users/{userID:[0-9]+}/limits", getuserplan.New(s.GetUserPlan))
+	adminRouter.Method(http.MethodPut, "/users/{userID:[0-9]+}/plan", assignplan.New(s.AssignPlan))
+	adminRouter.Method(http.MethodPatch, "/users/{userID:[0-9]+}/limits", overridelimits.New(s.OverrideLimits))
+
 	telegramRouter := chi.NewRouter()
 	telegramRouter.Method(
 		http.MethodPost,
 		fmt.Sprintf("/updates/{bot}/%s", deps.Config.TelegramURLSecret),
-		telegram.New(deps.Logger, deps.TelegramBotMessageSender, s.VerifyTelegramChannel),
+		telegram.New(deps.PackageLogger("internal/http/handlers/telegram"), deps.TelegramBotMessageSender, s.VerifyTelegramChannel),
 	)
 
 	router := chi.NewRouter()
+	router.Use(httplogging.WithRequestContext)
+	router.Use(httptracing.TraceRequests(deps.Tracer, deps.Config.TelegramURLSecret))
+	router.Use(httpmetrics.MeasureRequests(deps.Metrics, deps.Config.TelegramURLSecret))
 	router.Use(middleware.Recoverer)
 	router.Use(sentryhttp.New(sentryhttp.Options{Repanic: true}).Handle)
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   deps.Config.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{httplogging.REQUEST_ID_HEADER},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -126,10 +211,16 @@ func InitHttpServer(deps *deps.Deps, s *services.Services) *http.Server {
 	router.Mount("/channels", channelsRouter)
 	router.Mount("/reminders", reminderRouter)
 	router.Mount("/telegram", telegramRouter)
+	router.Mount("/admin", adminRouter)
+	router.Method(
+		http.MethodGet,
+		"/calendar/{token}.ics",
+		getcalendarfeed.New(s.GetCalendarFeed, deps.Now),
+	)
 	router.Method(
 		http.MethodGet,
 		"/sse/{sessionToken}",
-		events.New(deps.Logger, deps.SseServer, s.GetUserBySessionToken),
+		events.New(deps.PackageLogger("internal/http/handlers/user/events"), deps.SseServer, s.GetUserBySessionToken),
 	)
 
 	address := fmt.Sprintf("0.0.0.0:%d", deps.Config.Port)
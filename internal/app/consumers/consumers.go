@@ -8,7 +8,10 @@ import (
 	reminderreadyforsending "remindme/internal/rabbitmq/consumers/reminder_ready_for_sending"
 )
 
-func initReminderReadyForSendingConsumer(deps *deps.Deps, services *services.Services) func() {
+func initReminderReadyForSendingConsumer(
+	deps *deps.Deps,
+	services *services.Services,
+) (*reminderreadyforsending.Consumer, func(ctx context.Context)) {
 	rabbitmqChannel, err := deps.Rabbitmq.Channel()
 	if err != nil {
 		deps.Logger.Error(context.Background(), "Could not create RabbitMQ channel.", dl.Entry("err", err))
@@ -17,10 +20,18 @@ func initReminderReadyForSendingConsumer(deps *deps.Deps, services *services.Ser
 
 	queue := deps.Config.RabbitmqReminderReadyQueue
 	reminderReadyForSendingConsumer := reminderreadyforsending.New(
-		deps.Logger,
+		deps.PackageLogger("internal/rabbitmq/consumers/reminder_ready_for_sending"),
+		deps.Tracer,
 		rabbitmqChannel,
 		queue,
 		services.SendReminder,
+		deps.Metrics,
+		reminderreadyforsending.Options{
+			Workers:         deps.Config.RabbitmqConsumerWorkers,
+			Prefetch:        deps.Config.RabbitmqConsumerPrefetch,
+			DeliveryTimeout: deps.Config.RabbitmqConsumerDeliveryTimeout,
+			StatsInterval:   deps.Config.RabbitmqConsumerStatsInterval,
+		},
 	)
 	if err = reminderReadyForSendingConsumer.Consume(); err != nil {
 		deps.Logger.Error(
@@ -32,13 +43,34 @@ func initReminderReadyForSendingConsumer(deps *deps.Deps, services *services.Ser
 		panic(err)
 	}
 
-	return func() { rabbitmqChannel.Close() }
+	return reminderReadyForSendingConsumer, func(ctx context.Context) {
+		if err := reminderReadyForSendingConsumer.Shutdown(ctx); err != nil {
+			deps.Logger.Warning(
+				ctx,
+				"Reminder sending consumer has not been drained gracefully.",
+				dl.Entry("err", err),
+				dl.Entry("queue", queue),
+			)
+		}
+		rabbitmqChannel.Close()
+	}
+}
+
+type Consumers struct {
+	ReminderReadyForSending *reminderreadyforsending.Consumer
 }
 
-func InitConsumers(deps *deps.Deps, services *services.Services) func() {
-	shutdownReminderReadyForSendingConsumer := initReminderReadyForSendingConsumer(deps, services)
+// InitConsumers starts all RabbitMQ consumers, the returned function drains them
+// and waits for in-flight deliveries until ctx is done.
+func InitConsumers(deps *deps.Deps, services *services.Services) (*Consumers, func(ctx context.Context)) {
+	consumers := &Consumers{}
+	var shutdownReminderReadyForSendingConsumer func(ctx context.Context)
+	consumers.ReminderReadyForSending, shutdownReminderReadyForSendingConsumer = initReminderReadyForSendingConsumer(
+		deps,
+		services,
+	)
 
-	return func() {
-		shutdownReminderReadyForSendingConsumer()
+	return consumers, func(ctx context.Context) {
+		shutdownReminderReadyForSendingConsumer(ctx)
 	}
 }
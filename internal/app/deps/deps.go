@@ -3,6 +3,8 @@ package deps
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"remindme/internal/config"
 	"remindme/internal/core/domain/bot"
 	"remindme/internal/core/domain/channel"
@@ -13,12 +15,15 @@ import (
 	duow "remindme/internal/core/domain/unit_of_work"
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services/captcha"
+	"remindme/internal/db"
 	dbchannel "remindme/internal/db/channel"
 	dbreminder "remindme/internal/db/reminder"
 	uow "remindme/internal/db/unit_of_work"
 	dbuser "remindme/internal/db/user"
 	"remindme/internal/implementations/email"
 	"remindme/internal/implementations/logging"
+	"remindme/internal/implementations/metrics"
+	"remindme/internal/implementations/oidc"
 	passwordhasher "remindme/internal/implementations/password_hasher"
 	passwordresetter "remindme/internal/implementations/password_resetter"
 	randomstringgenerator "remindme/internal/implementations/random_string_generator"
@@ -27,26 +32,43 @@ import (
 	remindernlqparser "remindme/internal/implementations/reminder_nlq_parser"
 	remindersender "remindme/internal/implementations/reminder_sender"
 	telegrambotmessagesender "remindme/internal/implementations/telegram_bot_message_sender"
+	"remindme/internal/implementations/totp"
 	"remindme/internal/rabbitmq"
 	reminderscheduler "remindme/internal/rabbitmq/publishers/reminder_scheduler"
+	"remindme/internal/tracing"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/getsentry/sentry-go"
 	"github.com/go-redis/redis/v9"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/r3labs/sse/v2"
 	"github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	GOOGLE_OIDC_PROVIDER = user.OIDCProviderName("google")
+	GITHUB_OIDC_PROVIDER = user.OIDCProviderName("github")
+)
+
 type Deps struct {
 	Config    *config.Config
 	AwsConfig aws.Config
+	// Logger is the root logger, components are given loggers of their packages.
 	Logger    dl.Logger
+	zapLogger *logging.ZapLogger
+
+	// Metrics are registered in the default Prometheus registry.
+	Metrics *metrics.Prometheus
+
+	Tracer *tracing.Tracer
 
 	DB        *pgxpool.Pool
 	Redis     *redis.Client
@@ -58,9 +80,21 @@ type Deps struct {
 	UnitOfWork         duow.UnitOfWork
 	UserRepository     user.UserRepository
 	LimitsRepository   user.LimitsRepository
+	PlanRepository     user.PlanRepository
 	SessionRepository  user.SessionRepository
 	ChannelRepository  channel.Repository
 	ReminderRepository reminder.ReminderRepository
+	UsageRepository    reminder.UsageRepository
+
+	ExternalIdentityRepository   user.ExternalIdentityRepository
+	OIDCAuthorizationRepository  user.OIDCAuthorizationRepository
+	TOTPRepository               user.TOTPRepository
+	TwoFactorChallengeRepository user.TwoFactorChallengeRepository
+	APITokenRepository           user.APITokenRepository
+	AccountDeletionRepository    user.AccountDeletionRepository
+	EmailChangeRepository        user.EmailChangeRepository
+	LimitsAuditRepository        user.LimitsAuditRepository
+	CalendarFeedRepository       user.CalendarFeedRepository
 
 	RateLimiter drl.RateLimiter
 
@@ -71,12 +105,20 @@ type Deps struct {
 	UserActivationTokenSender    user.ActivationTokenSender
 	UserIdentityGenerator        user.IdentityGenerator
 	UserSessionTokenGenerator    user.SessionTokenGenerator
+	SessionExpiry                user.SessionExpiry
 	PasswordHasher               user.PasswordHasher
 	PasswordResetter             user.PasswordResetter
 	PasswordResetTokenSender     user.PasswordResetTokenSender
 	CaptchaValidator             captcha.CaptchaValidator
-	DefaultUserLimits            user.Limits
 	DefaultAnonymousUserLimits   user.Limits
+	OIDCProviders                map[user.OIDCProviderName]user.OIDCProvider
+	OIDCAuthorizationGenerator   user.OIDCAuthorizationGenerator
+	TOTPAuthenticator            user.TOTPAuthenticator
+	TwoFactorGenerator           user.TwoFactorGenerator
+	APITokenGenerator            user.APITokenGenerator
+	EmailChangeTokenGenerator    user.EmailChangeTokenGenerator
+	EmailChangeSender            user.EmailChangeSender
+	CalendarFeedTokenGenerator   user.CalendarFeedTokenGenerator
 
 	ChannelVerificationTokenGenerator channel.VerificationTokenGenerator
 
@@ -89,20 +131,36 @@ func InitDeps() (*Deps, func()) {
 	deps := &Deps{}
 
 	deps.initConfig()
-	deps.initAwsConfig()
+	deps.Now = func() time.Time { return time.Now().UTC() }
 
 	closeLogger := deps.initLogger()
+	deps.initMetrics()
+	shutdownTracer := deps.initTracer()
+	deps.initAwsConfig()
 	closePgxPool := deps.initPgxPool()
 	closeRedisClient := deps.initRedisClient()
 	closeRabbitmqConn := deps.initRabbitmqConnection()
 	closeSseServer := deps.initSseServer()
 
-	deps.UnitOfWork = uow.NewPgxUnitOfWork(deps.DB)
-	deps.UserRepository = dbuser.NewPgxRepository(deps.DB)
-	deps.LimitsRepository = dbuser.NewPgxLimitsRepository(deps.DB)
-	deps.SessionRepository = dbuser.NewPgxSessionRepository(deps.DB)
-	deps.ChannelRepository = dbchannel.NewPgxChannelRepository(deps.DB)
-	deps.ReminderRepository = dbreminder.NewPgxReminderRepository(deps.DB)
+	tokenHasher := db.NewTokenHasher(deps.Config.Secret)
+	tracedDB := db.NewTracingPool(deps.DB, deps.Tracer)
+	deps.UnitOfWork = uow.NewPgxUnitOfWork(tracedDB, tokenHasher)
+	deps.UserRepository = dbuser.NewPgxRepository(tracedDB, tokenHasher)
+	deps.LimitsRepository = dbuser.NewPgxLimitsRepository(tracedDB)
+	deps.PlanRepository = dbuser.NewPgxPlanRepository(tracedDB)
+	deps.SessionRepository = dbuser.NewPgxSessionRepository(tracedDB, tokenHasher)
+	deps.ChannelRepository = dbchannel.NewPgxChannelRepository(tracedDB, tokenHasher)
+	deps.ReminderRepository = dbreminder.NewPgxReminderRepository(tracedDB)
+	deps.UsageRepository = dbreminder.NewPgxUsageRepository(tracedDB)
+	deps.ExternalIdentityRepository = dbuser.NewPgxExternalIdentityRepository(tracedDB)
+	deps.OIDCAuthorizationRepository = dbuser.NewPgxOIDCAuthorizationRepository(tracedDB, tokenHasher)
+	deps.TOTPRepository = dbuser.NewPgxTOTPRepository(tracedDB, db.NewSecretBox(deps.Config.Secret), tokenHasher)
+	deps.TwoFactorChallengeRepository = dbuser.NewPgxTwoFactorChallengeRepository(tracedDB, tokenHasher)
+	deps.APITokenRepository = dbuser.NewPgxAPITokenRepository(tracedDB, tokenHasher)
+	deps.AccountDeletionRepository = dbuser.NewPgxAccountDeletionRepository(tracedDB)
+	deps.EmailChangeRepository = dbuser.NewPgxEmailChangeRepository(tracedDB, tokenHasher)
+	deps.LimitsAuditRepository = dbuser.NewPgxLimitsAuditRepository(tracedDB)
+	deps.CalendarFeedRepository = dbuser.NewPgxCalendarFeedRepository(tracedDB, tokenHasher)
 
 	deps.EmailSender = email.NewEmailSender(
 		deps.AwsConfig,
@@ -112,14 +170,21 @@ func InitDeps() (*Deps, func()) {
 		deps.Config.AwsEmailPasswordResetTemplate,
 		deps.Config.AwsEmailPasswordResetBaseUrl,
 		deps.Config.AwsEmailActivateChannelTemplate,
+		deps.Config.AwsEmailChangeEmailTemplate,
+		deps.Config.AwsEmailChangeEmailBaseUrl,
+		deps.Config.AwsEmailEmailChangedTemplate,
+		deps.Config.AwsEmailRevertEmailBaseUrl,
 	)
 
-	deps.Now = func() time.Time { return time.Now().UTC() }
-	deps.RateLimiter = ratelimiter.NewRedis(deps.Redis, deps.Logger, deps.Now)
+	deps.RateLimiter = ratelimiter.NewRedis(deps.Redis, deps.PackageLogger("internal/implementations/rate_limiter"), deps.Metrics, deps.Now)
 	deps.UserActivationTokenGenerator = randomstringgenerator.NewGenerator()
 	deps.UserActivationTokenSender = deps.EmailSender
 	deps.UserIdentityGenerator = randomstringgenerator.NewGenerator()
 	deps.UserSessionTokenGenerator = randomstringgenerator.NewGenerator()
+	deps.SessionExpiry = user.SessionExpiry{
+		Idle:     deps.Config.SessionIdleTimeout,
+		Absolute: deps.Config.SessionAbsoluteTimeout,
+	}
 	deps.PasswordHasher = passwordhasher.NewBcrypt(deps.Config.Secret, deps.Config.BcryptHasherCost)
 	deps.PasswordResetter = passwordresetter.NewHMAC(
 		deps.Config.Secret,
@@ -128,13 +193,6 @@ func InitDeps() (*Deps, func()) {
 	)
 	deps.PasswordResetTokenSender = deps.EmailSender
 	deps.CaptchaValidator = deps.initCaptchaValidator()
-	deps.DefaultUserLimits = user.Limits{
-		EmailChannelCount:        c.NewOptional(uint32(1), true),
-		TelegramChannelCount:     c.NewOptional(uint32(1), true),
-		ActiveReminderCount:      c.NewOptional(uint32(10), true),
-		MonthlySentReminderCount: c.NewOptional(uint32(100), true),
-		ReminderEveryPerDayCount: c.NewOptional(1.0, true),
-	}
 	deps.DefaultAnonymousUserLimits = user.Limits{
 		EmailChannelCount:        c.NewOptional(uint32(1), true),
 		TelegramChannelCount:     c.NewOptional(uint32(1), true),
@@ -143,6 +201,15 @@ func InitDeps() (*Deps, func()) {
 		ReminderEveryPerDayCount: c.NewOptional(1.0, true),
 	}
 
+	deps.OIDCProviders = deps.initOIDCProviders()
+	deps.OIDCAuthorizationGenerator = randomstringgenerator.NewGenerator()
+	deps.TOTPAuthenticator = totp.New(deps.Config.TotpIssuer)
+	deps.TwoFactorGenerator = randomstringgenerator.NewGenerator()
+	deps.APITokenGenerator = randomstringgenerator.NewGenerator()
+	deps.EmailChangeTokenGenerator = randomstringgenerator.NewGenerator()
+	deps.CalendarFeedTokenGenerator = randomstringgenerator.NewGenerator()
+	deps.EmailChangeSender = deps.EmailSender
+
 	deps.ChannelVerificationTokenGenerator = randomstringgenerator.NewGenerator()
 
 	closeReminderScheduler := deps.initRabbitmqReminderScheduler()
@@ -151,10 +218,12 @@ func InitDeps() (*Deps, func()) {
 		deps.Config.TelegramBaseURL,
 		deps.Config.TelegramTokenByBot(),
 		deps.Config.TelegramRequestTimeout,
+		tracing.NewTransport(deps.Tracer, nil),
 	)
 
 	deps.ReminderSender = remindersender.New(
-		deps.Logger,
+		deps.PackageLogger("internal/implementations/reminder_sender"),
+		deps.Metrics,
 		deps.ChannelRepository,
 		deps.SseServer,
 		remindersender.NewEmail(deps.AwsConfig, deps.Config.AwsEmailSender, deps.Config.AwsEmailReminderTemplate),
@@ -174,6 +243,7 @@ func InitDeps() (*Deps, func()) {
 			closePgxPool,
 			closeLogger,
 			flushSentry,
+			shutdownTracer,
 		}
 
 		var wg sync.WaitGroup
@@ -209,6 +279,9 @@ func (deps *Deps) initAwsConfig() {
 				"",
 			),
 		),
+		awsConfig.WithHTTPClient(
+			&http.Client{Transport: tracing.NewTransport(deps.Tracer, awshttp.NewBuildableClient().GetTransport())},
+		),
 		awsConfig.WithRetryer(func() aws.Retryer {
 			return retry.AddWithMaxAttempts(
 				retry.AddWithMaxBackoffDelay(retry.NewStandard(), time.Second*5),
@@ -223,21 +296,102 @@ func (deps *Deps) initAwsConfig() {
 }
 
 func (deps *Deps) initLogger() func() {
-	logger := logging.NewZapLogger()
+	logger, err := logging.NewZapLogger(logging.Options{
+		Level:         deps.Config.LogLevel,
+		PackageLevels: deps.Config.LogPackageLevels,
+		Development:   deps.Config.LogDevelopment,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not create logger: %v", err))
+	}
 	deps.Logger = logger
+	deps.zapLogger = logger
 	return func() { logger.Sync() }
 }
 
+// PackageLogger returns the logger of the package, path is relative to the module
+// and selects the level configured for the package in LOG_PACKAGE_LEVELS.
+func (deps *Deps) PackageLogger(path string) dl.Logger {
+	return deps.zapLogger.Named(path)
+}
+
+func (deps *Deps) initMetrics() {
+	deps.Metrics = metrics.NewPrometheus(prometheus.DefaultRegisterer)
+}
+
+func (deps *Deps) initTracer() func() {
+	log := deps.PackageLogger("internal/tracing")
+	options := tracing.TracerProviderOptions{
+		ServiceName:   deps.Config.TracingServiceName,
+		SampleRatio:   deps.Config.TracingSampleRatio,
+		BatchSize:     deps.Config.TracingBatchSize,
+		QueueSize:     deps.Config.TracingQueueSize,
+		FlushInterval: deps.Config.TracingFlushInterval,
+		Timeout:       deps.Config.TracingExportTimeout,
+	}
+	if deps.Config.OtlpTracesEndpoint == nil {
+		deps.Tracer = tracing.NewTracer(tracing.NewTracerProvider(log, nil, options))
+		deps.Logger.Info(context.Background(), "Trace export is disabled.")
+		return func() {}
+	}
+
+	exporter, err := tracing.NewOTLPExporter(context.Background(), tracing.OTLPExporterOptions{
+		Endpoint: *deps.Config.OtlpTracesEndpoint,
+		Timeout:  deps.Config.TracingExportTimeout,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not create trace exporter: %v", err))
+	}
+	provider := tracing.NewTracerProvider(log, exporter, options)
+	deps.Tracer = tracing.NewTracer(provider)
+	deps.Logger.Info(
+		context.Background(),
+		"Trace export has been successfully initialized.",
+		dl.Entry("sampleRatio", deps.Config.TracingSampleRatio),
+	)
+	return func() {
+		deps.Logger.Info(context.Background(), "Flushing trace spans.")
+		ctx, cancel := context.WithTimeout(context.Background(), deps.Config.TracingExportTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			deps.Logger.Error(context.Background(), "Could not flush trace spans.", dl.Entry("err", err))
+			return
+		}
+		deps.Logger.Info(context.Background(), "Trace spans flushed.")
+	}
+}
+
 func (deps *Deps) initPgxPool() func() {
-	db, err := pgxpool.Connect(context.Background(), deps.Config.PostgresqlURL)
+	poolConfig, err := pgxpool.ParseConfig(deps.Config.PostgresqlURL)
+	if err != nil {
+		deps.Logger.Error(context.Background(), "Could not parse DB URL.", dl.Entry("err", err))
+		panic(err)
+	}
+	pool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
 	if err != nil {
 		deps.Logger.Error(context.Background(), "Could not connect to DB.", dl.Entry("err", err))
 		panic(err)
 	}
-	deps.DB = db
+	deps.DB = pool
+	prometheus.MustRegister(
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: "remindme_db_pool_acquired_connections",
+				Help: "Currently acquired connections of the DB pool.",
+			},
+			func() float64 { return float64(pool.Stat().AcquiredConns()) },
+		),
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: "remindme_db_pool_total_connections",
+				Help: "Total connections of the DB pool.",
+			},
+			func() float64 { return float64(pool.Stat().TotalConns()) },
+		),
+	)
 	return func() {
 		deps.Logger.Info(context.Background(), "Shutting down DB connection.")
-		db.Close()
+		pool.Close()
 		deps.Logger.Info(context.Background(), "DB connection shut down.")
 	}
 }
@@ -258,7 +412,7 @@ func (deps *Deps) initRedisClient() func() {
 }
 
 func (deps *Deps) initRabbitmqConnection() func() {
-	rabbitmqConnection, err := rabbitmq.Dial(deps.Config.RabbitmqURL, deps.Logger)
+	rabbitmqConnection, err := rabbitmq.Dial(deps.Config.RabbitmqURL, deps.PackageLogger("internal/rabbitmq"))
 	if err != nil {
 		deps.Logger.Error(context.Background(), "Could not connect to RabbitMQ.", dl.Entry("err", err))
 		panic("could not connect to RabbitMQ")
@@ -308,10 +462,12 @@ func (deps *Deps) initRabbitmqReminderScheduler() func() {
 	}
 
 	deps.ReminderScheduler = reminderscheduler.NewRabbitMQ(
-		deps.Logger,
+		deps.PackageLogger("internal/rabbitmq/publishers/reminder_scheduler"),
+		deps.Tracer,
 		rabbitmqChannel,
 		deps.Config.RabbitmqDelayedExchange,
 		deps.Config.RabbitmqReminderReadyQueue,
+		deps.Config.RabbitmqReminderSchemaVersion,
 		deps.Now,
 	)
 
@@ -338,13 +494,58 @@ func (deps *Deps) initCaptchaValidator() captcha.CaptchaValidator {
 		return captcha.NewAllowAlwaysCaptchaValidator()
 	}
 	return recaptcha.New(
-		deps.Logger,
+		deps.PackageLogger("internal/implementations/recaptcha"),
 		deps.Config.GoogleRecaptchaSecretKey,
 		deps.Config.GoogleRecaptchaScoreThreshold,
 		deps.Config.GoogleRecaptchaRequestTimeout,
+		tracing.NewTransport(deps.Tracer, nil),
 	)
 }
 
+// initOIDCProviders enables providers which have a client ID configured,
+// the redirect URL of a provider is OIDC_REDIRECT_BASE_URL followed by its name.
+func (deps *Deps) initOIDCProviders() map[user.OIDCProviderName]user.OIDCProvider {
+	redirectURL := func(name user.OIDCProviderName) url.URL {
+		u := deps.Config.OidcRedirectBaseURL
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + string(name)
+		return u
+	}
+	providers := make(map[user.OIDCProviderName]user.OIDCProvider)
+	if deps.Config.GoogleOidcClientID != "" {
+		providers[GOOGLE_OIDC_PROVIDER] = oidc.New(
+			oidc.Options{
+				ClientID:         deps.Config.GoogleOidcClientID,
+				ClientSecret:     deps.Config.GoogleOidcClientSecret,
+				Issuer:           deps.Config.GoogleOidcIssuer,
+				AuthorizationURL: deps.Config.GoogleOidcAuthorizationURL,
+				TokenURL:         deps.Config.GoogleOidcTokenURL,
+				JWKSURL:          deps.Config.GoogleOidcJWKSURL,
+				RedirectURL:      redirectURL(GOOGLE_OIDC_PROVIDER),
+				Scopes:           []string{"openid", "email"},
+			},
+			deps.Config.OidcRequestTimeout,
+			tracing.NewTransport(deps.Tracer, nil),
+			deps.Now,
+		)
+	}
+	if deps.Config.GithubOauthClientID != "" {
+		providers[GITHUB_OIDC_PROVIDER] = oidc.NewGitHub(
+			oidc.GitHubOptions{
+				ClientID:         deps.Config.GithubOauthClientID,
+				ClientSecret:     deps.Config.GithubOauthClientSecret,
+				AuthorizationURL: deps.Config.GithubOauthAuthorizationURL,
+				TokenURL:         deps.Config.GithubOauthTokenURL,
+				APIURL:           deps.Config.GithubAPIURL,
+				RedirectURL:      redirectURL(GITHUB_OIDC_PROVIDER),
+			},
+			deps.Config.OidcRequestTimeout,
+			tracing.NewTransport(deps.Tracer, nil),
+		)
+	}
+	deps.Logger.Info(context.Background(), "OIDC providers initialized.", dl.Entry("count", len(providers)))
+	return providers
+}
+
 func (deps *Deps) initSentry() func() {
 	if deps.Config.SentryDsn != nil {
 		err := sentry.Init(sentry.ClientOptions{
@@ -0,0 +1,87 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"remindme/internal/app/deps"
+	dl "remindme/internal/core/domain/logging"
+	"remindme/internal/http/handlers/health"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	errRabbitmqConnectionClosed = errors.New("connection is closed")
+	errConsumerIsNotActive      = errors.New("consumer is not active")
+)
+
+// DependencyChecks checks the connections every binary opens in deps.InitDeps.
+func DependencyChecks(deps *deps.Deps) []health.Check {
+	return []health.Check{
+		{
+			Name:  "postgresql",
+			Check: func(ctx context.Context) error { return deps.DB.Ping(ctx) },
+		},
+		{
+			Name:  "redis",
+			Check: func(ctx context.Context) error { return deps.Redis.Ping(ctx).Err() },
+		},
+		{
+			Name: "rabbitmq",
+			Check: func(ctx context.Context) error {
+				if deps.Rabbitmq.IsClosed() {
+					return errRabbitmqConnectionClosed
+				}
+				return nil
+			},
+		},
+	}
+}
+
+type consumer interface {
+	IsActive() bool
+}
+
+func ConsumerCheck(name string, c consumer) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if !c.IsActive() {
+				return errConsumerIsNotActive
+			}
+			return nil
+		},
+	}
+}
+
+// InitServer creates a server for probes and metrics scraping,
+// it listens on a separate port so the endpoints are not exposed with the public API.
+func InitServer(deps *deps.Deps, port uint16, liveness http.Handler, checks ...health.Check) *http.Server {
+	router := chi.NewRouter()
+	router.Method(http.MethodGet, "/healthz", liveness)
+	router.Method(http.MethodGet, "/readyz", health.NewReadiness(deps.Config.ReadinessTimeout, checks...))
+	router.Method(http.MethodGet, "/metrics", promhttp.Handler())
+
+	return &http.Server{
+		Handler: router,
+		Addr:    fmt.Sprintf("0.0.0.0:%d", port),
+	}
+}
+
+func Start(server *http.Server, deps *deps.Deps) {
+	deps.Logger.Info(context.Background(), "Ops server has started.", dl.Entry("address", server.Addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		panic(err)
+	}
+}
+
+func Shutdown(server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server.Shutdown(ctx)
+}
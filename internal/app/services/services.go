@@ -2,32 +2,71 @@ package services
 
 import (
 	"remindme/internal/app/deps"
+	dl "remindme/internal/core/domain/logging"
 	drl "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services"
 	activateuser "remindme/internal/core/services/activate_user"
+	"remindme/internal/core/services/admin"
+	assignplan "remindme/internal/core/services/assign_plan"
 	"remindme/internal/core/services/auth"
+	bulkupdatereminders "remindme/internal/core/services/bulk_update_reminders"
+	cancelaccountdeletion "remindme/internal/core/services/cancel_account_deletion"
 	"remindme/internal/core/services/captcha"
 	changepassword "remindme/internal/core/services/change_password"
+	claimaccount "remindme/internal/core/services/claim_account"
+	confirmemailchange "remindme/internal/core/services/confirm_email_change"
+	confirmtotp "remindme/internal/core/services/confirm_totp"
+	createapitoken "remindme/internal/core/services/create_api_token"
+	createcalendarfeed "remindme/internal/core/services/create_calendar_feed"
 	createemailchannel "remindme/internal/core/services/create_email_channel"
 	createreminder "remindme/internal/core/services/create_reminder"
 	createreminderbynlq "remindme/internal/core/services/create_reminder_by_nlq"
 	createtelegramchannel "remindme/internal/core/services/create_telegram_channel"
+	deleteexpiredsessions "remindme/internal/core/services/delete_expired_sessions"
+	deleteinactiveusers "remindme/internal/core/services/delete_inactive_users"
 	deletereminder "remindme/internal/core/services/delete_reminder"
+	disabletotp "remindme/internal/core/services/disable_totp"
+	enabletotp "remindme/internal/core/services/enable_totp"
+	exportuserdata "remindme/internal/core/services/export_user_data"
+	getcalendarfeed "remindme/internal/core/services/get_calendar_feed"
 	getlimitforactivereminders "remindme/internal/core/services/get_limit_for_active_reminders"
 	getlimitforchannels "remindme/internal/core/services/get_limit_for_channels"
 	getlimitforsentreminders "remindme/internal/core/services/get_limit_for_sent_reminders"
 	getuserbysessiontoken "remindme/internal/core/services/get_user_by_session_token"
+	getuserplan "remindme/internal/core/services/get_user_plan"
+	importreminders "remindme/internal/core/services/import_reminders"
+	listapitokens "remindme/internal/core/services/list_api_tokens"
+	listplans "remindme/internal/core/services/list_plans"
 	listuserchannels "remindme/internal/core/services/list_user_channels"
 	listuserreminders "remindme/internal/core/services/list_user_reminders"
+	listusersessions "remindme/internal/core/services/list_user_sessions"
 	loginwithemail "remindme/internal/core/services/log_in_with_email"
+	loginwithoidc "remindme/internal/core/services/log_in_with_oidc"
+	loginwithtwofactor "remindme/internal/core/services/log_in_with_two_factor"
 	logout "remindme/internal/core/services/log_out"
+	overridelimits "remindme/internal/core/services/override_limits"
+	pausereminder "remindme/internal/core/services/pause_reminder"
+	purgeaccounts "remindme/internal/core/services/purge_accounts"
 	ratelimiting "remindme/internal/core/services/rate_limiting"
+	reconcileusage "remindme/internal/core/services/reconcile_usage"
+	requestaccountdeletion "remindme/internal/core/services/request_account_deletion"
+	requestemailchange "remindme/internal/core/services/request_email_change"
+	resendactivationtoken "remindme/internal/core/services/resend_activation_token"
 	resetpassword "remindme/internal/core/services/reset_password"
+	resumereminder "remindme/internal/core/services/resume_reminder"
+	revertemailchange "remindme/internal/core/services/revert_email_change"
+	revokeapitoken "remindme/internal/core/services/revoke_api_token"
+	revokecalendarfeed "remindme/internal/core/services/revoke_calendar_feed"
+	revokeothersessions "remindme/internal/core/services/revoke_other_sessions"
+	revokesession "remindme/internal/core/services/revoke_session"
 	schedulereminders "remindme/internal/core/services/schedule_reminders"
 	sendpasswordresettoken "remindme/internal/core/services/send_password_reset_token"
 	sendreminder "remindme/internal/core/services/send_reminder"
 	signupanonymously "remindme/internal/core/services/sign_up_anonymously"
 	signupwithemail "remindme/internal/core/services/sign_up_with_email"
+	startoidclogin "remindme/internal/core/services/start_oidc_login"
+	"remindme/internal/core/services/tracing"
 	updatereminder "remindme/internal/core/services/update_reminder"
 	updatereminderchannels "remindme/internal/core/services/update_reminder_channels"
 	updateuser "remindme/internal/core/services/update_user"
@@ -39,17 +78,51 @@ type Services struct {
 	SignUpWithEmail        services.Service[signupwithemail.Input, signupwithemail.Result]
 	SignUpAnonymously      services.Service[signupanonymously.Input, signupanonymously.Result]
 	ActivateUser           services.Service[activateuser.Input, activateuser.Result]
+	ResendActivationToken  services.Service[resendactivationtoken.Input, resendactivationtoken.Result]
 	LogInWithEmail         services.Service[loginwithemail.Input, loginwithemail.Result]
+	LogInWithTwoFactor     services.Service[loginwithtwofactor.Input, loginwithtwofactor.Result]
 	SendPasswordResetToken services.Service[sendpasswordresettoken.Input, sendpasswordresettoken.Result]
 	ResetPassword          services.Service[resetpassword.Input, resetpassword.Result]
+	StartOIDCLogin         services.Service[startoidclogin.Input, startoidclogin.Result]
+	LogInWithOIDC          services.Service[loginwithoidc.Input, loginwithoidc.Result]
+	ConfirmEmailChange     services.Service[confirmemailchange.Input, confirmemailchange.Result]
+	RevertEmailChange      services.Service[revertemailchange.Input, revertemailchange.Result]
 
 	ChangePassword             services.Service[changepassword.Input, changepassword.Result]
+	ClaimAccount               services.Service[claimaccount.Input, claimaccount.Result]
 	GetUserBySessionToken      services.Service[getuserbysessiontoken.Input, getuserbysessiontoken.Result]
 	UpdateUser                 services.Service[updateuser.Input, updateuser.Result]
 	GetLimitForActiveReminders services.Service[getlimitforactivereminders.Input, getlimitforactivereminders.Result]
 	GetLimitForSentReminders   services.Service[getlimitforsentreminders.Input, getlimitforsentreminders.Result]
 	GetLimitForChannels        services.Service[getlimitforchannels.Input, getlimitforchannels.Result]
 	LogOut                     services.Service[logout.Input, logout.Result]
+	ListUserSessions           services.Service[listusersessions.Input, listusersessions.Result]
+	RevokeSession              services.Service[revokesession.Input, revokesession.Result]
+	RevokeOtherSessions        services.Service[revokeothersessions.Input, revokeothersessions.Result]
+	LinkOIDCIdentity           services.Service[startoidclogin.Input, startoidclogin.Result]
+	CompleteOIDCIdentityLink   services.Service[loginwithoidc.Input, loginwithoidc.Result]
+	EnableTOTP                 services.Service[enabletotp.Input, enabletotp.Result]
+	ConfirmTOTP                services.Service[confirmtotp.Input, confirmtotp.Result]
+	DisableTOTP                services.Service[disabletotp.Input, disabletotp.Result]
+	CreateAPIToken             services.Service[createapitoken.Input, createapitoken.Result]
+	ListAPITokens              services.Service[listapitokens.Input, listapitokens.Result]
+	RevokeAPIToken             services.Service[revokeapitoken.Input, revokeapitoken.Result]
+	CreateCalendarFeed         services.Service[createcalendarfeed.Input, createcalendarfeed.Result]
+	RevokeCalendarFeed         services.Service[revokecalendarfeed.Input, revokecalendarfeed.Result]
+	GetCalendarFeed            services.Service[getcalendarfeed.Input, getcalendarfeed.Result]
+	RequestEmailChange         services.Service[requestemailchange.Input, requestemailchange.Result]
+	RequestAccountDeletion     services.Service[requestaccountdeletion.Input, requestaccountdeletion.Result]
+	CancelAccountDeletion      services.Service[cancelaccountdeletion.Input, cancelaccountdeletion.Result]
+	ExportUserData             services.Service[exportuserdata.Input, exportuserdata.Result]
+	PurgeAccounts              services.Service[purgeaccounts.Input, purgeaccounts.Result]
+	DeleteInactiveUsers        services.Service[deleteinactiveusers.Input, deleteinactiveusers.Result]
+	DeleteExpiredSessions      services.Service[deleteexpiredsessions.Input, deleteexpiredsessions.Result]
+	ReconcileUsage             services.Service[reconcileusage.Input, reconcileusage.Result]
+
+	ListPlans      services.Service[listplans.Input, listplans.Result]
+	GetUserPlan    services.Service[getuserplan.Input, getuserplan.Result]
+	AssignPlan     services.Service[assignplan.Input, assignplan.Result]
+	OverrideLimits services.Service[overridelimits.Input, overridelimits.Result]
 
 	CreateEmailChannel    services.Service[createemailchannel.Input, createemailchannel.Result]
 	CreateTelegramChannel services.Service[createtelegramchannel.Input, createtelegramchannel.Result]
@@ -57,10 +130,14 @@ type Services struct {
 	VerifyEmailChannel    services.Service[verifyemailchannel.Input, verifyemailchannel.Result]
 	VerifyTelegramChannel services.Service[verifytelegramchannel.Input, verifytelegramchannel.Result]
 
+	BulkUpdateReminders    services.Service[bulkupdatereminders.Input, bulkupdatereminders.Result]
 	CreateReminder         services.Service[createreminder.Input, createreminder.Result]
 	CreateReminderByNLQ    services.Service[createreminderbynlq.Input, createreminder.Result]
 	DeleteReminder         services.Service[deletereminder.Input, deletereminder.Result]
+	ImportReminders        services.Service[importreminders.Input, importreminders.Result]
 	ListUserReminders      services.Service[listuserreminders.Input, listuserreminders.Result]
+	PauseReminder          services.Service[pausereminder.Input, pausereminder.Result]
+	ResumeReminder         services.Service[resumereminder.Input, resumereminder.Result]
 	ScheduleReminders      services.Service[schedulereminders.Input, schedulereminders.Result]
 	UpdateReminder         services.Service[updatereminder.Input, updatereminder.Result]
 	UpdateReminderChannels services.Service[updatereminderchannels.Input, updatereminderchannels.Result]
@@ -73,13 +150,14 @@ func InitServices(deps *deps.Deps) *Services {
 	s.SignUpWithEmail = captcha.WithCaptcha(
 		deps.CaptchaValidator,
 		signupwithemail.NewWithActivationTokenSending(
-			deps.Logger,
+			logger(deps, "sign_up_with_email"),
 			deps.UserActivationTokenSender,
 			signupwithemail.New(
-				deps.Logger,
+				logger(deps, "sign_up_with_email"),
 				deps.UnitOfWork,
 				deps.PasswordHasher,
 				deps.UserActivationTokenGenerator,
+				deps.Config.ActivationTokenTTL,
 				deps.Now,
 			),
 		),
@@ -87,7 +165,7 @@ func InitServices(deps *deps.Deps) *Services {
 	s.SignUpAnonymously = captcha.WithCaptcha(
 		deps.CaptchaValidator,
 		signupanonymously.New(
-			deps.Logger,
+			logger(deps, "sign_up_anonymously"),
 			deps.UnitOfWork,
 			deps.UserIdentityGenerator,
 			deps.UserSessionTokenGenerator,
@@ -96,36 +174,77 @@ func InitServices(deps *deps.Deps) *Services {
 		),
 	)
 	s.ActivateUser = activateuser.New(
-		deps.Logger,
+		logger(deps, "activate_user"),
 		deps.UnitOfWork,
+		deps.PlanRepository,
 		deps.Now,
-		deps.DefaultUserLimits,
+		user.PlanName(deps.Config.DefaultPlan),
 	)
 	s.LogInWithEmail = ratelimiting.WithRateLimiting(
-		deps.Logger,
+		logger(deps, "rate_limiting"),
 		deps.RateLimiter,
 		drl.Limit{Interval: drl.Hour, Value: 10},
 		loginwithemail.New(
-			deps.Logger,
+			logger(deps, "log_in_with_email"),
 			deps.UserRepository,
 			deps.SessionRepository,
 			deps.PasswordHasher,
 			deps.UserSessionTokenGenerator,
+			deps.TOTPRepository,
+			deps.TwoFactorChallengeRepository,
+			deps.TwoFactorGenerator,
 			deps.Now,
 		),
 	)
+	s.LogInWithTwoFactor = loginwithtwofactor.NewWithChallenge(
+		logger(deps, "log_in_with_two_factor"),
+		deps.TwoFactorChallengeRepository,
+		deps.Config.TwoFactorChallengeTTL,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Minute, Value: 5},
+			loginwithtwofactor.New(
+				logger(deps, "log_in_with_two_factor"),
+				deps.TOTPRepository,
+				deps.TwoFactorChallengeRepository,
+				deps.SessionRepository,
+				deps.TOTPAuthenticator,
+				deps.Config.TwoFactorChallengeTTL,
+				deps.UserSessionTokenGenerator,
+				deps.Now,
+			),
+		),
+	)
 	s.LogOut = logout.New(
-		deps.Logger,
+		logger(deps, "log_out"),
 		deps.SessionRepository,
 	)
+	s.ResendActivationToken = captcha.WithCaptcha(
+		deps.CaptchaValidator,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 3},
+			resendactivationtoken.New(
+				logger(deps, "resend_activation_token"),
+				deps.UserRepository,
+				deps.UserActivationTokenGenerator,
+				deps.UserActivationTokenSender,
+				deps.Config.ActivationTokenTTL,
+				deps.Now,
+			),
+		),
+	)
 	s.SendPasswordResetToken = captcha.WithCaptcha(
 		deps.CaptchaValidator,
 		ratelimiting.WithRateLimiting(
-			deps.Logger,
+			logger(deps, "rate_limiting"),
 			deps.RateLimiter,
 			drl.Limit{Interval: drl.Hour, Value: 3},
 			sendpasswordresettoken.New(
-				deps.Logger,
+				logger(deps, "send_password_reset_token"),
 				deps.UserRepository,
 				deps.PasswordResetter,
 				deps.PasswordResetTokenSender,
@@ -133,51 +252,409 @@ func InitServices(deps *deps.Deps) *Services {
 		),
 	)
 	s.ResetPassword = resetpassword.New(
-		deps.Logger,
+		logger(deps, "reset_password"),
 		deps.UserRepository,
+		deps.SessionRepository,
 		deps.PasswordResetter,
 		deps.PasswordHasher,
 	)
+	s.ConfirmEmailChange = confirmemailchange.New(
+		logger(deps, "confirm_email_change"),
+		deps.UnitOfWork,
+		deps.EmailChangeTokenGenerator,
+		deps.EmailChangeSender,
+		deps.Config.EmailChangeRevertPeriod,
+		deps.Now,
+	)
+	s.RevertEmailChange = revertemailchange.New(
+		logger(deps, "revert_email_change"),
+		deps.UnitOfWork,
+		deps.Now,
+	)
+	s.StartOIDCLogin = startoidclogin.New(
+		logger(deps, "start_oidc_login"),
+		deps.OIDCProviders,
+		deps.OIDCAuthorizationRepository,
+		deps.OIDCAuthorizationGenerator,
+		deps.Config.OidcAuthorizationTTL,
+		deps.Now,
+	)
+	s.LogInWithOIDC = loginwithoidc.New(
+		logger(deps, "log_in_with_oidc"),
+		deps.UnitOfWork,
+		deps.OIDCProviders,
+		deps.OIDCAuthorizationRepository,
+		deps.Config.OidcAuthorizationTTL,
+		deps.UserIdentityGenerator,
+		deps.UserSessionTokenGenerator,
+		deps.TOTPRepository,
+		deps.TwoFactorChallengeRepository,
+		deps.TwoFactorGenerator,
+		deps.PlanRepository,
+		deps.Now,
+		user.PlanName(deps.Config.DefaultPlan),
+	)
 	s.ChangePassword = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		changepassword.New(
-			deps.Logger,
+			logger(deps, "change_password"),
 			deps.UserRepository,
+			deps.SessionRepository,
 			deps.PasswordHasher,
 		),
 	)
+	s.ClaimAccount = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 5},
+			claimaccount.NewWithActivationTokenSending(
+				logger(deps, "claim_account"),
+				deps.UserActivationTokenSender,
+				claimaccount.New(
+					logger(deps, "claim_account"),
+					deps.UserRepository,
+					deps.ExternalIdentityRepository,
+					deps.PasswordHasher,
+					deps.UserActivationTokenGenerator,
+					deps.Config.ActivationTokenTTL,
+					deps.Now,
+				),
+			),
+		),
+	)
+	s.ListUserSessions = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		listusersessions.New(
+			logger(deps, "list_user_sessions"),
+			deps.SessionRepository,
+			deps.SessionExpiry,
+			deps.Now,
+		),
+	)
+	s.RevokeSession = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		revokesession.New(
+			logger(deps, "revoke_session"),
+			deps.SessionRepository,
+		),
+	)
+	s.RevokeOtherSessions = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		revokeothersessions.New(
+			logger(deps, "revoke_other_sessions"),
+			deps.SessionRepository,
+		),
+	)
+	s.LinkOIDCIdentity = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		startoidclogin.New(
+			logger(deps, "start_oidc_login"),
+			deps.OIDCProviders,
+			deps.OIDCAuthorizationRepository,
+			deps.OIDCAuthorizationGenerator,
+			deps.Config.OidcAuthorizationTTL,
+			deps.Now,
+		),
+	)
+	s.CompleteOIDCIdentityLink = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		loginwithoidc.New(
+			logger(deps, "log_in_with_oidc"),
+			deps.UnitOfWork,
+			deps.OIDCProviders,
+			deps.OIDCAuthorizationRepository,
+			deps.Config.OidcAuthorizationTTL,
+			deps.UserIdentityGenerator,
+			deps.UserSessionTokenGenerator,
+			deps.TOTPRepository,
+			deps.TwoFactorChallengeRepository,
+			deps.TwoFactorGenerator,
+			deps.PlanRepository,
+			deps.Now,
+			user.PlanName(deps.Config.DefaultPlan),
+		),
+	)
+	s.EnableTOTP = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		enabletotp.New(
+			logger(deps, "enable_totp"),
+			deps.TOTPRepository,
+			deps.TOTPAuthenticator,
+			deps.TwoFactorGenerator,
+			deps.Now,
+		),
+	)
+	s.ConfirmTOTP = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Minute, Value: 5},
+			confirmtotp.New(
+				logger(deps, "confirm_totp"),
+				deps.TOTPRepository,
+				deps.TOTPAuthenticator,
+				deps.TwoFactorGenerator,
+				deps.Now,
+			),
+		),
+	)
+	s.DisableTOTP = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Minute, Value: 5},
+			disabletotp.New(
+				logger(deps, "disable_totp"),
+				deps.TOTPRepository,
+				deps.TOTPAuthenticator,
+				deps.Now,
+			),
+		),
+	)
+	s.CreateAPIToken = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		createapitoken.New(
+			logger(deps, "create_api_token"),
+			deps.APITokenRepository,
+			deps.APITokenGenerator,
+			deps.Now,
+		),
+	)
+	s.ListAPITokens = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		listapitokens.New(
+			logger(deps, "list_api_tokens"),
+			deps.APITokenRepository,
+		),
+	)
+	s.RevokeAPIToken = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		revokeapitoken.New(
+			logger(deps, "revoke_api_token"),
+			deps.APITokenRepository,
+		),
+	)
+	s.CreateCalendarFeed = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		createcalendarfeed.New(
+			logger(deps, "create_calendar_feed"),
+			deps.CalendarFeedRepository,
+			deps.CalendarFeedTokenGenerator,
+			deps.Now,
+		),
+	)
+	s.RevokeCalendarFeed = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		revokecalendarfeed.New(
+			logger(deps, "revoke_calendar_feed"),
+			deps.CalendarFeedRepository,
+		),
+	)
+	s.GetCalendarFeed = getcalendarfeed.New(
+		logger(deps, "get_calendar_feed"),
+		deps.CalendarFeedRepository,
+		deps.ReminderRepository,
+		deps.Config.CalendarFeedSentPeriod,
+		deps.Now,
+	)
+	s.RequestEmailChange = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 5},
+			requestemailchange.New(
+				logger(deps, "request_email_change"),
+				deps.UserRepository,
+				deps.EmailChangeRepository,
+				deps.PasswordHasher,
+				deps.EmailChangeTokenGenerator,
+				deps.EmailChangeSender,
+				deps.Config.EmailChangeConfirmationPeriod,
+				deps.Now,
+			),
+		),
+	)
+	s.RequestAccountDeletion = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 5},
+			requestaccountdeletion.New(
+				logger(deps, "request_account_deletion"),
+				deps.AccountDeletionRepository,
+				deps.SessionRepository,
+				deps.ExternalIdentityRepository,
+				deps.PasswordHasher,
+				deps.SessionExpiry,
+				deps.Config.ReauthenticationPeriod,
+				deps.Config.AccountDeletionGracePeriod,
+				deps.Now,
+			),
+		),
+	)
+	s.CancelAccountDeletion = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		cancelaccountdeletion.New(
+			logger(deps, "cancel_account_deletion"),
+			deps.AccountDeletionRepository,
+		),
+	)
+	s.ExportUserData = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 5},
+			exportuserdata.New(
+				logger(deps, "export_user_data"),
+				deps.ChannelRepository,
+				deps.ReminderRepository,
+				deps.SessionRepository,
+				deps.APITokenRepository,
+				deps.ExternalIdentityRepository,
+				deps.LimitsRepository,
+				deps.SessionExpiry,
+				deps.Now,
+			),
+		),
+	)
+	s.PurgeAccounts = purgeaccounts.New(
+		logger(deps, "purge_accounts"),
+		deps.AccountDeletionRepository,
+		deps.UnitOfWork,
+		deps.Config.AccountPurgeBatchSize,
+		deps.Now,
+	)
+	s.DeleteInactiveUsers = deleteinactiveusers.New(
+		logger(deps, "delete_inactive_users"),
+		deps.UserRepository,
+		deps.Config.InactiveUserRetentionPeriod,
+		deps.Config.InactiveUserCleanupBatchSize,
+		deps.Now,
+	)
+	s.DeleteExpiredSessions = deleteexpiredsessions.New(
+		logger(deps, "delete_expired_sessions"),
+		deps.SessionRepository,
+		deps.SessionExpiry,
+		deps.Config.SessionCleanupBatchSize,
+		deps.Now,
+	)
+	s.ReconcileUsage = reconcileusage.New(
+		logger(deps, "reconcile_usage"),
+		deps.UnitOfWork,
+		deps.Config.UsageReconciliationBatchSize,
+		deps.Now,
+	)
 	s.UpdateUser = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		updateuser.New(
-			deps.Logger,
+			logger(deps, "update_user"),
 			deps.UserRepository,
 		),
 	)
 	s.GetUserBySessionToken = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		getuserbysessiontoken.New(),
 	)
 	s.GetLimitForActiveReminders = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		getlimitforactivereminders.New(
-			deps.Logger,
+			logger(deps, "get_limit_for_active_reminders"),
 			deps.LimitsRepository,
-			deps.ReminderRepository,
+			deps.UsageRepository,
 		),
 	)
 	s.GetLimitForSentReminders = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		getlimitforsentreminders.New(
-			deps.Logger,
+			logger(deps, "get_limit_for_sent_reminders"),
 			deps.LimitsRepository,
-			deps.ReminderRepository,
+			deps.UsageRepository,
 			deps.Now,
 		),
 	)
 	s.GetLimitForChannels = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		getlimitforchannels.New(
-			deps.Logger,
+			logger(deps, "get_limit_for_channels"),
 			deps.LimitsRepository,
 			deps.ChannelRepository,
 		),
@@ -185,11 +662,14 @@ func InitServices(deps *deps.Deps) *Services {
 
 	s.CreateEmailChannel = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		createemailchannel.NewWithVerificationTokenSending(
-			deps.Logger,
+			logger(deps, "create_email_channel"),
 			deps.EmailSender,
 			createemailchannel.New(
-				deps.Logger,
+				logger(deps, "create_email_channel"),
 				deps.UnitOfWork,
 				deps.ChannelVerificationTokenGenerator,
 				deps.Now,
@@ -198,8 +678,11 @@ func InitServices(deps *deps.Deps) *Services {
 	)
 	s.CreateTelegramChannel = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		createtelegramchannel.New(
-			deps.Logger,
+			logger(deps, "create_telegram_channel"),
 			deps.UnitOfWork,
 			deps.ChannelVerificationTokenGenerator,
 			deps.Now,
@@ -207,34 +690,55 @@ func InitServices(deps *deps.Deps) *Services {
 	)
 	s.ListUserChannels = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		listuserchannels.New(
-			deps.Logger,
+			logger(deps, "list_user_channels"),
 			deps.ChannelRepository,
 		),
 	)
 	s.VerifyEmailChannel = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		ratelimiting.WithRateLimiting(
-			deps.Logger,
+			logger(deps, "rate_limiting"),
 			deps.RateLimiter,
 			drl.Limit{Interval: drl.Minute, Value: 5},
 			verifyemailchannel.New(
-				deps.Logger,
+				logger(deps, "verify_email_channel"),
 				deps.ChannelRepository,
 				deps.Now,
 			),
 		),
 	)
 	s.VerifyTelegramChannel = verifytelegramchannel.New(
-		deps.Logger,
+		logger(deps, "verify_telegram_channel"),
 		deps.ChannelRepository,
 		deps.Now,
 	)
 
+	s.BulkUpdateReminders = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		bulkupdatereminders.New(
+			logger(deps, "bulk_update_reminders"),
+			deps.UnitOfWork,
+			deps.ReminderScheduler,
+			deps.Now,
+		),
+	)
 	s.CreateReminder = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		createreminder.New(
-			deps.Logger,
+			logger(deps, "create_reminder"),
 			deps.UnitOfWork,
 			deps.ReminderScheduler,
 			deps.Now,
@@ -242,13 +746,33 @@ func InitServices(deps *deps.Deps) *Services {
 	)
 	s.CreateReminderByNLQ = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		createreminderbynlq.New(
-			deps.Logger,
+			logger(deps, "create_reminder_by_nlq"),
 			deps.ReminderNLQParser,
 			deps.ChannelRepository,
 			deps.Now,
 			createreminder.New(
-				deps.Logger,
+				logger(deps, "create_reminder"),
+				deps.UnitOfWork,
+				deps.ReminderScheduler,
+				deps.Now,
+			),
+		),
+	)
+	s.ImportReminders = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		ratelimiting.WithRateLimiting(
+			logger(deps, "rate_limiting"),
+			deps.RateLimiter,
+			drl.Limit{Interval: drl.Hour, Value: 20},
+			importreminders.New(
+				logger(deps, "import_reminders"),
 				deps.UnitOfWork,
 				deps.ReminderScheduler,
 				deps.Now,
@@ -257,29 +781,62 @@ func InitServices(deps *deps.Deps) *Services {
 	)
 	s.DeleteReminder = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		deletereminder.New(
-			deps.Logger,
+			logger(deps, "delete_reminder"),
 			deps.UnitOfWork,
 			deps.Now,
 		),
 	)
 	s.ListUserReminders = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		listuserreminders.New(
-			deps.Logger,
+			logger(deps, "list_user_reminders"),
 			deps.ReminderRepository,
 		),
 	)
+	s.PauseReminder = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		pausereminder.New(
+			logger(deps, "pause_reminder"),
+			deps.UnitOfWork,
+		),
+	)
+	s.ResumeReminder = auth.WithAuthentication(
+		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
+		resumereminder.New(
+			logger(deps, "resume_reminder"),
+			deps.UnitOfWork,
+			deps.ReminderScheduler,
+			deps.Now,
+		),
+	)
 	s.ScheduleReminders = schedulereminders.New(
-		deps.Logger,
+		logger(deps, "schedule_reminders"),
 		deps.UnitOfWork,
 		deps.ReminderScheduler,
+		deps.Metrics,
+		deps.Config.RemindersSchedulingBatchSize,
 		deps.Now,
 	)
 	s.UpdateReminder = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		updatereminder.New(
-			deps.Logger,
+			logger(deps, "update_reminder"),
 			deps.UnitOfWork,
 			deps.ReminderScheduler,
 			deps.Now,
@@ -287,28 +844,126 @@ func InitServices(deps *deps.Deps) *Services {
 	)
 	s.UpdateReminderChannels = auth.WithAuthentication(
 		deps.SessionRepository,
+		deps.APITokenRepository,
+		deps.SessionExpiry,
+		deps.Now,
 		updatereminderchannels.New(
-			deps.Logger,
+			logger(deps, "update_reminder_channels"),
 			deps.UnitOfWork,
 			deps.Now,
 		),
 	)
 	s.SendReminder = sendreminder.NewSendService(
-		deps.Logger,
-		deps.ReminderRepository,
+		logger(deps, "send_reminder"),
+		deps.UnitOfWork,
 		deps.ReminderSender,
 		deps.Now,
 		sendreminder.NewCreateNextPeriodicService(
-			deps.Logger,
+			logger(deps, "send_reminder"),
 			deps.UnitOfWork,
 			deps.ReminderScheduler,
 			sendreminder.NewPrepareService(
-				deps.Logger,
+				logger(deps, "send_reminder"),
 				deps.UnitOfWork,
 				deps.Now,
 			),
 		),
 	)
 
+	s.ListPlans = admin.WithAdminAuthentication(
+		logger(deps, "admin"),
+		deps.Config.AdminTokens,
+		listplans.New(logger(deps, "list_plans"), deps.PlanRepository),
+	)
+	s.GetUserPlan = admin.WithAdminAuthentication(
+		logger(deps, "admin"),
+		deps.Config.AdminTokens,
+		getuserplan.New(logger(deps, "get_user_plan"), deps.LimitsRepository, deps.LimitsAuditRepository),
+	)
+	s.AssignPlan = admin.WithAdminAuthentication(
+		logger(deps, "admin"),
+		deps.Config.AdminTokens,
+		assignplan.New(logger(deps, "assign_plan"), deps.UnitOfWork, deps.PlanRepository, deps.Now),
+	)
+	s.OverrideLimits = admin.WithAdminAuthentication(
+		logger(deps, "admin"),
+		deps.Config.AdminTokens,
+		overridelimits.New(logger(deps, "override_limits"), deps.UnitOfWork, deps.Now),
+	)
+
+	withTracing(deps, s)
 	return s
 }
+
+// withTracing runs every service in its own span, authentication,
+// rate limiting and captcha checks are included in it.
+// logger returns the logger of a package of internal/core/services.
+func logger(deps *deps.Deps, pkg string) dl.Logger {
+	return deps.PackageLogger("internal/core/services/" + pkg)
+}
+
+func withTracing(deps *deps.Deps, s *Services) {
+	s.SignUpWithEmail = tracing.WithTracing(deps.Tracer, "SignUpWithEmail", s.SignUpWithEmail)
+	s.SignUpAnonymously = tracing.WithTracing(deps.Tracer, "SignUpAnonymously", s.SignUpAnonymously)
+	s.ActivateUser = tracing.WithTracing(deps.Tracer, "ActivateUser", s.ActivateUser)
+	s.LogInWithEmail = tracing.WithTracing(deps.Tracer, "LogInWithEmail", s.LogInWithEmail)
+	s.LogInWithTwoFactor = tracing.WithTracing(deps.Tracer, "LogInWithTwoFactor", s.LogInWithTwoFactor)
+	s.ResendActivationToken = tracing.WithTracing(deps.Tracer, "ResendActivationToken", s.ResendActivationToken)
+	s.SendPasswordResetToken = tracing.WithTracing(deps.Tracer, "SendPasswordResetToken", s.SendPasswordResetToken)
+	s.ResetPassword = tracing.WithTracing(deps.Tracer, "ResetPassword", s.ResetPassword)
+	s.StartOIDCLogin = tracing.WithTracing(deps.Tracer, "StartOIDCLogin", s.StartOIDCLogin)
+	s.LogInWithOIDC = tracing.WithTracing(deps.Tracer, "LogInWithOIDC", s.LogInWithOIDC)
+	s.ConfirmEmailChange = tracing.WithTracing(deps.Tracer, "ConfirmEmailChange", s.ConfirmEmailChange)
+	s.RevertEmailChange = tracing.WithTracing(deps.Tracer, "RevertEmailChange", s.RevertEmailChange)
+	s.ChangePassword = tracing.WithTracing(deps.Tracer, "ChangePassword", s.ChangePassword)
+	s.ClaimAccount = tracing.WithTracing(deps.Tracer, "ClaimAccount", s.ClaimAccount)
+	s.GetUserBySessionToken = tracing.WithTracing(deps.Tracer, "GetUserBySessionToken", s.GetUserBySessionToken)
+	s.UpdateUser = tracing.WithTracing(deps.Tracer, "UpdateUser", s.UpdateUser)
+	s.GetLimitForActiveReminders = tracing.WithTracing(deps.Tracer, "GetLimitForActiveReminders", s.GetLimitForActiveReminders)
+	s.GetLimitForSentReminders = tracing.WithTracing(deps.Tracer, "GetLimitForSentReminders", s.GetLimitForSentReminders)
+	s.GetLimitForChannels = tracing.WithTracing(deps.Tracer, "GetLimitForChannels", s.GetLimitForChannels)
+	s.LogOut = tracing.WithTracing(deps.Tracer, "LogOut", s.LogOut)
+	s.ListUserSessions = tracing.WithTracing(deps.Tracer, "ListUserSessions", s.ListUserSessions)
+	s.RevokeSession = tracing.WithTracing(deps.Tracer, "RevokeSession", s.RevokeSession)
+	s.RevokeOtherSessions = tracing.WithTracing(deps.Tracer, "RevokeOtherSessions", s.RevokeOtherSessions)
+	s.LinkOIDCIdentity = tracing.WithTracing(deps.Tracer, "LinkOIDCIdentity", s.LinkOIDCIdentity)
+	s.CompleteOIDCIdentityLink = tracing.WithTracing(deps.Tracer, "CompleteOIDCIdentityLink", s.CompleteOIDCIdentityLink)
+	s.EnableTOTP = tracing.WithTracing(deps.Tracer, "EnableTOTP", s.EnableTOTP)
+	s.ConfirmTOTP = tracing.WithTracing(deps.Tracer, "ConfirmTOTP", s.ConfirmTOTP)
+	s.DisableTOTP = tracing.WithTracing(deps.Tracer, "DisableTOTP", s.DisableTOTP)
+	s.CreateAPIToken = tracing.WithTracing(deps.Tracer, "CreateAPIToken", s.CreateAPIToken)
+	s.ListAPITokens = tracing.WithTracing(deps.Tracer, "ListAPITokens", s.ListAPITokens)
+	s.RevokeAPIToken = tracing.WithTracing(deps.Tracer, "RevokeAPIToken", s.RevokeAPIToken)
+	s.CreateCalendarFeed = tracing.WithTracing(deps.Tracer, "CreateCalendarFeed", s.CreateCalendarFeed)
+	s.RevokeCalendarFeed = tracing.WithTracing(deps.Tracer, "RevokeCalendarFeed", s.RevokeCalendarFeed)
+	s.GetCalendarFeed = tracing.WithTracing(deps.Tracer, "GetCalendarFeed", s.GetCalendarFeed)
+	s.RequestEmailChange = tracing.WithTracing(deps.Tracer, "RequestEmailChange", s.RequestEmailChange)
+	s.RequestAccountDeletion = tracing.WithTracing(deps.Tracer, "RequestAccountDeletion", s.RequestAccountDeletion)
+	s.CancelAccountDeletion = tracing.WithTracing(deps.Tracer, "CancelAccountDeletion", s.CancelAccountDeletion)
+	s.ExportUserData = tracing.WithTracing(deps.Tracer, "ExportUserData", s.ExportUserData)
+	s.PurgeAccounts = tracing.WithTracing(deps.Tracer, "PurgeAccounts", s.PurgeAccounts)
+	s.DeleteInactiveUsers = tracing.WithTracing(deps.Tracer, "DeleteInactiveUsers", s.DeleteInactiveUsers)
+	s.DeleteExpiredSessions = tracing.WithTracing(deps.Tracer, "DeleteExpiredSessions", s.DeleteExpiredSessions)
+	s.ReconcileUsage = tracing.WithTracing(deps.Tracer, "ReconcileUsage", s.ReconcileUsage)
+	s.ListPlans = tracing.WithTracing(deps.Tracer, "ListPlans", s.ListPlans)
+	s.GetUserPlan = tracing.WithTracing(deps.Tracer, "GetUserPlan", s.GetUserPlan)
+	s.AssignPlan = tracing.WithTracing(deps.Tracer, "AssignPlan", s.AssignPlan)
+	s.OverrideLimits = tracing.WithTracing(deps.Tracer, "OverrideLimits", s.OverrideLimits)
+	s.CreateEmailChannel = tracing.WithTracing(deps.Tracer, "CreateEmailChannel", s.CreateEmailChannel)
+	s.CreateTelegramChannel = tracing.WithTracing(deps.Tracer, "CreateTelegramChannel", s.CreateTelegramChannel)
+	s.ListUserChannels = tracing.WithTracing(deps.Tracer, "ListUserChannels", s.ListUserChannels)
+	s.VerifyEmailChannel = tracing.WithTracing(deps.Tracer, "VerifyEmailChannel", s.VerifyEmailChannel)
+	s.VerifyTelegramChannel = tracing.WithTracing(deps.Tracer, "VerifyTelegramChannel", s.VerifyTelegramChannel)
+	s.BulkUpdateReminders = tracing.WithTracing(deps.Tracer, "BulkUpdateReminders", s.BulkUpdateReminders)
+	s.CreateReminder = tracing.WithTracing(deps.Tracer, "CreateReminder", s.CreateReminder)
+	s.CreateReminderByNLQ = tracing.WithTracing(deps.Tracer, "CreateReminderByNLQ", s.CreateReminderByNLQ)
+	s.DeleteReminder = tracing.WithTracing(deps.Tracer, "DeleteReminder", s.DeleteReminder)
+	s.ImportReminders = tracing.WithTracing(deps.Tracer, "ImportReminders", s.ImportReminders)
+	s.ListUserReminders = tracing.WithTracing(deps.Tracer, "ListUserReminders", s.ListUserReminders)
+	s.PauseReminder = tracing.WithTracing(deps.Tracer, "PauseReminder", s.PauseReminder)
+	s.ResumeReminder = tracing.WithTracing(deps.Tracer, "ResumeReminder", s.ResumeReminder)
+	s.ScheduleReminders = tracing.WithTracing(deps.Tracer, "ScheduleReminders", s.ScheduleReminders)
+	s.UpdateReminder = tracing.WithTracing(deps.Tracer, "UpdateReminder", s.UpdateReminder)
+	s.UpdateReminderChannels = tracing.WithTracing(deps.Tracer, "UpdateReminderChannels", s.UpdateReminderChannels)
+	s.SendReminder = tracing.WithTracing(deps.Tracer, "SendReminder", s.SendReminder)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"remindme/internal/core/domain/channel"
 	"time"
 
@@ -10,38 +11,101 @@ import (
 )
 
 type Config struct {
-	IsTestMode                      bool          `env:"TEST_MODE" envDefault:"false"`
-	BaseURL                         url.URL       `env:"BASE_URL" envDefault:"localhost"`
-	AllowedOrigins                  []string      `env:"ALLOWED_ORIGINS" envDefault:"*"`
-	Port                            uint16        `env:"PORT" envDefault:"9090"`
-	Secret                          string        `env:"SECRET,notEmpty"`
-	PostgresqlURL                   string        `env:"POSTGRESQL_URL,notEmpty"`
-	RedisURL                        string        `env:"REDIS_URL,notEmpty"`
-	RabbitmqURL                     string        `env:"RABBITMQ_URL,notEmpty"`
-	RabbitmqDelayedExchange         string        `env:"RABBITMQ_DELAYED_EXHANGE,notEmpty" envDefault:"remindme-delayed"`
-	RabbitmqReminderReadyQueue      string        `env:"RABBITMQ_REMINDER_READY_QUEUE,notEmpty" envDefault:"reminders-ready-for-sending"`
-	BcryptHasherCost                int           `env:"BCRYPT_HASHER_COST" envDefault:"10"`
-	PasswordResetValidDurationHours int           `env:"PASSWORD_RESET_VALIDATION_HOURS" envDefault:"24"`
-	TelegramURLSecret               string        `env:"TELEGRAM_URL_SECRET,notEmpty"`
-	TelegramBaseURL                 url.URL       `env:"TELEGRAM_BASE_URL" envDefault:"https://api.telegram.org"`
-	TelegramBots                    []string      `env:"TELEGRAM_BOTS,notEmpty"`
-	TelegramTokens                  []string      `env:"TELEGRAM_TOKENS,notEmpty"`
-	TelegramRequestTimeout          time.Duration `env:"TELEGRAM_REQUEST_TIMEOUT" envDefault:"30s"`
-	RemindersSchedulingPeriod       time.Duration `env:"REMINDERS_SCHEDULING_PERIOD" envDefault:"3h"`
-	GoogleRecaptchaSecretKey        string        `env:"GOOGLE_RECAPTCHA_SECRET_KEY,notEmpty"`
-	GoogleRecaptchaScoreThreshold   float64       `env:"GOOGLE_RECAPTCHA_SCORE_THRESHOLD" envDefault:"0.5"`
-	GoogleRecaptchaRequestTimeout   time.Duration `env:"GOOGLE_RECAPTCHA_REQUEST_TIMEOUT" envDefault:"15s"`
-	AwsRegion                       string        `env:"AWS_REGION,notEmpty"`
-	AwsAccessKey                    string        `env:"AWS_ACCESS_KEY,notEmpty"`
-	AwsSecretKey                    string        `env:"AWS_SECRET_KEY,notEmpty"`
-	AwsEmailSender                  string        `env:"AWS_EMAIL_SENDER,notEmpty" envDefault:"no-reply@remindme.one"`
-	AwsEmailReminderTemplate        string        `env:"AWS_EMAIL_REMINDER_TEMPLATE,notEmpty" envDefault:"reminder-v1"`
-	AwsEmailActivateAccountTemplate string        `env:"AWS_EMAIL_ACTIVATE_ACCOUNT_TEMPLATE,notEmpty" envDefault:"signup-activation-v1"`
-	AwsEmailActivationUrl           url.URL       `env:"AWS_EMAIL_ACTIVATION_URL,notEmpty" envDefault:"https://remindme.one/app/auth/activate"`
-	AwsEmailPasswordResetTemplate   string        `env:"AWS_EMAIL_PASSWORD_RESET_TEMPLATE,notEmpty" envDefault:"password-reset-v1"`
-	AwsEmailPasswordResetBaseUrl    url.URL       `env:"AWS_EMAIL_PASSWORD_RESET_BASE_URL,notEmpty" envDefault:"https://remindme.one/app/auth/password_reset"`
-	AwsEmailActivateChannelTemplate string        `env:"AWS_EMAIL_ACTIVATE_CHANNEL_TEMPLATE,notEmpty" envDefault:"email-channel-confirm-v1"`
-	SentryDsn                       *url.URL      `env:"SENTRY_DSN"`
+	IsTestMode                      bool              `env:"TEST_MODE" envDefault:"false"`
+	BaseURL                         url.URL           `env:"BASE_URL" envDefault:"localhost"`
+	AllowedOrigins                  []string          `env:"ALLOWED_ORIGINS" envDefault:"*"`
+	Port                            uint16            `env:"PORT" envDefault:"9090"`
+	OpsPort                         uint16            `env:"OPS_PORT" envDefault:"9092"`
+	ReadinessTimeout                time.Duration     `env:"READINESS_TIMEOUT" envDefault:"3s"`
+	Secret                          string            `env:"SECRET,notEmpty"`
+	PostgresqlURL                   string            `env:"POSTGRESQL_URL,notEmpty"`
+	RedisURL                        string            `env:"REDIS_URL,notEmpty"`
+	RabbitmqURL                     string            `env:"RABBITMQ_URL,notEmpty"`
+	RabbitmqDelayedExchange         string            `env:"RABBITMQ_DELAYED_EXHANGE,notEmpty" envDefault:"remindme-delayed"`
+	RabbitmqReminderReadyQueue      string            `env:"RABBITMQ_REMINDER_READY_QUEUE,notEmpty" envDefault:"reminders-ready-for-sending"`
+	RabbitmqReminderSchemaVersion   int               `env:"RABBITMQ_REMINDER_SCHEMA_VERSION" envDefault:"2"`
+	RabbitmqConsumerWorkers         int               `env:"RABBITMQ_CONSUMER_WORKERS" envDefault:"8"`
+	RabbitmqConsumerPrefetch        int               `env:"RABBITMQ_CONSUMER_PREFETCH" envDefault:"16"`
+	RabbitmqConsumerDeliveryTimeout time.Duration     `env:"RABBITMQ_CONSUMER_DELIVERY_TIMEOUT" envDefault:"1m"`
+	RabbitmqConsumerShutdownTimeout time.Duration     `env:"RABBITMQ_CONSUMER_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	RabbitmqConsumerStatsInterval   time.Duration     `env:"RABBITMQ_CONSUMER_STATS_INTERVAL" envDefault:"1m"`
+	BcryptHasherCost                int               `env:"BCRYPT_HASHER_COST" envDefault:"10"`
+	PasswordResetValidDurationHours int               `env:"PASSWORD_RESET_VALIDATION_HOURS" envDefault:"24"`
+	SessionIdleTimeout              time.Duration     `env:"SESSION_IDLE_TIMEOUT" envDefault:"720h"`
+	SessionAbsoluteTimeout          time.Duration     `env:"SESSION_ABSOLUTE_TIMEOUT" envDefault:"2160h"`
+	SessionCleanupPeriod            time.Duration     `env:"SESSION_CLEANUP_PERIOD" envDefault:"1h"`
+	SessionCleanupBatchSize         uint              `env:"SESSION_CLEANUP_BATCH_SIZE" envDefault:"1000"`
+	ActivationTokenTTL              time.Duration     `env:"ACTIVATION_TOKEN_TTL" envDefault:"48h"`
+	InactiveUserRetentionPeriod     time.Duration     `env:"INACTIVE_USER_RETENTION_PERIOD" envDefault:"168h"`
+	InactiveUserCleanupPeriod       time.Duration     `env:"INACTIVE_USER_CLEANUP_PERIOD" envDefault:"1h"`
+	InactiveUserCleanupBatchSize    uint              `env:"INACTIVE_USER_CLEANUP_BATCH_SIZE" envDefault:"100"`
+	DefaultPlan                     string            `env:"DEFAULT_PLAN,notEmpty" envDefault:"free"`
+	AdminTokens                     map[string]string `env:"ADMIN_TOKENS"`
+	TotpIssuer                      string            `env:"TOTP_ISSUER" envDefault:"RemindMe"`
+	TwoFactorChallengeTTL           time.Duration     `env:"TWO_FACTOR_CHALLENGE_TTL" envDefault:"5m"`
+	EmailChangeConfirmationPeriod   time.Duration     `env:"EMAIL_CHANGE_CONFIRMATION_PERIOD" envDefault:"24h"`
+	EmailChangeRevertPeriod         time.Duration     `env:"EMAIL_CHANGE_REVERT_PERIOD" envDefault:"168h"`
+	AccountDeletionGracePeriod      time.Duration     `env:"ACCOUNT_DELETION_GRACE_PERIOD" envDefault:"720h"`
+	ReauthenticationPeriod          time.Duration     `env:"REAUTHENTICATION_PERIOD" envDefault:"10m"`
+	AccountPurgePeriod              time.Duration     `env:"ACCOUNT_PURGE_PERIOD" envDefault:"1h"`
+	AccountPurgeBatchSize           uint              `env:"ACCOUNT_PURGE_BATCH_SIZE" envDefault:"100"`
+	UsageReconciliationPeriod       time.Duration     `env:"USAGE_RECONCILIATION_PERIOD" envDefault:"24h"`
+	UsageReconciliationBatchSize    uint              `env:"USAGE_RECONCILIATION_BATCH_SIZE" envDefault:"500"`
+	CalendarFeedBaseURL             url.URL           `env:"CALENDAR_FEED_BASE_URL" envDefault:"https://remindme.one/api/calendar"`
+	CalendarFeedSentPeriod          time.Duration     `env:"CALENDAR_FEED_SENT_PERIOD" envDefault:"720h"`
+	OidcRedirectBaseURL             url.URL           `env:"OIDC_REDIRECT_BASE_URL" envDefault:"https://remindme.one/app/auth/oidc"`
+	OidcAuthorizationTTL            time.Duration     `env:"OIDC_AUTHORIZATION_TTL" envDefault:"10m"`
+	OidcRequestTimeout              time.Duration     `env:"OIDC_REQUEST_TIMEOUT" envDefault:"15s"`
+	GoogleOidcClientID              string            `env:"GOOGLE_OIDC_CLIENT_ID"`
+	GoogleOidcClientSecret          string            `env:"GOOGLE_OIDC_CLIENT_SECRET"`
+	GoogleOidcIssuer                string            `env:"GOOGLE_OIDC_ISSUER" envDefault:"https://accounts.google.com"`
+	GoogleOidcAuthorizationURL      url.URL           `env:"GOOGLE_OIDC_AUTHORIZATION_URL" envDefault:"https://accounts.google.com/o/oauth2/v2/auth"`
+	GoogleOidcTokenURL              url.URL           `env:"GOOGLE_OIDC_TOKEN_URL" envDefault:"https://oauth2.googleapis.com/token"`
+	GoogleOidcJWKSURL               url.URL           `env:"GOOGLE_OIDC_JWKS_URL" envDefault:"https://www.googleapis.com/oauth2/v3/certs"`
+	GithubOauthClientID             string            `env:"GITHUB_OAUTH_CLIENT_ID"`
+	GithubOauthClientSecret         string            `env:"GITHUB_OAUTH_CLIENT_SECRET"`
+	GithubOauthAuthorizationURL     url.URL           `env:"GITHUB_OAUTH_AUTHORIZATION_URL" envDefault:"https://github.com/login/oauth/authorize"`
+	GithubOauthTokenURL             url.URL           `env:"GITHUB_OAUTH_TOKEN_URL" envDefault:"https://github.com/login/oauth/access_token"`
+	GithubAPIURL                    url.URL           `env:"GITHUB_API_URL" envDefault:"https://api.github.com"`
+	TelegramURLSecret               string            `env:"TELEGRAM_URL_SECRET,notEmpty"`
+	TelegramBaseURL                 url.URL           `env:"TELEGRAM_BASE_URL" envDefault:"https://api.telegram.org"`
+	TelegramBots                    []string          `env:"TELEGRAM_BOTS,notEmpty"`
+	TelegramTokens                  []string          `env:"TELEGRAM_TOKENS,notEmpty"`
+	TelegramRequestTimeout          time.Duration     `env:"TELEGRAM_REQUEST_TIMEOUT" envDefault:"30s"`
+	RemindersSchedulingPeriod       time.Duration     `env:"REMINDERS_SCHEDULING_PERIOD" envDefault:"3h"`
+	RemindersSchedulingBatchSize    uint              `env:"REMINDERS_SCHEDULING_BATCH_SIZE" envDefault:"1000"`
+	InstanceID                      string            `env:"INSTANCE_ID"`
+	SchedulerLeaderLockID           int64             `env:"SCHEDULER_LEADER_LOCK_ID" envDefault:"7263540001"`
+	SchedulerElectionPeriod         time.Duration     `env:"SCHEDULER_ELECTION_PERIOD" envDefault:"10s"`
+	SchedulerHealthPort             uint16            `env:"SCHEDULER_HEALTH_PORT" envDefault:"9091"`
+	GoogleRecaptchaSecretKey        string            `env:"GOOGLE_RECAPTCHA_SECRET_KEY,notEmpty"`
+	GoogleRecaptchaScoreThreshold   float64           `env:"GOOGLE_RECAPTCHA_SCORE_THRESHOLD" envDefault:"0.5"`
+	GoogleRecaptchaRequestTimeout   time.Duration     `env:"GOOGLE_RECAPTCHA_REQUEST_TIMEOUT" envDefault:"15s"`
+	AwsRegion                       string            `env:"AWS_REGION,notEmpty"`
+	AwsAccessKey                    string            `env:"AWS_ACCESS_KEY,notEmpty"`
+	AwsSecretKey                    string            `env:"AWS_SECRET_KEY,notEmpty"`
+	AwsEmailSender                  string            `env:"AWS_EMAIL_SENDER,notEmpty" envDefault:"no-reply@remindme.one"`
+	AwsEmailReminderTemplate        string            `env:"AWS_EMAIL_REMINDER_TEMPLATE,notEmpty" envDefault:"reminder-v1"`
+	AwsEmailActivateAccountTemplate string            `env:"AWS_EMAIL_ACTIVATE_ACCOUNT_TEMPLATE,notEmpty" envDefault:"signup-activation-v1"`
+	AwsEmailActivationUrl           url.URL           `env:"AWS_EMAIL_ACTIVATION_URL,notEmpty" envDefault:"https://remindme.one/app/auth/activate"`
+	AwsEmailPasswordResetTemplate   string            `env:"AWS_EMAIL_PASSWORD_RESET_TEMPLATE,notEmpty" envDefault:"password-reset-v1"`
+	AwsEmailPasswordResetBaseUrl    url.URL           `env:"AWS_EMAIL_PASSWORD_RESET_BASE_URL,notEmpty" envDefault:"https://remindme.one/app/auth/password_reset"`
+	AwsEmailActivateChannelTemplate string            `env:"AWS_EMAIL_ACTIVATE_CHANNEL_TEMPLATE,notEmpty" envDefault:"email-channel-confirm-v1"`
+	AwsEmailChangeEmailTemplate     string            `env:"AWS_EMAIL_CHANGE_EMAIL_TEMPLATE,notEmpty" envDefault:"email-change-v1"`
+	AwsEmailChangeEmailBaseUrl      url.URL           `env:"AWS_EMAIL_CHANGE_EMAIL_BASE_URL,notEmpty" envDefault:"https://remindme.one/app/auth/email/confirm"`
+	AwsEmailEmailChangedTemplate    string            `env:"AWS_EMAIL_EMAIL_CHANGED_TEMPLATE,notEmpty" envDefault:"email-changed-v1"`
+	AwsEmailRevertEmailBaseUrl      url.URL           `env:"AWS_EMAIL_REVERT_EMAIL_BASE_URL,notEmpty" envDefault:"https://remindme.one/app/auth/email/revert"`
+	LogLevel                        string            `env:"LOG_LEVEL" envDefault:"info"`
+	LogPackageLevels                map[string]string `env:"LOG_PACKAGE_LEVELS"`
+	LogDevelopment                  bool              `env:"LOG_DEVELOPMENT" envDefault:"false"`
+	SentryDsn                       *url.URL          `env:"SENTRY_DSN"`
+	OtlpTracesEndpoint              *url.URL          `env:"OTLP_TRACES_ENDPOINT"`
+	TracingServiceName              string            `env:"TRACING_SERVICE_NAME" envDefault:"remindme"`
+	TracingSampleRatio              float64           `env:"TRACING_SAMPLE_RATIO" envDefault:"0.1"`
+	TracingBatchSize                int               `env:"TRACING_BATCH_SIZE" envDefault:"512"`
+	TracingQueueSize                int               `env:"TRACING_QUEUE_SIZE" envDefault:"4096"`
+	TracingFlushInterval            time.Duration     `env:"TRACING_FLUSH_INTERVAL" envDefault:"5s"`
+	TracingExportTimeout            time.Duration     `env:"TRACING_EXPORT_TIMEOUT" envDefault:"10s"`
 }
 
 func Load() (*Config, error) {
@@ -49,6 +113,13 @@ func Load() (*Config, error) {
 	if err := env.Parse(cfg); err != nil {
 		return cfg, err
 	}
+	if cfg.InstanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return cfg, err
+		}
+		cfg.InstanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
 	if len(cfg.TelegramBots) == 0 || len(cfg.TelegramBots) != len(cfg.TelegramTokens) {
 		return cfg, fmt.Errorf(
 			"invalid telegram bots (%v) or tokens (%v)",
@@ -3,6 +3,7 @@ package channel
 import (
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/user"
 	"time"
 )
@@ -11,6 +12,10 @@ type ID int64
 
 type VerificationToken string
 
+func (t VerificationToken) Redact() string {
+	return logging.Redacted
+}
+
 type Channel struct {
 	ID                ID
 	Type              Type
@@ -0,0 +1,50 @@
+package channel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrCursorNotValid = errors.New("invalid cursor")
+
+// Cursor is a position in channels read with OrderBy. Unlike an offset it stays right after
+// the channel it was made from, while channels before it are created or deleted.
+type Cursor struct {
+	OrderBy OrderBy
+	ID      ID
+}
+
+func NewCursor(orderBy OrderBy, ch Channel) Cursor {
+	return Cursor{OrderBy: orderBy, ID: ch.ID}
+}
+
+type encodedCursor struct {
+	OrderBy string `json:"o"`
+	ID      ID     `json:"i"`
+}
+
+// String encodes the cursor into an opaque URL safe string.
+func (c Cursor) String() string {
+	data, _ := json.Marshal(encodedCursor{OrderBy: c.OrderBy.v, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func ParseCursor(value string) (c Cursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, ErrCursorNotValid
+	}
+	var decoded encodedCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return c, ErrCursorNotValid
+	}
+	c.ID = decoded.ID
+	if decoded.OrderBy != "" {
+		c.OrderBy, err = ParseOrderBy(decoded.OrderBy)
+		if err != nil {
+			return c, ErrCursorNotValid
+		}
+	}
+	return c, nil
+}
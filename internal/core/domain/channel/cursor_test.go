@@ -0,0 +1,30 @@
+package channel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	for _, orderBy := range []OrderBy{OrderByNotSet, OrderByIDAsc, OrderByIDDesc} {
+		t.Run(orderBy.v, func(t *testing.T) {
+			cursor := NewCursor(orderBy, Channel{ID: 42})
+
+			parsed, err := ParseCursor(cursor.String())
+
+			assert.Nil(t, err)
+			assert.Equal(t, Cursor{OrderBy: orderBy, ID: 42}, parsed)
+		})
+	}
+}
+
+func TestParseCursorNotValid(t *testing.T) {
+	for _, value := range []string{"", "not base64!", "bm90IGpzb24", "eyJvIjoidHlwZV9hc2MifQ"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseCursor(value)
+
+			assert.ErrorIs(t, err, ErrCursorNotValid)
+		})
+	}
+}
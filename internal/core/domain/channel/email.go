@@ -0,0 +1,54 @@
+package channel
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+)
+
+// MoveEmailChannels points the verified email channels of the user from one email to another after
+// the login email is changed, the new email is verified by the change. Nothing is updated if the user
+// already has a channel with the new email. It returns IDs of the updated channels.
+func MoveEmailChannels(
+	ctx context.Context,
+	repository Repository,
+	userID user.ID,
+	from c.Email,
+	to c.Email,
+) ([]ID, error) {
+	channels, err := repository.Read(ctx, ReadOptions{
+		UserIDEquals: c.NewOptional(userID, true),
+		TypeEquals:   c.NewOptional(Email, true),
+		OrderBy:      OrderByIDAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	toMove := make([]Channel, 0, len(channels))
+	for _, channel := range channels {
+		settings, ok := channel.Settings.(*EmailSettings)
+		if !ok {
+			continue
+		}
+		if settings.Email == to {
+			return nil, nil
+		}
+		if settings.Email == from && channel.IsVerified() {
+			toMove = append(toMove, channel)
+		}
+	}
+
+	moved := make([]ID, 0, len(toMove))
+	for _, channel := range toMove {
+		_, err := repository.Update(ctx, UpdateInput{
+			ID:               channel.ID,
+			DoSettingsUpdate: true,
+			Settings:         NewEmailSettings(to),
+		})
+		if err != nil {
+			return nil, err
+		}
+		moved = append(moved, channel.ID)
+	}
+	return moved, nil
+}
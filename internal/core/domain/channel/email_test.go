@@ -0,0 +1,50 @@
+package channel
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveEmailChannels(t *testing.T) {
+	verifiedAt := c.NewOptional(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true)
+	repository := NewFakeRepository()
+	repository.ReadChannels = []Channel{
+		{ID: 1, Type: Email, Settings: NewEmailSettings("old@test.test"), VerifiedAt: verifiedAt},
+		{ID: 2, Type: Email, Settings: NewEmailSettings("other@test.test"), VerifiedAt: verifiedAt},
+		{
+			ID:                3,
+			Type:              Email,
+			Settings:          NewEmailSettings("old@test.test"),
+			VerificationToken: c.NewOptional(VerificationToken("token"), true),
+		},
+	}
+
+	moved, err := MoveEmailChannels(context.Background(), repository, 1, "old@test.test", "new@test.test")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{1}, moved)
+	assert.Equal(
+		t,
+		[]UpdateInput{{ID: 1, DoSettingsUpdate: true, Settings: NewEmailSettings("new@test.test")}},
+		repository.Updated,
+	)
+}
+
+func TestMoveEmailChannelsKeepsExistingChannel(t *testing.T) {
+	verifiedAt := c.NewOptional(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true)
+	repository := NewFakeRepository()
+	repository.ReadChannels = []Channel{
+		{ID: 1, Type: Email, Settings: NewEmailSettings("old@test.test"), VerifiedAt: verifiedAt},
+		{ID: 2, Type: Email, Settings: NewEmailSettings("new@test.test"), VerifiedAt: verifiedAt},
+	}
+
+	moved, err := MoveEmailChannels(context.Background(), repository, 1, "old@test.test", "new@test.test")
+
+	assert.Nil(t, err)
+	assert.Empty(t, moved)
+	assert.Empty(t, repository.Updated)
+}
@@ -25,6 +25,8 @@ type ReadOptions struct {
 	OrderBy         OrderBy
 	Limit           c.Optional[uint]
 	Offset          uint
+	// After selects channels following the cursor in OrderBy, the cursor OrderBy is not checked.
+	After c.Optional[Cursor]
 }
 
 type UpdateInput struct {
@@ -40,6 +42,8 @@ type UpdateInput struct {
 type Repository interface {
 	Create(ctx context.Context, input CreateInput) (Channel, error)
 	GetByID(ctx context.Context, id ID) (Channel, error)
+	// HasVerificationToken reports whether the token is the pending verification token of the channel.
+	HasVerificationToken(ctx context.Context, id ID, token VerificationToken) (bool, error)
 	Read(ctx context.Context, options ReadOptions) ([]Channel, error)
 	Count(ctx context.Context, options ReadOptions) (uint, error)
 	Update(ctx context.Context, input UpdateInput) (Channel, error)
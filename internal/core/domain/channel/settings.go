@@ -2,6 +2,7 @@ package channel
 
 import (
 	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
 )
 
 type Settings interface {
@@ -30,6 +31,10 @@ type TelegramBot string
 
 type TelegramChatID int64
 
+func (id TelegramChatID) Redact() string {
+	return logging.MaskID(int64(id))
+}
+
 type TelegramSettings struct {
 	Bot    TelegramBot
 	ChatID TelegramChatID
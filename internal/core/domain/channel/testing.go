@@ -18,6 +18,7 @@ type FakeRepository struct {
 	CountChannels      uint
 	Options            []ReadOptions
 	UpdateError        error
+	Updated            []UpdateInput
 	lock               sync.Mutex
 }
 
@@ -62,6 +63,13 @@ func (r *FakeRepository) GetByID(ctx context.Context, id ID) (c Channel, err err
 	return r.GetByIDChannel, nil
 }
 
+func (r *FakeRepository) HasVerificationToken(ctx context.Context, id ID, token VerificationToken) (bool, error) {
+	if r.GetByIDError != nil {
+		return false, r.GetByIDError
+	}
+	return r.GetByIDChannel.VerificationToken.IsPresent && r.GetByIDChannel.VerificationToken.Value == token, nil
+}
+
 func (r *FakeRepository) Count(ctx context.Context, options ReadOptions) (count uint, err error) {
 	if r.CountReturnsError {
 		return count, fmt.Errorf("could not count channels")
@@ -76,6 +84,9 @@ func (r *FakeRepository) Update(ctx context.Context, input UpdateInput) (channel
 	if r.UpdateError != nil {
 		return channel, r.UpdateError
 	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Updated = append(r.Updated, input)
 	channel.ID = input.ID
 	if input.DoVerificationTokenUpdate {
 		channel.VerificationToken = input.VerificationToken
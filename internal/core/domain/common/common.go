@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"remindme/internal/core/domain/logging"
 	"strings"
 )
 
@@ -26,3 +27,7 @@ type Email string
 func NewEmail(rawEmail string) Email {
 	return Email(strings.ToLower(rawEmail))
 }
+
+func (e Email) Redact() string {
+	return logging.MaskEmail(string(e))
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey int
+
+const (
+	requestScopeKey contextKey = iota
+	userIDKey
+	reminderIDKey
+)
+
+// requestScope is shared by every context derived from a request, so a user ID
+// set deep inside a service is also attached to entries logged by HTTP middleware.
+type requestScope struct {
+	requestID string
+	userID    int64
+	hasUserID bool
+	lock      sync.RWMutex
+}
+
+// WithRequestID starts a request scope, log entries of ctx and contexts derived from it
+// get the request ID and the user ID once it is known.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestScopeKey, &requestScope{requestID: requestID})
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	scope, ok := ctx.Value(requestScopeKey).(*requestScope)
+	if !ok {
+		return "", false
+	}
+	return scope.requestID, true
+}
+
+// WithUserID attaches the user ID to log entries of ctx and of the request scope of ctx, if any.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	if scope, ok := ctx.Value(requestScopeKey).(*requestScope); ok {
+		scope.lock.Lock()
+		scope.userID = userID
+		scope.hasUserID = true
+		scope.lock.Unlock()
+	}
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	if userID, ok := ctx.Value(userIDKey).(int64); ok {
+		return userID, true
+	}
+	scope, ok := ctx.Value(requestScopeKey).(*requestScope)
+	if !ok {
+		return 0, false
+	}
+	scope.lock.RLock()
+	defer scope.lock.RUnlock()
+	return scope.userID, scope.hasUserID
+}
+
+func WithReminderID(ctx context.Context, reminderID int64) context.Context {
+	return context.WithValue(ctx, reminderIDKey, reminderID)
+}
+
+func ReminderIDFromContext(ctx context.Context) (int64, bool) {
+	reminderID, ok := ctx.Value(reminderIDKey).(int64)
+	return reminderID, ok
+}
+
+// ContextEntries returns the correlation IDs known to ctx as log entries.
+func ContextEntries(ctx context.Context) []LogEntry {
+	entries := make([]LogEntry, 0, 3)
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		entries = append(entries, Entry("requestID", requestID))
+	}
+	if userID, ok := UserIDFromContext(ctx); ok {
+		entries = append(entries, Entry("userID", userID))
+	}
+	if reminderID, ok := ReminderIDFromContext(ctx); ok {
+		entries = append(entries, Entry("reminderID", reminderID))
+	}
+	return entries
+}
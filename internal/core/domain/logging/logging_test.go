@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextEntries(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Empty(ContextEntries(context.Background()))
+
+	requestCtx := WithRequestID(context.Background(), "request-1")
+	ctx := WithReminderID(WithUserID(requestCtx, 42), 7)
+	assert.Equal(
+		[]LogEntry{Entry("requestID", "request-1"), Entry("userID", int64(42)), Entry("reminderID", int64(7))},
+		ContextEntries(ctx),
+	)
+	assert.Equal(
+		[]LogEntry{Entry("requestID", "request-1"), Entry("userID", int64(42))},
+		ContextEntries(requestCtx),
+		"user ID is shared with the request scope",
+	)
+}
+
+func TestMasking(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal("j***@example.com", MaskEmail("john@example.com"))
+	assert.Equal(Redacted, MaskEmail("not-an-email"))
+	assert.Equal("***789", MaskID(123456789))
+	assert.Equal(Redacted, MaskID(12))
+}
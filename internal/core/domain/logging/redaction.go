@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"strconv"
+	"strings"
+)
+
+const Redacted = "***"
+
+// Redactable is implemented by sensitive values, loggers write the result of Redact instead of the value.
+type Redactable interface {
+	Redact() string
+}
+
+// MaskEmail keeps the first letter and the domain of an email, e.g. "j***@example.com".
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return Redacted
+	}
+	return local[:1] + Redacted + "@" + domain
+}
+
+// MaskID keeps the last three digits of an identifier, e.g. "***789".
+func MaskID(id int64) string {
+	s := strconv.FormatInt(id, 10)
+	if len(s) <= 3 {
+		return Redacted
+	}
+	return Redacted + s[len(s)-3:]
+}
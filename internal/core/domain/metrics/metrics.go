@@ -0,0 +1,12 @@
+package metrics
+
+import "remindme/internal/core/domain/channel"
+
+type Metrics interface {
+	// RemindersScheduled counts reminders handed over to the queue and the ones which could not be.
+	RemindersScheduled(scheduled, failed int)
+	// ReminderSent counts sending of a reminder to a channel, err is nil if the reminder has been sent.
+	ReminderSent(channelType channel.Type, err error)
+	// RateLimitExceeded counts requests rejected by the named rate limit.
+	RateLimitExceeded(limit string)
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"remindme/internal/core/domain/channel"
+	"sync"
+)
+
+type FakeMetrics struct {
+	Scheduled         int
+	SchedulingFailed  int
+	Sent              map[channel.Type]int
+	SendingFailed     map[channel.Type]int
+	RateLimitRejected map[string]int
+	lock              sync.Mutex
+}
+
+func NewFakeMetrics() *FakeMetrics {
+	return &FakeMetrics{
+		Sent:              make(map[channel.Type]int),
+		SendingFailed:     make(map[channel.Type]int),
+		RateLimitRejected: make(map[string]int),
+	}
+}
+
+func (m *FakeMetrics) RemindersScheduled(scheduled, failed int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.Scheduled += scheduled
+	m.SchedulingFailed += failed
+}
+
+func (m *FakeMetrics) ReminderSent(channelType channel.Type, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err != nil {
+		m.SendingFailed[channelType]++
+		return
+	}
+	m.Sent[channelType]++
+}
+
+func (m *FakeMetrics) RateLimitExceeded(limit string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.RateLimitRejected[limit]++
+}
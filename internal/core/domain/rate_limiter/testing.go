@@ -4,6 +4,7 @@ import "context"
 
 type FakeRateLimiter struct {
 	IsAllowed bool
+	Keys      []string
 }
 
 func NewFakeRateLimiter(isAllowed bool) *FakeRateLimiter {
@@ -11,6 +12,7 @@ func NewFakeRateLimiter(isAllowed bool) *FakeRateLimiter {
 }
 
 func (rl *FakeRateLimiter) CheckLimit(ctx context.Context, key string, limit Limit) Result {
+	rl.Keys = append(rl.Keys, key)
 	if rl.IsAllowed {
 		return Result{IsAllowed: true}
 	}
@@ -0,0 +1,51 @@
+package reminder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrCursorNotValid = errors.New("invalid cursor")
+
+// Cursor is a position in reminders read with OrderBy. Unlike an offset it stays right after
+// the reminder it was made from, while reminders before it are created or deleted.
+type Cursor struct {
+	OrderBy OrderBy
+	At      time.Time
+	ID      ID
+}
+
+func NewCursor(orderBy OrderBy, rem Reminder) Cursor {
+	return Cursor{OrderBy: orderBy, At: rem.At, ID: rem.ID}
+}
+
+type encodedCursor struct {
+	OrderBy OrderBy   `json:"o"`
+	At      time.Time `json:"a"`
+	ID      ID        `json:"i"`
+}
+
+// String encodes the cursor into an opaque URL safe string.
+func (c Cursor) String() string {
+	data, _ := json.Marshal(encodedCursor(c))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func ParseCursor(value string) (c Cursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, ErrCursorNotValid
+	}
+	var decoded encodedCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return c, ErrCursorNotValid
+	}
+	if decoded.OrderBy != OrderByNotSet {
+		if _, err := ParseOrderBy(string(decoded.OrderBy)); err != nil {
+			return c, ErrCursorNotValid
+		}
+	}
+	return Cursor(decoded), nil
+}
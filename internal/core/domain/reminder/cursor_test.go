@@ -0,0 +1,32 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	at := time.Date(2020, 1, 1, 9, 30, 0, 0, time.UTC)
+	for _, orderBy := range []OrderBy{OrderByNotSet, OrderByIDAsc, OrderByIDDesc, OrderByAtAsc, OrderByAtDesc} {
+		t.Run(string(orderBy), func(t *testing.T) {
+			cursor := NewCursor(orderBy, Reminder{ID: 42, At: at})
+
+			parsed, err := ParseCursor(cursor.String())
+
+			assert.Nil(t, err)
+			assert.Equal(t, Cursor{OrderBy: orderBy, At: at, ID: 42}, parsed)
+		})
+	}
+}
+
+func TestParseCursorNotValid(t *testing.T) {
+	for _, value := range []string{"", "not base64!", "bm90IGpzb24", "eyJvIjoiYm9keV9hc2MifQ"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseCursor(value)
+
+			assert.ErrorIs(t, err, ErrCursorNotValid)
+		})
+	}
+}
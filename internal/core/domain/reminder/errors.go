@@ -14,6 +14,18 @@ var (
 	ErrReminderPermission          = errors.New("reminder permission error")
 	ErrReminderNotActive           = errors.New("reminder is not active")
 	ErrReminderIsSending           = errors.New("reminder is sending")
+	ErrReminderNotPeriodic         = errors.New("reminder is not periodic")
+	ErrReminderNotPaused           = errors.New("reminder is not paused")
 
 	ErrNaturalQueryParsing = errors.New("reminder params parsing error")
+
+	ErrImportInvalidFile           = errors.New("import file is not valid")
+	ErrImportTooManyRows           = errors.New("import file has too many reminders")
+	ErrImportInvalidRow            = errors.New("import row is not valid")
+	ErrImportUnsupportedRecurrence = errors.New("recurrence rule is not supported")
+
+	ErrBulkActionNotValid    = errors.New("bulk action is not valid")
+	ErrBulkSelectionNotValid = errors.New("either reminder IDs or a filter must be set")
+	ErrBulkTooManyReminders  = errors.New("bulk action selects too many reminders")
+	ErrBulkShiftNotSet       = errors.New("bulk action shift is not set")
 )
@@ -56,6 +56,14 @@ func (e Every) String() string {
 	return fmt.Sprintf("%d %s", e.n, e.period)
 }
 
+func (e Every) N() uint32 {
+	return e.n
+}
+
+func (e Every) Period() Period {
+	return e.period
+}
+
 func (e Every) IsZero() bool {
 	return e.n == 0 && e.period == PeriodUnknown
 }
@@ -139,6 +147,24 @@ func (e Every) NextFrom(t time.Time) time.Time {
 	}
 }
 
+// NextAfter returns the first time of the recurrence starting at t which is after the time,
+// t is returned if it is after the time already. The recurrence must be valid.
+func (e Every) NextAfter(t time.Time, after time.Time) time.Time {
+	if t.After(after) {
+		return t
+	}
+	switch e.period {
+	case PeriodMinute, PeriodHour, PeriodDay, PeriodWeek:
+		d := e.TotalDuration()
+		return t.Add((after.Sub(t)/d + 1) * d)
+	default:
+		for !t.After(after) {
+			t = e.NextFrom(t)
+		}
+		return t
+	}
+}
+
 func (e Every) PerDayCount() float64 {
 	return float64(24*time.Hour) / float64(e.TotalDuration())
 }
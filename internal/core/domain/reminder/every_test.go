@@ -362,3 +362,61 @@ func TestPerDayCount(t *testing.T) {
 		})
 	}
 }
+
+func TestEveryNextAfter(t *testing.T) {
+	cases := []struct {
+		e        Every
+		t        string
+		after    string
+		expected string
+	}{
+		{
+			e:        EveryDay,
+			t:        "2023-01-10T10:00:00Z",
+			after:    "2023-01-01T00:00:00Z",
+			expected: "2023-01-10T10:00:00Z",
+		},
+		{
+			e:        NewEvery(15, PeriodMinute),
+			t:        "2010-01-01T10:00:00Z",
+			after:    "2023-01-01T00:07:00Z",
+			expected: "2023-01-01T00:15:00Z",
+		},
+		{
+			e:        EveryDay,
+			t:        "2022-12-01T10:00:00Z",
+			after:    "2023-01-01T10:00:00Z",
+			expected: "2023-01-02T10:00:00Z",
+		},
+		{
+			e:        NewEvery(2, PeriodWeek),
+			t:        "2022-12-01T10:00:00Z",
+			after:    "2023-01-01T00:00:00Z",
+			expected: "2023-01-12T10:00:00Z",
+		},
+		{
+			e:        EveryMonth,
+			t:        "2022-10-31T10:00:00Z",
+			after:    "2023-01-01T00:00:00Z",
+			expected: "2023-01-30T10:00:00Z",
+		},
+		{
+			e:        EveryYear,
+			t:        "2019-03-01T10:00:00Z",
+			after:    "2023-01-01T00:00:00Z",
+			expected: "2023-03-01T10:00:00Z",
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.e.String(), func(t *testing.T) {
+			from, err := time.Parse(time.RFC3339, testcase.t)
+			assert.Nil(t, err)
+			after, err := time.Parse(time.RFC3339, testcase.after)
+			assert.Nil(t, err)
+			expected, err := time.Parse(time.RFC3339, testcase.expected)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, testcase.e.NextAfter(from, after))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"time"
+)
+
+// PauseUpdate returns the update pausing an active periodic reminder.
+// A paused reminder keeps its At and is neither scheduled nor sent until it is resumed.
+func PauseUpdate(rem Reminder) (update UpdateInput, err error) {
+	if !rem.IsActive() {
+		return update, ErrReminderNotActive
+	}
+	if !rem.Every.IsPresent {
+		return update, ErrReminderNotPeriodic
+	}
+	return UpdateInput{
+		ID:                  rem.ID,
+		DoStatusUpdate:      true,
+		Status:              StatusPaused,
+		DoScheduledAtUpdate: true,
+	}, nil
+}
+
+// ResumeUpdate returns the update resuming a paused reminder. Occurrences which passed
+// while the reminder was paused are not sent, it is resumed at the next future occurrence.
+func ResumeUpdate(rem Reminder, now time.Time) (update UpdateInput, err error) {
+	if !rem.IsPaused() {
+		return update, ErrReminderNotPaused
+	}
+	at := rem.At
+	if rem.Every.IsPresent {
+		at = rem.Every.Value.NextAfter(rem.At, now.Add(MIN_DURATION_FROM_NOW))
+	}
+	update = UpdateInput{
+		ID:                  rem.ID,
+		DoAtUpdate:          true,
+		At:                  at,
+		DoStatusUpdate:      true,
+		Status:              StatusCreated,
+		DoScheduledAtUpdate: true,
+	}
+	if at.Sub(now) < DURATION_FOR_SCHEDULING {
+		update.Status = StatusScheduled
+		update.ScheduledAt = c.NewOptional(now, true)
+	}
+	return update, nil
+}
+
+// CheckResumeLimit checks that one more active reminder does not exceed the active reminder count limit.
+// Resuming does not change the count if paused reminders count as active already.
+func CheckResumeLimit(ctx context.Context, usage UsageRepository, userID user.ID, limits user.Limits) error {
+	if !limits.ActiveReminderCount.IsPresent || limits.PausedRemindersCountAsActive {
+		return nil
+	}
+	count, err := usage.GetActiveCount(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= uint(limits.ActiveReminderCount.Value) {
+		return user.ErrLimitActiveReminderCountExceeded
+	}
+	return nil
+}
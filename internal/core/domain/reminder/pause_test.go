@@ -0,0 +1,144 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseUpdate(t *testing.T) {
+	periodic := Reminder{ID: 1, Status: StatusScheduled, Every: c.NewOptional(EveryDay, true)}
+	cases := []struct {
+		id            string
+		rem           Reminder
+		expectedError error
+	}{
+		{id: "periodic", rem: periodic},
+		{id: "one-off", rem: Reminder{ID: 1, Status: StatusCreated}, expectedError: ErrReminderNotPeriodic},
+		{
+			id:            "paused",
+			rem:           Reminder{ID: 1, Status: StatusPaused, Every: c.NewOptional(EveryDay, true)},
+			expectedError: ErrReminderNotActive,
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			update, err := PauseUpdate(testcase.rem)
+
+			assert.ErrorIs(t, err, testcase.expectedError)
+			if testcase.expectedError == nil {
+				assert.Equal(t, UpdateInput{
+					ID:                  1,
+					DoStatusUpdate:      true,
+					Status:              StatusPaused,
+					DoScheduledAtUpdate: true,
+				}, update)
+			}
+		})
+	}
+}
+
+func TestResumeUpdate(t *testing.T) {
+	now := time.Date(2020, 1, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		id                  string
+		at                  time.Time
+		every               Every
+		expectedAt          time.Time
+		expectedStatus      Status
+		expectedScheduledAt c.Optional[time.Time]
+	}{
+		{
+			id:             "future",
+			at:             now.Add(72 * time.Hour),
+			every:          EveryWeek,
+			expectedAt:     now.Add(72 * time.Hour),
+			expectedStatus: StatusCreated,
+		},
+		{
+			id:                  "missed occurrences",
+			at:                  time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+			every:               EveryDay,
+			expectedAt:          time.Date(2020, 1, 11, 9, 0, 0, 0, time.UTC),
+			expectedStatus:      StatusScheduled,
+			expectedScheduledAt: c.NewOptional(now, true),
+		},
+		{
+			id:             "missed monthly occurrences",
+			at:             time.Date(2019, 11, 10, 12, 0, 0, 0, time.UTC),
+			every:          EveryMonth,
+			expectedAt:     time.Date(2020, 2, 10, 12, 0, 0, 0, time.UTC),
+			expectedStatus: StatusCreated,
+		},
+		{
+			id:                  "too close to now",
+			at:                  now.Add(MIN_DURATION_FROM_NOW / 2),
+			every:               EveryHour,
+			expectedAt:          now.Add(MIN_DURATION_FROM_NOW/2 + time.Hour),
+			expectedStatus:      StatusScheduled,
+			expectedScheduledAt: c.NewOptional(now, true),
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			rem := Reminder{ID: 1, Status: StatusPaused, At: testcase.at, Every: c.NewOptional(testcase.every, true)}
+
+			update, err := ResumeUpdate(rem, now)
+
+			assert.Nil(t, err)
+			assert.Equal(t, UpdateInput{
+				ID:                  1,
+				DoAtUpdate:          true,
+				At:                  testcase.expectedAt,
+				DoStatusUpdate:      true,
+				Status:              testcase.expectedStatus,
+				DoScheduledAtUpdate: true,
+				ScheduledAt:         testcase.expectedScheduledAt,
+			}, update)
+		})
+	}
+
+	_, err := ResumeUpdate(Reminder{Status: StatusScheduled}, now)
+	assert.ErrorIs(t, err, ErrReminderNotPaused)
+}
+
+func TestCheckResumeLimit(t *testing.T) {
+	cases := []struct {
+		id            string
+		limits        user.Limits
+		expectedError error
+	}{
+		{id: "unlimited", limits: user.Limits{}},
+		{id: "below limit", limits: user.Limits{ActiveReminderCount: c.NewOptional(uint32(3), true)}},
+		{
+			id:            "limit reached",
+			limits:        user.Limits{ActiveReminderCount: c.NewOptional(uint32(2), true)},
+			expectedError: user.ErrLimitActiveReminderCountExceeded,
+		},
+		{
+			id: "paused count as active",
+			limits: user.Limits{
+				ActiveReminderCount:          c.NewOptional(uint32(2), true),
+				PausedRemindersCountAsActive: true,
+			},
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			usage := NewTestUsageRepository()
+			usage.ActiveCount = 2
+			usage.PausedCount = 1
+
+			err := CheckResumeLimit(context.Background(), usage, 1, testcase.limits)
+
+			assert.ErrorIs(t, err, testcase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"time"
+)
+
+// SentQuota is the usage of the sent reminder limit of a user in the current quota window.
+type SentQuota struct {
+	Window user.QuotaWindow
+	Limit  uint32
+	Count  uint
+	// Since is the beginning of the window, reminders sent since then are counted.
+	Since time.Time
+	// ResetAt is when the counted reminders start leaving the window and the quota is freed.
+	ResetAt time.Time
+}
+
+func (q SentQuota) IsExceeded() bool {
+	return q.Count >= uint(q.Limit)
+}
+
+// Covers reports if a reminder sent at the time would still be limited by the current usage.
+func (q SentQuota) Covers(at time.Time) bool {
+	return at.Before(q.ResetAt)
+}
+
+// GetSentQuota reads the number of reminders successfully sent by the user in the quota window
+// containing now from usage counters, calendar windows follow the user time zone.
+// The quota is not present if the user has no limit.
+func GetSentQuota(
+	ctx context.Context,
+	usage UsageRepository,
+	userID user.ID,
+	limits user.Limits,
+	loc *time.Location,
+	now time.Time,
+) (quota c.Optional[SentQuota], err error) {
+	if !limits.MonthlySentReminderCount.IsPresent {
+		return quota, nil
+	}
+
+	window := limits.SentReminderQuotaWindow
+	if window != user.QuotaWindowRolling30Days {
+		window = user.QuotaWindowCalendarMonth
+	}
+	// Calendar windows start at a bucket boundary, the bucket containing a rolling window start
+	// is counted as a whole which may only overestimate the usage.
+	since := SentUsageBucket(window.Start(now, loc))
+	count, err := usage.CountSent(ctx, userID, since)
+	if err != nil {
+		return quota, err
+	}
+
+	resetAt := window.ResetAt(now, loc)
+	if window == user.QuotaWindowRolling30Days && count > 0 {
+		// The quota is freed when the earliest counted bucket gets out of the window. Buckets follow
+		// sent times, reminders are not necessarily sent in the order they are scheduled at.
+		first, err := usage.GetFirstSentBucket(ctx, userID, since)
+		if err != nil {
+			return quota, err
+		}
+		if first.IsPresent {
+			resetAt = window.ResetAt(first.Value.Add(SENT_USAGE_BUCKET_DURATION), loc)
+		}
+	}
+
+	return c.NewOptional(SentQuota{
+		Window:  window,
+		Limit:   limits.MonthlySentReminderCount.Value,
+		Count:   count,
+		Since:   since,
+		ResetAt: resetAt,
+	}, true), nil
+}
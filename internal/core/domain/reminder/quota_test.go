@@ -0,0 +1,68 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSentQuotaRollingWindow(t *testing.T) {
+	now := time.Date(2020, 2, 1, 10, 7, 0, 0, time.UTC)
+	limits := user.Limits{
+		MonthlySentReminderCount: c.NewOptional(uint32(10), true),
+		SentReminderQuotaWindow:  user.QuotaWindowRolling30Days,
+	}
+	usage := NewTestUsageRepository()
+	ctx := context.Background()
+	// The window starts at 10:07, the bucket containing its start begins at 10:00.
+	assert.Nil(t, usage.AddSent(ctx, 1, time.Date(2020, 1, 2, 9, 59, 0, 0, time.UTC), 1))
+	assert.Nil(t, usage.AddSent(ctx, 1, time.Date(2020, 1, 2, 10, 3, 0, 0, time.UTC), 1))
+	assert.Nil(t, usage.AddSent(ctx, 1, time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC), 1))
+
+	quota, err := GetSentQuota(ctx, usage, 1, limits, time.UTC, now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.NewOptional(SentQuota{
+		Window:  user.QuotaWindowRolling30Days,
+		Limit:   10,
+		Count:   2,
+		Since:   time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+		ResetAt: time.Date(2020, 2, 1, 10, 15, 0, 0, time.UTC),
+	}, true), quota)
+}
+
+func TestGetSentQuotaResetFollowsSentTime(t *testing.T) {
+	now := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	limits := user.Limits{
+		MonthlySentReminderCount: c.NewOptional(uint32(10), true),
+		SentReminderQuotaWindow:  user.QuotaWindowRolling30Days,
+	}
+	usage := NewTestUsageRepository()
+	ctx := context.Background()
+	// The reminder scheduled earlier is sent later, e.g. after it was retried.
+	sentLater := Reminder{
+		CreatedBy: 1,
+		At:        time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC),
+		Status:    StatusSentSuccess,
+		SentAt:    c.NewOptional(time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC), true),
+	}
+	sentEarlier := Reminder{
+		CreatedBy: 1,
+		At:        time.Date(2020, 1, 10, 0, 0, 0, 0, time.UTC),
+		Status:    StatusSentSuccess,
+		SentAt:    c.NewOptional(time.Date(2020, 1, 10, 0, 5, 0, 0, time.UTC), true),
+	}
+	assert.Nil(t, UpdateUsage(ctx, usage, Reminder{}, sentLater))
+	assert.Nil(t, UpdateUsage(ctx, usage, Reminder{}, sentEarlier))
+
+	quota, err := GetSentQuota(ctx, usage, 1, limits, time.UTC, now)
+
+	assert.Nil(t, err)
+	assert.True(t, quota.IsPresent)
+	assert.Equal(t, uint(2), quota.Value.Count)
+	assert.Equal(t, time.Date(2020, 2, 9, 0, 15, 0, 0, time.UTC), quota.Value.ResetAt)
+}
@@ -44,6 +44,10 @@ func (r *Reminder) IsActive() bool {
 	return r.Status == StatusCreated || r.Status == StatusScheduled
 }
 
+func (r *Reminder) IsPaused() bool {
+	return r.Status == StatusPaused
+}
+
 type ReminderWithChannels struct {
 	Reminder
 	ChannelIDs []channel.ID
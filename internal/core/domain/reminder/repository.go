@@ -20,14 +20,25 @@ type CreateInput struct {
 	Status      Status
 }
 
+// ReadOptions filters reminders, After bounds are inclusive and Before bounds are exclusive.
 type ReadOptions struct {
 	CreatedByEquals c.Optional[user.ID]
 	SentAfter       c.Optional[time.Time]
 	StatusIn        c.Optional[[]Status]
 	StatusNotEquals c.Optional[Status]
+	// BodySearch is a full-text query in the web search syntax, e.g. "call -mom".
+	BodySearch      c.Optional[string]
+	AtAfter         c.Optional[time.Time]
+	AtBefore        c.Optional[time.Time]
+	IsPeriodic      c.Optional[bool]
+	ChannelIDEquals c.Optional[channel.ID]
+	CreatedAfter    c.Optional[time.Time]
+	CreatedBefore   c.Optional[time.Time]
 	OrderBy         OrderBy
 	Limit           c.Optional[uint]
 	Offset          uint
+	// After selects reminders following the cursor in OrderBy, the cursor OrderBy is not checked.
+	After c.Optional[Cursor]
 }
 
 type UpdateInput struct {
@@ -48,9 +59,18 @@ type UpdateInput struct {
 	CanceledAt          c.Optional[time.Time]
 }
 
+// ReadForSchedulingInput selects a page of reminders to be scheduled,
+// pages are ordered by At and ID and start right after (AfterAt, AfterID).
+type ReadForSchedulingInput struct {
+	AtBefore time.Time
+	AfterAt  time.Time
+	AfterID  ID
+	Limit    uint
+}
+
 type ScheduleInput struct {
+	IDs         []ID
 	ScheduledAt time.Time
-	AtBefore    time.Time
 }
 
 type ReminderRepository interface {
@@ -60,6 +80,7 @@ type ReminderRepository interface {
 	Read(ctx context.Context, options ReadOptions) ([]ReminderWithChannels, error)
 	Count(ctx context.Context, options ReadOptions) (uint, error)
 	Update(ctx context.Context, input UpdateInput) (Reminder, error)
+	ReadForScheduling(ctx context.Context, input ReadForSchedulingInput) ([]Reminder, error)
 	Schedule(ctx context.Context, input ScheduleInput) ([]Reminder, error)
 	Delete(ctx context.Context, id ID) error
 }
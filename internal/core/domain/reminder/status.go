@@ -22,6 +22,8 @@ func ParseStatus(value string) (Status, error) {
 		return StatusSentLimitExceeded, nil
 	case "canceled":
 		return StatusCanceled, nil
+	case "paused":
+		return StatusPaused, nil
 	default:
 		return StatusInvalid, ErrParseStatus
 	}
@@ -36,4 +38,5 @@ const (
 	StatusSentError         = Status("sent_error")
 	StatusSentLimitExceeded = Status("sent_limit_exceeded")
 	StatusCanceled          = Status("canceled")
+	StatusPaused            = Status("paused")
 )
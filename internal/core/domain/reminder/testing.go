@@ -2,33 +2,40 @@ package reminder
 
 import (
 	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
 	"sync"
 	"time"
 )
 
 type TestReminderRepository struct {
-	CreateError          error
-	CreatedCount         int
-	Created              Reminder
-	CreatedID            ID
-	GetByIDError         error
-	GetByIDReminder      ReminderWithChannels
-	ReadError            error
-	ReadReminders        []ReminderWithChannels
-	ReadWith             []ReadOptions
-	CountError           error
-	CountResult          uint
-	CountWith            []ReadOptions
-	ReminderBeforeUpdate Reminder
-	UpdateError          error
-	LockError            error
-	LockWith             []ID
-	ScheduleWith         []ScheduleInput
-	ScheduleResult       []Reminder
-	ScheduleError        error
-	DeleteWith           []ID
-	DeleteError          error
-	lock                 sync.Mutex
+	CreateError      error
+	CreatedCount     int
+	Created          Reminder
+	CreatedID        ID
+	GetByIDError     error
+	GetByIDReminder  ReminderWithChannels
+	GetByIDReminders map[ID]ReminderWithChannels
+	ReadError        error
+	ReadReminders    []ReminderWithChannels
+	// ReadPages are returned by consecutive reads instead of ReadReminders if set.
+	ReadPages              [][]ReminderWithChannels
+	ReadWith               []ReadOptions
+	CountError             error
+	CountResult            uint
+	CountWith              []ReadOptions
+	ReminderBeforeUpdate   Reminder
+	UpdateError            error
+	LockError              error
+	LockWith               []ID
+	ReadForSchedulingWith  []ReadForSchedulingInput
+	ReadForSchedulingError error
+	ScheduleWith           []ScheduleInput
+	ScheduleResult         []Reminder
+	ScheduleError          error
+	DeleteWith             []ID
+	DeleteError            error
+	lock                   sync.Mutex
 }
 
 func NewTestReminderRepository() *TestReminderRepository {
@@ -73,6 +80,13 @@ func (r *TestReminderRepository) GetByID(ctx context.Context, id ID) (rem Remind
 	if r.GetByIDError != nil {
 		return rem, r.GetByIDError
 	}
+	if r.GetByIDReminders != nil {
+		rem, ok := r.GetByIDReminders[id]
+		if !ok {
+			return rem, ErrReminderDoesNotExist
+		}
+		return rem, nil
+	}
 	rem = r.GetByIDReminder
 	rem.ID = id
 	return rem, nil
@@ -85,6 +99,12 @@ func (r *TestReminderRepository) Read(ctx context.Context, options ReadOptions)
 	r.lock.Lock()
 	defer r.lock.Unlock()
 	r.ReadWith = append(r.ReadWith, options)
+	if r.ReadPages != nil {
+		if len(r.ReadWith) > len(r.ReadPages) {
+			return nil, nil
+		}
+		return r.ReadPages[len(r.ReadWith)-1], nil
+	}
 	return r.ReadReminders, nil
 }
 
@@ -103,6 +123,9 @@ func (r *TestReminderRepository) Update(ctx context.Context, input UpdateInput)
 		return rem, r.UpdateError
 	}
 	rem = r.ReminderBeforeUpdate
+	if before, ok := r.GetByIDReminders[input.ID]; ok {
+		rem = before.Reminder
+	}
 	rem.ID = input.ID
 	if input.DoAtUpdate {
 		rem.At = input.At
@@ -128,6 +151,31 @@ func (r *TestReminderRepository) Update(ctx context.Context, input UpdateInput)
 	return rem, nil
 }
 
+// ReadForScheduling pages through ScheduleResult which must be ordered by At and ID.
+func (r *TestReminderRepository) ReadForScheduling(
+	ctx context.Context,
+	input ReadForSchedulingInput,
+) ([]Reminder, error) {
+	if r.ReadForSchedulingError != nil {
+		return nil, r.ReadForSchedulingError
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ReadForSchedulingWith = append(r.ReadForSchedulingWith, input)
+
+	reminders := make([]Reminder, 0, input.Limit)
+	for _, rem := range r.ScheduleResult {
+		if uint(len(reminders)) == input.Limit {
+			break
+		}
+		if rem.At.Before(input.AfterAt) || rem.At.Equal(input.AfterAt) && rem.ID <= input.AfterID {
+			continue
+		}
+		reminders = append(reminders, rem)
+	}
+	return reminders, nil
+}
+
 func (r *TestReminderRepository) Schedule(ctx context.Context, input ScheduleInput) ([]Reminder, error) {
 	if r.ScheduleError != nil {
 		return nil, r.ScheduleError
@@ -135,7 +183,18 @@ func (r *TestReminderRepository) Schedule(ctx context.Context, input ScheduleInp
 	r.lock.Lock()
 	defer r.lock.Unlock()
 	r.ScheduleWith = append(r.ScheduleWith, input)
-	return r.ScheduleResult, nil
+
+	reminders := make([]Reminder, 0, len(input.IDs))
+	for _, id := range input.IDs {
+		for _, rem := range r.ScheduleResult {
+			if rem.ID == id {
+				rem.Status = StatusScheduled
+				rem.ScheduledAt = c.NewOptional(input.ScheduledAt, true)
+				reminders = append(reminders, rem)
+			}
+		}
+	}
+	return reminders, nil
 }
 
 func (r *TestReminderRepository) Delete(ctx context.Context, id ID) error {
@@ -148,6 +207,133 @@ func (r *TestReminderRepository) Delete(ctx context.Context, id ID) error {
 	return nil
 }
 
+type TestUsageRepository struct {
+	Error            error
+	ActiveCount      uint
+	PausedCount      uint
+	SentBuckets      map[time.Time]uint
+	CountSentWith    []time.Time
+	ReconcileWith    []ReconcileUsageInput
+	ReconcileResults []ReconcileUsageResult
+	lock             sync.Mutex
+}
+
+func NewTestUsageRepository() *TestUsageRepository {
+	return &TestUsageRepository{SentBuckets: make(map[time.Time]uint)}
+}
+
+func (r *TestUsageRepository) AddActive(ctx context.Context, userID user.ID, delta int) error {
+	if r.Error != nil {
+		return r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ActiveCount = addDelta(r.ActiveCount, delta)
+	return nil
+}
+
+func (r *TestUsageRepository) AddPaused(ctx context.Context, userID user.ID, delta int) error {
+	if r.Error != nil {
+		return r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.PausedCount = addDelta(r.PausedCount, delta)
+	return nil
+}
+
+func (r *TestUsageRepository) AddSent(ctx context.Context, userID user.ID, at time.Time, delta int) error {
+	if r.Error != nil {
+		return r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	bucket := SentUsageBucket(at)
+	r.SentBuckets[bucket] = addDelta(r.SentBuckets[bucket], delta)
+	return nil
+}
+
+func (r *TestUsageRepository) GetActiveCount(ctx context.Context, userID user.ID) (uint, error) {
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ActiveCount, nil
+}
+
+func (r *TestUsageRepository) GetPausedCount(ctx context.Context, userID user.ID) (uint, error) {
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.PausedCount, nil
+}
+
+func (r *TestUsageRepository) CountSent(ctx context.Context, userID user.ID, since time.Time) (uint, error) {
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.CountSentWith = append(r.CountSentWith, since)
+	count := uint(0)
+	for bucket, bucketCount := range r.SentBuckets {
+		if !bucket.Before(SentUsageBucket(since)) {
+			count += bucketCount
+		}
+	}
+	return count, nil
+}
+
+func (r *TestUsageRepository) GetFirstSentBucket(
+	ctx context.Context,
+	userID user.ID,
+	since time.Time,
+) (first c.Optional[time.Time], err error) {
+	if r.Error != nil {
+		return first, r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for bucket, bucketCount := range r.SentBuckets {
+		if bucketCount == 0 || bucket.Before(SentUsageBucket(since)) {
+			continue
+		}
+		if !first.IsPresent || bucket.Before(first.Value) {
+			first = c.NewOptional(bucket, true)
+		}
+	}
+	return first, nil
+}
+
+// Reconcile returns ReconcileResults one by one and an empty result when there is none left.
+func (r *TestUsageRepository) Reconcile(
+	ctx context.Context,
+	input ReconcileUsageInput,
+) (result ReconcileUsageResult, err error) {
+	if r.Error != nil {
+		return result, r.Error
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ReconcileWith = append(r.ReconcileWith, input)
+	if len(r.ReconcileResults) == 0 {
+		return result, nil
+	}
+	result = r.ReconcileResults[0]
+	r.ReconcileResults = r.ReconcileResults[1:]
+	return result, nil
+}
+
+func addDelta(value uint, delta int) uint {
+	if delta < 0 && uint(-delta) > value {
+		return 0
+	}
+	return uint(int(value) + delta)
+}
+
 type TestReminderChannelRepository struct {
 	CreateError             error
 	CreatedForReminder      ID
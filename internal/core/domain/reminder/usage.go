@@ -0,0 +1,133 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"time"
+)
+
+const (
+	// SENT_USAGE_BUCKET_DURATION is the granularity sent reminders are counted with. Every time zone
+	// offset is a multiple of it, so calendar quota windows always start at a bucket boundary.
+	SENT_USAGE_BUCKET_DURATION = 15 * time.Minute
+	// SENT_USAGE_RETENTION covers the longest quota window, older sent reminder counters are not needed.
+	SENT_USAGE_RETENTION = 35 * 24 * time.Hour
+)
+
+// SentUsageBucket returns the beginning of the bucket a reminder sent at the time is counted in.
+func SentUsageBucket(at time.Time) time.Time {
+	return at.UTC().Truncate(SENT_USAGE_BUCKET_DURATION)
+}
+
+type ReconcileUsageInput struct {
+	AfterUserID user.ID
+	Limit       uint
+	// SentAfter is the beginning of the period sent reminder counters are recomputed for,
+	// counters before it are deleted.
+	SentAfter time.Time
+}
+
+type ReconcileUsageResult struct {
+	// UserIDs are the users whose counters were reconciled in the batch.
+	UserIDs []user.ID
+	// ActiveFixed is the number of users whose active or paused reminder counter was wrong.
+	ActiveFixed uint
+	// SentFixed is the number of sent reminder counters which were wrong.
+	SentFixed uint
+}
+
+// UsageRepository keeps per-user counters of reminders, so that limit checks do not have to count
+// reminder rows. The counters must be updated in the same transaction as the reminders.
+type UsageRepository interface {
+	AddActive(ctx context.Context, userID user.ID, delta int) error
+	AddPaused(ctx context.Context, userID user.ID, delta int) error
+	AddSent(ctx context.Context, userID user.ID, at time.Time, delta int) error
+	GetActiveCount(ctx context.Context, userID user.ID) (uint, error)
+	GetPausedCount(ctx context.Context, userID user.ID) (uint, error)
+	// CountSent returns the number of reminders sent in the bucket containing since and the later ones.
+	CountSent(ctx context.Context, userID user.ID, since time.Time) (uint, error)
+	// GetFirstSentBucket returns the earliest bucket with sent reminders out of the bucket
+	// containing since and the later ones.
+	GetFirstSentBucket(ctx context.Context, userID user.ID, since time.Time) (c.Optional[time.Time], error)
+	// Reconcile recomputes counters of a batch of users ordered by ID from reminders,
+	// the method works only within a DB transaction.
+	Reconcile(ctx context.Context, input ReconcileUsageInput) (ReconcileUsageResult, error)
+}
+
+// UpdateUsage applies a change of a reminder to the usage counters of its creator.
+// A zero before stands for a created reminder and a zero after for a deleted one.
+func UpdateUsage(ctx context.Context, usage UsageRepository, before, after Reminder) error {
+	userID := after.CreatedBy
+	if after.Status == StatusInvalid {
+		userID = before.CreatedBy
+	}
+
+	activeDelta := countActive(after) - countActive(before)
+	pausedDelta := countPaused(after) - countPaused(before)
+	beforeSent, isBeforeSent := sentBucket(before)
+	afterSent, isAfterSent := sentBucket(after)
+	isSentChanged := isBeforeSent != isAfterSent || !beforeSent.Equal(afterSent)
+	if activeDelta == 0 && pausedDelta == 0 && !isSentChanged {
+		return nil
+	}
+
+	// The active counter is always touched first, its row serializes counter updates of the user
+	// with reconciliation.
+	if err := usage.AddActive(ctx, userID, activeDelta); err != nil {
+		return err
+	}
+	if pausedDelta != 0 {
+		if err := usage.AddPaused(ctx, userID, pausedDelta); err != nil {
+			return err
+		}
+	}
+	if !isSentChanged {
+		return nil
+	}
+	if isBeforeSent {
+		if err := usage.AddSent(ctx, userID, beforeSent, -1); err != nil {
+			return err
+		}
+	}
+	if isAfterSent {
+		if err := usage.AddSent(ctx, userID, afterSent, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountActive returns the number of reminders counted against the active reminder count limit.
+func CountActive(ctx context.Context, usage UsageRepository, userID user.ID, limits user.Limits) (uint, error) {
+	count, err := usage.GetActiveCount(ctx, userID)
+	if err != nil || !limits.PausedRemindersCountAsActive {
+		return count, err
+	}
+	pausedCount, err := usage.GetPausedCount(ctx, userID)
+	if err != nil {
+		return count, err
+	}
+	return count + pausedCount, nil
+}
+
+func countActive(rem Reminder) int {
+	if rem.IsActive() {
+		return 1
+	}
+	return 0
+}
+
+func countPaused(rem Reminder) int {
+	if rem.IsPaused() {
+		return 1
+	}
+	return 0
+}
+
+func sentBucket(rem Reminder) (time.Time, bool) {
+	if rem.Status != StatusSentSuccess || !rem.SentAt.IsPresent {
+		return time.Time{}, false
+	}
+	return SentUsageBucket(rem.SentAt.Value), true
+}
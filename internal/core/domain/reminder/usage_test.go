@@ -0,0 +1,99 @@
+package reminder
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUsage(t *testing.T) {
+	sentAt := time.Date(2020, 1, 1, 10, 20, 0, 0, time.UTC)
+	scheduled := Reminder{CreatedBy: 1, Status: StatusScheduled}
+	sending := Reminder{CreatedBy: 1, Status: StatusSending}
+	sent := Reminder{CreatedBy: 1, Status: StatusSentSuccess, SentAt: c.NewOptional(sentAt, true)}
+	paused := Reminder{CreatedBy: 1, Status: StatusPaused}
+	cases := []struct {
+		id                  string
+		before              Reminder
+		after               Reminder
+		expectedActiveCount uint
+		expectedPausedCount uint
+		expectedSentBuckets map[time.Time]uint
+	}{
+		{id: "created", after: scheduled, expectedActiveCount: 2, expectedPausedCount: 1, expectedSentBuckets: map[time.Time]uint{}},
+		{id: "deleted", before: scheduled, expectedActiveCount: 0, expectedPausedCount: 1, expectedSentBuckets: map[time.Time]uint{}},
+		{id: "rescheduled", before: scheduled, after: scheduled, expectedActiveCount: 1, expectedPausedCount: 1, expectedSentBuckets: map[time.Time]uint{}},
+		{id: "sending", before: scheduled, after: sending, expectedActiveCount: 0, expectedPausedCount: 1, expectedSentBuckets: map[time.Time]uint{}},
+		{
+			id:                  "sent",
+			before:              sending,
+			after:               sent,
+			expectedActiveCount: 1,
+			expectedPausedCount: 1,
+			expectedSentBuckets: map[time.Time]uint{time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC): 1},
+		},
+		{
+			id:                  "sent deleted",
+			before:              sent,
+			expectedActiveCount: 1,
+			expectedPausedCount: 1,
+			expectedSentBuckets: map[time.Time]uint{time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC): 0},
+		},
+		{
+			id:                  "paused",
+			before:              scheduled,
+			after:               paused,
+			expectedActiveCount: 0,
+			expectedPausedCount: 2,
+			expectedSentBuckets: map[time.Time]uint{},
+		},
+		{
+			id:                  "resumed",
+			before:              paused,
+			after:               scheduled,
+			expectedActiveCount: 2,
+			expectedPausedCount: 0,
+			expectedSentBuckets: map[time.Time]uint{},
+		},
+		{
+			id:                  "paused deleted",
+			before:              paused,
+			expectedActiveCount: 1,
+			expectedPausedCount: 0,
+			expectedSentBuckets: map[time.Time]uint{},
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			usage := NewTestUsageRepository()
+			usage.ActiveCount = 1
+			usage.PausedCount = 1
+
+			err := UpdateUsage(context.Background(), usage, testcase.before, testcase.after)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expectedActiveCount, usage.ActiveCount)
+			assert.Equal(t, testcase.expectedPausedCount, usage.PausedCount)
+			assert.Equal(t, testcase.expectedSentBuckets, usage.SentBuckets)
+		})
+	}
+}
+
+func TestCountActive(t *testing.T) {
+	usage := NewTestUsageRepository()
+	usage.ActiveCount = 2
+	usage.PausedCount = 3
+
+	count, err := CountActive(context.Background(), usage, 1, user.Limits{})
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), count)
+
+	count, err = CountActive(context.Background(), usage, 1, user.Limits{PausedRemindersCountAsActive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, uint(5), count)
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+type FakeSpan struct {
+	Name       string
+	Attributes []SpanAttribute
+	Errors     []error
+	IsEnded    bool
+	lock       sync.Mutex
+}
+
+func (s *FakeSpan) SetAttributes(attributes ...SpanAttribute) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Attributes = append(s.Attributes, attributes...)
+}
+
+func (s *FakeSpan) RecordError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Errors = append(s.Errors, err)
+}
+
+func (s *FakeSpan) End() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.IsEnded = true
+}
+
+type FakeTracer struct {
+	Spans []*FakeSpan
+	lock  sync.Mutex
+}
+
+func NewFakeTracer() *FakeTracer {
+	return &FakeTracer{}
+}
+
+func (t *FakeTracer) Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span) {
+	span := &FakeSpan{Name: name, Attributes: attributes}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Spans = append(t.Spans, span)
+	return ctx, span
+}
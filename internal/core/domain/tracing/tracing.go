@@ -0,0 +1,24 @@
+package tracing
+
+import "context"
+
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+func Attribute(k string, v interface{}) SpanAttribute {
+	return SpanAttribute{Key: k, Value: v}
+}
+
+type Span interface {
+	SetAttributes(attributes ...SpanAttribute)
+	RecordError(err error)
+	End()
+}
+
+type Tracer interface {
+	// Start starts a child span of the span found in ctx, or a new trace if there is none.
+	// The returned context carries the started span.
+	Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span)
+}
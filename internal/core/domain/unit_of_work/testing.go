@@ -8,31 +8,49 @@ import (
 )
 
 type FakeUnitOfWorkContext struct {
-	UserRepository            *user.FakeUserRepository
-	SessionRepository         *user.FakeSessionRepository
-	LimitsRepository          *user.FakeLimitsRepository
-	ChannelRepository         *channel.FakeRepository
-	ReminderRepository        *reminder.TestReminderRepository
-	ReminderChannelRepository *reminder.TestReminderChannelRepository
-	WasRollbackCalled         bool
-	WasCommitCalled           bool
+	UserRepository             *user.FakeUserRepository
+	SessionRepository          *user.FakeSessionRepository
+	APITokenRepository         *user.FakeAPITokenRepository
+	LimitsRepository           *user.FakeLimitsRepository
+	LimitsAuditRepository      *user.FakeLimitsAuditRepository
+	ExternalIdentityRepository *user.FakeExternalIdentityRepository
+	AccountDeletionRepository  *user.FakeAccountDeletionRepository
+	EmailChangeRepository      *user.FakeEmailChangeRepository
+	ChannelRepository          *channel.FakeRepository
+	ReminderRepository         *reminder.TestReminderRepository
+	ReminderChannelRepository  *reminder.TestReminderChannelRepository
+	UsageRepository            *reminder.TestUsageRepository
+	WasRollbackCalled          bool
+	WasCommitCalled            bool
 }
 
 func NewFakeUnitOfWorkContext(
 	userRepository *user.FakeUserRepository,
 	sessionRepository *user.FakeSessionRepository,
+	apiTokenRepository *user.FakeAPITokenRepository,
 	limitsRepository *user.FakeLimitsRepository,
+	limitsAuditRepository *user.FakeLimitsAuditRepository,
+	externalIdentityRepository *user.FakeExternalIdentityRepository,
+	accountDeletionRepository *user.FakeAccountDeletionRepository,
+	emailChangeRepository *user.FakeEmailChangeRepository,
 	channelRepository *channel.FakeRepository,
 	reminderRepository *reminder.TestReminderRepository,
 	reminderChannelRepository *reminder.TestReminderChannelRepository,
+	usageRepository *reminder.TestUsageRepository,
 ) *FakeUnitOfWorkContext {
 	return &FakeUnitOfWorkContext{
-		UserRepository:            userRepository,
-		SessionRepository:         sessionRepository,
-		LimitsRepository:          limitsRepository,
-		ChannelRepository:         channelRepository,
-		ReminderRepository:        reminderRepository,
-		ReminderChannelRepository: reminderChannelRepository,
+		UserRepository:             userRepository,
+		SessionRepository:          sessionRepository,
+		APITokenRepository:         apiTokenRepository,
+		LimitsRepository:           limitsRepository,
+		LimitsAuditRepository:      limitsAuditRepository,
+		ExternalIdentityRepository: externalIdentityRepository,
+		AccountDeletionRepository:  accountDeletionRepository,
+		EmailChangeRepository:      emailChangeRepository,
+		ChannelRepository:          channelRepository,
+		ReminderRepository:         reminderRepository,
+		ReminderChannelRepository:  reminderChannelRepository,
+		UsageRepository:            usageRepository,
 	}
 }
 
@@ -54,10 +72,30 @@ func (c *FakeUnitOfWorkContext) Sessions() user.SessionRepository {
 	return c.SessionRepository
 }
 
+func (c *FakeUnitOfWorkContext) APITokens() user.APITokenRepository {
+	return c.APITokenRepository
+}
+
 func (c *FakeUnitOfWorkContext) Limits() user.LimitsRepository {
 	return c.LimitsRepository
 }
 
+func (c *FakeUnitOfWorkContext) LimitsAudit() user.LimitsAuditRepository {
+	return c.LimitsAuditRepository
+}
+
+func (c *FakeUnitOfWorkContext) ExternalIdentities() user.ExternalIdentityRepository {
+	return c.ExternalIdentityRepository
+}
+
+func (c *FakeUnitOfWorkContext) AccountDeletions() user.AccountDeletionRepository {
+	return c.AccountDeletionRepository
+}
+
+func (c *FakeUnitOfWorkContext) EmailChanges() user.EmailChangeRepository {
+	return c.EmailChangeRepository
+}
+
 func (c *FakeUnitOfWorkContext) Channels() channel.Repository {
 	return c.ChannelRepository
 }
@@ -70,6 +108,10 @@ func (c *FakeUnitOfWorkContext) ReminderChannels() reminder.ReminderChannelRepos
 	return c.ReminderChannelRepository
 }
 
+func (c *FakeUnitOfWorkContext) Usage() reminder.UsageRepository {
+	return c.UsageRepository
+}
+
 type FakeUnitOfWork struct {
 	Context *FakeUnitOfWorkContext
 }
@@ -80,10 +122,16 @@ func NewFakeUnitOfWork() *FakeUnitOfWork {
 		Context: NewFakeUnitOfWorkContext(
 			userRepository,
 			user.NewFakeSessionRepository(userRepository),
+			user.NewFakeAPITokenRepository(userRepository),
 			user.NewFakeLimitsRepository(),
+			user.NewFakeLimitsAuditRepository(),
+			user.NewFakeExternalIdentityRepository(userRepository),
+			user.NewFakeAccountDeletionRepository(),
+			user.NewFakeEmailChangeRepository(),
 			channel.NewFakeRepository(),
 			reminder.NewTestReminderRepository(),
 			reminder.NewTestReminderChannelRepository(),
+			reminder.NewTestUsageRepository(),
 		),
 	}
 }
@@ -100,10 +148,30 @@ func (u *FakeUnitOfWork) Sessions() *user.FakeSessionRepository {
 	return u.Context.SessionRepository
 }
 
+func (u *FakeUnitOfWork) APITokens() *user.FakeAPITokenRepository {
+	return u.Context.APITokenRepository
+}
+
 func (u *FakeUnitOfWork) Limits() *user.FakeLimitsRepository {
 	return u.Context.LimitsRepository
 }
 
+func (u *FakeUnitOfWork) LimitsAudit() *user.FakeLimitsAuditRepository {
+	return u.Context.LimitsAuditRepository
+}
+
+func (u *FakeUnitOfWork) ExternalIdentities() *user.FakeExternalIdentityRepository {
+	return u.Context.ExternalIdentityRepository
+}
+
+func (u *FakeUnitOfWork) AccountDeletions() *user.FakeAccountDeletionRepository {
+	return u.Context.AccountDeletionRepository
+}
+
+func (u *FakeUnitOfWork) EmailChanges() *user.FakeEmailChangeRepository {
+	return u.Context.EmailChangeRepository
+}
+
 func (u *FakeUnitOfWork) Channels() *channel.FakeRepository {
 	return u.Context.ChannelRepository
 }
@@ -115,3 +183,7 @@ func (u *FakeUnitOfWork) Reminders() *reminder.TestReminderRepository {
 func (u *FakeUnitOfWork) ReminderChannels() *reminder.TestReminderChannelRepository {
 	return u.Context.ReminderChannelRepository
 }
+
+func (u *FakeUnitOfWork) Usage() *reminder.TestUsageRepository {
+	return u.Context.UsageRepository
+}
@@ -13,10 +13,16 @@ type Context interface {
 
 	Users() user.UserRepository
 	Sessions() user.SessionRepository
+	APITokens() user.APITokenRepository
 	Limits() user.LimitsRepository
+	LimitsAudit() user.LimitsAuditRepository
+	ExternalIdentities() user.ExternalIdentityRepository
+	AccountDeletions() user.AccountDeletionRepository
+	EmailChanges() user.EmailChangeRepository
 	Channels() channel.Repository
 	Reminders() reminder.ReminderRepository
 	ReminderChannels() reminder.ReminderChannelRepository
+	Usage() reminder.UsageRepository
 }
 
 type UnitOfWork interface {
@@ -0,0 +1,15 @@
+package user
+
+import "time"
+
+// AccountDeletion is requested by the user, the account is purged together with all
+// its data once PurgeAt has passed, unless the deletion is canceled before.
+type AccountDeletion struct {
+	UserID      ID
+	RequestedAt time.Time
+	PurgeAt     time.Time
+}
+
+func (d AccountDeletion) IsDue(at time.Time) bool {
+	return !d.PurgeAt.After(at)
+}
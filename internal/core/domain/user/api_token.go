@@ -0,0 +1,73 @@
+package user
+
+import (
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"strings"
+	"time"
+)
+
+// API_TOKEN_PREFIX starts every API token, so it can be told apart from a session token
+// passed the same way and recognized by secret scanners.
+const API_TOKEN_PREFIX = "rmpat_"
+
+// API_TOKEN_MAX_LIFETIME limits for how long an API token can be issued.
+const API_TOKEN_MAX_LIFETIME = 365 * 24 * time.Hour
+
+type Scope string
+
+const (
+	ScopeRemindersRead  Scope = "reminders:read"
+	ScopeRemindersWrite Scope = "reminders:write"
+	ScopeChannelsRead   Scope = "channels:read"
+	ScopeChannelsWrite  Scope = "channels:write"
+	ScopeProfileRead    Scope = "profile:read"
+	ScopeProfileWrite   Scope = "profile:write"
+)
+
+var Scopes = []Scope{
+	ScopeRemindersRead,
+	ScopeRemindersWrite,
+	ScopeChannelsRead,
+	ScopeChannelsWrite,
+	ScopeProfileRead,
+	ScopeProfileWrite,
+}
+
+type APITokenID int64
+
+type APITokenSecret string
+
+func (t APITokenSecret) Redact() string {
+	return logging.Redacted
+}
+
+// IsAPIToken reports whether the bearer token is an API token and not a session token.
+func IsAPIToken(token SessionToken) bool {
+	return strings.HasPrefix(string(token), API_TOKEN_PREFIX)
+}
+
+// APIToken is a personal access token used for automation, it grants only its scopes.
+type APIToken struct {
+	ID         APITokenID
+	UserID     ID
+	Name       string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt c.Optional[time.Time]
+}
+
+func (t APIToken) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type APITokenGenerator interface {
+	// GenerateAPITokenSecret returns a secret starting with API_TOKEN_PREFIX.
+	GenerateAPITokenSecret() APITokenSecret
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"time"
+)
+
+// CalendarFeedToken is the secret part of the ICS subscription URL. Calendar apps can't send
+// headers, so the token authenticates feed requests alone and grants read access to reminders only.
+type CalendarFeedToken string
+
+func (t CalendarFeedToken) Redact() string {
+	return logging.Redacted
+}
+
+// CalendarFeed is the ICS subscription of a user, a user has at most one feed.
+type CalendarFeed struct {
+	UserID     ID
+	CreatedAt  time.Time
+	LastUsedAt c.Optional[time.Time]
+}
+
+type CalendarFeedTokenGenerator interface {
+	GenerateCalendarFeedToken() CalendarFeedToken
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"time"
+)
+
+type EmailChangeID int64
+
+type EmailChangeToken string
+
+func (t EmailChangeToken) Redact() string {
+	return logging.Redacted
+}
+
+// EmailChange is pending until it is confirmed with the token sent to the new email.
+// A confirmed change may be reverted until RevertExpiresAt with the token sent to the old email.
+type EmailChange struct {
+	ID              EmailChangeID
+	UserID          ID
+	OldEmail        c.Email
+	NewEmail        c.Email
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+	ConfirmedAt     c.Optional[time.Time]
+	RevertExpiresAt c.Optional[time.Time]
+}
+
+type EmailChangeTokenGenerator interface {
+	GenerateEmailChangeToken() EmailChangeToken
+}
+
+type EmailChangeSender interface {
+	// SendEmailChangeConfirmation sends the confirmation token to the new email.
+	SendEmailChangeConfirmation(ctx context.Context, change EmailChange, token EmailChangeToken) error
+	// SendEmailChangeNotification notifies the old email about the change and sends the revert token.
+	SendEmailChangeNotification(ctx context.Context, change EmailChange, revertToken EmailChangeToken) error
+}
@@ -12,6 +12,48 @@ var (
 	ErrSessionDoesNotExist        = errors.New("session does not exist")
 	ErrInvalidPasswordFResetToken = errors.New("invalid password reset token")
 	ErrInvalidActivationToken     = errors.New("invalid activation token")
+	ErrUserIsNotAnonymous         = errors.New("user is not anonymous")
+	ErrUserIsAlreadyActive        = errors.New("user is already active")
+)
+
+var (
+	ErrAPITokenDoesNotExist = errors.New("API token does not exist")
+	ErrInsufficientScope    = errors.New("insufficient scope")
+)
+
+var (
+	ErrCalendarFeedDoesNotExist = errors.New("calendar feed does not exist")
+)
+
+var (
+	ErrEmailIsNotSet           = errors.New("email is not set")
+	ErrEmailIsNotChanged       = errors.New("email is not changed")
+	ErrInvalidEmailChangeToken = errors.New("invalid email change token")
+)
+
+var (
+	ErrAccountDeletionAlreadyRequested = errors.New("account deletion is already requested")
+	ErrAccountDeletionNotRequested     = errors.New("account deletion is not requested")
+	ErrReauthenticationRequired        = errors.New("reauthentication is required")
+)
+
+var (
+	ErrUnknownOIDCProvider           = errors.New("unknown OIDC provider")
+	ErrInvalidOIDCState              = errors.New("invalid OIDC state")
+	ErrOIDCAuthenticationFailed      = errors.New("OIDC authentication failed")
+	ErrExternalIdentityAlreadyLinked = errors.New("external identity is already linked")
+)
+
+var (
+	ErrTOTPNotEnrolled           = errors.New("TOTP is not enrolled")
+	ErrTOTPAlreadyEnabled        = errors.New("TOTP is already enabled")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid two-factor challenge")
+)
+
+var (
+	ErrPlanDoesNotExist = errors.New("plan does not exist")
+	ErrLimitsDoNotExist = errors.New("limits do not exist")
 )
 
 var (
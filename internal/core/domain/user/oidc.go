@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"time"
+)
+
+type OIDCProviderName string
+
+type OIDCState string
+
+func (s OIDCState) Redact() string {
+	return logging.Redacted
+}
+
+type OIDCNonce string
+
+func (n OIDCNonce) Redact() string {
+	return logging.Redacted
+}
+
+type OIDCCodeVerifier string
+
+func (v OIDCCodeVerifier) Redact() string {
+	return logging.Redacted
+}
+
+// Challenge returns the S256 PKCE code challenge of the verifier.
+func (v OIDCCodeVerifier) Challenge() string {
+	hash := sha256.Sum256([]byte(v))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+type OIDCAuthorizationCode string
+
+func (c OIDCAuthorizationCode) Redact() string {
+	return logging.Redacted
+}
+
+// OIDCAuthorization is the pending authorization request, it is kept until the provider
+// redirects the user back with the state. UserID is set when the external identity is
+// linked to the logged in user instead of logging in.
+type OIDCAuthorization struct {
+	State        OIDCState
+	Provider     OIDCProviderName
+	Nonce        OIDCNonce
+	CodeVerifier OIDCCodeVerifier
+	UserID       c.Optional[ID]
+	CreatedAt    time.Time
+}
+
+// OIDCClaims are the verified claims of the authenticated user.
+// Email is set only if the provider verified it.
+type OIDCClaims struct {
+	Subject string
+	Email   c.Optional[c.Email]
+}
+
+type OIDCProvider interface {
+	AuthorizationURL(state OIDCState, nonce OIDCNonce, codeChallenge string) string
+	// Exchange redeems the authorization code and verifies the ID token. Errors caused by
+	// invalid codes or tokens wrap ErrOIDCAuthenticationFailed.
+	Exchange(
+		ctx context.Context,
+		code OIDCAuthorizationCode,
+		codeVerifier OIDCCodeVerifier,
+		nonce OIDCNonce,
+	) (OIDCClaims, error)
+}
+
+type OIDCAuthorizationGenerator interface {
+	GenerateOIDCState() OIDCState
+	GenerateOIDCNonce() OIDCNonce
+	GenerateOIDCCodeVerifier() OIDCCodeVerifier
+}
+
+type ExternalIdentityID int64
+
+type ExternalIdentity struct {
+	ID        ExternalIdentityID
+	UserID    ID
+	Provider  OIDCProviderName
+	Subject   string
+	CreatedAt time.Time
+}
@@ -1,9 +1,16 @@
 package user
 
-import "context"
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+)
 
 type PasswordResetToken string
 
+func (t PasswordResetToken) Redact() string {
+	return logging.Redacted
+}
+
 type PasswordResetter interface {
 	GenerateToken(user User) PasswordResetToken
 	GetUserID(token PasswordResetToken) (ID, bool)
@@ -0,0 +1,51 @@
+package user
+
+import (
+	c "remindme/internal/core/domain/common"
+	"time"
+)
+
+type PlanName string
+
+const (
+	PlanFree PlanName = "free"
+	PlanPro  PlanName = "pro"
+	PlanTeam PlanName = "team"
+)
+
+// Plan is a named set of limits stored in the DB, assigning a plan to a user
+// copies the plan limits to the user limits.
+type Plan struct {
+	Name   PlanName
+	Limits Limits
+}
+
+// UserPlan holds the actual user limits and the plan they were assigned from.
+// The limits may differ from the plan ones if they were overridden individually.
+// Anonymous users do not have a plan.
+type UserPlan struct {
+	UserID ID
+	Plan   c.Optional[PlanName]
+	Limits Limits
+}
+
+type LimitsAuditEntryID int64
+
+type LimitsAuditAction string
+
+const (
+	LimitsAuditActionAssignPlan     LimitsAuditAction = "assign_plan"
+	LimitsAuditActionOverrideLimits LimitsAuditAction = "override_limits"
+)
+
+// LimitsAuditEntry records a change of user limits made by an admin.
+type LimitsAuditEntry struct {
+	ID        LimitsAuditEntryID
+	UserID    ID
+	Actor     string
+	Action    LimitsAuditAction
+	Plan      c.Optional[PlanName]
+	Before    Limits
+	After     Limits
+	CreatedAt time.Time
+}
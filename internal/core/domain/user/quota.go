@@ -0,0 +1,39 @@
+package user
+
+import "time"
+
+// QuotaWindow defines the period in which sent reminders are counted against the limit.
+type QuotaWindow string
+
+const (
+	// QuotaWindowCalendarMonth resets the quota at midnight of the first day of a month in the user time zone.
+	QuotaWindowCalendarMonth QuotaWindow = "calendar_month"
+	// QuotaWindowRolling30Days counts reminders sent in the last 30 days.
+	QuotaWindowRolling30Days QuotaWindow = "rolling_30_days"
+
+	ROLLING_QUOTA_WINDOW_DURATION = 30 * 24 * time.Hour
+)
+
+// Start returns the beginning of the window containing at, unknown windows are treated as calendar months.
+func (w QuotaWindow) Start(at time.Time, loc *time.Location) time.Time {
+	if w == QuotaWindowRolling30Days {
+		return at.Add(-ROLLING_QUOTA_WINDOW_DURATION)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := at.In(loc)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc).UTC()
+}
+
+// ResetAt returns when a reminder sent at sentAt stops being counted against the quota.
+func (w QuotaWindow) ResetAt(sentAt time.Time, loc *time.Location) time.Time {
+	if w == QuotaWindowRolling30Days {
+		return sentAt.Add(ROLLING_QUOTA_WINDOW_DURATION)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := sentAt.In(loc)
+	return time.Date(local.Year(), local.Month()+1, 1, 0, 0, 0, 0, loc).UTC()
+}
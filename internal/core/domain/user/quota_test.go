@@ -0,0 +1,31 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarMonthRespectsTimeZone(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	// It is already February 1st 06:00 in UTC+10.
+	at := time.Date(2020, 1, 31, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2020, 1, 31, 14, 0, 0, 0, time.UTC), QuotaWindowCalendarMonth.Start(at, loc))
+	assert.Equal(t, time.Date(2020, 2, 29, 14, 0, 0, 0, time.UTC), QuotaWindowCalendarMonth.ResetAt(at, loc))
+}
+
+func TestCalendarMonthDefaultsToUTC(t *testing.T) {
+	at := time.Date(2020, 12, 31, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC), QuotaWindow("").Start(at, nil))
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), QuotaWindow("").ResetAt(at, nil))
+}
+
+func TestRolling30Days(t *testing.T) {
+	at := time.Date(2020, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2020, 2, 14, 12, 0, 0, 0, time.UTC), QuotaWindowRolling30Days.Start(at, time.UTC))
+	assert.Equal(t, time.Date(2020, 4, 14, 12, 0, 0, 0, time.UTC), QuotaWindowRolling30Days.ResetAt(at, time.UTC))
+}
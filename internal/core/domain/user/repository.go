@@ -14,42 +14,283 @@ type CreateUserInput struct {
 	TimeZone        *time.Location
 	ActivatedAt     c.Optional[time.Time]
 	ActivationToken c.Optional[ActivationToken]
+	// ActivationTokenExpiresAt must be set together with the activation token.
+	ActivationTokenExpiresAt c.Optional[time.Time]
 }
 
 type UpdateUserInput struct {
 	ID               ID
 	DoTimeZoneUpdate bool
 	TimeZone         *time.Location
+	DoEmailUpdate    bool
+	Email            c.Email
+}
+
+type ClaimUserInput struct {
+	ID                       ID
+	Email                    c.Email
+	PasswordHash             PasswordHash
+	ActivationToken          ActivationToken
+	ActivationTokenExpiresAt time.Time
+}
+
+type SetActivationTokenInput struct {
+	ID        ID
+	Token     ActivationToken
+	ExpiresAt time.Time
+}
+
+type DeleteInactiveUsersInput struct {
+	CreatedBefore time.Time
+	Limit         uint
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, input CreateUserInput) (User, error)
 	GetByID(ctx context.Context, id ID) (User, error)
 	GetByEmail(ctx context.Context, email c.Email) (User, error)
+	// Activate returns ErrInvalidActivationToken if the token does not exist or is expired at the given time.
 	Activate(ctx context.Context, token ActivationToken, at time.Time) (User, error)
+	// SetActivationToken replaces the activation token of a user who is not activated yet,
+	// ErrUserDoesNotExist is returned if there is no such user or the user has no pending activation.
+	SetActivationToken(ctx context.Context, input SetActivationTokenInput) (User, error)
+	// Claim attaches email and password to an anonymous user, ErrUserIsNotAnonymous
+	// is returned if the user can not be claimed or has an external identity linked.
+	Claim(ctx context.Context, input ClaimUserInput) (User, error)
 	SetPassword(ctx context.Context, id ID, password PasswordHash) error
+	// Update returns ErrEmailAlreadyExists if the email is updated to the one of another user.
 	Update(ctx context.Context, input UpdateUserInput) (User, error)
+	// Delete deletes the user with all the data, it returns ErrUserDoesNotExist if there is no such user.
+	Delete(ctx context.Context, id ID) error
+	// DeleteInactive deletes up to input.Limit users which were never activated
+	// and were created before input.CreatedBefore. It returns the number of deleted users.
+	DeleteInactive(ctx context.Context, input DeleteInactiveUsersInput) (uint, error)
 }
 
 type CreateSessionInput struct {
 	UserID    ID
 	Token     SessionToken
 	CreatedAt time.Time
+	UserAgent string
+	IP        string
+}
+
+type GetUserBySessionTokenInput struct {
+	Token  SessionToken
+	At     time.Time
+	Expiry SessionExpiry
+}
+
+type ListSessionsInput struct {
+	UserID       ID
+	CurrentToken SessionToken
+	At           time.Time
+	Expiry       SessionExpiry
+}
+
+type DeleteExpiredSessionsInput struct {
+	At     time.Time
+	Expiry SessionExpiry
+	Limit  uint
+}
+
+type DeleteSessionsInput struct {
+	UserID      ID
+	ExceptToken c.Optional[SessionToken]
 }
 
 type SessionRepository interface {
 	Create(ctx context.Context, input CreateSessionInput) error
-	GetUserByToken(ctx context.Context, token SessionToken) (User, error)
+	// GetUserByToken marks the session as seen at input.At,
+	// ErrUserDoesNotExist is returned if the session does not exist or is expired.
+	GetUserByToken(ctx context.Context, input GetUserBySessionTokenInput) (User, error)
+	// List returns user sessions which are not expired, most recently seen first.
+	List(ctx context.Context, input ListSessionsInput) ([]Session, error)
 	Delete(ctx context.Context, token SessionToken) (userID ID, err error)
+	DeleteByID(ctx context.Context, userID ID, id SessionID) error
+	DeleteAll(ctx context.Context, input DeleteSessionsInput) error
+	// DeleteExpired deletes up to input.Limit sessions which are expired at input.At
+	// and returns the number of deleted sessions.
+	DeleteExpired(ctx context.Context, input DeleteExpiredSessionsInput) (uint, error)
 }
 
 type CreateLimitsInput struct {
 	UserID ID
+	Plan   c.Optional[PlanName]
+	Limits Limits
+}
+
+type UpdateLimitsInput struct {
+	UserID ID
+	Plan   c.Optional[PlanName]
 	Limits Limits
 }
 
 type LimitsRepository interface {
 	Create(ctx context.Context, input CreateLimitsInput) (Limits, error)
+	Update(ctx context.Context, input UpdateLimitsInput) (Limits, error)
 	GetUserLimits(ctx context.Context, userID ID) (Limits, error)
 	GetUserLimitsWithLock(ctx context.Context, userID ID) (Limits, error)
+	// GetUserPlan returns ErrLimitsDoNotExist if the user has no limits, i.e. is not activated yet.
+	GetUserPlan(ctx context.Context, userID ID) (UserPlan, error)
+	GetUserPlanWithLock(ctx context.Context, userID ID) (UserPlan, error)
+}
+
+type PlanRepository interface {
+	// Get returns ErrPlanDoesNotExist if there is no plan with the name.
+	Get(ctx context.Context, name PlanName) (Plan, error)
+	List(ctx context.Context) ([]Plan, error)
+}
+
+type CreateLimitsAuditEntryInput struct {
+	UserID    ID
+	Actor     string
+	Action    LimitsAuditAction
+	Plan      c.Optional[PlanName]
+	Before    Limits
+	After     Limits
+	CreatedAt time.Time
+}
+
+type LimitsAuditRepository interface {
+	Create(ctx context.Context, input CreateLimitsAuditEntryInput) (LimitsAuditEntry, error)
+	// List returns audit entries of the user, the most recent first.
+	List(ctx context.Context, userID ID) ([]LimitsAuditEntry, error)
+}
+
+type CreateExternalIdentityInput struct {
+	UserID    ID
+	Provider  OIDCProviderName
+	Subject   string
+	CreatedAt time.Time
+}
+
+type ExternalIdentityRepository interface {
+	// Create returns ErrExternalIdentityAlreadyLinked if the identity belongs to a user.
+	Create(ctx context.Context, input CreateExternalIdentityInput) (ExternalIdentity, error)
+	// GetUser returns ErrUserDoesNotExist if the identity is not linked to any user.
+	GetUser(ctx context.Context, provider OIDCProviderName, subject string) (User, error)
+	// List returns identities linked to the user, the first linked first.
+	List(ctx context.Context, userID ID) ([]ExternalIdentity, error)
+}
+
+type OIDCAuthorizationRepository interface {
+	Create(ctx context.Context, authorization OIDCAuthorization) error
+	// Pop deletes the authorization so the state can be used only once, authorizations
+	// created before createdSince are deleted as well and ErrInvalidOIDCState is returned
+	// if the state does not exist or is expired.
+	Pop(ctx context.Context, state OIDCState, createdSince time.Time) (OIDCAuthorization, error)
+}
+
+type ConfirmTOTPInput struct {
+	UserID        ID
+	ConfirmedAt   time.Time
+	Step          int64
+	RecoveryCodes []RecoveryCode
+}
+
+type TOTPRepository interface {
+	// Get returns ErrTOTPNotEnrolled if the user has no TOTP.
+	Get(ctx context.Context, userID ID) (TOTP, error)
+	// Create replaces the not confirmed TOTP of the user,
+	// it returns ErrTOTPAlreadyEnabled if TOTP is confirmed.
+	Create(ctx context.Context, totp TOTP) error
+	// Confirm enables TOTP and stores the recovery codes,
+	// it returns ErrTOTPNotEnrolled if there is no TOTP to confirm.
+	Confirm(ctx context.Context, input ConfirmTOTPInput) error
+	// UseStep records the step of an accepted code, it returns ErrInvalidTwoFactorCode
+	// if the step is not after the last used one.
+	UseStep(ctx context.Context, userID ID, step int64) error
+	// UseRecoveryCode deletes the code, it returns ErrInvalidTwoFactorCode if there is no such code.
+	UseRecoveryCode(ctx context.Context, userID ID, code RecoveryCode) error
+	// Delete disables TOTP and deletes the recovery codes.
+	Delete(ctx context.Context, userID ID) error
+}
+
+type TwoFactorChallengeRepository interface {
+	Create(ctx context.Context, challenge TwoFactorChallenge) error
+	// Get returns ErrInvalidTwoFactorChallenge if the token does not exist or is expired.
+	Get(ctx context.Context, token TwoFactorChallengeToken, createdSince time.Time) (TwoFactorChallenge, error)
+	// Pop deletes the challenge so the token can be used only once, challenges created
+	// before createdSince are deleted as well and ErrInvalidTwoFactorChallenge is returned
+	// if the token does not exist or is expired.
+	Pop(ctx context.Context, token TwoFactorChallengeToken, createdSince time.Time) (TwoFactorChallenge, error)
+}
+
+type CreateEmailChangeInput struct {
+	UserID    ID
+	OldEmail  c.Email
+	NewEmail  c.Email
+	Token     EmailChangeToken
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type ConfirmEmailChangeInput struct {
+	ID              EmailChangeID
+	ConfirmedAt     time.Time
+	RevertToken     EmailChangeToken
+	RevertExpiresAt time.Time
+}
+
+type EmailChangeRepository interface {
+	// Create replaces the pending change of the user if there is one.
+	Create(ctx context.Context, input CreateEmailChangeInput) (EmailChange, error)
+	// GetByTokenWithLock returns the pending change, ErrInvalidEmailChangeToken is returned
+	// if the token does not exist, is expired or the change is already confirmed.
+	GetByTokenWithLock(ctx context.Context, token EmailChangeToken, at time.Time) (EmailChange, error)
+	Confirm(ctx context.Context, input ConfirmEmailChangeInput) (EmailChange, error)
+	// GetByRevertTokenWithLock returns the confirmed change,
+	// ErrInvalidEmailChangeToken is returned if the revert token does not exist or is expired.
+	GetByRevertTokenWithLock(ctx context.Context, token EmailChangeToken, at time.Time) (EmailChange, error)
+	Delete(ctx context.Context, id EmailChangeID) error
+}
+
+type CreateAPITokenInput struct {
+	UserID    ID
+	Secret    APITokenSecret
+	Name      string
+	Scopes    []Scope
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type APITokenRepository interface {
+	Create(ctx context.Context, input CreateAPITokenInput) (APIToken, error)
+	// GetUserByToken updates the last used time of the token, it returns
+	// ErrUserDoesNotExist if the token does not exist or is expired.
+	GetUserByToken(ctx context.Context, secret APITokenSecret, at time.Time) (User, APIToken, error)
+	List(ctx context.Context, userID ID) ([]APIToken, error)
+	// Delete returns ErrAPITokenDoesNotExist if the user has no such token.
+	Delete(ctx context.Context, userID ID, id APITokenID) error
+	DeleteAll(ctx context.Context, userID ID) error
+}
+
+type CreateCalendarFeedInput struct {
+	UserID    ID
+	Token     CalendarFeedToken
+	CreatedAt time.Time
+}
+
+type CalendarFeedRepository interface {
+	// Create replaces the feed of the user if it exists, so that the old token stops working.
+	Create(ctx context.Context, input CreateCalendarFeedInput) (CalendarFeed, error)
+	// GetUserByToken updates the last used time of the feed, it returns
+	// ErrCalendarFeedDoesNotExist if the token does not exist.
+	GetUserByToken(ctx context.Context, token CalendarFeedToken, at time.Time) (User, error)
+	// Delete returns ErrCalendarFeedDoesNotExist if the user has no feed.
+	Delete(ctx context.Context, userID ID) error
+}
+
+type AccountDeletionRepository interface {
+	// Create returns ErrAccountDeletionAlreadyRequested if the deletion of the account is pending.
+	Create(ctx context.Context, deletion AccountDeletion) error
+	// Get returns ErrAccountDeletionNotRequested if the deletion of the account is not pending.
+	Get(ctx context.Context, userID ID) (AccountDeletion, error)
+	// GetWithLock works like Get and locks the deletion until the end of the transaction.
+	GetWithLock(ctx context.Context, userID ID) (AccountDeletion, error)
+	// ReadDue returns the deletions to be purged at the moment, the earliest first.
+	ReadDue(ctx context.Context, at time.Time, limit uint) ([]AccountDeletion, error)
+	// Delete cancels the deletion, it returns ErrAccountDeletionNotRequested if it is not pending.
+	Delete(ctx context.Context, userID ID) error
 }
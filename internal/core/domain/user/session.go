@@ -1,5 +1,51 @@
 package user
 
+import "time"
+
+// LAST_SEEN_PRECISION is how often the last seen time of sessions and the last used time
+// of API tokens are updated, authenticating a request does not write it every time.
+const LAST_SEEN_PRECISION = time.Minute
+
+// SeenBefore returns the time sessions and API tokens last seen at or before are updated at the given moment.
+func SeenBefore(at time.Time) time.Time {
+	return at.Add(-LAST_SEEN_PRECISION)
+}
+
 type SessionTokenGenerator interface {
 	GenerateSessionToken() SessionToken
 }
+
+type SessionID int64
+
+type Session struct {
+	ID         SessionID
+	UserID     ID
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	IP         string
+	// IsCurrent is set for the session the listing was requested with.
+	IsCurrent bool
+}
+
+// SessionExpiry defines for how long a session stays valid.
+type SessionExpiry struct {
+	// Session expires if it has not been used for this duration.
+	Idle time.Duration
+	// Session expires after this duration since it was created even if it is in use.
+	Absolute time.Duration
+}
+
+// IdleSince returns the time sessions not seen after are expired at the given moment.
+func (e SessionExpiry) IdleSince(at time.Time) time.Time {
+	return at.Add(-e.Idle)
+}
+
+// CreatedSince returns the time sessions created before are expired at the given moment.
+func (e SessionExpiry) CreatedSince(at time.Time) time.Time {
+	return at.Add(-e.Absolute)
+}
+
+func (e SessionExpiry) IsExpired(s Session, at time.Time) bool {
+	return !s.LastSeenAt.After(e.IdleSince(at)) || !s.CreatedAt.After(e.CreatedSince(at))
+}
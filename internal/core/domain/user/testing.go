@@ -122,13 +122,15 @@ func (r *FakeUserRepository) Create(ctx context.Context, input CreateUserInput)
 		maxID = u.ID
 	}
 	u = User{
-		ID:              maxID + 1,
-		Email:           input.Email,
-		PasswordHash:    input.PasswordHash,
-		Identity:        input.Identity,
-		CreatedAt:       input.CreatedAt,
-		ActivatedAt:     input.ActivatedAt,
-		ActivationToken: input.ActivationToken,
+		ID:                       maxID + 1,
+		Email:                    input.Email,
+		PasswordHash:             input.PasswordHash,
+		Identity:                 input.Identity,
+		CreatedAt:                input.CreatedAt,
+		ActivatedAt:              input.ActivatedAt,
+		ActivationToken:          input.ActivationToken,
+		ActivationTokenExpiresAt: input.ActivationTokenExpiresAt,
+		TimeZone:                 input.TimeZone,
 	}
 	r.Users = append(r.Users, u)
 	return u, nil
@@ -161,14 +163,60 @@ func (r *FakeUserRepository) Activate(ctx context.Context, token ActivationToken
 	defer r.lock.Unlock()
 	for ix, u := range r.Users {
 		if !u.IsActive() && u.ActivationToken.IsPresent && u.ActivationToken.Value == token {
+			if u.ActivationTokenExpiresAt.IsPresent && !u.ActivationTokenExpiresAt.Value.After(at) {
+				return u, ErrInvalidActivationToken
+			}
 			r.Users[ix].ActivatedAt = c.NewOptional(at, true)
 			r.Users[ix].ActivationToken = c.NewOptional(ActivationToken(""), false)
+			r.Users[ix].ActivationTokenExpiresAt = c.NewOptional(time.Time{}, false)
 			return r.Users[ix], nil
 		}
 	}
 	return u, ErrInvalidActivationToken
 }
 
+func (r *FakeUserRepository) SetActivationToken(ctx context.Context, input SetActivationTokenInput) (u User, err error) {
+	if r.ReturnError {
+		return u, fmt.Errorf("could not set activation token %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, u := range r.Users {
+		if u.ID == input.ID && u.ActivationToken.IsPresent {
+			r.Users[ix].ActivationToken = c.NewOptional(input.Token, true)
+			r.Users[ix].ActivationTokenExpiresAt = c.NewOptional(input.ExpiresAt, true)
+			return r.Users[ix], nil
+		}
+	}
+	return u, ErrUserDoesNotExist
+}
+
+func (r *FakeUserRepository) Claim(ctx context.Context, input ClaimUserInput) (u User, err error) {
+	if r.ReturnError {
+		return u, fmt.Errorf("could not claim user %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, u := range r.Users {
+		if u.ID != input.ID && u.Email.IsPresent && u.Email.Value == input.Email {
+			return u, ErrEmailAlreadyExists
+		}
+	}
+	for ix, u := range r.Users {
+		if u.ID == input.ID {
+			if !u.CanBeClaimed() {
+				return u, ErrUserIsNotAnonymous
+			}
+			r.Users[ix].Email = c.NewOptional(input.Email, true)
+			r.Users[ix].PasswordHash = c.NewOptional(input.PasswordHash, true)
+			r.Users[ix].ActivationToken = c.NewOptional(input.ActivationToken, true)
+			r.Users[ix].ActivationTokenExpiresAt = c.NewOptional(input.ActivationTokenExpiresAt, true)
+			return r.Users[ix], nil
+		}
+	}
+	return u, ErrUserDoesNotExist
+}
+
 func (r *FakeUserRepository) SetPassword(ctx context.Context, id ID, password PasswordHash) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -186,6 +234,14 @@ func (r *FakeUserRepository) Update(ctx context.Context, input UpdateUserInput)
 	defer r.lock.Unlock()
 	for ix, u := range r.Users {
 		if u.ID == input.ID {
+			if input.DoEmailUpdate {
+				for _, other := range r.Users {
+					if other.ID != input.ID && other.Email.IsPresent && other.Email.Value == input.Email {
+						return u, ErrEmailAlreadyExists
+					}
+				}
+				r.Users[ix].Email = c.NewOptional(input.Email, true)
+			}
 			if input.DoTimeZoneUpdate {
 				r.Users[ix].TimeZone = input.TimeZone
 			}
@@ -195,17 +251,52 @@ func (r *FakeUserRepository) Update(ctx context.Context, input UpdateUserInput)
 	return u, ErrUserDoesNotExist
 }
 
+func (r *FakeUserRepository) Delete(ctx context.Context, id ID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete user %d", id)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, u := range r.Users {
+		if u.ID == id {
+			r.Users = append(r.Users[:ix], r.Users[ix+1:]...)
+			return nil
+		}
+	}
+	return ErrUserDoesNotExist
+}
+
+func (r *FakeUserRepository) DeleteInactive(ctx context.Context, input DeleteInactiveUsersInput) (uint, error) {
+	if r.ReturnError {
+		return 0, fmt.Errorf("could not delete inactive users %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var deleted uint
+	users := r.Users[:0]
+	for _, u := range r.Users {
+		if deleted < input.Limit && !u.ActivatedAt.IsPresent && u.CreatedAt.Before(input.CreatedBefore) {
+			deleted++
+			continue
+		}
+		users = append(users, u)
+	}
+	r.Users = users
+	return deleted, nil
+}
+
 type FakeSessionRepository struct {
-	UserIdByToken  map[SessionToken]ID
+	Sessions       []Session
 	UserRepository UserRepository
 	ReturnError    bool
+	tokens         map[SessionID]SessionToken
 	lock           sync.Mutex
 }
 
 func NewFakeSessionRepository(userRepository UserRepository) *FakeSessionRepository {
 	return &FakeSessionRepository{
-		UserIdByToken:  make(map[SessionToken]ID),
 		UserRepository: userRepository,
+		tokens:         make(map[SessionID]SessionToken),
 	}
 }
 
@@ -215,35 +306,137 @@ func (r *FakeSessionRepository) Create(ctx context.Context, input CreateSessionI
 	}
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.UserIdByToken[input.Token] = input.UserID
+	id := SessionID(len(r.tokens) + 1)
+	r.Sessions = append(r.Sessions, Session{
+		ID:         id,
+		UserID:     input.UserID,
+		CreatedAt:  input.CreatedAt,
+		LastSeenAt: input.CreatedAt,
+		UserAgent:  input.UserAgent,
+		IP:         input.IP,
+	})
+	r.tokens[id] = input.Token
 	return nil
 }
 
-func (r *FakeSessionRepository) GetUserByToken(ctx context.Context, token SessionToken) (u User, err error) {
+func (r *FakeSessionRepository) GetUserByToken(
+	ctx context.Context,
+	input GetUserBySessionTokenInput,
+) (u User, err error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	userId, ok := r.UserIdByToken[token]
-	if !ok {
-		return u, ErrUserDoesNotExist
+	for ix, session := range r.Sessions {
+		if r.tokens[session.ID] != input.Token {
+			continue
+		}
+		if input.Expiry.IsExpired(session, input.At) {
+			return u, ErrUserDoesNotExist
+		}
+		if !session.LastSeenAt.After(SeenBefore(input.At)) {
+			r.Sessions[ix].LastSeenAt = input.At
+		}
+		return r.UserRepository.GetByID(ctx, session.UserID)
+	}
+	return u, ErrUserDoesNotExist
+}
+
+func (r *FakeSessionRepository) List(ctx context.Context, input ListSessionsInput) ([]Session, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not list sessions %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	sessions := make([]Session, 0, len(r.Sessions))
+	for _, session := range r.Sessions {
+		if session.UserID != input.UserID || input.Expiry.IsExpired(session, input.At) {
+			continue
+		}
+		session.IsCurrent = r.tokens[session.ID] == input.CurrentToken
+		sessions = append(sessions, session)
 	}
-	return r.UserRepository.GetByID(ctx, userId)
+	return sessions, nil
 }
 
 func (r *FakeSessionRepository) Delete(ctx context.Context, token SessionToken) (ID, error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	userID, ok := r.UserIdByToken[token]
-	if !ok {
-		return ID(0), ErrSessionDoesNotExist
+	for _, session := range r.Sessions {
+		if r.tokens[session.ID] == token {
+			r.delete(session.ID)
+			return session.UserID, nil
+		}
+	}
+	return ID(0), ErrSessionDoesNotExist
+}
+
+func (r *FakeSessionRepository) DeleteByID(ctx context.Context, userID ID, id SessionID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete session %d", id)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, session := range r.Sessions {
+		if session.ID == id && session.UserID == userID {
+			r.delete(session.ID)
+			return nil
+		}
+	}
+	return ErrSessionDoesNotExist
+}
+
+func (r *FakeSessionRepository) DeleteAll(ctx context.Context, input DeleteSessionsInput) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete sessions %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	sessions := make([]Session, 0, len(r.Sessions))
+	for _, session := range r.Sessions {
+		isExcepted := input.ExceptToken.IsPresent && r.tokens[session.ID] == input.ExceptToken.Value
+		if session.UserID == input.UserID && !isExcepted {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	r.Sessions = sessions
+	return nil
+}
+
+func (r *FakeSessionRepository) DeleteExpired(ctx context.Context, input DeleteExpiredSessionsInput) (uint, error) {
+	if r.ReturnError {
+		return 0, fmt.Errorf("could not delete expired sessions %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var deleted uint
+	sessions := make([]Session, 0, len(r.Sessions))
+	for _, session := range r.Sessions {
+		if deleted < input.Limit && input.Expiry.IsExpired(session, input.At) {
+			deleted++
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	r.Sessions = sessions
+	return deleted, nil
+}
+
+func (r *FakeSessionRepository) delete(id SessionID) {
+	sessions := make([]Session, 0, len(r.Sessions))
+	for _, session := range r.Sessions {
+		if session.ID != id {
+			sessions = append(sessions, session)
+		}
 	}
-	delete(r.UserIdByToken, token)
-	return userID, nil
+	r.Sessions = sessions
 }
 
 type FakeLimitsRepository struct {
 	ReturnError bool
 	Created     []Limits
+	Updated     []UpdateLimitsInput
 	Limits      Limits
+	Plan        c.Optional[PlanName]
 	lock        sync.Mutex
 }
 
@@ -263,6 +456,16 @@ func (r *FakeLimitsRepository) Create(ctx context.Context, input CreateLimitsInp
 	return l, nil
 }
 
+func (r *FakeLimitsRepository) Update(ctx context.Context, input UpdateLimitsInput) (l Limits, err error) {
+	if r.ReturnError {
+		return l, fmt.Errorf("could not update limits %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Updated = append(r.Updated, input)
+	return input.Limits, nil
+}
+
 func (r *FakeLimitsRepository) GetUserLimits(ctx context.Context, userID ID) (l Limits, err error) {
 	if r.ReturnError {
 		return l, fmt.Errorf("could not get user limits")
@@ -277,6 +480,93 @@ func (r *FakeLimitsRepository) GetUserLimitsWithLock(ctx context.Context, userID
 	return r.Limits, nil
 }
 
+func (r *FakeLimitsRepository) GetUserPlan(ctx context.Context, userID ID) (p UserPlan, err error) {
+	if r.ReturnError {
+		return p, fmt.Errorf("could not get user plan")
+	}
+	return UserPlan{UserID: userID, Plan: r.Plan, Limits: r.Limits}, nil
+}
+
+func (r *FakeLimitsRepository) GetUserPlanWithLock(ctx context.Context, userID ID) (p UserPlan, err error) {
+	return r.GetUserPlan(ctx, userID)
+}
+
+type FakePlanRepository struct {
+	Plans       []Plan
+	ReturnError bool
+}
+
+func NewFakePlanRepository(plans ...Plan) *FakePlanRepository {
+	return &FakePlanRepository{Plans: plans}
+}
+
+func (r *FakePlanRepository) Get(ctx context.Context, name PlanName) (p Plan, err error) {
+	if r.ReturnError {
+		return p, fmt.Errorf("could not get plan %s", name)
+	}
+	for _, p := range r.Plans {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return p, ErrPlanDoesNotExist
+}
+
+func (r *FakePlanRepository) List(ctx context.Context) ([]Plan, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not list plans")
+	}
+	return r.Plans, nil
+}
+
+type FakeLimitsAuditRepository struct {
+	Entries     []LimitsAuditEntry
+	ReturnError bool
+	lock        sync.Mutex
+}
+
+func NewFakeLimitsAuditRepository() *FakeLimitsAuditRepository {
+	return &FakeLimitsAuditRepository{}
+}
+
+func (r *FakeLimitsAuditRepository) Create(
+	ctx context.Context,
+	input CreateLimitsAuditEntryInput,
+) (entry LimitsAuditEntry, err error) {
+	if r.ReturnError {
+		return entry, fmt.Errorf("could not create limits audit entry %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entry = LimitsAuditEntry{
+		ID:        LimitsAuditEntryID(len(r.Entries) + 1),
+		UserID:    input.UserID,
+		Actor:     input.Actor,
+		Action:    input.Action,
+		Plan:      input.Plan,
+		Before:    input.Before,
+		After:     input.After,
+		CreatedAt: input.CreatedAt,
+	}
+	r.Entries = append(r.Entries, entry)
+	return entry, nil
+}
+
+func (r *FakeLimitsAuditRepository) List(ctx context.Context, userID ID) ([]LimitsAuditEntry, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not list limits audit entries of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entries := make([]LimitsAuditEntry, 0, len(r.Entries))
+	for i := len(r.Entries) - 1; i >= 0; i-- {
+		if r.Entries[i].UserID == userID {
+			entries = append(entries, r.Entries[i])
+		}
+	}
+	return entries, nil
+}
+
 type FakePasswordResetter struct {
 	Token         PasswordResetToken
 	UserID        ID
@@ -330,3 +620,839 @@ func (s *FakePasswordResetTokenSender) SendPasswordResetToken(
 	s.SentTo = append(s.SentTo, user)
 	return nil
 }
+
+type FakeExternalIdentityRepository struct {
+	Identities     []ExternalIdentity
+	UserRepository UserRepository
+	ReturnError    bool
+	lock           sync.Mutex
+}
+
+func NewFakeExternalIdentityRepository(userRepository UserRepository) *FakeExternalIdentityRepository {
+	return &FakeExternalIdentityRepository{UserRepository: userRepository}
+}
+
+func (r *FakeExternalIdentityRepository) Create(
+	ctx context.Context,
+	input CreateExternalIdentityInput,
+) (identity ExternalIdentity, err error) {
+	if r.ReturnError {
+		return identity, fmt.Errorf("could not create external identity %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, identity := range r.Identities {
+		if identity.Provider == input.Provider && identity.Subject == input.Subject {
+			return identity, ErrExternalIdentityAlreadyLinked
+		}
+	}
+	identity = ExternalIdentity{
+		ID:        ExternalIdentityID(len(r.Identities) + 1),
+		UserID:    input.UserID,
+		Provider:  input.Provider,
+		Subject:   input.Subject,
+		CreatedAt: input.CreatedAt,
+	}
+	r.Identities = append(r.Identities, identity)
+	return identity, nil
+}
+
+func (r *FakeExternalIdentityRepository) GetUser(
+	ctx context.Context,
+	provider OIDCProviderName,
+	subject string,
+) (u User, err error) {
+	if r.ReturnError {
+		return u, fmt.Errorf("could not get user by external identity %s:%s", provider, subject)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, identity := range r.Identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return r.UserRepository.GetByID(ctx, identity.UserID)
+		}
+	}
+	return u, ErrUserDoesNotExist
+}
+
+func (r *FakeExternalIdentityRepository) List(ctx context.Context, userID ID) ([]ExternalIdentity, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not list external identities of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	identities := make([]ExternalIdentity, 0, len(r.Identities))
+	for _, identity := range r.Identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+type FakeOIDCAuthorizationRepository struct {
+	Authorizations []OIDCAuthorization
+	ReturnError    bool
+	lock           sync.Mutex
+}
+
+func NewFakeOIDCAuthorizationRepository() *FakeOIDCAuthorizationRepository {
+	return &FakeOIDCAuthorizationRepository{}
+}
+
+func (r *FakeOIDCAuthorizationRepository) Create(ctx context.Context, authorization OIDCAuthorization) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not create OIDC authorization")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Authorizations = append(r.Authorizations, authorization)
+	return nil
+}
+
+func (r *FakeOIDCAuthorizationRepository) Pop(
+	ctx context.Context,
+	state OIDCState,
+	createdSince time.Time,
+) (a OIDCAuthorization, err error) {
+	if r.ReturnError {
+		return a, fmt.Errorf("could not pop OIDC authorization")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err = ErrInvalidOIDCState
+	authorizations := make([]OIDCAuthorization, 0, len(r.Authorizations))
+	for _, authorization := range r.Authorizations {
+		if !authorization.CreatedAt.After(createdSince) {
+			continue
+		}
+		if authorization.State == state {
+			a, err = authorization, nil
+			continue
+		}
+		authorizations = append(authorizations, authorization)
+	}
+	r.Authorizations = authorizations
+	return a, err
+}
+
+type FakeOIDCAuthorizationGenerator struct {
+	State        OIDCState
+	Nonce        OIDCNonce
+	CodeVerifier OIDCCodeVerifier
+}
+
+func NewFakeOIDCAuthorizationGenerator(state, nonce, codeVerifier string) *FakeOIDCAuthorizationGenerator {
+	return &FakeOIDCAuthorizationGenerator{
+		State:        OIDCState(state),
+		Nonce:        OIDCNonce(nonce),
+		CodeVerifier: OIDCCodeVerifier(codeVerifier),
+	}
+}
+
+func (g *FakeOIDCAuthorizationGenerator) GenerateOIDCState() OIDCState {
+	return g.State
+}
+
+func (g *FakeOIDCAuthorizationGenerator) GenerateOIDCNonce() OIDCNonce {
+	return g.Nonce
+}
+
+func (g *FakeOIDCAuthorizationGenerator) GenerateOIDCCodeVerifier() OIDCCodeVerifier {
+	return g.CodeVerifier
+}
+
+// FakeOIDCProvider accepts Code only, the nonce and the code verifier must match the
+// ones the last authorization URL was built with.
+type FakeOIDCProvider struct {
+	Code          OIDCAuthorizationCode
+	Claims        OIDCClaims
+	ReturnError   bool
+	nonce         OIDCNonce
+	codeChallenge string
+}
+
+func NewFakeOIDCProvider(code string, claims OIDCClaims) *FakeOIDCProvider {
+	return &FakeOIDCProvider{Code: OIDCAuthorizationCode(code), Claims: claims}
+}
+
+func (p *FakeOIDCProvider) AuthorizationURL(state OIDCState, nonce OIDCNonce, codeChallenge string) string {
+	p.nonce = nonce
+	p.codeChallenge = codeChallenge
+	return fmt.Sprintf("https://oidc.test/authorize?state=%s&nonce=%s&code_challenge=%s", state, nonce, codeChallenge)
+}
+
+func (p *FakeOIDCProvider) Exchange(
+	ctx context.Context,
+	code OIDCAuthorizationCode,
+	codeVerifier OIDCCodeVerifier,
+	nonce OIDCNonce,
+) (claims OIDCClaims, err error) {
+	if p.ReturnError {
+		return claims, fmt.Errorf("could not exchange code")
+	}
+	if code != p.Code || nonce != p.nonce || codeVerifier.Challenge() != p.codeChallenge {
+		return claims, fmt.Errorf("%w: invalid code", ErrOIDCAuthenticationFailed)
+	}
+	return p.Claims, nil
+}
+
+type FakeTOTPRepository struct {
+	TOTPs         map[ID]TOTP
+	RecoveryCodes map[ID][]RecoveryCode
+	ReturnError   bool
+	lock          sync.Mutex
+}
+
+func NewFakeTOTPRepository() *FakeTOTPRepository {
+	return &FakeTOTPRepository{
+		TOTPs:         make(map[ID]TOTP),
+		RecoveryCodes: make(map[ID][]RecoveryCode),
+	}
+}
+
+func (r *FakeTOTPRepository) Get(ctx context.Context, userID ID) (totp TOTP, err error) {
+	if r.ReturnError {
+		return totp, fmt.Errorf("could not get TOTP of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	totp, ok := r.TOTPs[userID]
+	if !ok {
+		return totp, ErrTOTPNotEnrolled
+	}
+	return totp, nil
+}
+
+func (r *FakeTOTPRepository) Create(ctx context.Context, totp TOTP) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not create TOTP of user %d", totp.UserID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if existing, ok := r.TOTPs[totp.UserID]; ok && existing.IsEnabled() {
+		return ErrTOTPAlreadyEnabled
+	}
+	r.TOTPs[totp.UserID] = totp
+	return nil
+}
+
+func (r *FakeTOTPRepository) Confirm(ctx context.Context, input ConfirmTOTPInput) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not confirm TOTP of user %d", input.UserID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	totp, ok := r.TOTPs[input.UserID]
+	if !ok || totp.IsEnabled() {
+		return ErrTOTPNotEnrolled
+	}
+	totp.ConfirmedAt = c.NewOptional(input.ConfirmedAt, true)
+	totp.LastUsedStep = input.Step
+	r.TOTPs[input.UserID] = totp
+	r.RecoveryCodes[input.UserID] = append([]RecoveryCode{}, input.RecoveryCodes...)
+	return nil
+}
+
+func (r *FakeTOTPRepository) UseStep(ctx context.Context, userID ID, step int64) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not use TOTP step of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	totp, ok := r.TOTPs[userID]
+	if !ok || totp.LastUsedStep >= step {
+		return ErrInvalidTwoFactorCode
+	}
+	totp.LastUsedStep = step
+	r.TOTPs[userID] = totp
+	return nil
+}
+
+func (r *FakeTOTPRepository) UseRecoveryCode(ctx context.Context, userID ID, code RecoveryCode) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not use recovery code of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	codes := r.RecoveryCodes[userID]
+	for i, recoveryCode := range codes {
+		if recoveryCode == code {
+			r.RecoveryCodes[userID] = append(codes[:i:i], codes[i+1:]...)
+			return nil
+		}
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+func (r *FakeTOTPRepository) Delete(ctx context.Context, userID ID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete TOTP of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.TOTPs, userID)
+	delete(r.RecoveryCodes, userID)
+	return nil
+}
+
+type FakeTwoFactorChallengeRepository struct {
+	Challenges  []TwoFactorChallenge
+	ReturnError bool
+	lock        sync.Mutex
+}
+
+func NewFakeTwoFactorChallengeRepository() *FakeTwoFactorChallengeRepository {
+	return &FakeTwoFactorChallengeRepository{}
+}
+
+func (r *FakeTwoFactorChallengeRepository) Create(ctx context.Context, challenge TwoFactorChallenge) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not create two-factor challenge")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Challenges = append(r.Challenges, challenge)
+	return nil
+}
+
+func (r *FakeTwoFactorChallengeRepository) Get(
+	ctx context.Context,
+	token TwoFactorChallengeToken,
+	createdSince time.Time,
+) (challenge TwoFactorChallenge, err error) {
+	if r.ReturnError {
+		return challenge, fmt.Errorf("could not get two-factor challenge")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, challenge := range r.Challenges {
+		if challenge.Token == token && challenge.CreatedAt.After(createdSince) {
+			return challenge, nil
+		}
+	}
+	return challenge, ErrInvalidTwoFactorChallenge
+}
+
+func (r *FakeTwoFactorChallengeRepository) Pop(
+	ctx context.Context,
+	token TwoFactorChallengeToken,
+	createdSince time.Time,
+) (challenge TwoFactorChallenge, err error) {
+	if r.ReturnError {
+		return challenge, fmt.Errorf("could not pop two-factor challenge")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err = ErrInvalidTwoFactorChallenge
+	challenges := make([]TwoFactorChallenge, 0, len(r.Challenges))
+	for _, c := range r.Challenges {
+		if !c.CreatedAt.After(createdSince) {
+			continue
+		}
+		if c.Token == token {
+			challenge, err = c, nil
+			continue
+		}
+		challenges = append(challenges, c)
+	}
+	r.Challenges = challenges
+	return challenge, err
+}
+
+type FakeTwoFactorGenerator struct {
+	Secret         TOTPSecret
+	ChallengeToken TwoFactorChallengeToken
+	recoveryCodes  int
+}
+
+func NewFakeTwoFactorGenerator(secret, challengeToken string) *FakeTwoFactorGenerator {
+	return &FakeTwoFactorGenerator{Secret: TOTPSecret(secret), ChallengeToken: TwoFactorChallengeToken(challengeToken)}
+}
+
+func (g *FakeTwoFactorGenerator) GenerateTOTPSecret() TOTPSecret {
+	return g.Secret
+}
+
+// GenerateRecoveryCode returns recovery-code-1, recovery-code-2 and so on.
+func (g *FakeTwoFactorGenerator) GenerateRecoveryCode() RecoveryCode {
+	g.recoveryCodes++
+	return RecoveryCode(fmt.Sprintf("recovery-code-%d", g.recoveryCodes))
+}
+
+func (g *FakeTwoFactorGenerator) GenerateTwoFactorChallengeToken() TwoFactorChallengeToken {
+	return g.ChallengeToken
+}
+
+// FakeTOTPAuthenticator accepts Code only, the step is the number of 30 second periods since the epoch.
+type FakeTOTPAuthenticator struct {
+	Code TwoFactorCode
+}
+
+func NewFakeTOTPAuthenticator(code string) *FakeTOTPAuthenticator {
+	return &FakeTOTPAuthenticator{Code: TwoFactorCode(code)}
+}
+
+func (a *FakeTOTPAuthenticator) URI(secret TOTPSecret, accountName string) string {
+	return fmt.Sprintf("otpauth://totp/%s?secret=%s", accountName, secret)
+}
+
+func (a *FakeTOTPAuthenticator) Validate(secret TOTPSecret, code TwoFactorCode, at time.Time) (int64, bool) {
+	return at.Unix() / 30, code == a.Code
+}
+
+type FakeAPITokenRepository struct {
+	Tokens         []APIToken
+	UserRepository UserRepository
+	ReturnError    bool
+	secrets        map[APITokenID]APITokenSecret
+	lock           sync.Mutex
+}
+
+func NewFakeAPITokenRepository(userRepository UserRepository) *FakeAPITokenRepository {
+	return &FakeAPITokenRepository{
+		UserRepository: userRepository,
+		secrets:        make(map[APITokenID]APITokenSecret),
+	}
+}
+
+func (r *FakeAPITokenRepository) Create(ctx context.Context, input CreateAPITokenInput) (APIToken, error) {
+	if r.ReturnError {
+		return APIToken{}, fmt.Errorf("could not create API token %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	token := APIToken{
+		ID:        APITokenID(len(r.secrets) + 1),
+		UserID:    input.UserID,
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		CreatedAt: input.CreatedAt,
+		ExpiresAt: input.ExpiresAt,
+	}
+	r.Tokens = append(r.Tokens, token)
+	r.secrets[token.ID] = input.Secret
+	return token, nil
+}
+
+func (r *FakeAPITokenRepository) GetUserByToken(
+	ctx context.Context,
+	secret APITokenSecret,
+	at time.Time,
+) (u User, token APIToken, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, t := range r.Tokens {
+		if r.secrets[t.ID] != secret {
+			continue
+		}
+		if !t.ExpiresAt.After(at) {
+			return u, token, ErrUserDoesNotExist
+		}
+		if !t.LastUsedAt.IsPresent || !t.LastUsedAt.Value.After(SeenBefore(at)) {
+			r.Tokens[ix].LastUsedAt = c.NewOptional(at, true)
+		}
+		u, err = r.UserRepository.GetByID(ctx, t.UserID)
+		return u, r.Tokens[ix], err
+	}
+	return u, token, ErrUserDoesNotExist
+}
+
+func (r *FakeAPITokenRepository) List(ctx context.Context, userID ID) ([]APIToken, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not list API tokens of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tokens := make([]APIToken, 0, len(r.Tokens))
+	for _, t := range r.Tokens {
+		if t.UserID == userID {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *FakeAPITokenRepository) Delete(ctx context.Context, userID ID, id APITokenID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete API token %d", id)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, t := range r.Tokens {
+		if t.ID == id && t.UserID == userID {
+			r.Tokens = append(r.Tokens[:ix], r.Tokens[ix+1:]...)
+			return nil
+		}
+	}
+	return ErrAPITokenDoesNotExist
+}
+
+func (r *FakeAPITokenRepository) DeleteAll(ctx context.Context, userID ID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete API tokens of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tokens := make([]APIToken, 0, len(r.Tokens))
+	for _, t := range r.Tokens {
+		if t.UserID != userID {
+			tokens = append(tokens, t)
+		}
+	}
+	r.Tokens = tokens
+	return nil
+}
+
+type FakeAPITokenGenerator struct {
+	Secret APITokenSecret
+}
+
+func NewFakeAPITokenGenerator(secret string) *FakeAPITokenGenerator {
+	return &FakeAPITokenGenerator{Secret: APITokenSecret(secret)}
+}
+
+func (g *FakeAPITokenGenerator) GenerateAPITokenSecret() APITokenSecret {
+	return g.Secret
+}
+
+type FakeCalendarFeedRepository struct {
+	Feeds          []CalendarFeed
+	UserRepository UserRepository
+	ReturnError    bool
+	tokens         map[ID]CalendarFeedToken
+	lock           sync.Mutex
+}
+
+func NewFakeCalendarFeedRepository(userRepository UserRepository) *FakeCalendarFeedRepository {
+	return &FakeCalendarFeedRepository{
+		UserRepository: userRepository,
+		tokens:         make(map[ID]CalendarFeedToken),
+	}
+}
+
+func (r *FakeCalendarFeedRepository) Create(ctx context.Context, input CreateCalendarFeedInput) (CalendarFeed, error) {
+	if r.ReturnError {
+		return CalendarFeed{}, fmt.Errorf("could not create calendar feed of user %d", input.UserID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	feed := CalendarFeed{UserID: input.UserID, CreatedAt: input.CreatedAt}
+	r.tokens[input.UserID] = input.Token
+	for ix, f := range r.Feeds {
+		if f.UserID == input.UserID {
+			r.Feeds[ix] = feed
+			return feed, nil
+		}
+	}
+	r.Feeds = append(r.Feeds, feed)
+	return feed, nil
+}
+
+func (r *FakeCalendarFeedRepository) GetUserByToken(
+	ctx context.Context,
+	token CalendarFeedToken,
+	at time.Time,
+) (u User, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, f := range r.Feeds {
+		if r.tokens[f.UserID] != token {
+			continue
+		}
+		r.Feeds[ix].LastUsedAt = c.NewOptional(at, true)
+		return r.UserRepository.GetByID(ctx, f.UserID)
+	}
+	return u, ErrCalendarFeedDoesNotExist
+}
+
+func (r *FakeCalendarFeedRepository) Delete(ctx context.Context, userID ID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete calendar feed of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, f := range r.Feeds {
+		if f.UserID == userID {
+			r.Feeds = append(r.Feeds[:ix], r.Feeds[ix+1:]...)
+			delete(r.tokens, userID)
+			return nil
+		}
+	}
+	return ErrCalendarFeedDoesNotExist
+}
+
+type FakeCalendarFeedTokenGenerator struct {
+	Token CalendarFeedToken
+}
+
+func NewFakeCalendarFeedTokenGenerator(token string) *FakeCalendarFeedTokenGenerator {
+	return &FakeCalendarFeedTokenGenerator{Token: CalendarFeedToken(token)}
+}
+
+func (g *FakeCalendarFeedTokenGenerator) GenerateCalendarFeedToken() CalendarFeedToken {
+	return g.Token
+}
+
+type FakeAccountDeletionRepository struct {
+	Deletions   []AccountDeletion
+	ReturnError bool
+	lock        sync.Mutex
+}
+
+func NewFakeAccountDeletionRepository() *FakeAccountDeletionRepository {
+	return &FakeAccountDeletionRepository{}
+}
+
+func (r *FakeAccountDeletionRepository) Create(ctx context.Context, deletion AccountDeletion) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not create account deletion %v", deletion)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, d := range r.Deletions {
+		if d.UserID == deletion.UserID {
+			return ErrAccountDeletionAlreadyRequested
+		}
+	}
+	r.Deletions = append(r.Deletions, deletion)
+	return nil
+}
+
+func (r *FakeAccountDeletionRepository) Get(ctx context.Context, userID ID) (AccountDeletion, error) {
+	if r.ReturnError {
+		return AccountDeletion{}, fmt.Errorf("could not get account deletion of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, d := range r.Deletions {
+		if d.UserID == userID {
+			return d, nil
+		}
+	}
+	return AccountDeletion{}, ErrAccountDeletionNotRequested
+}
+
+func (r *FakeAccountDeletionRepository) GetWithLock(ctx context.Context, userID ID) (AccountDeletion, error) {
+	return r.Get(ctx, userID)
+}
+
+func (r *FakeAccountDeletionRepository) ReadDue(
+	ctx context.Context,
+	at time.Time,
+	limit uint,
+) ([]AccountDeletion, error) {
+	if r.ReturnError {
+		return nil, fmt.Errorf("could not read due account deletions")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	deletions := make([]AccountDeletion, 0, len(r.Deletions))
+	for _, d := range r.Deletions {
+		if d.IsDue(at) && uint(len(deletions)) < limit {
+			deletions = append(deletions, d)
+		}
+	}
+	return deletions, nil
+}
+
+func (r *FakeAccountDeletionRepository) Delete(ctx context.Context, userID ID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete account deletion of user %d", userID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, d := range r.Deletions {
+		if d.UserID == userID {
+			r.Deletions = append(r.Deletions[:ix], r.Deletions[ix+1:]...)
+			return nil
+		}
+	}
+	return ErrAccountDeletionNotRequested
+}
+
+type FakeEmailChangeRepository struct {
+	Changes      []EmailChange
+	Tokens       map[EmailChangeID]EmailChangeToken
+	RevertTokens map[EmailChangeID]EmailChangeToken
+	ReturnError  bool
+	lock         sync.Mutex
+}
+
+func NewFakeEmailChangeRepository() *FakeEmailChangeRepository {
+	return &FakeEmailChangeRepository{
+		Tokens:       make(map[EmailChangeID]EmailChangeToken),
+		RevertTokens: make(map[EmailChangeID]EmailChangeToken),
+	}
+}
+
+func (r *FakeEmailChangeRepository) Create(
+	ctx context.Context,
+	input CreateEmailChangeInput,
+) (change EmailChange, err error) {
+	if r.ReturnError {
+		return change, fmt.Errorf("could not create email change %v", input)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var lastID EmailChangeID
+	changes := make([]EmailChange, 0, len(r.Changes)+1)
+	for _, ch := range r.Changes {
+		lastID = ch.ID
+		if ch.UserID == input.UserID && !ch.ConfirmedAt.IsPresent {
+			delete(r.Tokens, ch.ID)
+			continue
+		}
+		changes = append(changes, ch)
+	}
+	change = EmailChange{
+		ID:        lastID + 1,
+		UserID:    input.UserID,
+		OldEmail:  input.OldEmail,
+		NewEmail:  input.NewEmail,
+		CreatedAt: input.CreatedAt,
+		ExpiresAt: input.ExpiresAt,
+	}
+	r.Changes = append(changes, change)
+	r.Tokens[change.ID] = input.Token
+	return change, nil
+}
+
+func (r *FakeEmailChangeRepository) GetByTokenWithLock(
+	ctx context.Context,
+	token EmailChangeToken,
+	at time.Time,
+) (change EmailChange, err error) {
+	if r.ReturnError {
+		return change, fmt.Errorf("could not get email change")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, ch := range r.Changes {
+		if r.Tokens[ch.ID] == token && !ch.ConfirmedAt.IsPresent && ch.ExpiresAt.After(at) {
+			return ch, nil
+		}
+	}
+	return change, ErrInvalidEmailChangeToken
+}
+
+func (r *FakeEmailChangeRepository) Confirm(
+	ctx context.Context,
+	input ConfirmEmailChangeInput,
+) (change EmailChange, err error) {
+	if r.ReturnError {
+		return change, fmt.Errorf("could not confirm email change %d", input.ID)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, ch := range r.Changes {
+		if ch.ID == input.ID {
+			r.Changes[ix].ConfirmedAt = c.NewOptional(input.ConfirmedAt, true)
+			r.Changes[ix].RevertExpiresAt = c.NewOptional(input.RevertExpiresAt, true)
+			delete(r.Tokens, ch.ID)
+			r.RevertTokens[ch.ID] = input.RevertToken
+			return r.Changes[ix], nil
+		}
+	}
+	return change, ErrInvalidEmailChangeToken
+}
+
+func (r *FakeEmailChangeRepository) GetByRevertTokenWithLock(
+	ctx context.Context,
+	token EmailChangeToken,
+	at time.Time,
+) (change EmailChange, err error) {
+	if r.ReturnError {
+		return change, fmt.Errorf("could not get email change")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, ch := range r.Changes {
+		revertToken, ok := r.RevertTokens[ch.ID]
+		if ok && revertToken == token && ch.RevertExpiresAt.IsPresent && ch.RevertExpiresAt.Value.After(at) {
+			return ch, nil
+		}
+	}
+	return change, ErrInvalidEmailChangeToken
+}
+
+func (r *FakeEmailChangeRepository) Delete(ctx context.Context, id EmailChangeID) error {
+	if r.ReturnError {
+		return fmt.Errorf("could not delete email change %d", id)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ix, ch := range r.Changes {
+		if ch.ID == id {
+			r.Changes = append(r.Changes[:ix], r.Changes[ix+1:]...)
+			delete(r.Tokens, id)
+			delete(r.RevertTokens, id)
+			return nil
+		}
+	}
+	return ErrInvalidEmailChangeToken
+}
+
+type FakeEmailChangeTokenGenerator struct {
+	Tokens []EmailChangeToken
+	next   int
+}
+
+// NewFakeEmailChangeTokenGenerator returns the tokens in turn, the last one is repeated.
+func NewFakeEmailChangeTokenGenerator(tokens ...string) *FakeEmailChangeTokenGenerator {
+	g := &FakeEmailChangeTokenGenerator{}
+	for _, token := range tokens {
+		g.Tokens = append(g.Tokens, EmailChangeToken(token))
+	}
+	return g
+}
+
+func (g *FakeEmailChangeTokenGenerator) GenerateEmailChangeToken() EmailChangeToken {
+	token := g.Tokens[g.next]
+	if g.next < len(g.Tokens)-1 {
+		g.next++
+	}
+	return token
+}
+
+type FakeEmailChangeSender struct {
+	Confirmations []EmailChangeToken
+	Notifications []EmailChangeToken
+	SentChanges   []EmailChange
+	ReturnError   bool
+	lock          sync.Mutex
+}
+
+func NewFakeEmailChangeSender() *FakeEmailChangeSender {
+	return &FakeEmailChangeSender{}
+}
+
+func (s *FakeEmailChangeSender) SendEmailChangeConfirmation(
+	ctx context.Context,
+	change EmailChange,
+	token EmailChangeToken,
+) error {
+	if s.ReturnError {
+		return fmt.Errorf("could not send email change confirmation")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Confirmations = append(s.Confirmations, token)
+	s.SentChanges = append(s.SentChanges, change)
+	return nil
+}
+
+func (s *FakeEmailChangeSender) SendEmailChangeNotification(
+	ctx context.Context,
+	change EmailChange,
+	revertToken EmailChangeToken,
+) error {
+	if s.ReturnError {
+		return fmt.Errorf("could not send email change notification")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Notifications = append(s.Notifications, revertToken)
+	s.SentChanges = append(s.SentChanges, change)
+	return nil
+}
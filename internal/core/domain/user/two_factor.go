@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"strings"
+	"time"
+)
+
+// RECOVERY_CODE_COUNT recovery codes are issued when TOTP is confirmed.
+const RECOVERY_CODE_COUNT = 10
+
+type TOTPSecret string
+
+func (s TOTPSecret) Redact() string {
+	return logging.Redacted
+}
+
+// TwoFactorCode is a TOTP code or a recovery code.
+type TwoFactorCode string
+
+func (c TwoFactorCode) Redact() string {
+	return logging.Redacted
+}
+
+type RecoveryCode string
+
+func (c RecoveryCode) Redact() string {
+	return logging.Redacted
+}
+
+type TwoFactorChallengeToken string
+
+func (t TwoFactorChallengeToken) Redact() string {
+	return logging.Redacted
+}
+
+// TOTP is enrolled when the secret is created and enabled once a code generated
+// with it is confirmed. LastUsedStep is the time step of the last accepted code,
+// codes of the same or earlier steps are rejected, so every code is accepted once.
+type TOTP struct {
+	UserID       ID
+	Secret       TOTPSecret
+	CreatedAt    time.Time
+	ConfirmedAt  c.Optional[time.Time]
+	LastUsedStep int64
+}
+
+func (t TOTP) IsEnabled() bool {
+	return t.ConfirmedAt.IsPresent
+}
+
+// TwoFactorChallenge is issued after the password of a user with TOTP is checked,
+// it is exchanged for a session together with a TOTP or recovery code.
+type TwoFactorChallenge struct {
+	Token     TwoFactorChallengeToken
+	UserID    ID
+	CreatedAt time.Time
+}
+
+type TOTPAuthenticator interface {
+	// URI returns the otpauth URI to be shown as a QR code.
+	URI(secret TOTPSecret, accountName string) string
+	// Validate returns the time step the code is generated for.
+	Validate(secret TOTPSecret, code TwoFactorCode, at time.Time) (step int64, ok bool)
+}
+
+type TwoFactorGenerator interface {
+	GenerateTOTPSecret() TOTPSecret
+	GenerateRecoveryCode() RecoveryCode
+	GenerateTwoFactorChallengeToken() TwoFactorChallengeToken
+}
+
+// VerifyTwoFactorCode accepts a valid TOTP code which was not used before or an unused
+// recovery code, which is deleted. It returns ErrInvalidTwoFactorCode otherwise.
+func VerifyTwoFactorCode(
+	ctx context.Context,
+	repository TOTPRepository,
+	authenticator TOTPAuthenticator,
+	totp TOTP,
+	code TwoFactorCode,
+	at time.Time,
+) error {
+	if step, ok := authenticator.Validate(totp.Secret, code, at); ok {
+		return repository.UseStep(ctx, totp.UserID, step)
+	}
+	recoveryCode := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(string(code)), "-", ""))
+	return repository.UseRecoveryCode(ctx, totp.UserID, RecoveryCode(recoveryCode))
+}
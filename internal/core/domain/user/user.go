@@ -4,6 +4,7 @@ import (
 	"fmt"
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
 	"time"
 )
 
@@ -25,8 +26,16 @@ type Identity string
 
 type ActivationToken string
 
+func (t ActivationToken) Redact() string {
+	return logging.Redacted
+}
+
 type SessionToken string
 
+func (t SessionToken) Redact() string {
+	return logging.Redacted
+}
+
 type User struct {
 	ID              ID
 	Email           c.Optional[c.Email]
@@ -35,12 +44,15 @@ type User struct {
 	CreatedAt       time.Time
 	ActivatedAt     c.Optional[time.Time]
 	ActivationToken c.Optional[ActivationToken]
-	TimeZone        *time.Location
+	// ActivationTokenExpiresAt is not set for tokens issued before expiration was introduced.
+	ActivationTokenExpiresAt c.Optional[time.Time]
+	TimeZone                 *time.Location
 }
 
 func (u *User) Validate() error {
 	if u.Email.IsPresent {
-		if !u.PasswordHash.IsPresent {
+		// Users created by OIDC login have an identity instead of a password.
+		if !u.PasswordHash.IsPresent && !u.Identity.IsPresent {
 			return e.NewInvalidStateError(fmt.Sprintf("password hash is not set for user %d", u.ID))
 		}
 		return nil
@@ -54,18 +66,18 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// IsActive reports if the user may log in. An anonymous user who claimed an email
+// is not active until the email is confirmed with the activation token.
 func (u *User) IsActive() bool {
-	return u.ActivatedAt.IsPresent
+	return u.ActivatedAt.IsPresent && !u.ActivationToken.IsPresent
 }
 
-func (u *User) IsAnonymous() bool {
-	if u.Email.IsPresent && u.PasswordHash.IsPresent {
-		return false
-	}
-	if u.Identity.IsPresent {
-		return true
-	}
-	panic(fmt.Sprintf("neither email nor identity is not defined for user %d", u.ID))
+// CanBeClaimed reports if email and password may be attached to the user,
+// i.e. the user is anonymous or the previous claim is not activated yet.
+// Users created by OIDC login may look anonymous, they are told apart by
+// their external identities which have to be checked as well.
+func (u *User) CanBeClaimed() bool {
+	return u.Identity.IsPresent && (!u.Email.IsPresent || u.ActivationToken.IsPresent)
 }
 
 type Limits struct {
@@ -74,6 +86,10 @@ type Limits struct {
 	ActiveReminderCount      c.Optional[uint32]
 	MonthlySentReminderCount c.Optional[uint32]
 	ReminderEveryPerDayCount c.Optional[float64]
+	// SentReminderQuotaWindow is the window MonthlySentReminderCount applies to.
+	SentReminderQuotaWindow QuotaWindow
+	// PausedRemindersCountAsActive makes paused reminders count against ActiveReminderCount.
+	PausedRemindersCountAsActive bool
 }
 
 type Limit struct {
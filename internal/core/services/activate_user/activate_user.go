@@ -20,17 +20,20 @@ type Input struct {
 type Result struct{}
 
 type service struct {
-	log           logging.Logger
-	uow           uow.UnitOfWork
-	now           func() time.Time
-	defaultLimits user.Limits
+	log            logging.Logger
+	uow            uow.UnitOfWork
+	planRepository user.PlanRepository
+	now            func() time.Time
+	defaultPlan    user.PlanName
 }
 
+// New activates the user and assigns the default plan to them.
 func New(
 	log logging.Logger,
 	uow uow.UnitOfWork,
+	planRepository user.PlanRepository,
 	now func() time.Time,
-	defaultLimits user.Limits,
+	defaultPlan user.PlanName,
 ) services.Service[Input, Result] {
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
@@ -38,18 +41,28 @@ func New(
 	if uow == nil {
 		panic(e.NewNilArgumentError("uow"))
 	}
+	if planRepository == nil {
+		panic(e.NewNilArgumentError("planRepository"))
+	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
 	return &service{
-		log:           log,
-		uow:           uow,
-		now:           now,
-		defaultLimits: defaultLimits,
+		log:            log,
+		uow:            uow,
+		planRepository: planRepository,
+		now:            now,
+		defaultPlan:    defaultPlan,
 	}
 }
 
 func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	plan, err := s.planRepository.Get(ctx, s.defaultPlan)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("plan", s.defaultPlan))
+		return result, err
+	}
+
 	uow, err := s.uow.Begin(ctx)
 	if err != nil {
 		s.log.Error(
@@ -72,13 +85,12 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	}
 	s.log.Info(ctx, "User successfully activated.", logging.Entry("userId", u.ID))
 
-	if err = s.createLimits(ctx, uow, u); err != nil {
-		return result, err
+	if u.Identity.IsPresent {
+		err = s.completeClaim(ctx, uow, u, plan)
+	} else {
+		err = s.setUp(ctx, uow, u, plan)
 	}
-	if err = s.createInternalChannel(ctx, uow, u); err != nil {
-		return result, err
-	}
-	if err = s.createEmailChannel(ctx, uow, u); err != nil {
+	if err != nil {
 		return result, err
 	}
 
@@ -95,16 +107,89 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	return Result{}, nil
 }
 
+func (s *service) setUp(ctx context.Context, uow uow.Context, u user.User, plan user.Plan) error {
+	if err := s.createLimits(ctx, uow, u, plan); err != nil {
+		return err
+	}
+	if err := s.createInternalChannel(ctx, uow, u); err != nil {
+		return err
+	}
+	return s.createEmailChannel(ctx, uow, u)
+}
+
+// completeClaim turns a claimed anonymous user into a regular one, the user already has
+// limits, an internal channel and maybe an email channel with the claimed email.
+func (s *service) completeClaim(ctx context.Context, uow uow.Context, u user.User, plan user.Plan) error {
+	_, err := uow.Limits().Update(ctx, user.UpdateLimitsInput{
+		UserID: u.ID,
+		Plan:   c.NewOptional(plan.Name, true),
+		Limits: plan.Limits,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return err
+	}
+	s.log.Info(ctx, "Limits of the claimed user successfully updated.", logging.Entry("userID", u.ID))
+
+	emailChannels, err := uow.Channels().Read(ctx, channel.ReadOptions{
+		UserIDEquals: c.NewOptional(u.ID, true),
+		TypeEquals:   c.NewOptional(channel.Email, true),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return err
+	}
+	for _, emailChannel := range emailChannels {
+		settings, ok := emailChannel.Settings.(*channel.EmailSettings)
+		if !ok || settings.Email != u.Email.Value {
+			continue
+		}
+		if emailChannel.IsVerified() {
+			return nil
+		}
+		return s.verifyEmailChannel(ctx, uow, u, emailChannel)
+	}
+	return s.createEmailChannel(ctx, uow, u)
+}
+
+func (s *service) verifyEmailChannel(
+	ctx context.Context,
+	uow uow.Context,
+	user user.User,
+	emailChannel channel.Channel,
+) error {
+	_, err := uow.Channels().Update(ctx, channel.UpdateInput{
+		ID:                        emailChannel.ID,
+		DoVerificationTokenUpdate: true,
+		VerificationToken:         c.NewOptional(channel.VerificationToken(""), false),
+		DoVerifiedAtUpdate:        true,
+		VerifiedAt:                c.NewOptional(s.now(), true),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", user.ID), logging.Entry("channelID", emailChannel.ID))
+		return err
+	}
+	s.log.Info(
+		ctx,
+		"Email channel of the claimed user verified by activation.",
+		logging.Entry("userID", user.ID),
+		logging.Entry("channelID", emailChannel.ID),
+	)
+	return nil
+}
+
 func (s *service) createLimits(
 	ctx context.Context,
 	uow uow.Context,
 	u user.User,
+	plan user.Plan,
 ) error {
 	_, err := uow.Limits().Create(
 		ctx,
 		user.CreateLimitsInput{
 			UserID: u.ID,
-			Limits: s.defaultLimits,
+			Plan:   c.NewOptional(plan.Name, true),
+			Limits: plan.Limits,
 		},
 	)
 	if err != nil {
@@ -43,8 +43,9 @@ func (suite *testSuite) SetupTest() {
 	suite.Service = New(
 		suite.Logger,
 		suite.Uow,
+		user.NewFakePlanRepository(user.Plan{Name: user.PlanFree, Limits: DefaultLimits}),
 		func() time.Time { return Now },
-		DefaultLimits,
+		user.PlanFree,
 	)
 }
 
@@ -148,6 +149,114 @@ func (s *testSuite) TestActivationTokenInvalid() {
 	s.False(u.IsActive())
 }
 
+func (s *testSuite) TestActivationTokenExpired() {
+	inactiveUser := s.createInactiveUser()
+	_, err := s.Uow.Context.UserRepository.SetActivationToken(context.Background(), user.SetActivationTokenInput{
+		ID:        inactiveUser.ID,
+		Token:     user.ActivationToken(ACTIVATION_TOKEN),
+		ExpiresAt: Now,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(
+		context.Background(),
+		Input{ActivationToken: user.ActivationToken(ACTIVATION_TOKEN)},
+	)
+	s.ErrorIs(err, user.ErrInvalidActivationToken)
+
+	u, err := s.Uow.Context.UserRepository.GetByID(context.Background(), inactiveUser.ID)
+	s.Nil(err)
+	s.False(u.IsActive())
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestClaimedUserLimitsUpdated() {
+	claimedUser := s.createClaimedUser()
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{ActivationToken: user.ActivationToken(ACTIVATION_TOKEN)},
+	)
+	s.Nil(err)
+
+	u, err := s.Uow.Context.UserRepository.GetByID(context.Background(), claimedUser.ID)
+	s.Nil(err)
+	s.True(u.IsActive())
+	s.Equal(claimedUser.Identity, u.Identity)
+
+	s.Empty(s.Uow.Context.LimitsRepository.Created)
+	s.Equal(
+		[]user.UpdateLimitsInput{{
+			UserID: claimedUser.ID,
+			Plan:   c.NewOptional(user.PlanFree, true),
+			Limits: DefaultLimits,
+		}},
+		s.Uow.Context.LimitsRepository.Updated,
+	)
+	s.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestClaimedUserEmailChannelCreated() {
+	claimedUser := s.createClaimedUser()
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{ActivationToken: user.ActivationToken(ACTIVATION_TOKEN)},
+	)
+	s.Nil(err)
+
+	createdChannels := s.Uow.Context.ChannelRepository.Created
+	s.Equal(1, len(createdChannels), "internal channel already exists")
+	s.Equal(claimedUser.ID, createdChannels[0].CreatedBy)
+	s.Equal(channel.Email, createdChannels[0].Type)
+	s.Equal(c.NewEmail(EMAIL), createdChannels[0].Settings.(*channel.EmailSettings).Email)
+	s.True(createdChannels[0].IsVerified())
+}
+
+func (s *testSuite) TestClaimedUserEmailChannelExists() {
+	s.createClaimedUser()
+	s.Uow.Context.ChannelRepository.ReadChannels = []channel.Channel{{
+		ID:       10,
+		Type:     channel.Email,
+		Settings: channel.NewEmailSettings(c.NewEmail(EMAIL)),
+	}}
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{ActivationToken: user.ActivationToken(ACTIVATION_TOKEN)},
+	)
+	s.Nil(err)
+	s.Empty(s.Uow.Context.ChannelRepository.Created)
+	s.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) createClaimedUser() user.User {
+	s.T().Helper()
+	u, err := s.Uow.Context.UserRepository.Create(
+		context.Background(),
+		user.CreateUserInput{
+			Identity:    c.NewOptional(user.Identity("test-identity"), true),
+			CreatedAt:   Now,
+			ActivatedAt: c.NewOptional(Now, true),
+		},
+	)
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+	u, err = s.Uow.Context.UserRepository.Claim(context.Background(), user.ClaimUserInput{
+		ID:                       u.ID,
+		Email:                    c.NewEmail(EMAIL),
+		PasswordHash:             user.PasswordHash(PASSWORD_HASH),
+		ActivationToken:          user.ActivationToken(ACTIVATION_TOKEN),
+		ActivationTokenExpiresAt: Now.Add(time.Hour),
+	})
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+	s.False(u.IsActive())
+	return u
+}
+
 func (s *testSuite) createInactiveUser() user.User {
 	s.T().Helper()
 	u, err := s.Uow.Context.UserRepository.Create(
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+var ErrInvalidAdminToken = errors.New("invalid admin token")
+
+type Input interface {
+	WithAdmin(actor string) Input
+}
+
+type service[T Input, S any] struct {
+	log    logging.Logger
+	tokens map[string]string
+	inner  services.Service[T, S]
+}
+
+// WithAdminAuthentication runs the inner service if the bearer token of the request is one of
+// the admin tokens, tokens maps an actor name recorded in audit trails to the actor token.
+func WithAdminAuthentication[T Input, S any](
+	log logging.Logger,
+	tokens map[string]string,
+	inner services.Service[T, S],
+) services.Service[T, S] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if inner == nil {
+		panic(e.NewNilArgumentError("inner"))
+	}
+	return &service[T, S]{
+		log:    log,
+		tokens: tokens,
+		inner:  inner,
+	}
+}
+
+func (s *service[T, S]) Run(ctx context.Context, input T) (result S, err error) {
+	token, ok := ctx.Value(auth.CONTEXT_AUTH_TOKEN_KEY).(user.SessionToken)
+	if !ok {
+		return result, ErrInvalidAdminToken
+	}
+	actor, ok := s.authenticate(string(token))
+	if !ok {
+		s.log.Info(ctx, "Invalid admin token.")
+		return result, ErrInvalidAdminToken
+	}
+	s.log.Info(ctx, "Admin authenticated.", logging.Entry("actor", actor))
+	return s.inner.Run(ctx, input.WithAdmin(actor).(T))
+}
+
+func (s *service[T, S]) authenticate(token string) (actor string, ok bool) {
+	for name, adminToken := range s.tokens {
+		if adminToken == "" {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	ACTOR       = "test-admin"
+	ADMIN_TOKEN = "test-admin-token"
+)
+
+type input struct {
+	Actor string
+}
+
+func (i input) WithAdmin(actor string) Input {
+	i.Actor = actor
+	return i
+}
+
+type stubService struct {
+	Input     input
+	WasCalled bool
+}
+
+func (s *stubService) Run(ctx context.Context, input input) (result struct{}, err error) {
+	s.Input = input
+	s.WasCalled = true
+	return result, nil
+}
+
+type testSuite struct {
+	suite.Suite
+	Inner   *stubService
+	Service services.Service[input, struct{}]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Inner = &stubService{}
+	suite.Service = WithAdminAuthentication[input, struct{}](
+		logging.NewFakeLogger(),
+		map[string]string{ACTOR: ADMIN_TOKEN, "disabled-admin": ""},
+		suite.Inner,
+	)
+}
+
+func TestAdminAuthentication(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	ctx := context.WithValue(context.Background(), auth.CONTEXT_AUTH_TOKEN_KEY, user.SessionToken(ADMIN_TOKEN))
+
+	_, err := s.Service.Run(ctx, input{})
+
+	s.Nil(err)
+	s.True(s.Inner.WasCalled)
+	s.Equal(ACTOR, s.Inner.Input.Actor)
+}
+
+func (s *testSuite) TestInvalidToken() {
+	for _, token := range []string{"invalid-token", ""} {
+		ctx := context.WithValue(context.Background(), auth.CONTEXT_AUTH_TOKEN_KEY, user.SessionToken(token))
+
+		_, err := s.Service.Run(ctx, input{})
+
+		s.ErrorIs(err, ErrInvalidAdminToken, token)
+		s.False(s.Inner.WasCalled)
+	}
+}
+
+func (s *testSuite) TestNoToken() {
+	_, err := s.Service.Run(context.Background(), input{})
+
+	s.ErrorIs(err, ErrInvalidAdminToken)
+	s.False(s.Inner.WasCalled)
+}
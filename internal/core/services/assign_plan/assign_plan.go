@@ -0,0 +1,127 @@
+package assignplan
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	"time"
+)
+
+type Input struct {
+	Actor  string
+	UserID user.ID
+	Plan   user.PlanName
+}
+
+func (i Input) WithAdmin(actor string) admin.Input {
+	i.Actor = actor
+	return i
+}
+
+type Result struct {
+	UserPlan user.UserPlan
+}
+
+type service struct {
+	log            logging.Logger
+	unitOfWork     uow.UnitOfWork
+	planRepository user.PlanRepository
+	now            func() time.Time
+}
+
+// New replaces the user limits with the limits of the plan, overridden limits are reset.
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	planRepository user.PlanRepository,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if planRepository == nil {
+		panic(e.NewNilArgumentError("planRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:            log,
+		unitOfWork:     unitOfWork,
+		planRepository: planRepository,
+		now:            now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	ctx = logging.WithUserID(ctx, int64(input.UserID))
+	plan, err := s.planRepository.Get(ctx, input.Plan)
+	if errors.Is(err, user.ErrPlanDoesNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	before, err := uow.Limits().GetUserPlanWithLock(ctx, input.UserID)
+	if errors.Is(err, user.ErrLimitsDoNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	limits, err := uow.Limits().Update(ctx, user.UpdateLimitsInput{
+		UserID: input.UserID,
+		Plan:   c.NewOptional(plan.Name, true),
+		Limits: plan.Limits,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	_, err = uow.LimitsAudit().Create(ctx, user.CreateLimitsAuditEntryInput{
+		UserID:    input.UserID,
+		Actor:     input.Actor,
+		Action:    user.LimitsAuditActionAssignPlan,
+		Plan:      c.NewOptional(plan.Name, true),
+		Before:    before.Limits,
+		After:     limits,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Plan has been assigned to user.",
+		logging.Entry("actor", input.Actor),
+		logging.Entry("plan", plan.Name),
+		logging.Entry("previousPlan", before.Plan),
+	)
+	result.UserPlan = user.UserPlan{UserID: input.UserID, Plan: c.NewOptional(plan.Name, true), Limits: limits}
+	return result, nil
+}
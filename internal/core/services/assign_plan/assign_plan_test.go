@@ -0,0 +1,112 @@
+package assignplan
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID = user.ID(1)
+	ACTOR   = "test-admin"
+)
+
+var (
+	NOW        = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	FreeLimits = user.Limits{
+		EmailChannelCount:   c.NewOptional(uint32(1), true),
+		ActiveReminderCount: c.NewOptional(uint32(10), true),
+	}
+	ProLimits = user.Limits{
+		EmailChannelCount:   c.NewOptional(uint32(5), true),
+		ActiveReminderCount: c.NewOptional(uint32(100), true),
+	}
+)
+
+type testSuite struct {
+	suite.Suite
+	Uow     *uow.FakeUnitOfWork
+	Plans   *user.FakePlanRepository
+	Service services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Uow.Limits().Plan = c.NewOptional(user.PlanFree, true)
+	suite.Uow.Limits().Limits = FreeLimits
+	suite.Plans = user.NewFakePlanRepository(
+		user.Plan{Name: user.PlanFree, Limits: FreeLimits},
+		user.Plan{Name: user.PlanPro, Limits: ProLimits},
+	)
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow,
+		suite.Plans,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestAssignPlanService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{Actor: ACTOR, UserID: USER_ID, Plan: user.PlanPro},
+	)
+
+	s.Nil(err)
+	s.Equal(
+		user.UserPlan{UserID: USER_ID, Plan: c.NewOptional(user.PlanPro, true), Limits: ProLimits},
+		result.UserPlan,
+	)
+	s.Equal(
+		[]user.UpdateLimitsInput{{UserID: USER_ID, Plan: c.NewOptional(user.PlanPro, true), Limits: ProLimits}},
+		s.Uow.Limits().Updated,
+	)
+	s.Equal(
+		[]user.LimitsAuditEntry{{
+			ID:        1,
+			UserID:    USER_ID,
+			Actor:     ACTOR,
+			Action:    user.LimitsAuditActionAssignPlan,
+			Plan:      c.NewOptional(user.PlanPro, true),
+			Before:    FreeLimits,
+			After:     ProLimits,
+			CreatedAt: NOW,
+		}},
+		s.Uow.Context.LimitsAuditRepository.Entries,
+	)
+	s.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestPlanDoesNotExist() {
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{Actor: ACTOR, UserID: USER_ID, Plan: user.PlanName("unknown")},
+	)
+
+	s.ErrorIs(err, user.ErrPlanDoesNotExist)
+	s.Empty(s.Uow.Limits().Updated)
+	s.Empty(s.Uow.Context.LimitsAuditRepository.Entries)
+}
+
+func (s *testSuite) TestAuditFailed() {
+	s.Uow.Context.LimitsAuditRepository.ReturnError = true
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{Actor: ACTOR, UserID: USER_ID, Plan: user.PlanPro},
+	)
+
+	s.NotNil(err)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
@@ -3,8 +3,10 @@ package auth
 import (
 	"context"
 	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services"
+	"time"
 )
 
 type contextAuthToken string
@@ -15,24 +17,45 @@ type Input interface {
 	WithAuthenticatedUser(u user.User) Input
 }
 
+// ScopedInput is implemented by inputs of services which can be run with an API token
+// having the scope. Services with other inputs can be run with a session token only.
+type ScopedInput interface {
+	GetRequiredScope() user.Scope
+}
+
 type service[T Input, S any] struct {
-	sessionRepository user.SessionRepository
-	inner             services.Service[T, S]
+	sessionRepository  user.SessionRepository
+	apiTokenRepository user.APITokenRepository
+	sessionExpiry      user.SessionExpiry
+	now                func() time.Time
+	inner              services.Service[T, S]
 }
 
 func WithAuthentication[T Input, S any](
 	sessionRepository user.SessionRepository,
+	apiTokenRepository user.APITokenRepository,
+	sessionExpiry user.SessionExpiry,
+	now func() time.Time,
 	inner services.Service[T, S],
 ) services.Service[T, S] {
 	if sessionRepository == nil {
 		panic(e.NewNilArgumentError("sessionRepository"))
 	}
+	if apiTokenRepository == nil {
+		panic(e.NewNilArgumentError("apiTokenRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
 	if inner == nil {
 		panic(e.NewNilArgumentError("inner"))
 	}
 	return &service[T, S]{
-		sessionRepository: sessionRepository,
-		inner:             inner,
+		sessionRepository:  sessionRepository,
+		apiTokenRepository: apiTokenRepository,
+		sessionExpiry:      sessionExpiry,
+		now:                now,
+		inner:              inner,
 	}
 }
 
@@ -41,9 +64,37 @@ func (s *service[T, S]) Run(ctx context.Context, input T) (result S, err error)
 	if !ok {
 		return result, user.ErrUserDoesNotExist
 	}
-	u, err := s.sessionRepository.GetUserByToken(ctx, authToken)
+	var u user.User
+	if user.IsAPIToken(authToken) {
+		u, err = s.authenticateAPIToken(ctx, user.APITokenSecret(authToken), input)
+	} else {
+		u, err = s.sessionRepository.GetUserByToken(ctx, user.GetUserBySessionTokenInput{
+			Token:  authToken,
+			At:     s.now(),
+			Expiry: s.sessionExpiry,
+		})
+	}
 	if err != nil {
 		return result, err
 	}
+	ctx = logging.WithUserID(ctx, int64(u.ID))
 	return s.inner.Run(ctx, input.WithAuthenticatedUser(u).(T))
 }
+
+// authenticateAPIToken returns ErrInsufficientScope if the token does not have the scope
+// required by the input or the input cannot be used with API tokens at all.
+func (s *service[T, S]) authenticateAPIToken(
+	ctx context.Context,
+	secret user.APITokenSecret,
+	input T,
+) (u user.User, err error) {
+	u, token, err := s.apiTokenRepository.GetUserByToken(ctx, secret, s.now())
+	if err != nil {
+		return u, err
+	}
+	scopedInput, ok := interface{}(input).(ScopedInput)
+	if !ok || !token.HasScope(scopedInput.GetRequiredScope()) {
+		return u, user.ErrInsufficientScope
+	}
+	return u, nil
+}
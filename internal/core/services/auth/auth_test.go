@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	SESSION_TOKEN    = "test-session-token"
+	API_TOKEN_SECRET = user.API_TOKEN_PREFIX + "test-secret"
+)
+
+var (
+	NOW    = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+)
+
+type input struct {
+	UserID user.ID
+}
+
+func (i input) WithAuthenticatedUser(u user.User) Input {
+	i.UserID = u.ID
+	return i
+}
+
+type scopedInput struct {
+	UserID user.ID
+}
+
+func (i scopedInput) WithAuthenticatedUser(u user.User) Input {
+	i.UserID = u.ID
+	return i
+}
+
+func (i scopedInput) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
+type stubService[T Input] struct {
+	Input     T
+	WasCalled bool
+}
+
+func (s *stubService[T]) Run(ctx context.Context, input T) (result struct{}, err error) {
+	s.Input = input
+	s.WasCalled = true
+	return result, nil
+}
+
+type testSuite struct {
+	suite.Suite
+	UserRepository     *user.FakeUserRepository
+	SessionRepository  *user.FakeSessionRepository
+	APITokenRepository *user.FakeAPITokenRepository
+	Inner              *stubService[input]
+	ScopedInner        *stubService[scopedInput]
+	Service            services.Service[input, struct{}]
+	ScopedService      services.Service[scopedInput, struct{}]
+	User               user.User
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.SessionRepository = user.NewFakeSessionRepository(suite.UserRepository)
+	suite.APITokenRepository = user.NewFakeAPITokenRepository(suite.UserRepository)
+	suite.Inner = &stubService[input]{}
+	suite.ScopedInner = &stubService[scopedInput]{}
+	now := func() time.Time { return NOW }
+	suite.Service = WithAuthentication[input, struct{}](
+		suite.SessionRepository,
+		suite.APITokenRepository,
+		EXPIRY,
+		now,
+		suite.Inner,
+	)
+	suite.ScopedService = WithAuthentication[scopedInput, struct{}](
+		suite.SessionRepository,
+		suite.APITokenRepository,
+		EXPIRY,
+		now,
+		suite.ScopedInner,
+	)
+	u, err := suite.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:       c.NewOptional(c.NewEmail("test@test.test"), true),
+		CreatedAt:   NOW,
+		ActivatedAt: c.NewOptional(NOW, true),
+	})
+	suite.Require().Nil(err)
+	suite.User = u
+}
+
+func TestAuthenticationService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSessionToken() {
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    s.User.ID,
+		Token:     SESSION_TOKEN,
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(s.ctx(SESSION_TOKEN), input{})
+
+	s.Nil(err)
+	s.Equal(s.User.ID, s.Inner.Input.UserID)
+}
+
+func (s *testSuite) TestNoToken() {
+	_, err := s.Service.Run(context.Background(), input{})
+
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+	s.False(s.Inner.WasCalled)
+}
+
+func (s *testSuite) TestAPITokenWithScope() {
+	s.createAPIToken(NOW.Add(time.Hour), user.ScopeRemindersRead, user.ScopeRemindersWrite)
+
+	_, err := s.ScopedService.Run(s.ctx(API_TOKEN_SECRET), scopedInput{})
+
+	s.Nil(err)
+	s.Equal(s.User.ID, s.ScopedInner.Input.UserID)
+	s.Equal(c.NewOptional(NOW, true), s.APITokenRepository.Tokens[0].LastUsedAt)
+}
+
+func (s *testSuite) TestAPITokenWithoutScope() {
+	s.createAPIToken(NOW.Add(time.Hour), user.ScopeRemindersRead)
+
+	_, err := s.ScopedService.Run(s.ctx(API_TOKEN_SECRET), scopedInput{})
+
+	s.ErrorIs(err, user.ErrInsufficientScope)
+	s.False(s.ScopedInner.WasCalled)
+}
+
+func (s *testSuite) TestAPITokenForSessionOnlyService() {
+	s.createAPIToken(NOW.Add(time.Hour), user.Scopes...)
+
+	_, err := s.Service.Run(s.ctx(API_TOKEN_SECRET), input{})
+
+	s.ErrorIs(err, user.ErrInsufficientScope)
+	s.False(s.Inner.WasCalled)
+}
+
+func (s *testSuite) TestExpiredAPIToken() {
+	s.createAPIToken(NOW, user.ScopeRemindersWrite)
+
+	_, err := s.ScopedService.Run(s.ctx(API_TOKEN_SECRET), scopedInput{})
+
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+	s.False(s.ScopedInner.WasCalled)
+}
+
+func (s *testSuite) ctx(token string) context.Context {
+	return context.WithValue(context.Background(), CONTEXT_AUTH_TOKEN_KEY, user.SessionToken(token))
+}
+
+func (s *testSuite) createAPIToken(expiresAt time.Time, scopes ...user.Scope) {
+	s.T().Helper()
+	_, err := s.APITokenRepository.Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    s.User.ID,
+		Secret:    API_TOKEN_SECRET,
+		Name:      "test",
+		Scopes:    scopes,
+		CreatedAt: NOW,
+		ExpiresAt: expiresAt,
+	})
+	s.Require().Nil(err)
+}
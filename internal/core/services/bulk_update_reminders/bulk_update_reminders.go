@@ -0,0 +1,465 @@
+package bulkupdatereminders
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	updatereminder "remindme/internal/core/services/update_reminder"
+	updatereminderchannels "remindme/internal/core/services/update_reminder_channels"
+	"sort"
+	"time"
+)
+
+// MAX_REMINDER_COUNT limits the reminder IDs of a bulk action, reminders selected
+// by a filter are processed in pages of this size.
+const MAX_REMINDER_COUNT = 100
+
+type Action string
+
+const (
+	ActionCancel      = Action("cancel")
+	ActionDelete      = Action("delete")
+	ActionShift       = Action("shift")
+	ActionSetChannels = Action("set_channels")
+	ActionPause       = Action("pause")
+	ActionResume      = Action("resume")
+)
+
+// Filter selects reminders of the user the same way as listing them does,
+// only active ones by default or paused ones for ActionResume.
+type Filter struct {
+	StatusIn        c.Optional[[]reminder.Status]
+	BodySearch      c.Optional[string]
+	AtAfter         c.Optional[time.Time]
+	AtBefore        c.Optional[time.Time]
+	IsPeriodic      c.Optional[bool]
+	ChannelIDEquals c.Optional[channel.ID]
+	CreatedAfter    c.Optional[time.Time]
+	CreatedBefore   c.Optional[time.Time]
+}
+
+type Input struct {
+	UserID      user.ID
+	ReminderIDs []reminder.ID
+	Filter      c.Optional[Filter]
+	Action      Action
+	// Shift is added to At of the reminders by ActionShift.
+	Shift time.Duration
+	// ChannelIDs replace channels of the reminders by ActionSetChannels.
+	ChannelIDs reminder.ChannelIDs
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
+func (i Input) Validate() error {
+	switch i.Action {
+	case ActionCancel, ActionDelete, ActionPause, ActionResume:
+	case ActionShift:
+		if i.Shift == 0 {
+			return reminder.ErrBulkShiftNotSet
+		}
+	case ActionSetChannels:
+		if err := (updatereminderchannels.Input{ChannelIDs: i.ChannelIDs}).Validate(); err != nil {
+			return err
+		}
+	default:
+		return reminder.ErrBulkActionNotValid
+	}
+	if (len(i.ReminderIDs) > 0) == i.Filter.IsPresent {
+		return reminder.ErrBulkSelectionNotValid
+	}
+	if len(i.ReminderIDs) > MAX_REMINDER_COUNT {
+		return reminder.ErrBulkTooManyReminders
+	}
+	return nil
+}
+
+// Item is the outcome of the action for a reminder. Reminders which the action can not be applied to
+// have Error set and are left unchanged, the action is still applied to the rest of them.
+type Item struct {
+	ReminderID reminder.ID
+	Reminder   c.Optional[reminder.ReminderWithChannels]
+	Error      error
+}
+
+type Result struct {
+	Items        []Item
+	UpdatedCount uint
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	scheduler  reminder.Scheduler
+	now        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	scheduler reminder.Scheduler,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if scheduler == nil {
+		panic(e.NewNilArgumentError("scheduler"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		scheduler:  scheduler,
+		now:        now,
+	}
+}
+
+// Run applies the action to the selected reminders. Reminders selected by a filter are processed
+// in pages, every page is committed separately, so the pages before a failed one stay updated.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	if err := input.Validate(); err != nil {
+		return result, err
+	}
+
+	after := c.Optional[reminder.Cursor]{}
+	for {
+		page, err := s.runPage(ctx, input, after)
+		result.Items = append(result.Items, page.items...)
+		result.UpdatedCount += page.updatedCount
+		if err != nil {
+			return result, err
+		}
+		if !page.next.IsPresent {
+			break
+		}
+		after = page.next
+	}
+
+	s.log.Info(
+		ctx,
+		"Bulk reminder action has been successfully applied.",
+		logging.Entry("input", input),
+		logging.Entry("selectedCount", len(result.Items)),
+		logging.Entry("updatedCount", result.UpdatedCount),
+	)
+	return result, nil
+}
+
+type page struct {
+	items        []Item
+	updatedCount uint
+	// next is set if the filter selects more reminders after the page.
+	next c.Optional[reminder.Cursor]
+}
+
+// runPage applies the action to a page of reminders in a single transaction. Reminders
+// which have to be sent soon are scheduled after the commit, so that nothing is published
+// for the changes which are rolled back.
+func (s *service) runPage(
+	ctx context.Context,
+	input Input,
+	after c.Optional[reminder.Cursor],
+) (result page, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	reminderIDs, next, err := s.selectReminderIDs(ctx, uow, input, after)
+	if err != nil {
+		return result, err
+	}
+
+	var channelIDs []channel.ID
+	if input.Action == ActionSetChannels {
+		channelIDs, err = updatereminderchannels.ReadChannels(ctx, uow.Channels(), input.UserID, input.ChannelIDs)
+		if err != nil {
+			if !errors.Is(err, reminder.ErrReminderChannelsNotValid) &&
+				!errors.Is(err, reminder.ErrReminderChannelsNotVerified) {
+				logging.Error(ctx, s.log, err, logging.Entry("input", input))
+			}
+			return result, err
+		}
+	}
+
+	var limits user.Limits
+	if input.Action == ActionResume {
+		limits, err = uow.Limits().GetUserLimitsWithLock(ctx, input.UserID)
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+			return result, err
+		}
+	}
+
+	now := s.now()
+	items := make([]Item, 0, len(reminderIDs))
+	var updatedCount uint
+	for _, reminderID := range reminderIDs {
+		item, err := s.runItem(ctx, uow, input, reminderID, channelIDs, limits, now)
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminderID", reminderID))
+			return result, err
+		}
+		if item.Error == nil {
+			updatedCount++
+		}
+		items = append(items, item)
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.schedule(ctx, input.Action, items)
+
+	return page{items: items, updatedCount: updatedCount, next: next}, nil
+}
+
+// selectReminderIDs returns the IDs of the input or of the page of reminders matched by the filter.
+// The IDs are sorted, so that concurrent bulk actions lock reminders in the same order.
+func (s *service) selectReminderIDs(
+	ctx context.Context,
+	uow uow.Context,
+	input Input,
+	after c.Optional[reminder.Cursor],
+) (reminderIDs []reminder.ID, next c.Optional[reminder.Cursor], err error) {
+	if !input.Filter.IsPresent {
+		seen := make(map[reminder.ID]struct{}, len(input.ReminderIDs))
+		reminderIDs = make([]reminder.ID, 0, len(input.ReminderIDs))
+		for _, reminderID := range input.ReminderIDs {
+			if _, ok := seen[reminderID]; ok {
+				continue
+			}
+			seen[reminderID] = struct{}{}
+			reminderIDs = append(reminderIDs, reminderID)
+		}
+		sort.Slice(reminderIDs, func(i, j int) bool { return reminderIDs[i] < reminderIDs[j] })
+		return reminderIDs, next, nil
+	}
+
+	filter := input.Filter.Value
+	statusIn := filter.StatusIn
+	if !statusIn.IsPresent && input.Action == ActionResume {
+		statusIn = c.NewOptional([]reminder.Status{reminder.StatusPaused}, true)
+	} else if !statusIn.IsPresent {
+		statusIn = c.NewOptional([]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled}, true)
+	}
+	// One more reminder is read to find out if there is a next page.
+	reminders, err := uow.Reminders().Read(ctx, reminder.ReadOptions{
+		CreatedByEquals: c.NewOptional(input.UserID, true),
+		StatusIn:        statusIn,
+		BodySearch:      filter.BodySearch,
+		AtAfter:         filter.AtAfter,
+		AtBefore:        filter.AtBefore,
+		IsPeriodic:      filter.IsPeriodic,
+		ChannelIDEquals: filter.ChannelIDEquals,
+		CreatedAfter:    filter.CreatedAfter,
+		CreatedBefore:   filter.CreatedBefore,
+		OrderBy:         reminder.OrderByIDAsc,
+		Limit:           c.NewOptional[uint](MAX_REMINDER_COUNT+1, true),
+		After:           after,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return nil, next, err
+	}
+	if len(reminders) > MAX_REMINDER_COUNT {
+		reminders = reminders[:MAX_REMINDER_COUNT]
+		lastReminder := reminders[len(reminders)-1].Reminder
+		next = c.NewOptional(reminder.NewCursor(reminder.OrderByIDAsc, lastReminder), true)
+	}
+	reminderIDs = make([]reminder.ID, 0, len(reminders))
+	for _, rem := range reminders {
+		reminderIDs = append(reminderIDs, rem.ID)
+	}
+	return reminderIDs, next, nil
+}
+
+// runItem applies the action to a reminder, the returned error aborts the whole bulk action.
+func (s *service) runItem(
+	ctx context.Context,
+	uow uow.Context,
+	input Input,
+	reminderID reminder.ID,
+	channelIDs []channel.ID,
+	limits user.Limits,
+	now time.Time,
+) (item Item, err error) {
+	item.ReminderID = reminderID
+	reminderRepository := uow.Reminders()
+	if err := reminderRepository.Lock(ctx, reminderID); err != nil {
+		return item, err
+	}
+	rem, err := reminderRepository.GetByID(ctx, reminderID)
+	if err != nil {
+		if errors.Is(err, reminder.ErrReminderDoesNotExist) {
+			item.Error = err
+			return item, nil
+		}
+		return item, err
+	}
+	if rem.CreatedBy != input.UserID {
+		item.Error = reminder.ErrReminderPermission
+		return item, nil
+	}
+	if !canApply(input.Action, rem.Reminder) {
+		item.Error = reminder.ErrReminderNotActive
+		return item, nil
+	}
+
+	switch input.Action {
+	case ActionCancel:
+		updatedReminder, err := s.update(ctx, uow, rem.Reminder, reminder.UpdateInput{
+			ID:                 rem.ID,
+			DoStatusUpdate:     true,
+			Status:             reminder.StatusCanceled,
+			DoCanceledAtUpdate: true,
+			CanceledAt:         c.NewOptional(now, true),
+		})
+		if err != nil {
+			return item, err
+		}
+		rem.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	case ActionDelete:
+		if err := reminderRepository.Delete(ctx, rem.ID); err != nil {
+			return item, err
+		}
+		if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, reminder.Reminder{}); err != nil {
+			return item, err
+		}
+	case ActionShift:
+		at := rem.At.Add(input.Shift)
+		if err := updatereminder.ValidateAt(at, now); err != nil {
+			item.Error = err
+			return item, nil
+		}
+		update := reminder.UpdateInput{
+			ID:                  rem.ID,
+			DoAtUpdate:          true,
+			At:                  at,
+			DoStatusUpdate:      true,
+			Status:              reminder.StatusCreated,
+			DoScheduledAtUpdate: true,
+		}
+		if at.Sub(now) < reminder.DURATION_FOR_SCHEDULING {
+			update.Status = reminder.StatusScheduled
+			update.ScheduledAt = c.NewOptional(now, true)
+		}
+		updatedReminder, err := s.update(ctx, uow, rem.Reminder, update)
+		if err != nil {
+			return item, err
+		}
+		rem.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	case ActionPause:
+		update, err := reminder.PauseUpdate(rem.Reminder)
+		if err != nil {
+			item.Error = err
+			return item, nil
+		}
+		updatedReminder, err := s.update(ctx, uow, rem.Reminder, update)
+		if err != nil {
+			return item, err
+		}
+		rem.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	case ActionResume:
+		update, err := reminder.ResumeUpdate(rem.Reminder, now)
+		if err != nil {
+			item.Error = err
+			return item, nil
+		}
+		if err := reminder.CheckResumeLimit(ctx, uow.Usage(), input.UserID, limits); err != nil {
+			if errors.Is(err, user.ErrLimitActiveReminderCountExceeded) {
+				item.Error = err
+				return item, nil
+			}
+			return item, err
+		}
+		updatedReminder, err := s.update(ctx, uow, rem.Reminder, update)
+		if err != nil {
+			return item, err
+		}
+		rem.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	case ActionSetChannels:
+		reminderChannelRepository := uow.ReminderChannels()
+		if err := reminderChannelRepository.DeleteByReminderID(ctx, rem.ID); err != nil {
+			return item, err
+		}
+		_, err := reminderChannelRepository.Create(ctx, reminder.NewCreateChannelsInput(rem.ID, channelIDs...))
+		if err != nil {
+			return item, err
+		}
+		rem.ChannelIDs = channelIDs
+	}
+
+	item.Reminder = c.NewOptional(rem, true)
+	return item, nil
+}
+
+// update saves the reminder together with the usage counters of its creator.
+func (s *service) update(
+	ctx context.Context,
+	uow uow.Context,
+	rem reminder.Reminder,
+	update reminder.UpdateInput,
+) (reminder.Reminder, error) {
+	updatedReminder, err := uow.Reminders().Update(ctx, update)
+	if err != nil {
+		return updatedReminder, err
+	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem, updatedReminder); err != nil {
+		return updatedReminder, err
+	}
+	return updatedReminder, nil
+}
+
+// schedule sends the updated reminders which have to be sent soon to the scheduler. The changes
+// are committed already, so scheduling errors are logged and do not fail the bulk action.
+func (s *service) schedule(ctx context.Context, action Action, items []Item) {
+	if action != ActionShift && action != ActionResume {
+		return
+	}
+	for _, item := range items {
+		if item.Error != nil || !item.Reminder.IsPresent || item.Reminder.Value.Status != reminder.StatusScheduled {
+			continue
+		}
+		if err := s.scheduler.ScheduleReminder(ctx, item.Reminder.Value.Reminder); err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("reminderID", item.ReminderID))
+		}
+	}
+}
+
+// canApply checks the status of the reminder the same way as the services of the single reminder actions.
+// Paused reminders can be canceled, deleted or resumed, pause and resume check the status themselves.
+func canApply(action Action, rem reminder.Reminder) bool {
+	switch action {
+	case ActionCancel, ActionDelete:
+		return rem.IsActive() || rem.IsPaused()
+	case ActionPause, ActionResume:
+		return true
+	default:
+		return rem.IsActive()
+	}
+}
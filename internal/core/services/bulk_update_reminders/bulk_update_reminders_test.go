@@ -0,0 +1,442 @@
+package bulkupdatereminders
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID       = 1
+	OTHER_USER_ID = 2
+)
+
+var Now = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	logger     *logging.FakeLogger
+	unitOfWork *uow.FakeUnitOfWork
+	scheduler  *reminder.TestReminderScheduler
+	service    services.Service[Input, Result]
+}
+
+func (s *testSuite) SetupTest() {
+	s.logger = logging.NewFakeLogger()
+	s.unitOfWork = uow.NewFakeUnitOfWork()
+	s.unitOfWork.Reminders().GetByIDReminders = map[reminder.ID]reminder.ReminderWithChannels{
+		1: newReminder(1, USER_ID, reminder.StatusCreated, Now.Add(2*reminder.DURATION_FOR_SCHEDULING)),
+		2: newReminder(2, USER_ID, reminder.StatusScheduled, Now.Add(time.Hour)),
+		3: newReminder(3, USER_ID, reminder.StatusSentSuccess, Now.Add(-time.Hour)),
+		4: newReminder(4, OTHER_USER_ID, reminder.StatusCreated, Now.Add(time.Hour)),
+	}
+	s.unitOfWork.Usage().ActiveCount = 2
+	s.scheduler = reminder.NewTestReminderScheduler()
+	s.service = New(
+		s.logger,
+		s.unitOfWork,
+		s.scheduler,
+		func() time.Time { return Now },
+	)
+}
+
+func TestBulkUpdateRemindersService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func newReminder(id reminder.ID, userID user.ID, status reminder.Status, at time.Time) reminder.ReminderWithChannels {
+	return reminder.ReminderWithChannels{
+		Reminder: reminder.Reminder{
+			ID:        id,
+			CreatedBy: userID,
+			At:        at,
+			Status:    status,
+		},
+		ChannelIDs: []channel.ID{1},
+	}
+}
+
+// commitCheckingScheduler records if the changes are committed when a reminder is scheduled.
+type commitCheckingScheduler struct {
+	unitOfWork      *uow.FakeUnitOfWork
+	wasCommitCalled []bool
+}
+
+func (s *commitCheckingScheduler) ScheduleReminder(ctx context.Context, rem reminder.Reminder) error {
+	s.wasCommitCalled = append(s.wasCommitCalled, s.unitOfWork.Context.WasCommitCalled)
+	return nil
+}
+
+func itemErrors(items []Item) map[reminder.ID]error {
+	errs := make(map[reminder.ID]error, len(items))
+	for _, item := range items {
+		errs[item.ReminderID] = item.Error
+	}
+	return errs
+}
+
+func (s *testSuite) TestValidate() {
+	cases := []struct {
+		id            string
+		input         Input
+		expectedError error
+	}{
+		{
+			id:    "cancel",
+			input: Input{ReminderIDs: []reminder.ID{1}, Action: ActionCancel},
+		},
+		{
+			id:    "filter",
+			input: Input{Filter: c.NewOptional(Filter{}, true), Action: ActionDelete},
+		},
+		{
+			id:            "invalid action",
+			input:         Input{ReminderIDs: []reminder.ID{1}, Action: Action("archive")},
+			expectedError: reminder.ErrBulkActionNotValid,
+		},
+		{
+			id:            "no selection",
+			input:         Input{Action: ActionCancel},
+			expectedError: reminder.ErrBulkSelectionNotValid,
+		},
+		{
+			id: "ids and filter",
+			input: Input{
+				ReminderIDs: []reminder.ID{1},
+				Filter:      c.NewOptional(Filter{}, true),
+				Action:      ActionCancel,
+			},
+			expectedError: reminder.ErrBulkSelectionNotValid,
+		},
+		{
+			id:            "too many reminders",
+			input:         Input{ReminderIDs: make([]reminder.ID, MAX_REMINDER_COUNT+1), Action: ActionCancel},
+			expectedError: reminder.ErrBulkTooManyReminders,
+		},
+		{
+			id:            "shift not set",
+			input:         Input{ReminderIDs: []reminder.ID{1}, Action: ActionShift},
+			expectedError: reminder.ErrBulkShiftNotSet,
+		},
+		{
+			id:            "channels not set",
+			input:         Input{ReminderIDs: []reminder.ID{1}, Action: ActionSetChannels},
+			expectedError: reminder.ErrReminderChannelsNotSet,
+		},
+	}
+
+	for _, testcase := range cases {
+		s.Run(testcase.id, func() {
+			s.ErrorIs(testcase.input.Validate(), testcase.expectedError)
+		})
+	}
+}
+
+func (s *testSuite) TestCancel() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{5, 4, 3, 2, 1, 2},
+		Action:      ActionCancel,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(2), result.UpdatedCount)
+	s.Equal(map[reminder.ID]error{
+		1: nil,
+		2: nil,
+		3: reminder.ErrReminderNotActive,
+		4: reminder.ErrReminderPermission,
+		5: reminder.ErrReminderDoesNotExist,
+	}, itemErrors(result.Items))
+	s.Equal([]reminder.ID{1, 2, 3, 4, 5}, s.unitOfWork.Reminders().LockWith)
+	for _, item := range result.Items[:2] {
+		s.True(item.Reminder.IsPresent)
+		s.Equal(reminder.StatusCanceled, item.Reminder.Value.Status)
+		s.Equal(c.NewOptional(Now, true), item.Reminder.Value.CanceledAt)
+		s.Equal([]channel.ID{1}, item.Reminder.Value.ChannelIDs)
+	}
+	s.False(result.Items[2].Reminder.IsPresent)
+	s.Equal(uint(0), s.unitOfWork.Usage().ActiveCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestDelete() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1, 3},
+		Action:      ActionDelete,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal([]reminder.ID{1}, s.unitOfWork.Reminders().DeleteWith)
+	s.Equal(uint(1), s.unitOfWork.Usage().ActiveCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestShift() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1, 2},
+		Action:      ActionShift,
+		Shift:       -time.Hour - reminder.DURATION_FOR_SCHEDULING,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Nil(result.Items[0].Error)
+	s.Equal(reminder.StatusScheduled, result.Items[0].Reminder.Value.Status)
+	s.Equal(Now.Add(reminder.DURATION_FOR_SCHEDULING-time.Hour), result.Items[0].Reminder.Value.At)
+	s.Equal(c.NewOptional(Now, true), result.Items[0].Reminder.Value.ScheduledAt)
+	s.ErrorIs(result.Items[1].Error, reminder.ErrReminderTooEarly)
+	s.Len(s.scheduler.Scheduled, 1)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestShiftSchedulesAfterCommit() {
+	scheduler := &commitCheckingScheduler{unitOfWork: s.unitOfWork}
+	service := New(s.logger, s.unitOfWork, scheduler, func() time.Time { return Now })
+
+	_, err := service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1},
+		Action:      ActionShift,
+		Shift:       -time.Hour - reminder.DURATION_FOR_SCHEDULING,
+	})
+
+	s.Nil(err)
+	s.Equal([]bool{true}, scheduler.wasCommitCalled)
+}
+
+func (s *testSuite) TestShiftSchedulingErrorIsIgnored() {
+	s.scheduler.Error = errors.New("scheduler error")
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1},
+		Action:      ActionShift,
+		Shift:       -time.Hour - reminder.DURATION_FOR_SCHEDULING,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal(reminder.StatusScheduled, result.Items[0].Reminder.Value.Status)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestShiftOutOfSchedulingWindow() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{2},
+		Action:      ActionShift,
+		Shift:       reminder.DURATION_FOR_SCHEDULING,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal(reminder.StatusCreated, result.Items[0].Reminder.Value.Status)
+	s.False(result.Items[0].Reminder.Value.ScheduledAt.IsPresent)
+	s.Empty(s.scheduler.Scheduled)
+}
+
+func (s *testSuite) TestSetChannels() {
+	s.unitOfWork.Channels().ReadChannels = []channel.Channel{
+		{ID: 2, CreatedBy: USER_ID, VerifiedAt: c.NewOptional(Now, true)},
+	}
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{2},
+		Action:      ActionSetChannels,
+		ChannelIDs:  reminder.NewChannelIDs(2),
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal([]channel.ID{2}, result.Items[0].Reminder.Value.ChannelIDs)
+	s.Equal(reminder.ID(2), s.unitOfWork.ReminderChannels().DeletedByReminderID)
+	s.Equal(reminder.ID(2), s.unitOfWork.ReminderChannels().CreatedForReminder)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestSetChannelsNotValid() {
+	_, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{2},
+		Action:      ActionSetChannels,
+		ChannelIDs:  reminder.NewChannelIDs(2),
+	})
+
+	s.ErrorIs(err, reminder.ErrReminderChannelsNotValid)
+	s.False(s.unitOfWork.ReminderChannels().WasDeleteCalled)
+	s.False(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestFilter() {
+	s.unitOfWork.Reminders().ReadReminders = []reminder.ReminderWithChannels{
+		s.unitOfWork.Reminders().GetByIDReminders[1],
+		s.unitOfWork.Reminders().GetByIDReminders[2],
+	}
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID: USER_ID,
+		Filter: c.NewOptional(Filter{
+			BodySearch:      c.NewOptional("test", true),
+			AtBefore:        c.NewOptional(Now.Add(time.Hour), true),
+			IsPeriodic:      c.NewOptional(false, true),
+			ChannelIDEquals: c.NewOptional[channel.ID](1, true),
+		}, true),
+		Action: ActionCancel,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(2), result.UpdatedCount)
+	s.Equal([]reminder.ReadOptions{{
+		CreatedByEquals: c.NewOptional[user.ID](USER_ID, true),
+		StatusIn: c.NewOptional(
+			[]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled},
+			true,
+		),
+		BodySearch:      c.NewOptional("test", true),
+		AtBefore:        c.NewOptional(Now.Add(time.Hour), true),
+		IsPeriodic:      c.NewOptional(false, true),
+		ChannelIDEquals: c.NewOptional[channel.ID](1, true),
+		OrderBy:         reminder.OrderByIDAsc,
+		Limit:           c.NewOptional[uint](MAX_REMINDER_COUNT+1, true),
+	}}, s.unitOfWork.Reminders().ReadWith)
+}
+
+func (s *testSuite) TestFilterPages() {
+	firstPage := make([]reminder.ReminderWithChannels, 0, MAX_REMINDER_COUNT+1)
+	for id := reminder.ID(1); id <= MAX_REMINDER_COUNT+1; id++ {
+		firstPage = append(firstPage, newReminder(id, USER_ID, reminder.StatusCreated, Now))
+	}
+	s.unitOfWork.Reminders().ReadPages = [][]reminder.ReminderWithChannels{
+		firstPage,
+		firstPage[MAX_REMINDER_COUNT:],
+	}
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID: USER_ID,
+		Filter: c.NewOptional(Filter{}, true),
+		Action: ActionCancel,
+	})
+
+	s.Nil(err)
+	s.Len(result.Items, MAX_REMINDER_COUNT+1)
+	s.Equal(uint(2), result.UpdatedCount)
+	readWith := s.unitOfWork.Reminders().ReadWith
+	s.Len(readWith, 2)
+	s.False(readWith[0].After.IsPresent)
+	s.Equal(
+		c.NewOptional(reminder.NewCursor(reminder.OrderByIDAsc, firstPage[MAX_REMINDER_COUNT-1].Reminder), true),
+		readWith[1].After,
+	)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestPause() {
+	periodic := s.unitOfWork.Reminders().GetByIDReminders[2]
+	periodic.Every = c.NewOptional(reminder.EveryDay, true)
+	s.unitOfWork.Reminders().GetByIDReminders[2] = periodic
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1, 2, 3},
+		Action:      ActionPause,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal(map[reminder.ID]error{
+		1: reminder.ErrReminderNotPeriodic,
+		2: nil,
+		3: reminder.ErrReminderNotActive,
+	}, itemErrors(result.Items))
+	s.Equal(reminder.StatusPaused, result.Items[1].Reminder.Value.Status)
+	s.Equal(uint(1), s.unitOfWork.Usage().ActiveCount)
+	s.Equal(uint(1), s.unitOfWork.Usage().PausedCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestResume() {
+	paused := newReminder(5, USER_ID, reminder.StatusPaused, Now.Add(-time.Hour))
+	paused.Every = c.NewOptional(reminder.EveryDay, true)
+	s.unitOfWork.Reminders().GetByIDReminders[5] = paused
+	s.unitOfWork.Usage().PausedCount = 1
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{1, 5},
+		Action:      ActionResume,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.ErrorIs(result.Items[0].Error, reminder.ErrReminderNotPaused)
+	s.Nil(result.Items[1].Error)
+	s.Equal(reminder.StatusScheduled, result.Items[1].Reminder.Value.Status)
+	s.Equal(Now.Add(23*time.Hour), result.Items[1].Reminder.Value.At)
+	s.Len(s.scheduler.Scheduled, 1)
+	s.Equal(uint(3), s.unitOfWork.Usage().ActiveCount)
+	s.Equal(uint(0), s.unitOfWork.Usage().PausedCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestResumeLimitExceeded() {
+	s.unitOfWork.Limits().Limits = user.Limits{ActiveReminderCount: c.NewOptional(uint32(2), true)}
+	paused := newReminder(5, USER_ID, reminder.StatusPaused, Now.Add(time.Hour))
+	paused.Every = c.NewOptional(reminder.EveryDay, true)
+	s.unitOfWork.Reminders().GetByIDReminders[5] = paused
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{5},
+		Action:      ActionResume,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(0), result.UpdatedCount)
+	s.ErrorIs(result.Items[0].Error, user.ErrLimitActiveReminderCountExceeded)
+	s.Empty(s.scheduler.Scheduled)
+}
+
+func (s *testSuite) TestResumeFilter() {
+	_, err := s.service.Run(context.Background(), Input{
+		UserID: USER_ID,
+		Filter: c.NewOptional(Filter{}, true),
+		Action: ActionResume,
+	})
+
+	s.Nil(err)
+	s.Equal(
+		c.NewOptional([]reminder.Status{reminder.StatusPaused}, true),
+		s.unitOfWork.Reminders().ReadWith[0].StatusIn,
+	)
+}
+
+func (s *testSuite) TestDeletePaused() {
+	paused := newReminder(5, USER_ID, reminder.StatusPaused, Now.Add(time.Hour))
+	s.unitOfWork.Reminders().GetByIDReminders[5] = paused
+	s.unitOfWork.Usage().PausedCount = 1
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:      USER_ID,
+		ReminderIDs: []reminder.ID{5},
+		Action:      ActionDelete,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), result.UpdatedCount)
+	s.Equal(uint(0), s.unitOfWork.Usage().PausedCount)
+}
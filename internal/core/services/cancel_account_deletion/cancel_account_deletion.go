@@ -0,0 +1,56 @@
+package cancelaccountdeletion
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID user.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct{}
+
+type service struct {
+	log                logging.Logger
+	deletionRepository user.AccountDeletionRepository
+}
+
+func New(
+	log logging.Logger,
+	deletionRepository user.AccountDeletionRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if deletionRepository == nil {
+		panic(e.NewNilArgumentError("deletionRepository"))
+	}
+	return &service{
+		log:                log,
+		deletionRepository: deletionRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	err = s.deletionRepository.Delete(ctx, input.UserID)
+	if errors.Is(err, user.ErrAccountDeletionNotRequested) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	s.log.Info(ctx, "Account deletion has been canceled.", logging.Entry("userID", input.UserID))
+	return Result{}, nil
+}
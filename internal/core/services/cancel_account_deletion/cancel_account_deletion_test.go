@@ -0,0 +1,51 @@
+package cancelaccountdeletion
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	DeletionRepository *user.FakeAccountDeletionRepository
+	Service            services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.DeletionRepository = user.NewFakeAccountDeletionRepository()
+	suite.Service = New(logging.NewFakeLogger(), suite.DeletionRepository)
+}
+
+func TestCancelAccountDeletionService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	err := s.DeletionRepository.Create(context.Background(), user.AccountDeletion{
+		UserID:      USER_ID,
+		RequestedAt: NOW,
+		PurgeAt:     NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Empty(s.DeletionRepository.Deletions)
+}
+
+func (s *testSuite) TestNotRequested() {
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.ErrorIs(err, user.ErrAccountDeletionNotRequested)
+}
@@ -23,14 +23,16 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 type Result struct{}
 
 type service struct {
-	log            logging.Logger
-	userRepository user.UserRepository
-	passwordHasher user.PasswordHasher
+	log               logging.Logger
+	userRepository    user.UserRepository
+	sessionRepository user.SessionRepository
+	passwordHasher    user.PasswordHasher
 }
 
 func New(
 	log logging.Logger,
 	userRepository user.UserRepository,
+	sessionRepository user.SessionRepository,
 	passwordHasher user.PasswordHasher,
 ) services.Service[Input, Result] {
 	if log == nil {
@@ -39,13 +41,17 @@ func New(
 	if userRepository == nil {
 		panic(e.NewNilArgumentError("userRepository"))
 	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
 	if passwordHasher == nil {
 		panic(e.NewNilArgumentError("passwordHasher"))
 	}
 	return &service{
-		log:            log,
-		passwordHasher: passwordHasher,
-		userRepository: userRepository,
+		log:               log,
+		passwordHasher:    passwordHasher,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
 	}
 }
 
@@ -67,6 +73,12 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		logging.Error(ctx, s.log, err, logging.Entry("err", err))
 		return result, err
 	}
+	err = s.sessionRepository.DeleteAll(ctx, user.DeleteSessionsInput{UserID: input.User.ID})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("err", err))
+		return result, err
+	}
 
+	s.log.Info(ctx, "Password has been changed, all user sessions revoked.", logging.Entry("userID", input.User.ID))
 	return Result{}, nil
 }
@@ -14,23 +14,25 @@ import (
 const USER_ID = 123
 
 type suite struct {
-	log      *logging.FakeLogger
-	userRepo *user.FakeUserRepository
-	hasher   *user.FakePasswordHasher
+	log         *logging.FakeLogger
+	userRepo    *user.FakeUserRepository
+	sessionRepo *user.FakeSessionRepository
+	hasher      *user.FakePasswordHasher
 }
 
 func setupSuite() *suite {
 	userRepo := user.NewFakeUserRepository()
 	userRepo.Users = []user.User{{ID: USER_ID}}
 	return &suite{
-		log:      logging.NewFakeLogger(),
-		userRepo: userRepo,
-		hasher:   user.NewFakePasswordHasher(),
+		log:         logging.NewFakeLogger(),
+		userRepo:    userRepo,
+		sessionRepo: user.NewFakeSessionRepository(userRepo),
+		hasher:      user.NewFakePasswordHasher(),
 	}
 }
 
 func (s *suite) createService() services.Service[Input, Result] {
-	return New(s.log, s.userRepo, s.hasher)
+	return New(s.log, s.userRepo, s.sessionRepo, s.hasher)
 }
 
 func TestPasswordSuccessfullyChanged(t *testing.T) {
@@ -110,6 +112,38 @@ func TestCurrentPasswordInvalid(t *testing.T) {
 	require.ErrorIs(t, err, user.ErrInvalidCredentials)
 }
 
+func TestSessionsRevoked(t *testing.T) {
+	// Setup ---
+	suite := setupSuite()
+	service := suite.createService()
+	for _, token := range []string{"session-1", "session-2"} {
+		err := suite.sessionRepo.Create(context.Background(), user.CreateSessionInput{
+			UserID: USER_ID,
+			Token:  user.SessionToken(token),
+		})
+		require.NoError(t, err)
+	}
+	err := suite.sessionRepo.Create(context.Background(), user.CreateSessionInput{
+		UserID: USER_ID + 1,
+		Token:  user.SessionToken("other-user-session"),
+	})
+	require.NoError(t, err)
+
+	// Exercise ---
+	input := Input{
+		CurrentPassword: user.RawPassword("aaa"),
+		NewPassword:     user.RawPassword("bbb"),
+	}
+	input.User.ID = USER_ID
+	input.User.PasswordHash = c.NewOptional(hashPassword("aaa", suite.hasher), true)
+	_, err = service.Run(context.Background(), input)
+
+	// Verify ---
+	require.NoError(t, err)
+	require.Len(t, suite.sessionRepo.Sessions, 1)
+	require.Equal(t, user.ID(USER_ID+1), suite.sessionRepo.Sessions[0].UserID)
+}
+
 func hashPassword(raw string, hasher user.PasswordHasher) user.PasswordHash {
 	hash, err := hasher.HashPassword(user.RawPassword(raw))
 	if err != nil {
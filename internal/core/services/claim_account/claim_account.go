@@ -0,0 +1,140 @@
+package claimaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	Email    c.Email
+	Password user.RawPassword
+	User     user.User
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("claim-account::%d", i.User.ID)
+}
+
+type Result struct {
+	User user.User
+}
+
+type service struct {
+	log                        logging.Logger
+	userRepository             user.UserRepository
+	externalIdentityRepository user.ExternalIdentityRepository
+	passwordHasher             user.PasswordHasher
+	activationTokenGenerator   user.ActivationTokenGenerator
+	activationTokenTTL         time.Duration
+	now                        func() time.Time
+}
+
+// New attaches email and password to an anonymous user. The user stays anonymous,
+// keeping the session, reminders and channels, until the email is confirmed by activation.
+// Users with a linked external identity log in with it and can not be claimed.
+func New(
+	log logging.Logger,
+	userRepository user.UserRepository,
+	externalIdentityRepository user.ExternalIdentityRepository,
+	passwordHasher user.PasswordHasher,
+	activationTokenGenerator user.ActivationTokenGenerator,
+	activationTokenTTL time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if userRepository == nil {
+		panic(e.NewNilArgumentError("userRepository"))
+	}
+	if externalIdentityRepository == nil {
+		panic(e.NewNilArgumentError("externalIdentityRepository"))
+	}
+	if passwordHasher == nil {
+		panic(e.NewNilArgumentError("passwordHasher"))
+	}
+	if activationTokenGenerator == nil {
+		panic(e.NewNilArgumentError("activationTokenGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                        log,
+		userRepository:             userRepository,
+		externalIdentityRepository: externalIdentityRepository,
+		passwordHasher:             passwordHasher,
+		activationTokenGenerator:   activationTokenGenerator,
+		activationTokenTTL:         activationTokenTTL,
+		now:                        now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	if !input.User.CanBeClaimed() {
+		return result, user.ErrUserIsNotAnonymous
+	}
+	identities, err := s.externalIdentityRepository.List(ctx, input.User.ID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if len(identities) > 0 {
+		s.log.Info(
+			ctx,
+			"User with an external identity can not be claimed.",
+			logging.Entry("userID", input.User.ID),
+		)
+		return result, user.ErrUserIsNotAnonymous
+	}
+	passwordHash, err := s.passwordHasher.HashPassword(input.Password)
+	if err != nil {
+		s.log.Error(ctx, "Could not hash password.", logging.Entry("err", err))
+		return result, err
+	}
+
+	claimedUser, err := s.userRepository.Claim(ctx, user.ClaimUserInput{
+		ID:                       input.User.ID,
+		Email:                    input.Email,
+		PasswordHash:             passwordHash,
+		ActivationToken:          s.activationTokenGenerator.GenerateActivationToken(),
+		ActivationTokenExpiresAt: s.now().Add(s.activationTokenTTL),
+	})
+	if errors.Is(err, context.Canceled) {
+		return result, err
+	}
+	if errors.Is(err, user.ErrEmailAlreadyExists) || errors.Is(err, user.ErrUserIsNotAnonymous) {
+		s.log.Info(
+			ctx,
+			"Could not claim user account.",
+			logging.Entry("userID", input.User.ID),
+			logging.Entry("email", input.Email),
+			logging.Entry("err", err),
+		)
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Anonymous user account has been claimed, waiting for activation.",
+		logging.Entry("userID", claimedUser.ID),
+	)
+	return Result{User: claimedUser}, nil
+}
@@ -0,0 +1,147 @@
+package claimaccount
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	ACTIVATION_TOKEN = "test-activation-token"
+	EMAIL            = c.Email("test@test.test")
+	RAW_PASSWORD     = user.RawPassword("test-password")
+)
+
+const ACTIVATION_TOKEN_TTL = 48 * time.Hour
+
+var NOW time.Time = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	Logger                     *logging.FakeLogger
+	UserRepository             *user.FakeUserRepository
+	ExternalIdentityRepository *user.FakeExternalIdentityRepository
+	Service                    services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Logger = logging.NewFakeLogger()
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.ExternalIdentityRepository = user.NewFakeExternalIdentityRepository(suite.UserRepository)
+	suite.Service = New(
+		suite.Logger,
+		suite.UserRepository,
+		suite.ExternalIdentityRepository,
+		user.NewFakePasswordHasher(),
+		user.NewFakeActivationTokenGenerator(ACTIVATION_TOKEN),
+		ACTIVATION_TOKEN_TTL,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestClaimAccountService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) createAnonymousUser() user.User {
+	s.T().Helper()
+	u, err := s.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Identity:    c.NewOptional(user.Identity("test-identity"), true),
+		CreatedAt:   NOW,
+		ActivatedAt: c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+	return u
+}
+
+func (s *testSuite) TestSuccess() {
+	anonymousUser := s.createAnonymousUser()
+
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{Email: EMAIL, Password: RAW_PASSWORD, User: anonymousUser},
+	)
+
+	s.Require().Nil(err)
+	s.Equal(anonymousUser.ID, result.User.ID)
+	s.Equal(c.NewOptional(EMAIL, true), result.User.Email)
+	s.True(result.User.PasswordHash.IsPresent)
+	s.NotEqual(string(RAW_PASSWORD), string(result.User.PasswordHash.Value))
+	s.Equal(c.NewOptional(user.ActivationToken(ACTIVATION_TOKEN), true), result.User.ActivationToken)
+	s.Equal(c.NewOptional(NOW.Add(ACTIVATION_TOKEN_TTL), true), result.User.ActivationTokenExpiresAt)
+	s.Equal(anonymousUser.Identity, result.User.Identity)
+	s.False(result.User.IsActive(), "user can not log in with email until activation")
+}
+
+func (s *testSuite) TestUserWithExternalIdentity() {
+	anonymousUser := s.createAnonymousUser()
+	_, err := s.ExternalIdentityRepository.Create(context.Background(), user.CreateExternalIdentityInput{
+		UserID:    anonymousUser.ID,
+		Provider:  "test",
+		Subject:   "test-subject",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(
+		context.Background(),
+		Input{Email: EMAIL, Password: RAW_PASSWORD, User: anonymousUser},
+	)
+
+	s.ErrorIs(err, user.ErrUserIsNotAnonymous)
+	u, err := s.UserRepository.GetByID(context.Background(), anonymousUser.ID)
+	s.Require().Nil(err)
+	s.False(u.Email.IsPresent)
+}
+
+func (s *testSuite) TestClaimAgainBeforeActivation() {
+	anonymousUser := s.createAnonymousUser()
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{Email: EMAIL, Password: RAW_PASSWORD, User: anonymousUser},
+	)
+	s.Require().Nil(err)
+
+	result, err = s.Service.Run(
+		context.Background(),
+		Input{Email: c.Email("other@test.test"), Password: RAW_PASSWORD, User: result.User},
+	)
+	s.Require().Nil(err)
+	s.Equal(c.Email("other@test.test"), result.User.Email.Value)
+}
+
+func (s *testSuite) TestNotAnonymous() {
+	u, err := s.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.Email("user@test.test"), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(context.Background(), Input{Email: EMAIL, Password: RAW_PASSWORD, User: u})
+	s.True(errors.Is(err, user.ErrUserIsNotAnonymous))
+}
+
+func (s *testSuite) TestEmailAlreadyExists() {
+	_, err := s.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(EMAIL, true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test"), true),
+		CreatedAt:    NOW,
+	})
+	s.Require().Nil(err)
+	anonymousUser := s.createAnonymousUser()
+
+	_, err = s.Service.Run(
+		context.Background(),
+		Input{Email: EMAIL, Password: RAW_PASSWORD, User: anonymousUser},
+	)
+	s.True(errors.Is(err, user.ErrEmailAlreadyExists))
+}
@@ -0,0 +1,143 @@
+package confirmemailchange
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct {
+	Token user.EmailChangeToken
+}
+
+type Result struct {
+	User user.User
+}
+
+type service struct {
+	log            logging.Logger
+	unitOfWork     uow.UnitOfWork
+	tokenGenerator user.EmailChangeTokenGenerator
+	sender         user.EmailChangeSender
+	revertPeriod   time.Duration
+	now            func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	tokenGenerator user.EmailChangeTokenGenerator,
+	sender user.EmailChangeSender,
+	revertPeriod time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if tokenGenerator == nil {
+		panic(e.NewNilArgumentError("tokenGenerator"))
+	}
+	if sender == nil {
+		panic(e.NewNilArgumentError("sender"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:            log,
+		unitOfWork:     unitOfWork,
+		tokenGenerator: tokenGenerator,
+		sender:         sender,
+		revertPeriod:   revertPeriod,
+		now:            now,
+	}
+}
+
+// Run changes the email of the user together with the email channels pointing at the old email
+// and notifies the old email with a token the change can be reverted with. The change is not
+// confirmed if the notification fails, so the user can retry with the same token.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	now := s.now()
+	change, err := uow.EmailChanges().GetByTokenWithLock(ctx, input.Token, now)
+	if errors.Is(err, user.ErrInvalidEmailChangeToken) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	ctx = logging.WithUserID(ctx, int64(change.UserID))
+
+	u, err := uow.Users().GetByID(ctx, change.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if !u.Email.IsPresent || u.Email.Value != change.OldEmail {
+		// The email has been changed in another way since the change was requested.
+		return result, user.ErrInvalidEmailChangeToken
+	}
+	u, err = uow.Users().Update(ctx, user.UpdateUserInput{
+		ID:            u.ID,
+		DoEmailUpdate: true,
+		Email:         change.NewEmail,
+	})
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	movedChannels, err := channel.MoveEmailChannels(ctx, uow.Channels(), u.ID, change.OldEmail, change.NewEmail)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	revertToken := s.tokenGenerator.GenerateEmailChangeToken()
+	change, err = uow.EmailChanges().Confirm(ctx, user.ConfirmEmailChangeInput{
+		ID:              change.ID,
+		ConfirmedAt:     now,
+		RevertToken:     revertToken,
+		RevertExpiresAt: now.Add(s.revertPeriod),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	// The old email is the only way to revert a hijacking change, so the change is not committed
+	// if the old email cannot be notified. A notification of a change failed to commit carries
+	// a revert token which does not exist, so it cannot be used.
+	if err := s.sender.SendEmailChangeNotification(ctx, change, revertToken); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("changeID", change.ID))
+		return result, err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"Email change has been confirmed.",
+		logging.Entry("changeID", change.ID),
+		logging.Entry("movedChannelIDs", movedChannels),
+	)
+	return Result{User: u}, nil
+}
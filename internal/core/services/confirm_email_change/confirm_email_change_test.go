@@ -0,0 +1,141 @@
+package confirmemailchange
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	TOKEN         = "test-token"
+	REVERT_TOKEN  = "test-revert-token"
+	REVERT_PERIOD = 7 * 24 * time.Hour
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	Uow     *uow.FakeUnitOfWork
+	Sender  *user.FakeEmailChangeSender
+	Service services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Sender = user.NewFakeEmailChangeSender()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow,
+		user.NewFakeEmailChangeTokenGenerator(REVERT_TOKEN),
+		suite.Sender,
+		REVERT_PERIOD,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestConfirmEmailChangeService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "new@test.test")
+	s.Uow.Channels().ReadChannels = []channel.Channel{{
+		ID:         1,
+		Type:       channel.Email,
+		Settings:   channel.NewEmailSettings("old@test.test"),
+		VerifiedAt: c.NewOptional(NOW, true),
+	}}
+
+	result, err := s.Service.Run(context.Background(), Input{Token: TOKEN})
+
+	s.Nil(err)
+	s.Equal(c.NewOptional(c.Email("new@test.test"), true), result.User.Email)
+	s.True(s.Uow.Context.WasCommitCalled)
+	s.Equal(
+		[]channel.UpdateInput{{ID: 1, DoSettingsUpdate: true, Settings: channel.NewEmailSettings("new@test.test")}},
+		s.Uow.Channels().Updated,
+	)
+	change := s.Uow.EmailChanges().Changes[0]
+	s.Equal(c.NewOptional(NOW, true), change.ConfirmedAt)
+	s.Equal(c.NewOptional(NOW.Add(REVERT_PERIOD), true), change.RevertExpiresAt)
+	s.Equal([]user.EmailChangeToken{REVERT_TOKEN}, s.Sender.Notifications)
+	s.Equal([]user.EmailChange{change}, s.Sender.SentChanges)
+}
+
+func (s *testSuite) TestInvalidToken() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "new@test.test")
+
+	_, err := s.Service.Run(context.Background(), Input{Token: "invalid-token"})
+
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+	s.False(s.Uow.Context.WasCommitCalled)
+	s.Empty(s.Sender.Notifications)
+}
+
+func (s *testSuite) TestEmailTakenSinceRequest() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "new@test.test")
+	s.createUser("new@test.test")
+
+	_, err := s.Service.Run(context.Background(), Input{Token: TOKEN})
+
+	s.ErrorIs(err, user.ErrEmailAlreadyExists)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestEmailChangedSinceRequest() {
+	u := s.createUser("other@test.test")
+	s.createChange(u.ID, "new@test.test")
+
+	_, err := s.Service.Run(context.Background(), Input{Token: TOKEN})
+
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestNotificationErrorFailsConfirmation() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "new@test.test")
+	s.Sender.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{Token: TOKEN})
+
+	s.NotNil(err)
+	s.False(s.Uow.Context.WasCommitCalled)
+	s.True(s.Uow.Context.WasRollbackCalled)
+}
+
+func (s *testSuite) createChange(userID user.ID, email string) {
+	s.T().Helper()
+	_, err := s.Uow.EmailChanges().Create(context.Background(), user.CreateEmailChangeInput{
+		UserID:    userID,
+		OldEmail:  "old@test.test",
+		NewEmail:  c.Email(email),
+		Token:     TOKEN,
+		CreatedAt: NOW.Add(-time.Hour),
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.Uow.Users().Create(context.Background(), user.CreateUserInput{
+		Email:       c.NewOptional(c.Email(email), true),
+		CreatedAt:   NOW,
+		ActivatedAt: c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+	return u
+}
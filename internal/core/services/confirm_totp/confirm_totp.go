@@ -0,0 +1,116 @@
+package confirmtotp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	Code user.TwoFactorCode
+	User user.User
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("confirm-totp::%d", i.User.ID)
+}
+
+// Result has the recovery codes, they are stored hashed and can not be shown again.
+type Result struct {
+	RecoveryCodes []user.RecoveryCode
+}
+
+type service struct {
+	log                logging.Logger
+	totpRepository     user.TOTPRepository
+	totpAuthenticator  user.TOTPAuthenticator
+	twoFactorGenerator user.TwoFactorGenerator
+	now                func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	totpRepository user.TOTPRepository,
+	totpAuthenticator user.TOTPAuthenticator,
+	twoFactorGenerator user.TwoFactorGenerator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if totpAuthenticator == nil {
+		panic(e.NewNilArgumentError("totpAuthenticator"))
+	}
+	if twoFactorGenerator == nil {
+		panic(e.NewNilArgumentError("twoFactorGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                log,
+		totpRepository:     totpRepository,
+		totpAuthenticator:  totpAuthenticator,
+		twoFactorGenerator: twoFactorGenerator,
+		now:                now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	totp, err := s.totpRepository.Get(ctx, input.User.ID)
+	if errors.Is(err, user.ErrTOTPNotEnrolled) {
+		s.log.Info(ctx, "TOTP is not enrolled.", logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if totp.IsEnabled() {
+		s.log.Info(ctx, "TOTP is already enabled.", logging.Entry("userID", input.User.ID))
+		return result, user.ErrTOTPAlreadyEnabled
+	}
+
+	now := s.now()
+	step, ok := s.totpAuthenticator.Validate(totp.Secret, input.Code, now)
+	if !ok {
+		s.log.Info(ctx, "Invalid TOTP code.", logging.Entry("userID", input.User.ID))
+		return result, user.ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes := make([]user.RecoveryCode, 0, user.RECOVERY_CODE_COUNT)
+	for i := 0; i < user.RECOVERY_CODE_COUNT; i++ {
+		recoveryCodes = append(recoveryCodes, s.twoFactorGenerator.GenerateRecoveryCode())
+	}
+	err = s.totpRepository.Confirm(ctx, user.ConfirmTOTPInput{
+		UserID:        input.User.ID,
+		ConfirmedAt:   now,
+		Step:          step,
+		RecoveryCodes: recoveryCodes,
+	})
+	if errors.Is(err, user.ErrTOTPNotEnrolled) {
+		s.log.Info(ctx, "TOTP was confirmed or disabled concurrently.", logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	s.log.Info(ctx, "TOTP enabled.", logging.Entry("userID", input.User.ID))
+	return Result{RecoveryCodes: recoveryCodes}, nil
+}
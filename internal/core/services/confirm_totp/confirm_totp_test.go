@@ -0,0 +1,78 @@
+package confirmtotp
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const TOTP_CODE = "123456"
+
+var NOW time.Time = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	Logger         *logging.FakeLogger
+	TOTPRepository *user.FakeTOTPRepository
+	Service        services.Service[Input, Result]
+	User           user.User
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Logger = logging.NewFakeLogger()
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.Service = New(
+		suite.Logger,
+		suite.TOTPRepository,
+		user.NewFakeTOTPAuthenticator(TOTP_CODE),
+		user.NewFakeTwoFactorGenerator("test-secret", "test-challenge-token"),
+		func() time.Time { return NOW },
+	)
+	suite.User = user.User{ID: 1, Email: c.NewOptional(c.NewEmail("test@test.test"), true)}
+	suite.TOTPRepository.TOTPs[suite.User.ID] = user.TOTP{UserID: suite.User.ID, Secret: "test-secret"}
+}
+
+func TestConfirmTOTPService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{User: s.User, Code: TOTP_CODE})
+
+	s.Nil(err)
+	s.Len(result.RecoveryCodes, user.RECOVERY_CODE_COUNT)
+	s.Equal(result.RecoveryCodes, s.TOTPRepository.RecoveryCodes[s.User.ID])
+	totp := s.TOTPRepository.TOTPs[s.User.ID]
+	s.True(totp.IsEnabled())
+	s.Equal(NOW.Unix()/30, totp.LastUsedStep)
+}
+
+func (s *testSuite) TestInvalidCode() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: "654321"})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorCode)
+	s.False(s.TOTPRepository.TOTPs[s.User.ID].IsEnabled())
+}
+
+func (s *testSuite) TestNotEnrolled() {
+	delete(s.TOTPRepository.TOTPs, s.User.ID)
+
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrTOTPNotEnrolled)
+}
+
+func (s *testSuite) TestAlreadyEnabled() {
+	s.TOTPRepository.TOTPs[s.User.ID] = user.TOTP{UserID: s.User.ID, ConfirmedAt: c.NewOptional(NOW, true)}
+
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrTOTPAlreadyEnabled)
+	s.Empty(s.TOTPRepository.RecoveryCodes[s.User.ID])
+}
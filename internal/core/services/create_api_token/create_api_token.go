@@ -0,0 +1,87 @@
+package createapitoken
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	UserID    user.ID
+	Name      string
+	Scopes    []user.Scope
+	ExpiresIn time.Duration
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+// Result contains the secret, which is shown to the user once, only its hash is stored.
+type Result struct {
+	Token  user.APIToken
+	Secret user.APITokenSecret
+}
+
+type service struct {
+	log                logging.Logger
+	apiTokenRepository user.APITokenRepository
+	apiTokenGenerator  user.APITokenGenerator
+	now                func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	apiTokenRepository user.APITokenRepository,
+	apiTokenGenerator user.APITokenGenerator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if apiTokenRepository == nil {
+		panic(e.NewNilArgumentError("apiTokenRepository"))
+	}
+	if apiTokenGenerator == nil {
+		panic(e.NewNilArgumentError("apiTokenGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                log,
+		apiTokenRepository: apiTokenRepository,
+		apiTokenGenerator:  apiTokenGenerator,
+		now:                now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	secret := s.apiTokenGenerator.GenerateAPITokenSecret()
+	token, err := s.apiTokenRepository.Create(ctx, user.CreateAPITokenInput{
+		UserID:    input.UserID,
+		Secret:    secret,
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		CreatedAt: now,
+		ExpiresAt: now.Add(input.ExpiresIn),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"API token created.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("apiTokenID", token.ID),
+		logging.Entry("scopes", token.Scopes),
+	)
+	return Result{Token: token, Secret: secret}, nil
+}
@@ -0,0 +1,69 @@
+package createapitoken
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID          = 1
+	API_TOKEN_SECRET = user.API_TOKEN_PREFIX + "test-secret"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	APITokenRepository *user.FakeAPITokenRepository
+	Service            services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.APITokenRepository = user.NewFakeAPITokenRepository(user.NewFakeUserRepository())
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.APITokenRepository,
+		user.NewFakeAPITokenGenerator(API_TOKEN_SECRET),
+		func() time.Time { return NOW },
+	)
+}
+
+func TestCreateAPITokenService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{
+		UserID:    USER_ID,
+		Name:      "ci",
+		Scopes:    []user.Scope{user.ScopeRemindersWrite},
+		ExpiresIn: 24 * time.Hour,
+	})
+
+	s.Nil(err)
+	s.Equal(user.APITokenSecret(API_TOKEN_SECRET), result.Secret)
+	expected := user.APIToken{
+		ID:        1,
+		UserID:    USER_ID,
+		Name:      "ci",
+		Scopes:    []user.Scope{user.ScopeRemindersWrite},
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(24 * time.Hour),
+	}
+	s.Equal(expected, result.Token)
+	s.Equal([]user.APIToken{expected}, s.APITokenRepository.Tokens)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.APITokenRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, Name: "ci", ExpiresIn: time.Hour})
+
+	s.NotNil(err)
+}
@@ -0,0 +1,74 @@
+package createcalendarfeed
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	UserID user.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+// Result contains the token, which is shown to the user once, only its hash is stored.
+type Result struct {
+	Feed  user.CalendarFeed
+	Token user.CalendarFeedToken
+}
+
+type service struct {
+	log                    logging.Logger
+	calendarFeedRepository user.CalendarFeedRepository
+	tokenGenerator         user.CalendarFeedTokenGenerator
+	now                    func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	calendarFeedRepository user.CalendarFeedRepository,
+	tokenGenerator user.CalendarFeedTokenGenerator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if calendarFeedRepository == nil {
+		panic(e.NewNilArgumentError("calendarFeedRepository"))
+	}
+	if tokenGenerator == nil {
+		panic(e.NewNilArgumentError("tokenGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                    log,
+		calendarFeedRepository: calendarFeedRepository,
+		tokenGenerator:         tokenGenerator,
+		now:                    now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	token := s.tokenGenerator.GenerateCalendarFeedToken()
+	feed, err := s.calendarFeedRepository.Create(ctx, user.CreateCalendarFeedInput{
+		UserID:    input.UserID,
+		Token:     token,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.log.Info(ctx, "Calendar feed created.", logging.Entry("userID", input.UserID))
+	return Result{Feed: feed, Token: token}, nil
+}
@@ -0,0 +1,73 @@
+package createcalendarfeed
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID             = 1
+	CALENDAR_FEED_TOKEN = "test-calendar-token"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	CalendarFeedRepository *user.FakeCalendarFeedRepository
+	TokenGenerator         *user.FakeCalendarFeedTokenGenerator
+	Service                services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.CalendarFeedRepository = user.NewFakeCalendarFeedRepository(user.NewFakeUserRepository())
+	suite.TokenGenerator = user.NewFakeCalendarFeedTokenGenerator(CALENDAR_FEED_TOKEN)
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.CalendarFeedRepository,
+		suite.TokenGenerator,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestCreateCalendarFeedService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Equal(user.CalendarFeedToken(CALENDAR_FEED_TOKEN), result.Token)
+	expected := user.CalendarFeed{UserID: USER_ID, CreatedAt: NOW}
+	s.Equal(expected, result.Feed)
+	s.Equal([]user.CalendarFeed{expected}, s.CalendarFeedRepository.Feeds)
+}
+
+func (s *testSuite) TestRecreateReplacesFeed() {
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+	s.Require().Nil(err)
+	s.TokenGenerator.Token = "new-calendar-token"
+
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Equal(user.CalendarFeedToken("new-calendar-token"), result.Token)
+	s.Len(s.CalendarFeedRepository.Feeds, 1)
+	_, err = s.CalendarFeedRepository.GetUserByToken(context.Background(), CALENDAR_FEED_TOKEN, NOW)
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.CalendarFeedRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.NotNil(err)
+}
@@ -24,6 +24,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeChannelsWrite
+}
+
 type Result struct {
 	Channel           channel.Channel
 	VerificationToken channel.VerificationToken
@@ -15,7 +15,9 @@ import (
 )
 
 type Input struct {
-	UserID     user.ID
+	UserID user.ID
+	// TimeZone of the user defines calendar quota windows, UTC is used if it is not set.
+	TimeZone   *time.Location
 	At         time.Time
 	Body       string
 	Every      c.Optional[reminder.Every]
@@ -49,9 +51,14 @@ func (i Input) Validate(now time.Time) error {
 
 func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	i.UserID = u.ID
+	i.TimeZone = u.TimeZone
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
 type Result struct {
 	Reminder reminder.ReminderWithChannels
 }
@@ -134,6 +141,10 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), reminder.Reminder{}, createdReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminder", createdReminder))
+		return result, err
+	}
 	_, err = uow.ReminderChannels().Create(ctx, reminder.CreateChannelsInput{
 		ReminderID: createdReminder.ID,
 		ChannelIDs: input.ChannelIDs,
@@ -215,16 +226,7 @@ func (s *service) checkUserLimits(ctx context.Context, uow uow.Context, limits u
 	}
 
 	if limits.ActiveReminderCount.IsPresent {
-		activeReminderCount, err := uow.Reminders().Count(
-			ctx,
-			reminder.ReadOptions{
-				CreatedByEquals: c.NewOptional(input.UserID, true),
-				StatusIn: c.NewOptional(
-					[]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled},
-					true,
-				),
-			},
-		)
+		activeReminderCount, err := reminder.CountActive(ctx, uow.Usage(), input.UserID, limits)
 		if err != nil {
 			logging.Error(ctx, s.log, err, logging.Entry("input", input))
 			return err
@@ -234,24 +236,13 @@ func (s *service) checkUserLimits(ctx context.Context, uow uow.Context, limits u
 		}
 	}
 
-	now := s.now()
-	if limits.MonthlySentReminderCount.IsPresent && now.Year() == input.At.Year() && now.Month() == input.At.Month() {
-		sentAfter := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-		sentReminderCount, err := uow.Reminders().Count(
-			ctx,
-			reminder.ReadOptions{
-				CreatedByEquals: c.NewOptional(input.UserID, true),
-				StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
-				SentAfter:       c.NewOptional(sentAfter, true),
-			},
-		)
-		if err != nil {
-			logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("sentAfter", sentAfter))
-			return err
-		}
-		if sentReminderCount >= uint(limits.MonthlySentReminderCount.Value) {
-			return user.ErrLimitSentReminderCountExceeded
-		}
+	quota, err := reminder.GetSentQuota(ctx, uow.Usage(), input.UserID, limits, input.TimeZone, s.now())
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return err
+	}
+	if quota.IsPresent && quota.Value.Covers(input.At) && quota.Value.IsExceeded() {
+		return user.ErrLimitSentReminderCountExceeded
 	}
 
 	return nil
@@ -159,7 +159,8 @@ func (s *testSuite) TestCreateSuccessWithStatusCreated() {
 				MonthlySentReminderCount: testcase.limitMonthlySentReminderCount,
 				ReminderEveryPerDayCount: testcase.limitReminderEveryPerDayCount,
 			}
-			s.unitOfWork.Reminders().CountResult = testcase.actualReminderCount
+			s.unitOfWork.Usage().ActiveCount = testcase.actualReminderCount
+			s.unitOfWork.Usage().SentBuckets = map[time.Time]uint{testcase.now: testcase.actualReminderCount}
 
 			input := s.input
 			input.At = testcase.at
@@ -175,6 +176,7 @@ func (s *testSuite) TestCreateSuccessWithStatusCreated() {
 			assert.Equal(testcase.every, result.Reminder.Every)
 			assert.Equal(reminder.StatusCreated, result.Reminder.Status)
 			assert.ElementsMatch([]channel.ID{CHANNEL_ID_1, CHANNEL_ID_2}, result.Reminder.ChannelIDs)
+			assert.Equal(testcase.actualReminderCount+1, s.unitOfWork.Usage().ActiveCount)
 
 			assert.True(s.unitOfWork.Context.WasCommitCalled)
 
@@ -378,7 +380,8 @@ func (s *testSuite) TestCreateError() {
 				MonthlySentReminderCount: testcase.limitMonthlySentReminderCount,
 				ReminderEveryPerDayCount: testcase.limitReminderEveryPerDayCount,
 			}
-			s.unitOfWork.Reminders().CountResult = testcase.actualReminderCount
+			s.unitOfWork.Usage().ActiveCount = testcase.actualReminderCount
+			s.unitOfWork.Usage().SentBuckets = map[time.Time]uint{testcase.now: testcase.actualReminderCount}
 
 			input := s.input
 			input.At = testcase.at
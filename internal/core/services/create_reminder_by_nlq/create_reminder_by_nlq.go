@@ -25,6 +25,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
 type service struct {
 	log           logging.Logger
 	parser        reminder.NaturalLanguageQueryParser
@@ -101,6 +105,7 @@ func (s *service) Run(ctx context.Context, input Input) (result createreminder.R
 	)
 	result, err = s.createService.Run(ctx, createreminder.Input{
 		UserID:     input.User.ID,
+		TimeZone:   input.User.TimeZone,
 		At:         createParams.At.In(time.UTC),
 		Body:       createParams.Body,
 		Every:      createParams.Every,
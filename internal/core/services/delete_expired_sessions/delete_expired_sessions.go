@@ -0,0 +1,86 @@
+package deleteexpiredsessions
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct{}
+
+type Result struct {
+	Deleted uint
+}
+
+type service struct {
+	log               logging.Logger
+	sessionRepository user.SessionRepository
+	sessionExpiry     user.SessionExpiry
+	batchSize         uint
+	now               func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	sessionRepository user.SessionRepository,
+	sessionExpiry user.SessionExpiry,
+	batchSize uint,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	if batchSize == 0 {
+		panic("batch size must be positive")
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:               log,
+		sessionRepository: sessionRepository,
+		sessionExpiry:     sessionExpiry,
+		batchSize:         batchSize,
+		now:               now,
+	}
+}
+
+// Run deletes sessions which are idle or too old to be used, expired sessions are
+// never returned anyway and only take space. Sessions are deleted in batches
+// until there is none left.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	at := s.now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		deleted, err := s.sessionRepository.DeleteExpired(ctx, user.DeleteExpiredSessionsInput{
+			At:     at,
+			Expiry: s.sessionExpiry,
+			Limit:  s.batchSize,
+		})
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("deletedCount", result.Deleted))
+			return result, err
+		}
+		result.Deleted += deleted
+		if deleted < s.batchSize {
+			break
+		}
+	}
+	if result.Deleted > 0 {
+		s.log.Info(
+			ctx,
+			"Expired sessions have been deleted.",
+			logging.Entry("deletedCount", result.Deleted),
+			logging.Entry("at", at),
+		)
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package deleteexpiredsessions
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	NOW    = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+)
+
+type testSuite struct {
+	suite.Suite
+	SessionRepository *user.FakeSessionRepository
+	Service           services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.SessionRepository = user.NewFakeSessionRepository(user.NewFakeUserRepository())
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.SessionRepository,
+		EXPIRY,
+		2,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestDeleteExpiredSessionsService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestExpiredSessionsDeletedInBatches() {
+	s.createSession("idle-1", NOW.Add(-2*time.Hour), NOW.Add(-EXPIRY.Idle))
+	s.createSession("idle-2", NOW.Add(-2*time.Hour), NOW.Add(-EXPIRY.Idle-time.Minute))
+	s.createSession("old", NOW.Add(-EXPIRY.Absolute), NOW.Add(-time.Minute))
+	s.createSession("active", NOW.Add(-2*time.Hour), NOW.Add(-time.Minute))
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{Deleted: 3}, result)
+	s.Require().Len(s.SessionRepository.Sessions, 1)
+	s.Equal(user.SessionID(4), s.SessionRepository.Sessions[0].ID)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.SessionRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) createSession(token user.SessionToken, createdAt time.Time, lastSeenAt time.Time) {
+	s.T().Helper()
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    1,
+		Token:     token,
+		CreatedAt: createdAt,
+	})
+	s.Require().Nil(err)
+	s.SessionRepository.Sessions[len(s.SessionRepository.Sessions)-1].LastSeenAt = lastSeenAt
+}
@@ -0,0 +1,85 @@
+package deleteinactiveusers
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct{}
+
+type Result struct {
+	Deleted uint
+}
+
+type service struct {
+	log             logging.Logger
+	userRepository  user.UserRepository
+	retentionPeriod time.Duration
+	batchSize       uint
+	now             func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	userRepository user.UserRepository,
+	retentionPeriod time.Duration,
+	batchSize uint,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if userRepository == nil {
+		panic(e.NewNilArgumentError("userRepository"))
+	}
+	if batchSize == 0 {
+		panic("batch size must be positive")
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:             log,
+		userRepository:  userRepository,
+		retentionPeriod: retentionPeriod,
+		batchSize:       batchSize,
+		now:             now,
+	}
+}
+
+// Run deletes accounts which were never activated within the retention period,
+// so that their emails may be used to sign up again. Accounts are deleted in batches
+// until there is none left.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	createdBefore := s.now().Add(-s.retentionPeriod)
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		deleted, err := s.userRepository.DeleteInactive(ctx, user.DeleteInactiveUsersInput{
+			CreatedBefore: createdBefore,
+			Limit:         s.batchSize,
+		})
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("deletedCount", result.Deleted))
+			return result, err
+		}
+		result.Deleted += deleted
+		if deleted < s.batchSize {
+			break
+		}
+	}
+	if result.Deleted > 0 {
+		s.log.Info(
+			ctx,
+			"Inactive users have been deleted.",
+			logging.Entry("deletedCount", result.Deleted),
+			logging.Entry("createdBefore", createdBefore),
+		)
+	}
+	return result, nil
+}
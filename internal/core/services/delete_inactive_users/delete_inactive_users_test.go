@@ -0,0 +1,78 @@
+package deleteinactiveusers
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const RETENTION_PERIOD = 7 * 24 * time.Hour
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	UserRepository *user.FakeUserRepository
+	Service        services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.UserRepository,
+		RETENTION_PERIOD,
+		2,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestDeleteInactiveUsersService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestExpiredInactiveUsersDeletedInBatches() {
+	createdAt := NOW.Add(-RETENTION_PERIOD - time.Second)
+	s.createUser("inactive-1@test.test", createdAt, false)
+	s.createUser("inactive-2@test.test", createdAt, false)
+	s.createUser("inactive-3@test.test", createdAt, false)
+	recent := s.createUser("recent@test.test", NOW.Add(-time.Hour), false)
+	active := s.createUser("active@test.test", createdAt, true)
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{Deleted: 3}, result)
+	s.Equal([]user.User{recent, active}, s.UserRepository.Users)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.UserRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) createUser(email string, createdAt time.Time, isActive bool) user.User {
+	s.T().Helper()
+	input := user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(email), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test"), true),
+		CreatedAt:    createdAt,
+	}
+	if isActive {
+		input.ActivatedAt = c.NewOptional(createdAt, true)
+	} else {
+		input.ActivationToken = c.NewOptional(user.ActivationToken(email), true)
+	}
+	u, err := s.UserRepository.Create(context.Background(), input)
+	s.Require().Nil(err)
+	return u
+}
@@ -23,6 +23,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
 type Result struct {
 	Reminder reminder.ReminderWithChannels
 }
@@ -79,7 +83,7 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		s.log.Info(ctx, "Reminder belongs to another user.", logging.Entry("input", input))
 		return result, reminder.ErrReminderPermission
 	}
-	if !rem.IsActive() {
+	if !rem.IsActive() && !rem.IsPaused() {
 		s.log.Info(ctx, "Reminder is not active and can't be deleted.", logging.Entry("input", input))
 		return result, reminder.ErrReminderNotActive
 	}
@@ -94,6 +98,10 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		}
 		return result, err
 	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, reminder.Reminder{}); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
 
 	if err := uow.Commit(ctx); err != nil {
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
@@ -54,6 +54,7 @@ func (s *testSuite) TestDeleteSuccess() {
 	}{
 		{id: "1", status: reminder.StatusCreated, reminderID: reminder.ID(100), userID: user.ID(1)},
 		{id: "2", status: reminder.StatusScheduled, reminderID: reminder.ID(200), userID: user.ID(2)},
+		{id: "3", status: reminder.StatusPaused, reminderID: reminder.ID(300), userID: user.ID(3)},
 	}
 
 	for _, testcase := range cases {
@@ -0,0 +1,93 @@
+package disabletotp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+// Input requires a TOTP or recovery code, so a stolen session is not enough to disable TOTP.
+type Input struct {
+	Code user.TwoFactorCode
+	User user.User
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("disable-totp::%d", i.User.ID)
+}
+
+type Result struct{}
+
+type service struct {
+	log               logging.Logger
+	totpRepository    user.TOTPRepository
+	totpAuthenticator user.TOTPAuthenticator
+	now               func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	totpRepository user.TOTPRepository,
+	totpAuthenticator user.TOTPAuthenticator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if totpAuthenticator == nil {
+		panic(e.NewNilArgumentError("totpAuthenticator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:               log,
+		totpRepository:    totpRepository,
+		totpAuthenticator: totpAuthenticator,
+		now:               now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	totp, err := s.totpRepository.Get(ctx, input.User.ID)
+	if errors.Is(err, user.ErrTOTPNotEnrolled) || (err == nil && !totp.IsEnabled()) {
+		s.log.Info(ctx, "TOTP is not enabled.", logging.Entry("userID", input.User.ID))
+		return result, user.ErrTOTPNotEnrolled
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	err = user.VerifyTwoFactorCode(ctx, s.totpRepository, s.totpAuthenticator, totp, input.Code, s.now())
+	if errors.Is(err, user.ErrInvalidTwoFactorCode) {
+		s.log.Info(ctx, "Invalid two-factor code.", logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	if err = s.totpRepository.Delete(ctx, input.User.ID); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	s.log.Info(ctx, "TOTP disabled.", logging.Entry("userID", input.User.ID))
+	return result, nil
+}
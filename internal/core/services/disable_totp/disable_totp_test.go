@@ -0,0 +1,81 @@
+package disabletotp
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	TOTP_CODE     = "123456"
+	RECOVERY_CODE = "abcdefghij"
+)
+
+var NOW time.Time = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	Logger         *logging.FakeLogger
+	TOTPRepository *user.FakeTOTPRepository
+	Service        services.Service[Input, Result]
+	User           user.User
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Logger = logging.NewFakeLogger()
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.Service = New(
+		suite.Logger,
+		suite.TOTPRepository,
+		user.NewFakeTOTPAuthenticator(TOTP_CODE),
+		func() time.Time { return NOW },
+	)
+	suite.User = user.User{ID: 1, Email: c.NewOptional(c.NewEmail("test@test.test"), true)}
+	suite.TOTPRepository.TOTPs[suite.User.ID] = user.TOTP{
+		UserID:      suite.User.ID,
+		Secret:      "test-secret",
+		ConfirmedAt: c.NewOptional(NOW, true),
+	}
+	suite.TOTPRepository.RecoveryCodes[suite.User.ID] = []user.RecoveryCode{RECOVERY_CODE}
+}
+
+func TestDisableTOTPService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccessWithTOTPCode() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: TOTP_CODE})
+
+	s.Nil(err)
+	s.Empty(s.TOTPRepository.TOTPs)
+	s.Empty(s.TOTPRepository.RecoveryCodes)
+}
+
+func (s *testSuite) TestSuccessWithRecoveryCode() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: RECOVERY_CODE})
+
+	s.Nil(err)
+	s.Empty(s.TOTPRepository.TOTPs)
+}
+
+func (s *testSuite) TestInvalidCode() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: "654321"})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorCode)
+	s.Contains(s.TOTPRepository.TOTPs, s.User.ID)
+}
+
+func (s *testSuite) TestNotEnabled() {
+	s.TOTPRepository.TOTPs[s.User.ID] = user.TOTP{UserID: s.User.ID, Secret: "test-secret"}
+
+	_, err := s.Service.Run(context.Background(), Input{User: s.User, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrTOTPNotEnrolled)
+	s.Contains(s.TOTPRepository.TOTPs, s.User.ID)
+}
@@ -0,0 +1,93 @@
+package enabletotp
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	User user.User
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+// Result is shown to the user once, TOTP is enabled after a code is confirmed.
+type Result struct {
+	Secret user.TOTPSecret
+	URI    string
+}
+
+type service struct {
+	log                logging.Logger
+	totpRepository     user.TOTPRepository
+	totpAuthenticator  user.TOTPAuthenticator
+	twoFactorGenerator user.TwoFactorGenerator
+	now                func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	totpRepository user.TOTPRepository,
+	totpAuthenticator user.TOTPAuthenticator,
+	twoFactorGenerator user.TwoFactorGenerator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if totpAuthenticator == nil {
+		panic(e.NewNilArgumentError("totpAuthenticator"))
+	}
+	if twoFactorGenerator == nil {
+		panic(e.NewNilArgumentError("twoFactorGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                log,
+		totpRepository:     totpRepository,
+		totpAuthenticator:  totpAuthenticator,
+		twoFactorGenerator: twoFactorGenerator,
+		now:                now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	totp := user.TOTP{
+		UserID:    input.User.ID,
+		Secret:    s.twoFactorGenerator.GenerateTOTPSecret(),
+		CreatedAt: s.now(),
+	}
+	err = s.totpRepository.Create(ctx, totp)
+	if errors.Is(err, user.ErrTOTPAlreadyEnabled) {
+		s.log.Info(ctx, "TOTP is already enabled.", logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	accountName := string(input.User.Identity.Value)
+	if input.User.Email.IsPresent {
+		accountName = string(input.User.Email.Value)
+	}
+	s.log.Info(ctx, "TOTP enrolled, waiting for confirmation.", logging.Entry("userID", input.User.ID))
+	return Result{
+		Secret: totp.Secret,
+		URI:    s.totpAuthenticator.URI(totp.Secret, accountName),
+	}, nil
+}
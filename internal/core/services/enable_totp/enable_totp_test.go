@@ -0,0 +1,69 @@
+package enabletotp
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const SECRET = "TESTSECRET"
+
+var NOW time.Time = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	Logger         *logging.FakeLogger
+	TOTPRepository *user.FakeTOTPRepository
+	Service        services.Service[Input, Result]
+	User           user.User
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Logger = logging.NewFakeLogger()
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.Service = New(
+		suite.Logger,
+		suite.TOTPRepository,
+		user.NewFakeTOTPAuthenticator("123456"),
+		user.NewFakeTwoFactorGenerator(SECRET, "test-challenge-token"),
+		func() time.Time { return NOW },
+	)
+	suite.User = user.User{ID: 1, Email: c.NewOptional(c.NewEmail("test@test.test"), true)}
+}
+
+func TestEnableTOTPService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{User: s.User})
+
+	s.Nil(err)
+	s.Equal(Result{Secret: SECRET, URI: "otpauth://totp/test@test.test?secret=" + SECRET}, result)
+	s.Equal(user.TOTP{UserID: s.User.ID, Secret: SECRET, CreatedAt: NOW}, s.TOTPRepository.TOTPs[s.User.ID])
+}
+
+func (s *testSuite) TestAnonymousUserAccountName() {
+	anonymous := user.User{ID: 2, Identity: c.NewOptional(user.Identity("test-identity"), true)}
+
+	result, err := s.Service.Run(context.Background(), Input{User: anonymous})
+
+	s.Nil(err)
+	s.Equal("otpauth://totp/test-identity?secret="+SECRET, result.URI)
+}
+
+func (s *testSuite) TestAlreadyEnabled() {
+	enabled := user.TOTP{UserID: s.User.ID, Secret: "other", ConfirmedAt: c.NewOptional(NOW, true)}
+	s.TOTPRepository.TOTPs[s.User.ID] = enabled
+
+	_, err := s.Service.Run(context.Background(), Input{User: s.User})
+
+	s.ErrorIs(err, user.ErrTOTPAlreadyEnabled)
+	s.Equal(enabled, s.TOTPRepository.TOTPs[s.User.ID])
+}
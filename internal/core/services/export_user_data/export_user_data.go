@@ -0,0 +1,162 @@
+package exportuserdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	User user.User
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("export-user-data::%d", i.User.ID)
+}
+
+// Result holds all the data stored about the user, sent reminders make the delivery history.
+// Plan is not set for users who have no limits yet, i.e. are not activated.
+type Result struct {
+	User               user.User
+	Channels           []channel.Channel
+	Reminders          []reminder.ReminderWithChannels
+	Sessions           []user.Session
+	APITokens          []user.APIToken
+	ExternalIdentities []user.ExternalIdentity
+	Plan               c.Optional[user.UserPlan]
+}
+
+type service struct {
+	log                        logging.Logger
+	channelRepository          channel.Repository
+	reminderRepository         reminder.ReminderRepository
+	sessionRepository          user.SessionRepository
+	apiTokenRepository         user.APITokenRepository
+	externalIdentityRepository user.ExternalIdentityRepository
+	limitsRepository           user.LimitsRepository
+	sessionExpiry              user.SessionExpiry
+	now                        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	channelRepository channel.Repository,
+	reminderRepository reminder.ReminderRepository,
+	sessionRepository user.SessionRepository,
+	apiTokenRepository user.APITokenRepository,
+	externalIdentityRepository user.ExternalIdentityRepository,
+	limitsRepository user.LimitsRepository,
+	sessionExpiry user.SessionExpiry,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if channelRepository == nil {
+		panic(e.NewNilArgumentError("channelRepository"))
+	}
+	if reminderRepository == nil {
+		panic(e.NewNilArgumentError("reminderRepository"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	if apiTokenRepository == nil {
+		panic(e.NewNilArgumentError("apiTokenRepository"))
+	}
+	if externalIdentityRepository == nil {
+		panic(e.NewNilArgumentError("externalIdentityRepository"))
+	}
+	if limitsRepository == nil {
+		panic(e.NewNilArgumentError("limitsRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                        log,
+		channelRepository:          channelRepository,
+		reminderRepository:         reminderRepository,
+		sessionRepository:          sessionRepository,
+		apiTokenRepository:         apiTokenRepository,
+		externalIdentityRepository: externalIdentityRepository,
+		limitsRepository:           limitsRepository,
+		sessionExpiry:              sessionExpiry,
+		now:                        now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	result.User = input.User
+	if err = s.readData(ctx, input.User.ID, &result); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return Result{}, err
+	}
+	s.log.Info(
+		ctx,
+		"User data has been exported.",
+		logging.Entry("userID", input.User.ID),
+		logging.Entry("channelCount", len(result.Channels)),
+		logging.Entry("reminderCount", len(result.Reminders)),
+		logging.Entry("sessionCount", len(result.Sessions)),
+		logging.Entry("apiTokenCount", len(result.APITokens)),
+		logging.Entry("externalIdentityCount", len(result.ExternalIdentities)),
+	)
+	return result, nil
+}
+
+func (s *service) readData(ctx context.Context, userID user.ID, result *Result) (err error) {
+	result.Channels, err = s.channelRepository.Read(ctx, channel.ReadOptions{
+		UserIDEquals: c.NewOptional(userID, true),
+		OrderBy:      channel.OrderByIDAsc,
+	})
+	if err != nil {
+		return err
+	}
+	result.Reminders, err = s.reminderRepository.Read(ctx, reminder.ReadOptions{
+		CreatedByEquals: c.NewOptional(userID, true),
+		OrderBy:         reminder.OrderByIDAsc,
+	})
+	if err != nil {
+		return err
+	}
+	result.Sessions, err = s.sessionRepository.List(ctx, user.ListSessionsInput{
+		UserID: userID,
+		At:     s.now(),
+		Expiry: s.sessionExpiry,
+	})
+	if err != nil {
+		return err
+	}
+	result.APITokens, err = s.apiTokenRepository.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+	result.ExternalIdentities, err = s.externalIdentityRepository.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+	plan, err := s.limitsRepository.GetUserPlan(ctx, userID)
+	if errors.Is(err, user.ErrLimitsDoNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	result.Plan = c.NewOptional(plan, true)
+	return nil
+}
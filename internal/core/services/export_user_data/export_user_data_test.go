@@ -0,0 +1,132 @@
+package exportuserdata
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var (
+	NOW    = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+)
+
+type testSuite struct {
+	suite.Suite
+	ChannelRepository          *channel.FakeRepository
+	ReminderRepository         *reminder.TestReminderRepository
+	SessionRepository          *user.FakeSessionRepository
+	APITokenRepository         *user.FakeAPITokenRepository
+	ExternalIdentityRepository *user.FakeExternalIdentityRepository
+	LimitsRepository           *user.FakeLimitsRepository
+	Service                    services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	userRepository := user.NewFakeUserRepository()
+	suite.ChannelRepository = channel.NewFakeRepository()
+	suite.ReminderRepository = reminder.NewTestReminderRepository()
+	suite.SessionRepository = user.NewFakeSessionRepository(userRepository)
+	suite.APITokenRepository = user.NewFakeAPITokenRepository(userRepository)
+	suite.ExternalIdentityRepository = user.NewFakeExternalIdentityRepository(userRepository)
+	suite.LimitsRepository = user.NewFakeLimitsRepository()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.ChannelRepository,
+		suite.ReminderRepository,
+		suite.SessionRepository,
+		suite.APITokenRepository,
+		suite.ExternalIdentityRepository,
+		suite.LimitsRepository,
+		EXPIRY,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestExportUserDataService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	s.ChannelRepository.ReadChannels = []channel.Channel{{ID: 1, CreatedBy: USER_ID}}
+	s.ReminderRepository.ReadReminders = []reminder.ReminderWithChannels{
+		{Reminder: reminder.Reminder{ID: 2, CreatedBy: USER_ID}, ChannelIDs: []channel.ID{1}},
+	}
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    USER_ID,
+		Token:     "test-session-token",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	token, err := s.APITokenRepository.Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    USER_ID,
+		Name:      "test",
+		Secret:    "test-secret",
+		Scopes:    []user.Scope{user.ScopeRemindersRead},
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	identity, err := s.ExternalIdentityRepository.Create(context.Background(), user.CreateExternalIdentityInput{
+		UserID:    USER_ID,
+		Provider:  "test",
+		Subject:   "test-subject",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	s.LimitsRepository.Plan = c.NewOptional(user.PlanName("free"), true)
+	u := user.User{ID: USER_ID}
+
+	result, err := s.Service.Run(context.Background(), Input{User: u})
+
+	s.Nil(err)
+	s.Equal(Result{
+		User:               u,
+		Channels:           s.ChannelRepository.ReadChannels,
+		Reminders:          s.ReminderRepository.ReadReminders,
+		Sessions:           []user.Session{s.SessionRepository.Sessions[0]},
+		APITokens:          []user.APIToken{token},
+		ExternalIdentities: []user.ExternalIdentity{identity},
+		Plan: c.NewOptional(user.UserPlan{
+			UserID: USER_ID,
+			Plan:   c.NewOptional(user.PlanName("free"), true),
+		}, true),
+	}, result)
+	s.Equal(c.NewOptional(user.ID(USER_ID), true), s.ChannelRepository.Options[0].UserIDEquals)
+	s.Equal(c.NewOptional(user.ID(USER_ID), true), s.ReminderRepository.ReadWith[0].CreatedByEquals)
+	s.False(s.ReminderRepository.ReadWith[0].Limit.IsPresent)
+}
+
+func (s *testSuite) TestChannelsReadError() {
+	s.ChannelRepository.ReadReturnsError = true
+
+	_, err := s.Service.Run(context.Background(), Input{User: user.User{ID: USER_ID}})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) TestSessionsReadError() {
+	s.SessionRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{User: user.User{ID: USER_ID}})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) TestExternalIdentitiesReadError() {
+	s.ExternalIdentityRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{User: user.User{ID: USER_ID}})
+
+	s.NotNil(err)
+}
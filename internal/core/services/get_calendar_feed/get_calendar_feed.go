@@ -0,0 +1,113 @@
+package getcalendarfeed
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+// MAX_SENT_REMINDER_COUNT limits the number of recently sent reminders in the feed,
+// the latest ones are returned.
+const MAX_SENT_REMINDER_COUNT = 500
+
+type Input struct {
+	Token user.CalendarFeedToken
+}
+
+type Result struct {
+	User user.User
+	// Reminders contains active reminders followed by reminders sent within the sent period.
+	Reminders []reminder.Reminder
+}
+
+type service struct {
+	log                    logging.Logger
+	calendarFeedRepository user.CalendarFeedRepository
+	reminderRepository     reminder.ReminderRepository
+	sentPeriod             time.Duration
+	now                    func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	calendarFeedRepository user.CalendarFeedRepository,
+	reminderRepository reminder.ReminderRepository,
+	sentPeriod time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if calendarFeedRepository == nil {
+		panic(e.NewNilArgumentError("calendarFeedRepository"))
+	}
+	if reminderRepository == nil {
+		panic(e.NewNilArgumentError("reminderRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                    log,
+		calendarFeedRepository: calendarFeedRepository,
+		reminderRepository:     reminderRepository,
+		sentPeriod:             sentPeriod,
+		now:                    now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	u, err := s.calendarFeedRepository.GetUserByToken(ctx, input.Token, now)
+	if errors.Is(err, user.ErrCalendarFeedDoesNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	active, err := s.reminderRepository.Read(ctx, reminder.ReadOptions{
+		CreatedByEquals: c.NewOptional(u.ID, true),
+		StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled}, true),
+		OrderBy:         reminder.OrderByAtAsc,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return result, err
+	}
+	sent, err := s.reminderRepository.Read(ctx, reminder.ReadOptions{
+		CreatedByEquals: c.NewOptional(u.ID, true),
+		StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
+		SentAfter:       c.NewOptional(now.Add(-s.sentPeriod), true),
+		OrderBy:         reminder.OrderByAtDesc,
+		Limit:           c.NewOptional[uint](MAX_SENT_REMINDER_COUNT, true),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return result, err
+	}
+
+	result.User = u
+	result.Reminders = make([]reminder.Reminder, 0, len(active)+len(sent))
+	for _, rem := range active {
+		result.Reminders = append(result.Reminders, rem.Reminder)
+	}
+	for _, rem := range sent {
+		result.Reminders = append(result.Reminders, rem.Reminder)
+	}
+	s.log.Info(
+		ctx,
+		"Calendar feed read.",
+		logging.Entry("userID", u.ID),
+		logging.Entry("activeCount", len(active)),
+		logging.Entry("sentCount", len(sent)),
+	)
+	return result, nil
+}
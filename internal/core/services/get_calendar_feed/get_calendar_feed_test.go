@@ -0,0 +1,101 @@
+package getcalendarfeed
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	CALENDAR_FEED_TOKEN = user.CalendarFeedToken("test-calendar-token")
+	SENT_PERIOD         = 30 * 24 * time.Hour
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	User                   user.User
+	CalendarFeedRepository *user.FakeCalendarFeedRepository
+	ReminderRepository     *reminder.TestReminderRepository
+	Service                services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	userRepository := user.NewFakeUserRepository()
+	u, err := userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:     c.NewOptional(c.NewEmail("test@test.test"), true),
+		CreatedAt: NOW,
+		TimeZone:  time.UTC,
+	})
+	suite.Require().Nil(err)
+	suite.User = u
+	suite.CalendarFeedRepository = user.NewFakeCalendarFeedRepository(userRepository)
+	_, err = suite.CalendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    u.ID,
+		Token:     CALENDAR_FEED_TOKEN,
+		CreatedAt: NOW,
+	})
+	suite.Require().Nil(err)
+	suite.ReminderRepository = reminder.NewTestReminderRepository()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.CalendarFeedRepository,
+		suite.ReminderRepository,
+		SENT_PERIOD,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestGetCalendarFeedService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	rem := reminder.Reminder{ID: 1, CreatedBy: s.User.ID, At: NOW, Status: reminder.StatusCreated}
+	s.ReminderRepository.ReadReminders = []reminder.ReminderWithChannels{{Reminder: rem}}
+
+	result, err := s.Service.Run(context.Background(), Input{Token: CALENDAR_FEED_TOKEN})
+
+	s.Nil(err)
+	s.Equal(s.User, result.User)
+	s.Equal([]reminder.Reminder{rem, rem}, result.Reminders)
+	s.Equal([]reminder.ReadOptions{
+		{
+			CreatedByEquals: c.NewOptional(s.User.ID, true),
+			StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled}, true),
+			OrderBy:         reminder.OrderByAtAsc,
+		},
+		{
+			CreatedByEquals: c.NewOptional(s.User.ID, true),
+			StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
+			SentAfter:       c.NewOptional(NOW.Add(-SENT_PERIOD), true),
+			OrderBy:         reminder.OrderByAtDesc,
+			Limit:           c.NewOptional[uint](MAX_SENT_REMINDER_COUNT, true),
+		},
+	}, s.ReminderRepository.ReadWith)
+	s.Equal(c.NewOptional(NOW, true), s.CalendarFeedRepository.Feeds[0].LastUsedAt)
+}
+
+func (s *testSuite) TestUnknownToken() {
+	_, err := s.Service.Run(context.Background(), Input{Token: "other-calendar-token"})
+
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+	s.Empty(s.ReminderRepository.ReadWith)
+}
+
+func (s *testSuite) TestReadError() {
+	s.ReminderRepository.ReadError = errors.New("test error")
+
+	_, err := s.Service.Run(context.Background(), Input{Token: CALENDAR_FEED_TOKEN})
+
+	s.ErrorIs(err, s.ReminderRepository.ReadError)
+}
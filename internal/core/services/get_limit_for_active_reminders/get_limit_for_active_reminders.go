@@ -20,20 +20,24 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeProfileRead
+}
+
 type Result struct {
 	Limit c.Optional[user.Limit]
 }
 
 type service struct {
-	log       logging.Logger
-	limits    user.LimitsRepository
-	reminders reminder.ReminderRepository
+	log    logging.Logger
+	limits user.LimitsRepository
+	usage  reminder.UsageRepository
 }
 
 func New(
 	log logging.Logger,
 	limits user.LimitsRepository,
-	reminders reminder.ReminderRepository,
+	usage reminder.UsageRepository,
 ) services.Service[Input, Result] {
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
@@ -41,13 +45,13 @@ func New(
 	if limits == nil {
 		panic(e.NewNilArgumentError("limits"))
 	}
-	if reminders == nil {
-		panic(e.NewNilArgumentError("reminders"))
+	if usage == nil {
+		panic(e.NewNilArgumentError("usage"))
 	}
 	return &service{
-		log:       log,
-		limits:    limits,
-		reminders: reminders,
+		log:    log,
+		limits: limits,
+		usage:  usage,
 	}
 }
 
@@ -67,16 +71,7 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, nil
 	}
 
-	activeReminderCount, err := s.reminders.Count(
-		ctx,
-		reminder.ReadOptions{
-			CreatedByEquals: c.NewOptional(input.UserID, true),
-			StatusIn: c.NewOptional(
-				[]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled},
-				true,
-			),
-		},
-	)
+	activeReminderCount, err := reminder.CountActive(ctx, s.usage, input.UserID, limits)
 	if err != nil {
 		return result, err
 	}
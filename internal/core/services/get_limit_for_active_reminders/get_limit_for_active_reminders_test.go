@@ -19,21 +19,21 @@ var (
 )
 
 type Fixture struct {
-	log       *logging.FakeLogger
-	limits    *user.FakeLimitsRepository
-	reminders *reminder.TestReminderRepository
+	log    *logging.FakeLogger
+	limits *user.FakeLimitsRepository
+	usage  *reminder.TestUsageRepository
 }
 
 func NewFixture() Fixture {
 	return Fixture{
-		log:       logging.NewFakeLogger(),
-		limits:    user.NewFakeLimitsRepository(),
-		reminders: reminder.NewTestReminderRepository(),
+		log:    logging.NewFakeLogger(),
+		limits: user.NewFakeLimitsRepository(),
+		usage:  reminder.NewTestUsageRepository(),
 	}
 }
 
 func (f *Fixture) service() services.Service[Input, Result] {
-	return New(f.log, f.limits, f.reminders)
+	return New(f.log, f.limits, f.usage)
 }
 
 func TestGetLimitForActiveRemindersSuccess(t *testing.T) {
@@ -67,7 +67,7 @@ func TestGetLimitForActiveRemindersSuccess(t *testing.T) {
 		t.Run(testcase.id, func(t *testing.T) {
 			fixture := NewFixture()
 			fixture.limits.Limits = testcase.limits
-			fixture.reminders.CountResult = uint(testcase.reminderCount)
+			fixture.usage.ActiveCount = uint(testcase.reminderCount)
 
 			result, err := fixture.service().Run(context.Background(), Input{UserID: USER_ID})
 
@@ -77,28 +77,3 @@ func TestGetLimitForActiveRemindersSuccess(t *testing.T) {
 		})
 	}
 }
-
-func TestRemindersCounted(t *testing.T) {
-	fixture := NewFixture()
-	fixture.limits.Limits = user.Limits{
-		ActiveReminderCount:      c.NewOptional(uint32(5), true),
-		MonthlySentReminderCount: c.NewOptional(uint32(10), true),
-	}
-
-	_, err := fixture.service().Run(context.Background(), Input{UserID: USER_ID})
-
-	assert := require.New(t)
-	assert.Nil(err)
-	assert.Equal(
-		[]reminder.ReadOptions{
-			{
-				CreatedByEquals: c.NewOptional(USER_ID, true),
-				StatusIn: c.NewOptional(
-					[]reminder.Status{reminder.StatusCreated, reminder.StatusScheduled},
-					true,
-				),
-			},
-		},
-		fixture.reminders.CountWith,
-	)
-}
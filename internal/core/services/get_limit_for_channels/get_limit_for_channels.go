@@ -20,6 +20,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeProfileRead
+}
+
 type Result struct {
 	Email    c.Optional[user.Limit]
 	Telegram c.Optional[user.Limit]
@@ -14,28 +14,38 @@ import (
 
 type Input struct {
 	UserID user.ID
+	// TimeZone of the user defines calendar quota windows, UTC is used if it is not set.
+	TimeZone *time.Location
 }
 
 func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	i.UserID = u.ID
+	i.TimeZone = u.TimeZone
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeProfileRead
+}
+
 type Result struct {
-	Limit c.Optional[user.Limit]
+	Limit  c.Optional[user.Limit]
+	Window user.QuotaWindow
+	// ResetAt is when sent reminders start leaving the quota window, it is zero if there is no limit.
+	ResetAt time.Time
 }
 
 type service struct {
-	log       logging.Logger
-	limits    user.LimitsRepository
-	reminders reminder.ReminderRepository
-	now       func() time.Time
+	log    logging.Logger
+	limits user.LimitsRepository
+	usage  reminder.UsageRepository
+	now    func() time.Time
 }
 
 func New(
 	log logging.Logger,
 	limits user.LimitsRepository,
-	reminders reminder.ReminderRepository,
+	usage reminder.UsageRepository,
 	now func() time.Time,
 ) services.Service[Input, Result] {
 	if log == nil {
@@ -44,17 +54,17 @@ func New(
 	if limits == nil {
 		panic(e.NewNilArgumentError("limits"))
 	}
-	if reminders == nil {
-		panic(e.NewNilArgumentError("reminders"))
+	if usage == nil {
+		panic(e.NewNilArgumentError("usage"))
 	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
 	return &service{
-		log:       log,
-		limits:    limits,
-		reminders: reminders,
-		now:       now,
+		log:    log,
+		limits: limits,
+		usage:  usage,
+		now:    now,
 	}
 }
 
@@ -70,28 +80,19 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, err
 	}
 
-	if !limits.MonthlySentReminderCount.IsPresent {
-		return result, nil
-	}
-
-	now := s.now()
-	sentAfter := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-
-	sentReminderCount, err := s.reminders.Count(
-		ctx,
-		reminder.ReadOptions{
-			CreatedByEquals: c.NewOptional(input.UserID, true),
-			StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
-			SentAfter:       c.NewOptional(sentAfter, true),
-		},
-	)
+	quota, err := reminder.GetSentQuota(ctx, s.usage, input.UserID, limits, input.TimeZone, s.now())
 	if err != nil {
 		return result, err
 	}
+	if !quota.IsPresent {
+		return result, nil
+	}
 
 	result.Limit.IsPresent = true
-	result.Limit.Value.Value = uint32(limits.MonthlySentReminderCount.Value)
-	result.Limit.Value.Actual = uint32(sentReminderCount)
+	result.Limit.Value.Value = quota.Value.Limit
+	result.Limit.Value.Actual = uint32(quota.Value.Count)
+	result.Window = quota.Value.Window
+	result.ResetAt = quota.Value.ResetAt
 	s.log.Info(
 		ctx,
 		"Got user limits for monthly sent reminders.",
@@ -19,47 +19,85 @@ var (
 )
 
 type Fixture struct {
-	log       *logging.FakeLogger
-	limits    *user.FakeLimitsRepository
-	reminders *reminder.TestReminderRepository
+	log    *logging.FakeLogger
+	limits *user.FakeLimitsRepository
+	usage  *reminder.TestUsageRepository
 }
 
 func NewFixture() Fixture {
 	return Fixture{
-		log:       logging.NewFakeLogger(),
-		limits:    user.NewFakeLimitsRepository(),
-		reminders: reminder.NewTestReminderRepository(),
+		log:    logging.NewFakeLogger(),
+		limits: user.NewFakeLimitsRepository(),
+		usage:  reminder.NewTestUsageRepository(),
 	}
 }
 
 func (f *Fixture) service() services.Service[Input, Result] {
-	return New(f.log, f.limits, f.reminders, func() time.Time { return NOW })
+	return New(f.log, f.limits, f.usage, func() time.Time { return NOW })
 }
 
 func TestGetLimitForSentRemindersSuccess(t *testing.T) {
 	cases := []struct {
 		id             string
 		limits         user.Limits
-		reminderCount  uint32
+		sentBuckets    map[time.Time]uint
 		expectedResult Result
 	}{
 		{
 			id:             "1",
 			limits:         user.Limits{},
-			reminderCount:  100,
+			sentBuckets:    map[time.Time]uint{NOW: 100},
 			expectedResult: Result{},
 		},
 		{
-			id:             "2",
-			limits:         user.Limits{MonthlySentReminderCount: c.NewOptional(uint32(100), true)},
-			reminderCount:  100,
-			expectedResult: Result{Limit: c.NewOptional(user.Limit{Value: 100, Actual: 100}, true)},
+			id:          "2",
+			limits:      user.Limits{MonthlySentReminderCount: c.NewOptional(uint32(100), true)},
+			sentBuckets: map[time.Time]uint{NOW: 100},
+			expectedResult: Result{
+				Limit:   c.NewOptional(user.Limit{Value: 100, Actual: 100}, true),
+				Window:  user.QuotaWindowCalendarMonth,
+				ResetAt: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			id:          "3",
+			limits:      user.Limits{MonthlySentReminderCount: c.NewOptional(uint32(100), true)},
+			sentBuckets: map[time.Time]uint{NOW: 150, NOW.AddDate(0, 0, -14): 50},
+			expectedResult: Result{
+				Limit:   c.NewOptional(user.Limit{Value: 100, Actual: 200}, true),
+				Window:  user.QuotaWindowCalendarMonth,
+				ResetAt: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			id: "4",
+			limits: user.Limits{
+				MonthlySentReminderCount: c.NewOptional(uint32(100), true),
+				SentReminderQuotaWindow:  user.QuotaWindowRolling30Days,
+			},
+			sentBuckets: map[time.Time]uint{},
+			expectedResult: Result{
+				Limit:   c.NewOptional(user.Limit{Value: 100, Actual: 0}, true),
+				Window:  user.QuotaWindowRolling30Days,
+				ResetAt: NOW.Add(user.ROLLING_QUOTA_WINDOW_DURATION),
+			},
 		},
 		{
-			id:             "3",
-			limits:         user.Limits{MonthlySentReminderCount: c.NewOptional(uint32(100), true)},
-			reminderCount:  200,
-			expectedResult: Result{Limit: c.NewOptional(user.Limit{Value: 100, Actual: 200}, true)},
+			id: "5",
+			limits: user.Limits{
+				MonthlySentReminderCount: c.NewOptional(uint32(100), true),
+				SentReminderQuotaWindow:  user.QuotaWindowRolling30Days,
+			},
+			sentBuckets: map[time.Time]uint{
+				reminder.SentUsageBucket(NOW.AddDate(0, 0, -40)): 5,
+				reminder.SentUsageBucket(NOW.AddDate(0, 0, -10)): 3,
+				reminder.SentUsageBucket(NOW):                    1,
+			},
+			expectedResult: Result{
+				Limit:   c.NewOptional(user.Limit{Value: 100, Actual: 4}, true),
+				Window:  user.QuotaWindowRolling30Days,
+				ResetAt: time.Date(2022, 7, 5, 12, 45, 0, 0, time.UTC),
+			},
 		},
 	}
 
@@ -67,7 +105,9 @@ func TestGetLimitForSentRemindersSuccess(t *testing.T) {
 		t.Run(testcase.id, func(t *testing.T) {
 			fixture := NewFixture()
 			fixture.limits.Limits = testcase.limits
-			fixture.reminders.CountResult = uint(testcase.reminderCount)
+			for bucket, count := range testcase.sentBuckets {
+				fixture.usage.SentBuckets[bucket] = count
+			}
 
 			result, err := fixture.service().Run(context.Background(), Input{UserID: USER_ID})
 
@@ -78,28 +118,15 @@ func TestGetLimitForSentRemindersSuccess(t *testing.T) {
 	}
 }
 
-func TestRemindersCounted(t *testing.T) {
+func TestRemindersCountedInUserTimeZone(t *testing.T) {
 	fixture := NewFixture()
-	fixture.limits.Limits = user.Limits{
-		ActiveReminderCount:      c.NewOptional(uint32(5), true),
-		MonthlySentReminderCount: c.NewOptional(uint32(10), true),
-	}
+	fixture.limits.Limits = user.Limits{MonthlySentReminderCount: c.NewOptional(uint32(10), true)}
+	timeZone := time.FixedZone("UTC-5", -5*60*60)
 
-	_, err := fixture.service().Run(context.Background(), Input{UserID: USER_ID})
+	result, err := fixture.service().Run(context.Background(), Input{UserID: USER_ID, TimeZone: timeZone})
 
 	assert := require.New(t)
 	assert.Nil(err)
-	assert.Equal(
-		[]reminder.ReadOptions{
-			{
-				CreatedByEquals: c.NewOptional(USER_ID, true),
-				StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
-				SentAfter: c.NewOptional(
-					time.Date(NOW.Year(), NOW.Month(), 1, 0, 0, 0, 0, time.UTC),
-					true,
-				),
-			},
-		},
-		fixture.reminders.CountWith,
-	)
+	assert.Equal([]time.Time{time.Date(2022, 6, 1, 5, 0, 0, 0, time.UTC)}, fixture.usage.CountSentWith)
+	assert.Equal(time.Date(2022, 7, 1, 5, 0, 0, 0, time.UTC), result.ResetAt)
 }
@@ -16,6 +16,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeProfileRead
+}
+
 type Result struct {
 	User user.User
 }
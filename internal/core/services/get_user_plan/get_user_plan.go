@@ -0,0 +1,71 @@
+package getuserplan
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+)
+
+type Input struct {
+	Actor  string
+	UserID user.ID
+}
+
+func (i Input) WithAdmin(actor string) admin.Input {
+	i.Actor = actor
+	return i
+}
+
+type Result struct {
+	UserPlan user.UserPlan
+	Audit    []user.LimitsAuditEntry
+}
+
+type service struct {
+	log                   logging.Logger
+	limitsRepository      user.LimitsRepository
+	limitsAuditRepository user.LimitsAuditRepository
+}
+
+// New returns the user limits together with the trail of their changes.
+func New(
+	log logging.Logger,
+	limitsRepository user.LimitsRepository,
+	limitsAuditRepository user.LimitsAuditRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if limitsRepository == nil {
+		panic(e.NewNilArgumentError("limitsRepository"))
+	}
+	if limitsAuditRepository == nil {
+		panic(e.NewNilArgumentError("limitsAuditRepository"))
+	}
+	return &service{
+		log:                   log,
+		limitsRepository:      limitsRepository,
+		limitsAuditRepository: limitsAuditRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	userPlan, err := s.limitsRepository.GetUserPlan(ctx, input.UserID)
+	if errors.Is(err, user.ErrLimitsDoNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	audit, err := s.limitsAuditRepository.List(ctx, input.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	return Result{UserPlan: userPlan, Audit: audit}, nil
+}
@@ -0,0 +1,70 @@
+package getuserplan
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = user.ID(1)
+
+var (
+	NOW    = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	Limits = user.Limits{EmailChannelCount: c.NewOptional(uint32(5), true)}
+)
+
+type testSuite struct {
+	suite.Suite
+	LimitsRepository      *user.FakeLimitsRepository
+	LimitsAuditRepository *user.FakeLimitsAuditRepository
+	Service               services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.LimitsRepository = user.NewFakeLimitsRepository()
+	suite.LimitsRepository.Plan = c.NewOptional(user.PlanPro, true)
+	suite.LimitsRepository.Limits = Limits
+	suite.LimitsAuditRepository = user.NewFakeLimitsAuditRepository()
+	suite.Service = New(logging.NewFakeLogger(), suite.LimitsRepository, suite.LimitsAuditRepository)
+}
+
+func TestGetUserPlanService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	for _, action := range []user.LimitsAuditAction{
+		user.LimitsAuditActionAssignPlan,
+		user.LimitsAuditActionOverrideLimits,
+	} {
+		_, err := s.LimitsAuditRepository.Create(context.Background(), user.CreateLimitsAuditEntryInput{
+			UserID:    USER_ID,
+			Actor:     "test-admin",
+			Action:    action,
+			After:     Limits,
+			CreatedAt: NOW,
+		})
+		s.Require().Nil(err)
+	}
+
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Equal(user.UserPlan{UserID: USER_ID, Plan: c.NewOptional(user.PlanPro, true), Limits: Limits}, result.UserPlan)
+	s.Len(result.Audit, 2)
+	s.Equal(user.LimitsAuditActionOverrideLimits, result.Audit[0].Action, "most recent first")
+}
+
+func (s *testSuite) TestGetFailed() {
+	s.LimitsRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.NotNil(err)
+}
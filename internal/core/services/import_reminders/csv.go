@@ -0,0 +1,97 @@
+package importreminders
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/reminder"
+	"strings"
+	"time"
+)
+
+// CSV_TIME_LAYOUTS are accepted in addition to RFC 3339, times without an offset
+// are in the time zone of the user.
+var CSV_TIME_LAYOUTS = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// parsedRow is a reminder read from an import file, it is validated by the service.
+type parsedRow struct {
+	line      int
+	at        time.Time
+	body      string
+	every     c.Optional[reminder.Every]
+	isSkipped bool
+	err       error
+}
+
+// parseCSV reads a CSV file with a header, the at column is required, body and every are optional.
+func parseCSV(data []byte, loc *time.Location) ([]parsedRow, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: header is not valid", reminder.ErrImportInvalidFile)
+	}
+	columns := make(map[string]int, len(header))
+	for ix, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = ix
+	}
+	if _, ok := columns["at"]; !ok {
+		return nil, fmt.Errorf("%w: at column is missing", reminder.ErrImportInvalidFile)
+	}
+
+	rows := make([]parsedRow, 0)
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", reminder.ErrImportInvalidFile, err)
+		}
+		line, _ := r.FieldPos(0)
+		field := func(name string) string {
+			ix, ok := columns[name]
+			if !ok || ix >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[ix])
+		}
+
+		row := parsedRow{line: line, body: field("body")}
+		row.at, row.err = parseCSVTime(field("at"), loc)
+		if rawEvery := field("every"); row.err == nil && rawEvery != "" {
+			every, err := reminder.ParseEvery(rawEvery)
+			if err != nil {
+				row.err = fmt.Errorf("%w: every is not valid", reminder.ErrImportInvalidRow)
+			}
+			row.every = c.NewOptional(every, err == nil)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSVTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: at is not set", reminder.ErrImportInvalidRow)
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	for _, layout := range CSV_TIME_LAYOUTS {
+		if at, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return at, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: at is not valid", reminder.ErrImportInvalidRow)
+}
@@ -0,0 +1,377 @@
+package importreminders
+
+import (
+	"fmt"
+	"regexp"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/reminder"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ICS_DATE_FORMAT        = "20060102"
+	ICS_LOCAL_FORMAT       = "20060102T150405"
+	ICS_UTC_FORMAT         = "20060102T150405Z"
+	ICS_COMPONENT_EVENT    = "VEVENT"
+	ICS_COMPONENT_TODO     = "VTODO"
+	ICS_COMPONENT_ALARM    = "VALARM"
+	ICS_COMPONENT_CALENDAR = "VCALENDAR"
+)
+
+var icsDurationRegexp = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+var icsTextUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+type icsLine struct {
+	number int
+	value  string
+}
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsComponent is a VEVENT or a VTODO, only the first occurrence of each property is kept.
+type icsComponent struct {
+	name       string
+	line       int
+	properties map[string]icsProperty
+	alarms     []*icsComponent
+}
+
+// parseICS reads events and to-dos of an iCalendar file (RFC 5545), floating times are
+// in the time zone of the user, as well as times with unknown TZIDs.
+func parseICS(data []byte, loc *time.Location) ([]parsedRow, error) {
+	components := make([]*icsComponent, 0)
+	stack := make([]string, 0, 4)
+	isCalendar := false
+	var current, alarm *icsComponent
+	for _, line := range unfoldICS(string(data)) {
+		if line.value == "" {
+			continue
+		}
+		prop, err := parseICSLine(line.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d", reminder.ErrImportInvalidFile, line.number)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			name := strings.ToUpper(prop.value)
+			switch {
+			case name == ICS_COMPONENT_CALENDAR && len(stack) == 0:
+				isCalendar = true
+			case (name == ICS_COMPONENT_EVENT || name == ICS_COMPONENT_TODO) && current == nil:
+				current = newICSComponent(name, line.number)
+			case name == ICS_COMPONENT_ALARM && current != nil && alarm == nil:
+				alarm = newICSComponent(name, line.number)
+			}
+			stack = append(stack, name)
+		case "END":
+			name := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != name {
+				return nil, fmt.Errorf("%w: line %d", reminder.ErrImportInvalidFile, line.number)
+			}
+			stack = stack[:len(stack)-1]
+			switch {
+			case alarm != nil && name == ICS_COMPONENT_ALARM:
+				current.alarms = append(current.alarms, alarm)
+				alarm = nil
+			case current != nil && alarm == nil && name == current.name:
+				components = append(components, current)
+				current = nil
+			}
+		default:
+			if len(stack) == 0 {
+				continue
+			}
+			top := stack[len(stack)-1]
+			switch {
+			case alarm != nil && top == ICS_COMPONENT_ALARM:
+				alarm.add(prop)
+			case current != nil && top == current.name:
+				current.add(prop)
+			}
+		}
+	}
+	if !isCalendar || len(stack) != 0 {
+		return nil, fmt.Errorf("%w: calendar is not complete", reminder.ErrImportInvalidFile)
+	}
+
+	rows := make([]parsedRow, 0, len(components))
+	for _, component := range components {
+		rows = append(rows, component.toRow(loc))
+	}
+	return rows, nil
+}
+
+func newICSComponent(name string, line int) *icsComponent {
+	return &icsComponent{name: name, line: line, properties: make(map[string]icsProperty)}
+}
+
+func (comp *icsComponent) add(prop icsProperty) {
+	if _, ok := comp.properties[prop.name]; !ok {
+		comp.properties[prop.name] = prop
+	}
+}
+
+func (comp *icsComponent) toRow(loc *time.Location) (row parsedRow) {
+	row.line = comp.line
+	row.body = strings.TrimSpace(unescapeICSText(comp.properties["SUMMARY"].value))
+	if row.body == "" {
+		row.body = strings.TrimSpace(unescapeICSText(comp.properties["DESCRIPTION"].value))
+	}
+	status := strings.ToUpper(comp.properties["STATUS"].value)
+	_, isCompleted := comp.properties["COMPLETED"]
+	if status == "CANCELLED" || status == "COMPLETED" || isCompleted {
+		row.isSkipped = true
+		return row
+	}
+
+	start, err := parseICSTime(comp.properties["DTSTART"], loc)
+	if err != nil {
+		row.err = err
+		return row
+	}
+	endName := "DTEND"
+	if comp.name == ICS_COMPONENT_TODO {
+		endName = "DUE"
+	}
+	end, err := parseICSTime(comp.properties[endName], loc)
+	if err != nil {
+		row.err = err
+		return row
+	}
+	// Reminders of to-dos are due at their due time and of events at their start time.
+	at := start
+	if comp.name == ICS_COMPONENT_TODO && end.IsPresent {
+		at = end
+	}
+	if !start.IsPresent {
+		start = end
+	}
+	if !at.IsPresent {
+		row.err = fmt.Errorf("%w: time is not set", reminder.ErrImportInvalidRow)
+		return row
+	}
+
+	for _, alarm := range comp.alarms {
+		trigger, ok := alarm.properties["TRIGGER"]
+		if !ok {
+			continue
+		}
+		if strings.ToUpper(trigger.params["VALUE"]) == "DATE-TIME" {
+			at, err = parseICSTime(trigger, loc)
+		} else {
+			var offset time.Duration
+			offset, err = parseICSDuration(trigger.value)
+			base := start
+			if strings.ToUpper(trigger.params["RELATED"]) == "END" && end.IsPresent {
+				base = end
+			}
+			at = c.NewOptional(base.Value.Add(offset), base.IsPresent)
+		}
+		if err != nil {
+			row.err = err
+			return row
+		}
+		break
+	}
+	row.at = at.Value
+
+	if rrule, ok := comp.properties["RRULE"]; ok {
+		every, err := parseICSRecurrence(rrule.value, start.Value)
+		if err != nil {
+			row.err = err
+			return row
+		}
+		row.every = c.NewOptional(every, true)
+	}
+	return row
+}
+
+// parseICSRecurrence maps simple recurrence rules to reminder recurrences, BY rules are
+// supported only if they repeat the start of the recurrence.
+func parseICSRecurrence(value string, start time.Time) (every reminder.Every, err error) {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(value, ";") {
+		name, partValue, ok := strings.Cut(part, "=")
+		if !ok {
+			return every, reminder.ErrImportUnsupportedRecurrence
+		}
+		parts[strings.ToUpper(name)] = strings.ToUpper(partValue)
+	}
+
+	var period reminder.Period
+	switch parts["FREQ"] {
+	case "MINUTELY":
+		period = reminder.PeriodMinute
+	case "HOURLY":
+		period = reminder.PeriodHour
+	case "DAILY":
+		period = reminder.PeriodDay
+	case "WEEKLY":
+		period = reminder.PeriodWeek
+	case "MONTHLY":
+		period = reminder.PeriodMonth
+	case "YEARLY":
+		period = reminder.PeriodYear
+	default:
+		return every, reminder.ErrImportUnsupportedRecurrence
+	}
+	interval := uint64(1)
+	if rawInterval, ok := parts["INTERVAL"]; ok {
+		interval, err = strconv.ParseUint(rawInterval, 10, 32)
+		if err != nil {
+			return every, reminder.ErrImportUnsupportedRecurrence
+		}
+	}
+
+	for name, partValue := range parts {
+		isSupported := false
+		switch name {
+		case "FREQ", "INTERVAL", "WKST":
+			isSupported = true
+		case "BYDAY":
+			isSupported = period == reminder.PeriodWeek &&
+				partValue == strings.ToUpper(start.Weekday().String()[:2])
+		case "BYMONTHDAY":
+			isSupported = period == reminder.PeriodMonth && partValue == strconv.Itoa(start.Day())
+		case "BYMONTH":
+			isSupported = period == reminder.PeriodYear && partValue == strconv.Itoa(int(start.Month()))
+		}
+		if !isSupported {
+			return every, reminder.ErrImportUnsupportedRecurrence
+		}
+	}
+	return reminder.NewEvery(uint32(interval), period), nil
+}
+
+func parseICSTime(prop icsProperty, loc *time.Location) (at c.Optional[time.Time], err error) {
+	if prop.value == "" {
+		return at, nil
+	}
+	var t time.Time
+	switch {
+	case strings.ToUpper(prop.params["VALUE"]) == "DATE" || len(prop.value) == len(ICS_DATE_FORMAT):
+		t, err = time.ParseInLocation(ICS_DATE_FORMAT, prop.value, loc)
+	case strings.HasSuffix(prop.value, "Z"):
+		t, err = time.Parse(ICS_UTC_FORMAT, prop.value)
+	default:
+		t, err = time.ParseInLocation(ICS_LOCAL_FORMAT, prop.value, loadICSLocation(prop.params["TZID"], loc))
+	}
+	if err != nil {
+		return at, fmt.Errorf("%w: %s is not valid", reminder.ErrImportInvalidRow, strings.ToLower(prop.name))
+	}
+	return c.NewOptional(t, true), nil
+}
+
+// loadICSLocation resolves IANA time zone names, including prefixed ones like
+// "/mozilla.org/20050126_1/Europe/Berlin", the default location is used for other names.
+func loadICSLocation(tzid string, defaultLoc *time.Location) *time.Location {
+	if tzid == "" {
+		return defaultLoc
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+	parts := strings.Split(tzid, "/")
+	if len(parts) >= 2 {
+		if loc, err := time.LoadLocation(strings.Join(parts[len(parts)-2:], "/")); err == nil {
+			return loc
+		}
+	}
+	return defaultLoc
+}
+
+func parseICSDuration(value string) (time.Duration, error) {
+	match := icsDurationRegexp.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("%w: trigger is not valid", reminder.ErrImportInvalidRow)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	hasValue := false
+	for ix, unit := range units {
+		if match[ix+2] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[ix+2], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("%w: trigger is not valid", reminder.ErrImportInvalidRow)
+		}
+		d += time.Duration(n) * unit
+		hasValue = true
+	}
+	if !hasValue {
+		return 0, fmt.Errorf("%w: trigger is not valid", reminder.ErrImportInvalidRow)
+	}
+	if match[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+func unfoldICS(data string) []icsLine {
+	raw := strings.Split(strings.TrimPrefix(data, "\ufeff"), "\n")
+	lines := make([]icsLine, 0, len(raw))
+	for ix, value := range raw {
+		value = strings.TrimSuffix(value, "\r")
+		if len(lines) > 0 && len(value) > 0 && (value[0] == ' ' || value[0] == '\t') {
+			lines[len(lines)-1].value += value[1:]
+			continue
+		}
+		lines = append(lines, icsLine{number: ix + 1, value: value})
+	}
+	return lines
+}
+
+func parseICSLine(line string) (prop icsProperty, err error) {
+	parts := make([]string, 0, 2)
+	isQuoted := false
+	partStart := 0
+	for ix, r := range line {
+		switch {
+		case r == '"':
+			isQuoted = !isQuoted
+		case r == ';' && !isQuoted:
+			parts = append(parts, line[partStart:ix])
+			partStart = ix + 1
+		case r == ':' && !isQuoted:
+			parts = append(parts, line[partStart:ix])
+			return newICSProperty(parts, line[ix+1:])
+		}
+	}
+	return prop, reminder.ErrImportInvalidFile
+}
+
+func newICSProperty(parts []string, value string) (prop icsProperty, err error) {
+	prop.name = strings.ToUpper(parts[0])
+	prop.value = value
+	if prop.name == "" {
+		return prop, reminder.ErrImportInvalidFile
+	}
+	prop.params = make(map[string]string, len(parts)-1)
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return prop, reminder.ErrImportInvalidFile
+		}
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func unescapeICSText(value string) string {
+	return icsTextUnescaper.Replace(value)
+}
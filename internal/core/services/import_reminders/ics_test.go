@@ -0,0 +1,197 @@
+package importreminders
+
+import (
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/reminder"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func calendar(lines ...string) []byte {
+	return []byte(strings.Join(append(append([]string{"BEGIN:VCALENDAR"}, lines...), "END:VCALENDAR"), "\r\n"))
+}
+
+func TestParseICS(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(t, err)
+
+	cases := []struct {
+		id       string
+		data     []byte
+		expected parsedRow
+	}{
+		{
+			id: "tzid",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART;TZID=Europe/Berlin:20230105T100000",
+				`SUMMARY:Call\, then write\nnotes`,
+				"END:VEVENT",
+			),
+			expected: parsedRow{line: 2, at: time.Date(2023, 1, 5, 10, 0, 0, 0, berlin), body: "Call, then write\nnotes"},
+		},
+		{
+			id: "prefixed tzid",
+			data: calendar(
+				"BEGIN:VEVENT",
+				`DTSTART;TZID="/mozilla.org/20050126_1/Europe/Berlin":20230105T100000`,
+				"SUMMARY:test",
+				"END:VEVENT",
+			),
+			expected: parsedRow{line: 2, at: time.Date(2023, 1, 5, 10, 0, 0, 0, berlin), body: "test"},
+		},
+		{
+			id: "unknown tzid and folded summary",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART;TZID=Tokyo Standard Time:20230105T100000",
+				"SUMMARY:long",
+				"  summary",
+				"END:VEVENT",
+			),
+			expected: parsedRow{line: 2, at: time.Date(2023, 1, 5, 10, 0, 0, 0, tokyo), body: "long summary"},
+		},
+		{
+			id: "all day",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART;VALUE=DATE:20230105",
+				"SUMMARY:birthday",
+				"RRULE:FREQ=YEARLY;BYMONTH=1",
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"TRIGGER:PT9H",
+				"END:VALARM",
+				"END:VEVENT",
+			),
+			expected: parsedRow{
+				line:  2,
+				at:    time.Date(2023, 1, 5, 9, 0, 0, 0, tokyo),
+				body:  "birthday",
+				every: c.NewOptional(reminder.EveryYear, true),
+			},
+		},
+		{
+			id: "to-do with alarm related to due",
+			data: calendar(
+				"BEGIN:VTODO",
+				"DTSTART:20230104T100000Z",
+				"DUE:20230105T100000Z",
+				"SUMMARY:taxes",
+				"BEGIN:VALARM",
+				"TRIGGER;RELATED=END:-P1DT2H",
+				"END:VALARM",
+				"END:VTODO",
+			),
+			expected: parsedRow{line: 2, at: time.Date(2023, 1, 4, 8, 0, 0, 0, time.UTC), body: "taxes"},
+		},
+		{
+			id: "to-do with absolute alarm",
+			data: calendar(
+				"BEGIN:VTODO",
+				"DUE:20230105T100000Z",
+				"DESCRIPTION:milk",
+				"BEGIN:VALARM",
+				"TRIGGER;VALUE=DATE-TIME:20230105T080000Z",
+				"END:VALARM",
+				"END:VTODO",
+			),
+			expected: parsedRow{line: 2, at: time.Date(2023, 1, 5, 8, 0, 0, 0, time.UTC), body: "milk"},
+		},
+		{
+			id: "weekly on the start day",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART:20230105T100000Z",
+				"RRULE:FREQ=WEEKLY;WKST=MO;BYDAY=TH",
+				"END:VEVENT",
+			),
+			expected: parsedRow{
+				line:  2,
+				at:    time.Date(2023, 1, 5, 10, 0, 0, 0, time.UTC),
+				every: c.NewOptional(reminder.EveryWeek, true),
+			},
+		},
+		{
+			id: "weekly on other days",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART:20230105T100000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO,TH",
+				"END:VEVENT",
+			),
+			expected: parsedRow{
+				line: 2,
+				at:   time.Date(2023, 1, 5, 10, 0, 0, 0, time.UTC),
+				err:  reminder.ErrImportUnsupportedRecurrence,
+			},
+		},
+		{
+			id: "cancelled event",
+			data: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART:20230105T100000Z",
+				"STATUS:CANCELLED",
+				"SUMMARY:canceled",
+				"END:VEVENT",
+			),
+			expected: parsedRow{line: 2, body: "canceled", isSkipped: true},
+		},
+		{
+			id: "time zone definitions are ignored",
+			data: calendar(
+				"BEGIN:VTIMEZONE",
+				"TZID:Europe/Berlin",
+				"BEGIN:STANDARD",
+				"DTSTART:19701025T030000",
+				"END:STANDARD",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"DTSTART:20230105T100000Z",
+				"END:VEVENT",
+			),
+			expected: parsedRow{line: 8, at: time.Date(2023, 1, 5, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			rows, err := parseICS(testcase.data, tokyo)
+
+			assert.Nil(t, err)
+			if !assert.Len(t, rows, 1) {
+				return
+			}
+			assert.ErrorIs(t, rows[0].err, testcase.expected.err)
+			rows[0].err = testcase.expected.err
+			assert.True(t, testcase.expected.at.Equal(rows[0].at), rows[0].at)
+			rows[0].at = testcase.expected.at
+			assert.Equal(t, testcase.expected, rows[0])
+		})
+	}
+}
+
+func TestParseICSError(t *testing.T) {
+	cases := []struct {
+		id   string
+		data []byte
+	}{
+		{id: "not a calendar", data: []byte("BEGIN:VEVENT\r\nEND:VEVENT\r\n")},
+		{id: "unbalanced", data: calendar("BEGIN:VEVENT", "END:VTODO")},
+		{id: "not complete", data: []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n")},
+		{id: "invalid line", data: calendar("BEGIN:VEVENT", "SUMMARY", "END:VEVENT")},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			_, err := parseICS(testcase.data, time.UTC)
+
+			assert.ErrorIs(t, err, reminder.ErrImportInvalidFile)
+		})
+	}
+}
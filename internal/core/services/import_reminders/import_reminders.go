@@ -0,0 +1,363 @@
+package importreminders
+
+import (
+	"context"
+	"fmt"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	createreminder "remindme/internal/core/services/create_reminder"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const MAX_ROW_COUNT = 500
+
+type Format string
+
+const (
+	FormatICS Format = "ics"
+	FormatCSV Format = "csv"
+)
+
+type Input struct {
+	UserID   user.ID
+	TimeZone *time.Location
+	Format   Format
+	Data     []byte
+	// ChannelIDs are used for all imported reminders.
+	ChannelIDs reminder.ChannelIDs
+	// DryRun only validates the rows, reminders are created only if all rows are valid.
+	DryRun bool
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	i.TimeZone = u.TimeZone
+	return i
+}
+
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("import-reminders::%d", i.UserID)
+}
+
+type Row struct {
+	// Line is the line of the row in the file, it starts with 1.
+	Line  int
+	Input createreminder.Input
+	// IsSkipped is set for rows which are over, like past one-off events or completed to-dos.
+	IsSkipped bool
+	Error     error
+	Reminder  c.Optional[reminder.ReminderWithChannels]
+}
+
+type Result struct {
+	Rows []Row
+	// IsCreated is set if reminders were created, it is not set for dry runs and invalid files.
+	IsCreated bool
+}
+
+func (r Result) HasErrors() bool {
+	for _, row := range r.Rows {
+		if row.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	scheduler  reminder.Scheduler
+	now        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	scheduler reminder.Scheduler,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if scheduler == nil {
+		panic(e.NewNilArgumentError("scheduler"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		scheduler:  scheduler,
+		now:        now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	loc := input.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+	var parsedRows []parsedRow
+	switch input.Format {
+	case FormatICS:
+		parsedRows, err = parseICS(input.Data, loc)
+	case FormatCSV:
+		parsedRows, err = parseCSV(input.Data, loc)
+	default:
+		err = reminder.ErrImportInvalidFile
+	}
+	if err != nil {
+		return result, err
+	}
+	if len(parsedRows) > MAX_ROW_COUNT {
+		return result, reminder.ErrImportTooManyRows
+	}
+
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	if err := s.checkChannels(ctx, uow, input); err != nil {
+		return result, err
+	}
+	limits, err := uow.Limits().GetUserLimitsWithLock(ctx, input.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	result.Rows, err = s.validateRows(ctx, uow, limits, input, parsedRows, now)
+	if err != nil {
+		return result, err
+	}
+	if input.DryRun || result.HasErrors() {
+		s.log.Info(
+			ctx,
+			"Reminders import validated.",
+			logging.Entry("userID", input.UserID),
+			logging.Entry("rowCount", len(result.Rows)),
+			logging.Entry("dryRun", input.DryRun),
+			logging.Entry("hasErrors", result.HasErrors()),
+		)
+		return result, nil
+	}
+
+	createdCount := 0
+	for ix, row := range result.Rows {
+		if row.IsSkipped {
+			continue
+		}
+		rem, err := s.create(ctx, uow, row.Input, now)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Rows[ix].Reminder = c.NewOptional(rem, true)
+		createdCount++
+	}
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return Result{}, err
+	}
+
+	result.IsCreated = true
+	s.log.Info(
+		ctx,
+		"Reminders successfully imported.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("rowCount", len(result.Rows)),
+		logging.Entry("createdCount", createdCount),
+	)
+	return result, nil
+}
+
+// validateRows maps parsed rows to inputs of reminders, limits are checked for all rows together.
+func (s *service) validateRows(
+	ctx context.Context,
+	uow uow.Context,
+	limits user.Limits,
+	input Input,
+	parsedRows []parsedRow,
+	now time.Time,
+) ([]Row, error) {
+	activeCount := uint(0)
+	if limits.ActiveReminderCount.IsPresent {
+		count, err := reminder.CountActive(ctx, uow.Usage(), input.UserID, limits)
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+			return nil, err
+		}
+		activeCount = count
+	}
+	quota, err := reminder.GetSentQuota(ctx, uow.Usage(), input.UserID, limits, input.TimeZone, now)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(parsedRows))
+	for _, parsed := range parsedRows {
+		at := parsed.at.UTC()
+		// Recurring reminders which started in the past are imported from their next time.
+		if parsed.err == nil && parsed.every.IsPresent && parsed.every.Value.Validate() == nil {
+			at = parsed.every.Value.NextAfter(at, now.Add(reminder.MIN_DURATION_FROM_NOW))
+		}
+		row := Row{
+			Line: parsed.line,
+			Input: createreminder.Input{
+				UserID:     input.UserID,
+				TimeZone:   input.TimeZone,
+				At:         at,
+				Body:       parsed.body,
+				Every:      parsed.every,
+				ChannelIDs: input.ChannelIDs,
+			},
+			IsSkipped: parsed.isSkipped || (parsed.err == nil && !parsed.every.IsPresent && !at.After(now)),
+			Error:     parsed.err,
+		}
+		if row.IsSkipped {
+			row.Error = nil
+			rows = append(rows, row)
+			continue
+		}
+		if row.Error == nil {
+			row.Error = validateRow(row.Input, limits, quota, now)
+		}
+		if row.Error == nil && limits.ActiveReminderCount.IsPresent {
+			if activeCount >= uint(limits.ActiveReminderCount.Value) {
+				row.Error = user.ErrLimitActiveReminderCountExceeded
+			} else {
+				activeCount++
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func validateRow(
+	input createreminder.Input,
+	limits user.Limits,
+	quota c.Optional[reminder.SentQuota],
+	now time.Time,
+) error {
+	if utf8.RuneCountInString(input.Body) > reminder.MAX_BODY_LEN {
+		return fmt.Errorf("%w: body is too long", reminder.ErrImportInvalidRow)
+	}
+	if err := input.Validate(now); err != nil {
+		return err
+	}
+	if limits.ReminderEveryPerDayCount.IsPresent && input.Every.IsPresent {
+		if input.Every.Value.PerDayCount() > limits.ReminderEveryPerDayCount.Value {
+			return user.ErrLimitReminderEveryPerDayCountExceeded
+		}
+	}
+	if quota.IsPresent && quota.Value.Covers(input.At) && quota.Value.IsExceeded() {
+		return user.ErrLimitSentReminderCountExceeded
+	}
+	return nil
+}
+
+func (s *service) create(
+	ctx context.Context,
+	uow uow.Context,
+	input createreminder.Input,
+	now time.Time,
+) (result reminder.ReminderWithChannels, err error) {
+	createInput := reminder.CreateInput{
+		CreatedBy: input.UserID,
+		CreatedAt: now,
+		Body:      input.Body,
+		At:        input.At,
+		Every:     input.Every,
+		Status:    reminder.StatusCreated,
+	}
+	if input.At.Sub(now) < reminder.DURATION_FOR_SCHEDULING {
+		createInput.Status = reminder.StatusScheduled
+		createInput.ScheduledAt = c.NewOptional(now, true)
+	}
+	createdReminder, err := uow.Reminders().Create(ctx, createInput)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), reminder.Reminder{}, createdReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminder", createdReminder))
+		return result, err
+	}
+	createdChannelIDs, err := uow.ReminderChannels().Create(ctx, reminder.CreateChannelsInput{
+		ReminderID: createdReminder.ID,
+		ChannelIDs: input.ChannelIDs,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminder", createdReminder))
+		return result, err
+	}
+	if createdReminder.Status == reminder.StatusScheduled {
+		if err := s.scheduler.ScheduleReminder(ctx, createdReminder); err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminder", createdReminder))
+			return result, err
+		}
+	}
+	channelIDs := make([]channel.ID, 0, len(createdChannelIDs))
+	for channelID := range createdChannelIDs {
+		channelIDs = append(channelIDs, channelID)
+	}
+	sort.Slice(channelIDs, func(i, j int) bool { return channelIDs[i] < channelIDs[j] })
+	result.FromReminderAndChannels(createdReminder, channelIDs)
+	return result, nil
+}
+
+func (s *service) checkChannels(ctx context.Context, uow uow.Context, input Input) error {
+	if len(input.ChannelIDs) == 0 {
+		return reminder.ErrReminderChannelsNotSet
+	}
+	if len(input.ChannelIDs) > reminder.MAX_CHANNEL_COUNT {
+		return reminder.ErrReminderTooManyChannels
+	}
+	channelIDs := make([]channel.ID, 0, len(input.ChannelIDs))
+	for channelID := range input.ChannelIDs {
+		channelIDs = append(channelIDs, channelID)
+	}
+
+	channels, err := uow.Channels().Read(
+		ctx,
+		channel.ReadOptions{
+			IDIn:         c.NewOptional(channelIDs, true),
+			UserIDEquals: c.NewOptional(input.UserID, true),
+		},
+	)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return err
+	}
+	if len(channels) != len(channelIDs) {
+		return reminder.ErrReminderChannelsNotValid
+	}
+	for _, readChannel := range channels {
+		if !readChannel.IsVerified() {
+			return reminder.ErrReminderChannelsNotVerified
+		}
+	}
+	return nil
+}
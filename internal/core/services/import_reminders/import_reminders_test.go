@@ -0,0 +1,188 @@
+package importreminders
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID      = user.ID(42)
+	CHANNEL_ID_1 = channel.ID(1)
+	CHANNEL_ID_2 = channel.ID(2)
+)
+
+var Now = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	unitOfWork *uow.FakeUnitOfWork
+	scheduler  *reminder.TestReminderScheduler
+	service    services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.unitOfWork = uow.NewFakeUnitOfWork()
+	suite.unitOfWork.Channels().ReadChannels = []channel.Channel{
+		{ID: CHANNEL_ID_1, CreatedBy: USER_ID, CreatedAt: Now, VerifiedAt: c.NewOptional(Now, true)},
+		{ID: CHANNEL_ID_2, CreatedBy: USER_ID, CreatedAt: Now, VerifiedAt: c.NewOptional(Now, true)},
+	}
+	suite.unitOfWork.Limits().Limits = user.Limits{}
+	suite.scheduler = reminder.NewTestReminderScheduler()
+	suite.service = New(
+		logging.NewFakeLogger(),
+		suite.unitOfWork,
+		suite.scheduler,
+		func() time.Time { return Now },
+	)
+}
+
+func TestImportRemindersService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) input(format Format, data string, dryRun bool) Input {
+	return Input{
+		UserID:     USER_ID,
+		TimeZone:   time.UTC,
+		Format:     format,
+		Data:       []byte(data),
+		ChannelIDs: reminder.NewChannelIDs(CHANNEL_ID_1, CHANNEL_ID_2),
+		DryRun:     dryRun,
+	}
+}
+
+func (s *testSuite) TestDryRun() {
+	data := "at,body,every\n" +
+		"2023-01-02 10:00,call mom,\n" +
+		"2022-12-01T10:00:00Z,standup,1 day\n" +
+		"2022-12-01T10:00:00Z,over,\n"
+
+	result, err := s.service.Run(context.Background(), s.input(FormatCSV, data, true))
+
+	s.Nil(err)
+	s.False(result.IsCreated)
+	s.False(result.HasErrors())
+	s.Require().Len(result.Rows, 3)
+	s.Equal(2, result.Rows[0].Line)
+	s.Equal(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), result.Rows[0].Input.At)
+	s.Equal("call mom", result.Rows[0].Input.Body)
+	s.Equal(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), result.Rows[1].Input.At)
+	s.Equal(c.NewOptional(reminder.EveryDay, true), result.Rows[1].Input.Every)
+	s.True(result.Rows[2].IsSkipped)
+	s.Equal(0, s.unitOfWork.Reminders().CreatedCount)
+	s.False(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestCreate() {
+	data := "at,body\n2023-01-01T13:00:00Z,soon\n2023-01-05T10:00:00Z,later\n"
+	s.unitOfWork.Reminders().CreatedID = 7
+
+	result, err := s.service.Run(context.Background(), s.input(FormatCSV, data, false))
+
+	s.Nil(err)
+	s.True(result.IsCreated)
+	s.Equal(2, s.unitOfWork.Reminders().CreatedCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+	s.Require().True(result.Rows[0].Reminder.IsPresent)
+	s.Equal(reminder.StatusScheduled, result.Rows[0].Reminder.Value.Status)
+	s.Equal([]channel.ID{CHANNEL_ID_1, CHANNEL_ID_2}, result.Rows[0].Reminder.Value.ChannelIDs)
+	s.Equal(reminder.StatusCreated, result.Rows[1].Reminder.Value.Status)
+	s.Len(s.scheduler.Scheduled, 1)
+	s.Equal(uint(2), s.unitOfWork.Usage().ActiveCount)
+}
+
+func (s *testSuite) TestRowErrorsPreventCreation() {
+	data := "at,body,every\n2023-01-05T10:00:00Z,ok,\nnot a time,bad,\n2023-01-05T10:00:00Z,bad every,1 fortnight\n"
+
+	result, err := s.service.Run(context.Background(), s.input(FormatCSV, data, false))
+
+	s.Nil(err)
+	s.False(result.IsCreated)
+	s.True(result.HasErrors())
+	s.Nil(result.Rows[0].Error)
+	s.ErrorIs(result.Rows[1].Error, reminder.ErrImportInvalidRow)
+	s.ErrorIs(result.Rows[2].Error, reminder.ErrImportInvalidRow)
+	s.Equal(0, s.unitOfWork.Reminders().CreatedCount)
+	s.False(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestActiveReminderCountLimit() {
+	s.unitOfWork.Limits().Limits = user.Limits{ActiveReminderCount: c.NewOptional(uint32(3), true)}
+	s.unitOfWork.Usage().ActiveCount = 1
+	data := "at,body\n2023-01-05T10:00:00Z,1\n2023-01-05T10:00:00Z,2\n2022-01-05T10:00:00Z,past\n2023-01-05T10:00:00Z,3\n"
+
+	result, err := s.service.Run(context.Background(), s.input(FormatCSV, data, true))
+
+	s.Nil(err)
+	s.Nil(result.Rows[0].Error)
+	s.Nil(result.Rows[1].Error)
+	s.True(result.Rows[2].IsSkipped)
+	s.ErrorIs(result.Rows[3].Error, user.ErrLimitActiveReminderCountExceeded)
+}
+
+func (s *testSuite) TestEveryPerDayCountLimit() {
+	s.unitOfWork.Limits().Limits = user.Limits{ReminderEveryPerDayCount: c.NewOptional(1.0, true)}
+	data := "at,body,every\n2023-01-05T10:00:00Z,hourly,1 hour\n"
+
+	result, err := s.service.Run(context.Background(), s.input(FormatCSV, data, true))
+
+	s.Nil(err)
+	s.ErrorIs(result.Rows[0].Error, user.ErrLimitReminderEveryPerDayCountExceeded)
+}
+
+func (s *testSuite) TestICS() {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20230105T100000Z\r\n" +
+		"RRULE:FREQ=WEEKLY;INTERVAL=2\r\n" +
+		"SUMMARY:review\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	result, err := s.service.Run(context.Background(), s.input(FormatICS, data, true))
+
+	s.Nil(err)
+	s.Require().Len(result.Rows, 1)
+	s.Nil(result.Rows[0].Error)
+	s.Equal(2, result.Rows[0].Line)
+	s.Equal(time.Date(2023, 1, 5, 9, 45, 0, 0, time.UTC), result.Rows[0].Input.At)
+	s.Equal(c.NewOptional(reminder.NewEvery(2, reminder.PeriodWeek), true), result.Rows[0].Input.Every)
+}
+
+func (s *testSuite) TestInvalidFile() {
+	_, err := s.service.Run(context.Background(), s.input(FormatICS, "SUMMARY:test", true))
+
+	s.ErrorIs(err, reminder.ErrImportInvalidFile)
+}
+
+func (s *testSuite) TestTooManyRows() {
+	data := "at\n"
+	for i := 0; i <= MAX_ROW_COUNT; i++ {
+		data += "2023-01-05T10:00:00Z\n"
+	}
+
+	_, err := s.service.Run(context.Background(), s.input(FormatCSV, data, true))
+
+	s.ErrorIs(err, reminder.ErrImportTooManyRows)
+}
+
+func (s *testSuite) TestChannelsNotVerified() {
+	s.unitOfWork.Channels().ReadChannels[1].VerifiedAt = c.Optional[time.Time]{}
+
+	_, err := s.service.Run(context.Background(), s.input(FormatCSV, "at\n2023-01-05T10:00:00Z\n", true))
+
+	s.ErrorIs(err, reminder.ErrReminderChannelsNotVerified)
+}
@@ -0,0 +1,59 @@
+package listapitokens
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID user.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct {
+	Tokens []user.APIToken
+}
+
+type service struct {
+	log                logging.Logger
+	apiTokenRepository user.APITokenRepository
+}
+
+func New(
+	log logging.Logger,
+	apiTokenRepository user.APITokenRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if apiTokenRepository == nil {
+		panic(e.NewNilArgumentError("apiTokenRepository"))
+	}
+	return &service{
+		log:                log,
+		apiTokenRepository: apiTokenRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	tokens, err := s.apiTokenRepository.List(ctx, input.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"User API tokens successfully read.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("apiTokenCount", len(tokens)),
+	)
+	return Result{Tokens: tokens}, nil
+}
@@ -0,0 +1,56 @@
+package listapitokens
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	APITokenRepository *user.FakeAPITokenRepository
+	Service            services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.APITokenRepository = user.NewFakeAPITokenRepository(user.NewFakeUserRepository())
+	suite.Service = New(logging.NewFakeLogger(), suite.APITokenRepository)
+}
+
+func TestListAPITokensService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestOnlyUserTokensListed() {
+	s.createToken(USER_ID, "first")
+	s.createToken(USER_ID+1, "other")
+	s.createToken(USER_ID, "second")
+
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Require().Len(result.Tokens, 2)
+	s.Equal("first", result.Tokens[0].Name)
+	s.Equal("second", result.Tokens[1].Name)
+}
+
+func (s *testSuite) createToken(userID user.ID, name string) {
+	s.T().Helper()
+	_, err := s.APITokenRepository.Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    userID,
+		Secret:    user.APITokenSecret(user.API_TOKEN_PREFIX + name),
+		Name:      name,
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+}
@@ -0,0 +1,53 @@
+package listplans
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+)
+
+type Input struct {
+	Actor string
+}
+
+func (i Input) WithAdmin(actor string) admin.Input {
+	i.Actor = actor
+	return i
+}
+
+type Result struct {
+	Plans []user.Plan
+}
+
+type service struct {
+	log            logging.Logger
+	planRepository user.PlanRepository
+}
+
+func New(
+	log logging.Logger,
+	planRepository user.PlanRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if planRepository == nil {
+		panic(e.NewNilArgumentError("planRepository"))
+	}
+	return &service{
+		log:            log,
+		planRepository: planRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	plans, err := s.planRepository.List(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	return Result{Plans: plans}, nil
+}
@@ -11,8 +11,16 @@ import (
 	"remindme/internal/core/services/auth"
 )
 
+const MAX_LIMIT = 100
+
 type Input struct {
-	UserID user.ID
+	UserID  user.ID
+	OrderBy channel.OrderBy
+	// Limit is not set by default, so that all the channels are read.
+	Limit  c.Optional[uint]
+	Offset uint
+	// After continues reading from the NextCursor of a previous result with the same OrderBy.
+	After c.Optional[channel.Cursor]
 }
 
 func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
@@ -20,8 +28,14 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeChannelsRead
+}
+
 type Result struct {
 	Channels []channel.Channel
+	// NextCursor is set if there are more channels after the page.
+	NextCursor c.Optional[channel.Cursor]
 }
 
 type service struct {
@@ -46,10 +60,22 @@ func New(
 }
 
 func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
-	channels, err := s.channelRepository.Read(
-		ctx,
-		channel.ReadOptions{UserIDEquals: c.NewOptional(input.UserID, true)},
-	)
+	if input.After.IsPresent && input.After.Value.OrderBy != input.OrderBy {
+		s.log.Info(ctx, "Cursor was made for another order.", logging.Entry("input", input))
+		return result, channel.ErrCursorNotValid
+	}
+
+	readOptions := channel.ReadOptions{
+		UserIDEquals: c.NewOptional(input.UserID, true),
+		OrderBy:      input.OrderBy,
+		Offset:       input.Offset,
+		After:        input.After,
+	}
+	if input.Limit.IsPresent {
+		// One more channel is read to find out if there is a next page.
+		readOptions.Limit = c.NewOptional(input.Limit.Value+1, true)
+	}
+	channels, err := s.channelRepository.Read(ctx, readOptions)
 	if err != nil {
 		s.log.Error(
 			ctx,
@@ -59,11 +85,19 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		)
 		return result, err
 	}
+	if input.Limit.IsPresent && len(channels) > int(input.Limit.Value) {
+		channels = channels[:input.Limit.Value]
+		if input.Limit.Value > 0 {
+			lastChannel := channels[len(channels)-1]
+			result.NextCursor = c.NewOptional(channel.NewCursor(input.OrderBy, lastChannel), true)
+		}
+	}
 	s.log.Info(
 		ctx,
 		"User channels successfully read.",
 		logging.Entry("input", input),
 		logging.Entry("channelCount", len(channels)),
 	)
-	return Result{Channels: channels}, nil
+	result.Channels = channels
+	return result, nil
 }
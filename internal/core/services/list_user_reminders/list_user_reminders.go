@@ -2,6 +2,7 @@ package listuserreminders
 
 import (
 	"context"
+	"remindme/internal/core/domain/channel"
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
@@ -9,16 +10,26 @@ import (
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services"
 	"remindme/internal/core/services/auth"
+	"time"
 )
 
 const DEFAULT_LIMIT = 100
 
 type Input struct {
-	UserID   user.ID
-	StatusIn c.Optional[[]reminder.Status]
-	OrderBy  reminder.OrderBy
-	Limit    c.Optional[uint]
-	Offset   uint
+	UserID          user.ID
+	StatusIn        c.Optional[[]reminder.Status]
+	BodySearch      c.Optional[string]
+	AtAfter         c.Optional[time.Time]
+	AtBefore        c.Optional[time.Time]
+	IsPeriodic      c.Optional[bool]
+	ChannelIDEquals c.Optional[channel.ID]
+	CreatedAfter    c.Optional[time.Time]
+	CreatedBefore   c.Optional[time.Time]
+	OrderBy         reminder.OrderBy
+	Limit           c.Optional[uint]
+	Offset          uint
+	// After continues reading from the NextCursor of a previous result with the same OrderBy.
+	After c.Optional[reminder.Cursor]
 }
 
 func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
@@ -26,9 +37,16 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersRead
+}
+
 type Result struct {
-	Reminders  []reminder.ReminderWithChannels
+	Reminders []reminder.ReminderWithChannels
+	// TotalCount counts all the reminders matching the filters regardless of the page.
 	TotalCount uint
+	// NextCursor is set if there are more reminders after the page.
+	NextCursor c.Optional[reminder.Cursor]
 }
 
 type service struct {
@@ -53,6 +71,11 @@ func New(
 }
 
 func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	if input.After.IsPresent && input.After.Value.OrderBy != input.OrderBy {
+		s.log.Info(ctx, "Cursor was made for another order.", logging.Entry("input", input))
+		return result, reminder.ErrCursorNotValid
+	}
+
 	limit := c.NewOptional[uint](DEFAULT_LIMIT, true)
 	if input.Limit.IsPresent {
 		limit.Value = input.Limit.Value
@@ -61,15 +84,33 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	readOptions := reminder.ReadOptions{
 		CreatedByEquals: c.NewOptional(input.UserID, true),
 		StatusIn:        input.StatusIn,
+		BodySearch:      input.BodySearch,
+		AtAfter:         input.AtAfter,
+		AtBefore:        input.AtBefore,
+		IsPeriodic:      input.IsPeriodic,
+		ChannelIDEquals: input.ChannelIDEquals,
+		CreatedAfter:    input.CreatedAfter,
+		CreatedBefore:   input.CreatedBefore,
 		Limit:           limit,
 		Offset:          input.Offset,
 		OrderBy:         input.OrderBy,
 	}
-	reminders, err := s.reminderRepository.Read(ctx, readOptions)
+	// One more reminder is read to find out if there is a next page.
+	pageOptions := readOptions
+	pageOptions.Limit.Value++
+	pageOptions.After = input.After
+	reminders, err := s.reminderRepository.Read(ctx, pageOptions)
 	if err != nil {
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
+	if len(reminders) > int(limit.Value) {
+		reminders = reminders[:limit.Value]
+		if limit.Value > 0 {
+			lastReminder := reminders[len(reminders)-1].Reminder
+			result.NextCursor = c.NewOptional(reminder.NewCursor(input.OrderBy, lastReminder), true)
+		}
+	}
 	totalCount, err := s.reminderRepository.Count(ctx, readOptions)
 	if err != nil {
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
@@ -0,0 +1,93 @@
+package listuserreminders
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var At = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	reminderRepository *reminder.TestReminderRepository
+	service            services.Service[Input, Result]
+}
+
+func (s *testSuite) SetupTest() {
+	s.reminderRepository = reminder.NewTestReminderRepository()
+	s.reminderRepository.ReadReminders = []reminder.ReminderWithChannels{
+		{Reminder: reminder.Reminder{ID: 1, At: At}},
+		{Reminder: reminder.Reminder{ID: 2, At: At.Add(time.Hour)}},
+		{Reminder: reminder.Reminder{ID: 3, At: At.Add(2 * time.Hour)}},
+	}
+	s.reminderRepository.CountResult = 5
+	s.service = New(logging.NewFakeLogger(), s.reminderRepository)
+}
+
+func TestListUserRemindersService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestNextCursor() {
+	cursor := reminder.Cursor{OrderBy: reminder.OrderByAtAsc, At: At.Add(-time.Hour), ID: 7}
+
+	result, err := s.service.Run(context.Background(), Input{
+		UserID:  USER_ID,
+		OrderBy: reminder.OrderByAtAsc,
+		Limit:   c.NewOptional[uint](2, true),
+		After:   c.NewOptional(cursor, true),
+	})
+
+	s.Nil(err)
+	s.Len(result.Reminders, 2)
+	s.Equal(uint(5), result.TotalCount)
+	s.Equal(
+		c.NewOptional(reminder.Cursor{OrderBy: reminder.OrderByAtAsc, At: At.Add(time.Hour), ID: 2}, true),
+		result.NextCursor,
+	)
+	s.Equal(c.NewOptional[uint](3, true), s.reminderRepository.ReadWith[0].Limit)
+	s.Equal(c.NewOptional(cursor, true), s.reminderRepository.ReadWith[0].After)
+	s.False(s.reminderRepository.CountWith[0].After.IsPresent)
+}
+
+func (s *testSuite) TestLastPage() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID: USER_ID,
+		Limit:  c.NewOptional[uint](3, true),
+	})
+
+	s.Nil(err)
+	s.Len(result.Reminders, 3)
+	s.False(result.NextCursor.IsPresent)
+}
+
+func (s *testSuite) TestZeroLimit() {
+	result, err := s.service.Run(context.Background(), Input{
+		UserID: USER_ID,
+		Limit:  c.NewOptional[uint](0, true),
+	})
+
+	s.Nil(err)
+	s.Empty(result.Reminders)
+	s.False(result.NextCursor.IsPresent)
+}
+
+func (s *testSuite) TestCursorForAnotherOrder() {
+	_, err := s.service.Run(context.Background(), Input{
+		UserID:  USER_ID,
+		OrderBy: reminder.OrderByIDDesc,
+		After:   c.NewOptional(reminder.Cursor{OrderBy: reminder.OrderByAtAsc, ID: 7}, true),
+	})
+
+	s.ErrorIs(err, reminder.ErrCursorNotValid)
+	s.Empty(s.reminderRepository.ReadWith)
+}
@@ -0,0 +1,76 @@
+package listusersessions
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	UserID user.ID
+	// Token of the session the request is made with, the session is marked as current.
+	Token user.SessionToken
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct {
+	Sessions []user.Session
+}
+
+type service struct {
+	log               logging.Logger
+	sessionRepository user.SessionRepository
+	sessionExpiry     user.SessionExpiry
+	now               func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	sessionRepository user.SessionRepository,
+	sessionExpiry user.SessionExpiry,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:               log,
+		sessionRepository: sessionRepository,
+		sessionExpiry:     sessionExpiry,
+		now:               now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	sessions, err := s.sessionRepository.List(ctx, user.ListSessionsInput{
+		UserID:       input.UserID,
+		CurrentToken: input.Token,
+		At:           s.now(),
+		Expiry:       s.sessionExpiry,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"User sessions successfully read.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("sessionCount", len(sessions)),
+	)
+	return Result{Sessions: sessions}, nil
+}
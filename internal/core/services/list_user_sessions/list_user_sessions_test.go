@@ -0,0 +1,76 @@
+package listusersessions
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID       = 1
+	SESSION_TOKEN = "test-session-token"
+)
+
+var (
+	NOW    = time.Now().UTC()
+	EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+)
+
+type testSuite struct {
+	suite.Suite
+	SessionRepository *user.FakeSessionRepository
+	Service           services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.SessionRepository = user.NewFakeSessionRepository(user.NewFakeUserRepository())
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.SessionRepository,
+		EXPIRY,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestListUserSessionsService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestCurrentSessionMarked() {
+	s.createSession(USER_ID, SESSION_TOKEN, NOW)
+	s.createSession(USER_ID, "other-session-token", NOW)
+	s.createSession(USER_ID+1, "other-user-session-token", NOW)
+
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, Token: SESSION_TOKEN})
+
+	s.Nil(err)
+	s.Require().Len(result.Sessions, 2)
+	s.True(result.Sessions[0].IsCurrent)
+	s.False(result.Sessions[1].IsCurrent)
+}
+
+func (s *testSuite) TestExpiredSessionsSkipped() {
+	s.createSession(USER_ID, SESSION_TOKEN, NOW)
+	s.createSession(USER_ID, "idle-session-token", NOW.Add(-EXPIRY.Idle))
+
+	result, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, Token: SESSION_TOKEN})
+
+	s.Nil(err)
+	s.Require().Len(result.Sessions, 1)
+	s.True(result.Sessions[0].IsCurrent)
+}
+
+func (s *testSuite) createSession(userID user.ID, token string, at time.Time) {
+	s.T().Helper()
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    userID,
+		Token:     user.SessionToken(token),
+		CreatedAt: at,
+	})
+	s.Require().Nil(err)
+}
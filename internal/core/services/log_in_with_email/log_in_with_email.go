@@ -12,16 +12,21 @@ import (
 )
 
 type Input struct {
-	Email    c.Email
-	Password user.RawPassword
+	Email     c.Email
+	Password  user.RawPassword
+	UserAgent string
+	IP        string
 }
 
 func (i Input) GetRateLimitKey() string {
 	return "log-in-with-email::" + string(i.Email)
 }
 
+// Result has either a session token or, if the user has TOTP enabled,
+// a challenge token to be exchanged for a session with a two-factor code.
 type Result struct {
-	Token user.SessionToken
+	Token          user.SessionToken
+	ChallengeToken c.Optional[user.TwoFactorChallengeToken]
 }
 
 type service struct {
@@ -30,6 +35,9 @@ type service struct {
 	sessionRepository     user.SessionRepository
 	passwordHasher        user.PasswordHasher
 	sessionTokenGenerator user.SessionTokenGenerator
+	totpRepository        user.TOTPRepository
+	challengeRepository   user.TwoFactorChallengeRepository
+	twoFactorGenerator    user.TwoFactorGenerator
 	now                   func() time.Time
 }
 
@@ -39,6 +47,9 @@ func New(
 	sessionRepository user.SessionRepository,
 	passwordHasher user.PasswordHasher,
 	sessionTokenGenerator user.SessionTokenGenerator,
+	totpRepository user.TOTPRepository,
+	challengeRepository user.TwoFactorChallengeRepository,
+	twoFactorGenerator user.TwoFactorGenerator,
 	now func() time.Time,
 ) services.Service[Input, Result] {
 	if log == nil {
@@ -56,6 +67,15 @@ func New(
 	if sessionTokenGenerator == nil {
 		panic(e.NewNilArgumentError("sessionTokenGenerator"))
 	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if challengeRepository == nil {
+		panic(e.NewNilArgumentError("challengeRepository"))
+	}
+	if twoFactorGenerator == nil {
+		panic(e.NewNilArgumentError("twoFactorGenerator"))
+	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
@@ -65,6 +85,9 @@ func New(
 		sessionRepository:     sessionRepository,
 		passwordHasher:        passwordHasher,
 		sessionTokenGenerator: sessionTokenGenerator,
+		totpRepository:        totpRepository,
+		challengeRepository:   challengeRepository,
+		twoFactorGenerator:    twoFactorGenerator,
 		now:                   now,
 	}
 }
@@ -86,11 +109,24 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, user.ErrUserIsNotActive
 	}
 
+	totp, err := s.totpRepository.Get(ctx, u.ID)
+	if err == nil && totp.IsEnabled() {
+		return s.createChallenge(ctx, u)
+	}
+	if err != nil && !errors.Is(err, user.ErrTOTPNotEnrolled) {
+		if !errors.Is(err, context.Canceled) {
+			s.log.Error(ctx, "Could not get TOTP of user.", logging.Entry("userID", u.ID), logging.Entry("err", err))
+		}
+		return result, err
+	}
+
 	sessionToken := s.sessionTokenGenerator.GenerateSessionToken()
 	err = s.sessionRepository.Create(ctx, user.CreateSessionInput{
 		UserID:    u.ID,
 		Token:     sessionToken,
 		CreatedAt: s.now(),
+		UserAgent: input.UserAgent,
+		IP:        input.IP,
 	})
 	if errors.Is(err, context.Canceled) {
 		return result, err
@@ -112,3 +148,26 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	)
 	return Result{Token: sessionToken}, nil
 }
+
+func (s *service) createChallenge(ctx context.Context, u user.User) (result Result, err error) {
+	challenge := user.TwoFactorChallenge{
+		Token:     s.twoFactorGenerator.GenerateTwoFactorChallengeToken(),
+		UserID:    u.ID,
+		CreatedAt: s.now(),
+	}
+	err = s.challengeRepository.Create(ctx, challenge)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.log.Error(
+				ctx,
+				"Could not create two-factor challenge for user.",
+				logging.Entry("userID", u.ID),
+				logging.Entry("err", err),
+			)
+		}
+		return result, err
+	}
+
+	s.log.Info(ctx, "User password is valid, two-factor challenge created.", logging.Entry("userID", u.ID))
+	return Result{ChallengeToken: c.NewOptional(challenge.Token, true)}, nil
+}
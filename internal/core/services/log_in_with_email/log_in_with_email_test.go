@@ -16,6 +16,7 @@ import (
 const EMAIL = "test@test.test"
 const PASSWORD = "test-password"
 const SESSION_TOKEN = "test-session-token"
+const CHALLENGE_TOKEN = "test-challenge-token"
 
 var NOW time.Time = time.Now().UTC()
 
@@ -26,6 +27,8 @@ type testSuite struct {
 	SessionRepository     *user.FakeSessionRepository
 	PasswordHasher        *user.FakePasswordHasher
 	SessionTokenGenerator *user.FakeSessionTokenGenerator
+	TOTPRepository        *user.FakeTOTPRepository
+	ChallengeRepository   *user.FakeTwoFactorChallengeRepository
 	Service               services.Service[Input, Result]
 }
 
@@ -34,12 +37,17 @@ func (suite *testSuite) SetupTest() {
 	suite.UserRepository = user.NewFakeUserRepository()
 	suite.SessionRepository = user.NewFakeSessionRepository(suite.UserRepository)
 	suite.SessionTokenGenerator = user.NewFakeSessionTokenGenerator(SESSION_TOKEN)
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.ChallengeRepository = user.NewFakeTwoFactorChallengeRepository()
 	suite.Service = New(
 		suite.Logger,
 		suite.UserRepository,
 		suite.SessionRepository,
 		suite.PasswordHasher,
 		suite.SessionTokenGenerator,
+		suite.TOTPRepository,
+		suite.ChallengeRepository,
+		user.NewFakeTwoFactorGenerator("test-secret", CHALLENGE_TOKEN),
 		func() time.Time { return NOW },
 	)
 }
@@ -53,13 +61,56 @@ func (s *testSuite) TestSuccess() {
 
 	result, err := s.Service.Run(
 		context.Background(),
-		Input{Email: c.NewEmail(EMAIL), Password: user.RawPassword(PASSWORD)},
+		Input{
+			Email:     c.NewEmail(EMAIL),
+			Password:  user.RawPassword(PASSWORD),
+			UserAgent: "test-agent",
+			IP:        "127.0.0.1",
+		},
 	)
 
 	s.Nil(err)
-	u, err := s.SessionRepository.GetUserByToken(context.Background(), result.Token)
+	u, err := s.getUserByToken(result.Token)
 	s.Nil(err)
 	s.Equal(activeUser, u)
+	s.Require().Len(s.SessionRepository.Sessions, 1)
+	s.Equal("test-agent", s.SessionRepository.Sessions[0].UserAgent)
+	s.Equal("127.0.0.1", s.SessionRepository.Sessions[0].IP)
+	s.False(result.ChallengeToken.IsPresent)
+}
+
+func (s *testSuite) TestTwoFactorChallenge() {
+	u := s.createUser(true)
+	s.TOTPRepository.TOTPs[u.ID] = user.TOTP{UserID: u.ID, ConfirmedAt: c.NewOptional(NOW, true)}
+
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{Email: c.NewEmail(EMAIL), Password: user.RawPassword(PASSWORD)},
+	)
+
+	s.Nil(err)
+	s.Equal(c.NewOptional(user.TwoFactorChallengeToken(CHALLENGE_TOKEN), true), result.ChallengeToken)
+	s.Empty(result.Token)
+	s.Empty(s.SessionRepository.Sessions)
+	s.Equal(
+		[]user.TwoFactorChallenge{{Token: CHALLENGE_TOKEN, UserID: u.ID, CreatedAt: NOW}},
+		s.ChallengeRepository.Challenges,
+	)
+}
+
+func (s *testSuite) TestNotConfirmedTOTPIsIgnored() {
+	u := s.createUser(true)
+	s.TOTPRepository.TOTPs[u.ID] = user.TOTP{UserID: u.ID}
+
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{Email: c.NewEmail(EMAIL), Password: user.RawPassword(PASSWORD)},
+	)
+
+	s.Nil(err)
+	s.Equal(user.SessionToken(SESSION_TOKEN), result.Token)
+	s.False(result.ChallengeToken.IsPresent)
+	s.Empty(s.ChallengeRepository.Challenges)
 }
 
 func (s *testSuite) TestInvalidPassword() {
@@ -71,7 +122,7 @@ func (s *testSuite) TestInvalidPassword() {
 	)
 
 	s.True(errors.Is(err, user.ErrInvalidCredentials))
-	_, err = s.SessionRepository.GetUserByToken(context.Background(), result.Token)
+	_, err = s.getUserByToken(result.Token)
 	s.True(errors.Is(err, user.ErrUserDoesNotExist))
 }
 
@@ -84,7 +135,7 @@ func (s *testSuite) TestInvalidEmail() {
 	)
 
 	s.True(errors.Is(err, user.ErrInvalidCredentials))
-	_, err = s.SessionRepository.GetUserByToken(context.Background(), result.Token)
+	_, err = s.getUserByToken(result.Token)
 	s.True(errors.Is(err, user.ErrUserDoesNotExist))
 }
 
@@ -97,7 +148,7 @@ func (s *testSuite) TestUserIsNotActive() {
 	)
 
 	s.True(errors.Is(err, user.ErrUserIsNotActive))
-	_, err = s.SessionRepository.GetUserByToken(context.Background(), result.Token)
+	_, err = s.getUserByToken(result.Token)
 	s.True(errors.Is(err, user.ErrUserDoesNotExist))
 }
 
@@ -121,3 +172,11 @@ func (s *testSuite) createUser(isActive bool) user.User {
 	}
 	return u
 }
+
+func (s *testSuite) getUserByToken(token user.SessionToken) (user.User, error) {
+	return s.SessionRepository.GetUserByToken(context.Background(), user.GetUserBySessionTokenInput{
+		Token:  token,
+		At:     NOW,
+		Expiry: user.SessionExpiry{Idle: time.Hour, Absolute: time.Hour},
+	})
+}
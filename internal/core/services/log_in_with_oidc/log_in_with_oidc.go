@@ -0,0 +1,409 @@
+package loginwithoidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	Provider user.OIDCProviderName
+	Code     user.OIDCAuthorizationCode
+	State    user.OIDCState
+	// CookieState is the state bound to the browser which started the authorization.
+	CookieState user.OIDCState
+	UserAgent   string
+	IP          string
+	// TimeZone is used if a new user is created.
+	TimeZone *time.Location
+	// UserID is set when the callback completes linking for the logged in user.
+	UserID c.Optional[user.ID]
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = c.NewOptional(u.ID, true)
+	return i
+}
+
+// Result has either a session token or, if an existing user has TOTP enabled,
+// a challenge token to be exchanged for a session with a two-factor code.
+// Linking keeps the current session, so the result has neither.
+type Result struct {
+	User           user.User
+	Token          user.SessionToken
+	ChallengeToken c.Optional[user.TwoFactorChallengeToken]
+	IsNewUser      bool
+	IsLinked       bool
+}
+
+type service struct {
+	log                     logging.Logger
+	uow                     uow.UnitOfWork
+	providers               map[user.OIDCProviderName]user.OIDCProvider
+	authorizationRepository user.OIDCAuthorizationRepository
+	authorizationTTL        time.Duration
+	identityGenerator       user.IdentityGenerator
+	sessionTokenGenerator   user.SessionTokenGenerator
+	totpRepository          user.TOTPRepository
+	challengeRepository     user.TwoFactorChallengeRepository
+	twoFactorGenerator      user.TwoFactorGenerator
+	planRepository          user.PlanRepository
+	now                     func() time.Time
+	defaultPlan             user.PlanName
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	providers map[user.OIDCProviderName]user.OIDCProvider,
+	authorizationRepository user.OIDCAuthorizationRepository,
+	authorizationTTL time.Duration,
+	identityGenerator user.IdentityGenerator,
+	sessionTokenGenerator user.SessionTokenGenerator,
+	totpRepository user.TOTPRepository,
+	challengeRepository user.TwoFactorChallengeRepository,
+	twoFactorGenerator user.TwoFactorGenerator,
+	planRepository user.PlanRepository,
+	now func() time.Time,
+	defaultPlan user.PlanName,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if providers == nil {
+		panic(e.NewNilArgumentError("providers"))
+	}
+	if authorizationRepository == nil {
+		panic(e.NewNilArgumentError("authorizationRepository"))
+	}
+	if identityGenerator == nil {
+		panic(e.NewNilArgumentError("identityGenerator"))
+	}
+	if sessionTokenGenerator == nil {
+		panic(e.NewNilArgumentError("sessionTokenGenerator"))
+	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if challengeRepository == nil {
+		panic(e.NewNilArgumentError("challengeRepository"))
+	}
+	if twoFactorGenerator == nil {
+		panic(e.NewNilArgumentError("twoFactorGenerator"))
+	}
+	if planRepository == nil {
+		panic(e.NewNilArgumentError("planRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                     log,
+		uow:                     unitOfWork,
+		providers:               providers,
+		authorizationRepository: authorizationRepository,
+		authorizationTTL:        authorizationTTL,
+		identityGenerator:       identityGenerator,
+		sessionTokenGenerator:   sessionTokenGenerator,
+		totpRepository:          totpRepository,
+		challengeRepository:     challengeRepository,
+		twoFactorGenerator:      twoFactorGenerator,
+		planRepository:          planRepository,
+		now:                     now,
+		defaultPlan:             defaultPlan,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	provider, ok := s.providers[input.Provider]
+	if !ok {
+		s.log.Info(ctx, "Unknown OIDC provider.", logging.Entry("input", input))
+		return result, user.ErrUnknownOIDCProvider
+	}
+	if subtle.ConstantTimeCompare([]byte(input.State), []byte(input.CookieState)) != 1 {
+		s.log.Info(ctx, "OIDC state does not match the state cookie.", logging.Entry("input", input))
+		return result, user.ErrInvalidOIDCState
+	}
+
+	authorization, err := s.authorizationRepository.Pop(ctx, input.State, s.now().Add(-s.authorizationTTL))
+	if errors.Is(err, user.ErrInvalidOIDCState) {
+		s.log.Info(ctx, "OIDC state does not exist or is expired.", logging.Entry("input", input))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if authorization.Provider != input.Provider {
+		s.log.Info(
+			ctx,
+			"OIDC state was issued for another provider.",
+			logging.Entry("input", input),
+			logging.Entry("stateProvider", authorization.Provider),
+		)
+		return result, user.ErrInvalidOIDCState
+	}
+	if authorization.UserID != input.UserID {
+		s.log.Info(
+			ctx,
+			"OIDC state was issued for another user.",
+			logging.Entry("input", input),
+			logging.Entry("stateUserID", authorization.UserID),
+		)
+		return result, user.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, input.Code, authorization.CodeVerifier, authorization.Nonce)
+	if errors.Is(err, user.ErrOIDCAuthenticationFailed) {
+		s.log.Info(ctx, "OIDC authentication failed.", logging.Entry("input", input), logging.Entry("err", err))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	uow, err := s.uow.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	u, err := uow.ExternalIdentities().GetUser(ctx, input.Provider, claims.Subject)
+	switch {
+	case err == nil:
+		if authorization.UserID.IsPresent && authorization.UserID.Value != u.ID {
+			s.log.Info(
+				ctx,
+				"External identity is linked to another user.",
+				logging.Entry("input", input),
+				logging.Entry("userID", authorization.UserID.Value),
+			)
+			return result, user.ErrExternalIdentityAlreadyLinked
+		}
+	case !errors.Is(err, user.ErrUserDoesNotExist):
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	case authorization.UserID.IsPresent:
+		u, err = uow.Users().GetByID(ctx, authorization.UserID.Value)
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("userID", authorization.UserID.Value))
+			return result, err
+		}
+		if err = s.linkExternalIdentity(ctx, uow, u, input.Provider, claims); err != nil {
+			return result, err
+		}
+	default:
+		u, err = s.createUser(ctx, uow, input, claims)
+		if err != nil {
+			return result, err
+		}
+		if err = s.linkExternalIdentity(ctx, uow, u, input.Provider, claims); err != nil {
+			return result, err
+		}
+		result.IsNewUser = true
+	}
+
+	if !u.IsActive() {
+		s.log.Info(ctx, "User is not active.", logging.Entry("userID", u.ID))
+		return result, user.ErrUserIsNotActive
+	}
+
+	// Linking is done by a logged in user who already has a session.
+	if authorization.UserID.IsPresent {
+		if err = uow.Commit(ctx); err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+			return result, err
+		}
+		s.log.Info(
+			ctx,
+			"External identity linked with OIDC.",
+			logging.Entry("userID", u.ID),
+			logging.Entry("provider", input.Provider),
+		)
+		return Result{User: u, IsLinked: true}, nil
+	}
+
+	if !result.IsNewUser {
+		totp, err := s.totpRepository.Get(ctx, u.ID)
+		if err == nil && totp.IsEnabled() {
+			return s.createChallenge(ctx, u)
+		}
+		if err != nil && !errors.Is(err, user.ErrTOTPNotEnrolled) {
+			logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+			return result, err
+		}
+	}
+
+	sessionToken := s.sessionTokenGenerator.GenerateSessionToken()
+	err = uow.Sessions().Create(ctx, user.CreateSessionInput{
+		UserID:    u.ID,
+		Token:     sessionToken,
+		CreatedAt: s.now(),
+		UserAgent: input.UserAgent,
+		IP:        input.IP,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return result, err
+	}
+
+	if err = uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"User successfully authenticated with OIDC, session token created.",
+		logging.Entry("userID", u.ID),
+		logging.Entry("provider", input.Provider),
+		logging.Entry("isNewUser", result.IsNewUser),
+	)
+	result.User = u
+	result.Token = sessionToken
+	return result, nil
+}
+
+func (s *service) createChallenge(ctx context.Context, u user.User) (result Result, err error) {
+	challenge := user.TwoFactorChallenge{
+		Token:     s.twoFactorGenerator.GenerateTwoFactorChallengeToken(),
+		UserID:    u.ID,
+		CreatedAt: s.now(),
+	}
+	if err = s.challengeRepository.Create(ctx, challenge); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return result, err
+	}
+	s.log.Info(ctx, "User authenticated with OIDC, two-factor challenge created.", logging.Entry("userID", u.ID))
+	return Result{User: u, ChallengeToken: c.NewOptional(challenge.Token, true)}, nil
+}
+
+func (s *service) linkExternalIdentity(
+	ctx context.Context,
+	uow uow.Context,
+	u user.User,
+	provider user.OIDCProviderName,
+	claims user.OIDCClaims,
+) error {
+	identity, err := uow.ExternalIdentities().Create(ctx, user.CreateExternalIdentityInput{
+		UserID:    u.ID,
+		Provider:  provider,
+		Subject:   claims.Subject,
+		CreatedAt: s.now(),
+	})
+	if errors.Is(err, user.ErrExternalIdentityAlreadyLinked) {
+		s.log.Info(ctx, "External identity is already linked.", logging.Entry("userID", u.ID))
+		return err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return err
+	}
+	s.log.Info(
+		ctx,
+		"External identity linked to user.",
+		logging.Entry("userID", u.ID),
+		logging.Entry("externalIdentityID", identity.ID),
+	)
+	return nil
+}
+
+// createUser creates an active user with limits and channels like activation does,
+// the verified email of the external identity is used if there is one.
+func (s *service) createUser(
+	ctx context.Context,
+	uow uow.Context,
+	input Input,
+	claims user.OIDCClaims,
+) (u user.User, err error) {
+	now := s.now()
+	u, err = uow.Users().Create(ctx, user.CreateUserInput{
+		Email:       claims.Email,
+		Identity:    c.NewOptional(s.identityGenerator.GenerateIdentity(), true),
+		CreatedAt:   now,
+		ActivatedAt: c.NewOptional(now, true),
+		TimeZone:    input.TimeZone,
+	})
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		s.log.Info(ctx, "User with the OIDC email already exists.", logging.Entry("input", input))
+		return u, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return u, err
+	}
+
+	plan, err := s.planRepository.Get(ctx, s.defaultPlan)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("plan", s.defaultPlan))
+		return u, err
+	}
+	_, err = uow.Limits().Create(ctx, user.CreateLimitsInput{
+		UserID: u.ID,
+		Plan:   c.NewOptional(plan.Name, true),
+		Limits: plan.Limits,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID))
+		return u, err
+	}
+
+	if err = s.createChannel(ctx, uow, u, channel.Internal, channel.NewInternalSettings()); err != nil {
+		return u, err
+	}
+	if u.Email.IsPresent {
+		err = s.createChannel(ctx, uow, u, channel.Email, channel.NewEmailSettings(u.Email.Value))
+		if err != nil {
+			return u, err
+		}
+	}
+
+	s.log.Info(ctx, "User created by OIDC login.", logging.Entry("userID", u.ID))
+	return u, nil
+}
+
+func (s *service) createChannel(
+	ctx context.Context,
+	uow uow.Context,
+	u user.User,
+	channelType channel.Type,
+	settings channel.Settings,
+) error {
+	now := s.now()
+	newChannel, err := uow.Channels().Create(
+		ctx,
+		channel.CreateInput{
+			CreatedBy:  u.ID,
+			Type:       channelType,
+			Settings:   settings,
+			CreatedAt:  now,
+			VerifiedAt: c.NewOptional(now, true),
+		},
+	)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", u.ID), logging.Entry("type", channelType))
+		return err
+	}
+	s.log.Info(
+		ctx,
+		"Channel successfully created for the OIDC user.",
+		logging.Entry("userID", u.ID),
+		logging.Entry("channelID", newChannel.ID),
+		logging.Entry("type", channelType),
+	)
+	return nil
+}
@@ -0,0 +1,341 @@
+package loginwithoidc
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	PROVIDER      = user.OIDCProviderName("test")
+	CODE          = "test-code"
+	STATE         = "test-state"
+	NONCE         = "test-nonce"
+	CODE_VERIFIER = "test-code-verifier"
+	SUBJECT       = "test-subject"
+	EMAIL         = "test@test.test"
+	IDENTITY      = "test-identity"
+	SESSION_TOKEN = "test-session-token"
+	CHALLENGE     = "test-challenge-token"
+	TTL           = 10 * time.Minute
+)
+
+var (
+	NOW           = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	DefaultLimits = user.Limits{
+		EmailChannelCount:    c.NewOptional(uint32(3), true),
+		TelegramChannelCount: c.NewOptional(uint32(2), true),
+	}
+)
+
+type testSuite struct {
+	suite.Suite
+	Uow                     *uow.FakeUnitOfWork
+	Provider                *user.FakeOIDCProvider
+	AuthorizationRepository *user.FakeOIDCAuthorizationRepository
+	TOTPRepository          *user.FakeTOTPRepository
+	ChallengeRepository     *user.FakeTwoFactorChallengeRepository
+	Service                 services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Provider = user.NewFakeOIDCProvider(CODE, user.OIDCClaims{
+		Subject: SUBJECT,
+		Email:   c.NewOptional(c.NewEmail(EMAIL), true),
+	})
+	suite.AuthorizationRepository = user.NewFakeOIDCAuthorizationRepository()
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.ChallengeRepository = user.NewFakeTwoFactorChallengeRepository()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow,
+		map[user.OIDCProviderName]user.OIDCProvider{PROVIDER: suite.Provider},
+		suite.AuthorizationRepository,
+		TTL,
+		user.NewFakeIdentityGenerator(IDENTITY),
+		user.NewFakeSessionTokenGenerator(SESSION_TOKEN),
+		suite.TOTPRepository,
+		suite.ChallengeRepository,
+		user.NewFakeTwoFactorGenerator("test-secret", CHALLENGE),
+		user.NewFakePlanRepository(user.Plan{Name: user.PlanFree, Limits: DefaultLimits}),
+		func() time.Time { return NOW },
+		user.PlanFree,
+	)
+}
+
+func TestLogInWithOIDCService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestNewUserCreated() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	result, err := s.Service.Run(context.Background(), s.input())
+
+	assert := s.Require()
+	assert.Nil(err)
+	assert.True(result.IsNewUser)
+	assert.Equal(user.SessionToken(SESSION_TOKEN), result.Token)
+	assert.Equal(c.NewOptional(c.NewEmail(EMAIL), true), result.User.Email)
+	assert.Equal(c.NewOptional(user.Identity(IDENTITY), true), result.User.Identity)
+	assert.False(result.User.PasswordHash.IsPresent)
+	assert.True(result.User.IsActive())
+
+	assert.Equal(
+		[]user.ExternalIdentity{
+			{ID: 1, UserID: result.User.ID, Provider: PROVIDER, Subject: SUBJECT, CreatedAt: NOW},
+		},
+		s.Uow.ExternalIdentities().Identities,
+	)
+	assert.Len(s.Uow.Limits().Created, 1)
+	assert.Len(s.Uow.Channels().Created, 2)
+	assert.Equal(channel.Internal, s.Uow.Channels().Created[0].Type)
+	assert.Equal(channel.Email, s.Uow.Channels().Created[1].Type)
+	assert.True(s.Uow.Channels().Created[1].IsVerified())
+	assert.Len(s.Uow.Sessions().Sessions, 1)
+	assert.Empty(s.AuthorizationRepository.Authorizations)
+	assert.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestNewUserWithoutEmailCreated() {
+	s.Provider.Claims.Email = c.NewOptional(c.Email(""), false)
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	result, err := s.Service.Run(context.Background(), s.input())
+
+	assert := s.Require()
+	assert.Nil(err)
+	assert.True(result.IsNewUser)
+	assert.False(result.User.Email.IsPresent)
+	assert.Len(s.Uow.Channels().Created, 1)
+	assert.Equal(channel.Internal, s.Uow.Channels().Created[0].Type)
+}
+
+func (s *testSuite) TestExistingUserLoggedIn() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	first, err := s.Service.Run(context.Background(), s.input())
+	s.Require().Nil(err)
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	result, err := s.Service.Run(context.Background(), s.input())
+
+	assert := s.Require()
+	assert.Nil(err)
+	assert.False(result.IsNewUser)
+	assert.Equal(first.User.ID, result.User.ID)
+	assert.Len(s.Uow.Users().Users, 1)
+	assert.Len(s.Uow.ExternalIdentities().Identities, 1)
+	assert.Len(s.Uow.Sessions().Sessions, 2)
+}
+
+func (s *testSuite) TestExistingUserWithTOTPChallenged() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	first, err := s.Service.Run(context.Background(), s.input())
+	s.Require().Nil(err)
+	s.TOTPRepository.TOTPs[first.User.ID] = user.TOTP{UserID: first.User.ID, ConfirmedAt: c.NewOptional(NOW, true)}
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	result, err := s.Service.Run(context.Background(), s.input())
+
+	assert := s.Require()
+	assert.Nil(err)
+	assert.Empty(result.Token)
+	assert.Equal(c.NewOptional(user.TwoFactorChallengeToken(CHALLENGE), true), result.ChallengeToken)
+	assert.Equal(first.User.ID, s.ChallengeRepository.Challenges[0].UserID)
+	assert.Len(s.Uow.Sessions().Sessions, 1)
+}
+
+func (s *testSuite) TestIdentityLinkedToAuthenticatedUser() {
+	u := s.createUser("other@test.test")
+	s.authorize(c.NewOptional(u.ID, true), NOW)
+
+	result, err := s.Service.Run(context.Background(), s.linkInput(u))
+
+	assert := s.Require()
+	assert.Nil(err)
+	assert.False(result.IsNewUser)
+	assert.True(result.IsLinked)
+	assert.Equal(u.ID, result.User.ID)
+	assert.Empty(result.Token)
+	assert.Len(s.Uow.Users().Users, 1)
+	assert.Equal(u.ID, s.Uow.ExternalIdentities().Identities[0].UserID)
+	assert.Empty(s.Uow.Channels().Created)
+	assert.Empty(s.Uow.Sessions().Sessions)
+	assert.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestLinkWithoutAuthenticatedUser() {
+	u := s.createUser("other@test.test")
+	s.authorize(c.NewOptional(u.ID, true), NOW)
+
+	_, err := s.Service.Run(context.Background(), s.input())
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+	s.Empty(s.Uow.ExternalIdentities().Identities)
+	s.Empty(s.Uow.Sessions().Sessions)
+}
+
+func (s *testSuite) TestLinkByOtherUser() {
+	u := s.createUser("other@test.test")
+	s.authorize(c.NewOptional(u.ID, true), NOW)
+
+	_, err := s.Service.Run(context.Background(), s.linkInput(user.User{ID: u.ID + 1}))
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+	s.Empty(s.Uow.ExternalIdentities().Identities)
+}
+
+func (s *testSuite) TestLoginStateUsedForLink() {
+	u := s.createUser("other@test.test")
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	_, err := s.Service.Run(context.Background(), s.linkInput(u))
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+	s.Empty(s.Uow.ExternalIdentities().Identities)
+}
+
+func (s *testSuite) TestIdentityLinkedToOtherUser() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	_, err := s.Service.Run(context.Background(), s.input())
+	s.Require().Nil(err)
+	u := s.createUser("other@test.test")
+	s.authorize(c.NewOptional(u.ID, true), NOW)
+
+	_, err = s.Service.Run(context.Background(), s.linkInput(u))
+
+	s.ErrorIs(err, user.ErrExternalIdentityAlreadyLinked)
+}
+
+func (s *testSuite) TestEmailAlreadyExists() {
+	s.createUser(EMAIL)
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+
+	_, err := s.Service.Run(context.Background(), s.input())
+
+	s.ErrorIs(err, user.ErrEmailAlreadyExists)
+	s.Empty(s.Uow.ExternalIdentities().Identities)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestInvalidState() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	input := s.input()
+	input.State = "other-state"
+	input.CookieState = "other-state"
+
+	_, err := s.Service.Run(context.Background(), input)
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+}
+
+func (s *testSuite) TestStateCookieMismatch() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	input := s.input()
+	input.CookieState = "other-state"
+
+	_, err := s.Service.Run(context.Background(), input)
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+	s.Len(s.AuthorizationRepository.Authorizations, 1)
+	s.Empty(s.Uow.Users().Users)
+}
+
+func (s *testSuite) TestExpiredState() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW.Add(-TTL))
+
+	_, err := s.Service.Run(context.Background(), s.input())
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+	s.Empty(s.AuthorizationRepository.Authorizations)
+}
+
+func (s *testSuite) TestStateUsedOnce() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	_, err := s.Service.Run(context.Background(), s.input())
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(context.Background(), s.input())
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+}
+
+func (s *testSuite) TestStateOfOtherProvider() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	s.AuthorizationRepository.Authorizations[0].Provider = "other"
+
+	_, err := s.Service.Run(context.Background(), s.input())
+
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+}
+
+func (s *testSuite) TestInvalidCode() {
+	s.authorize(c.NewOptional(user.ID(0), false), NOW)
+	input := s.input()
+	input.Code = "other-code"
+
+	_, err := s.Service.Run(context.Background(), input)
+
+	s.ErrorIs(err, user.ErrOIDCAuthenticationFailed)
+	s.Empty(s.Uow.Users().Users)
+}
+
+func (s *testSuite) TestUnknownProvider() {
+	input := s.input()
+	input.Provider = "unknown"
+
+	_, err := s.Service.Run(context.Background(), input)
+
+	s.ErrorIs(err, user.ErrUnknownOIDCProvider)
+}
+
+func (s *testSuite) input() Input {
+	return Input{
+		Provider:    PROVIDER,
+		Code:        CODE,
+		State:       STATE,
+		CookieState: STATE,
+		TimeZone:    time.UTC,
+	}
+}
+
+func (s *testSuite) linkInput(u user.User) Input {
+	return s.input().WithAuthenticatedUser(u).(Input)
+}
+
+// authorize stores the authorization and primes the provider like the start of the login does.
+func (s *testSuite) authorize(userID c.Optional[user.ID], at time.Time) {
+	s.T().Helper()
+	codeVerifier := user.OIDCCodeVerifier(CODE_VERIFIER)
+	s.Provider.AuthorizationURL(STATE, NONCE, codeVerifier.Challenge())
+	err := s.AuthorizationRepository.Create(context.Background(), user.OIDCAuthorization{
+		State:        STATE,
+		Provider:     PROVIDER,
+		Nonce:        NONCE,
+		CodeVerifier: codeVerifier,
+		UserID:       userID,
+		CreatedAt:    at,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.Uow.Users().Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(email), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+	return u
+}
@@ -0,0 +1,64 @@
+package loginwithtwofactor
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type serviceWithChallenge struct {
+	log                 logging.Logger
+	challengeRepository user.TwoFactorChallengeRepository
+	challengeTTL        time.Duration
+	now                 func() time.Time
+	inner               services.Service[Input, Result]
+}
+
+// NewWithChallenge resolves the challenge token of the input before running inner, so that
+// the services wrapping inner, e.g. rate limiting, can use the user of the challenge.
+func NewWithChallenge(
+	log logging.Logger,
+	challengeRepository user.TwoFactorChallengeRepository,
+	challengeTTL time.Duration,
+	now func() time.Time,
+	inner services.Service[Input, Result],
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if challengeRepository == nil {
+		panic(e.NewNilArgumentError("challengeRepository"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	if inner == nil {
+		panic(e.NewNilArgumentError("inner"))
+	}
+	return &serviceWithChallenge{
+		log:                 log,
+		challengeRepository: challengeRepository,
+		challengeTTL:        challengeTTL,
+		now:                 now,
+		inner:               inner,
+	}
+}
+
+func (s *serviceWithChallenge) Run(ctx context.Context, input Input) (result Result, err error) {
+	challenge, err := s.challengeRepository.Get(ctx, input.ChallengeToken, s.now().Add(-s.challengeTTL))
+	if errors.Is(err, user.ErrInvalidTwoFactorChallenge) {
+		s.log.Info(ctx, "Two-factor challenge does not exist or is expired.")
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	input.Challenge = challenge
+	return s.inner.Run(ctx, input)
+}
@@ -0,0 +1,143 @@
+package loginwithtwofactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct {
+	ChallengeToken user.TwoFactorChallengeToken
+	Code           user.TwoFactorCode
+	UserAgent      string
+	IP             string
+	// Challenge is set by the service of NewWithChallenge.
+	Challenge user.TwoFactorChallenge
+}
+
+// GetRateLimitKey limits attempts per user, a new challenge must not reset the count of guesses.
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("log-in-with-two-factor::%d", i.Challenge.UserID)
+}
+
+type Result struct {
+	Token user.SessionToken
+}
+
+type service struct {
+	log                   logging.Logger
+	totpRepository        user.TOTPRepository
+	challengeRepository   user.TwoFactorChallengeRepository
+	sessionRepository     user.SessionRepository
+	totpAuthenticator     user.TOTPAuthenticator
+	challengeTTL          time.Duration
+	sessionTokenGenerator user.SessionTokenGenerator
+	now                   func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	totpRepository user.TOTPRepository,
+	challengeRepository user.TwoFactorChallengeRepository,
+	sessionRepository user.SessionRepository,
+	totpAuthenticator user.TOTPAuthenticator,
+	challengeTTL time.Duration,
+	sessionTokenGenerator user.SessionTokenGenerator,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if totpRepository == nil {
+		panic(e.NewNilArgumentError("totpRepository"))
+	}
+	if challengeRepository == nil {
+		panic(e.NewNilArgumentError("challengeRepository"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	if totpAuthenticator == nil {
+		panic(e.NewNilArgumentError("totpAuthenticator"))
+	}
+	if sessionTokenGenerator == nil {
+		panic(e.NewNilArgumentError("sessionTokenGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                   log,
+		totpRepository:        totpRepository,
+		challengeRepository:   challengeRepository,
+		sessionRepository:     sessionRepository,
+		totpAuthenticator:     totpAuthenticator,
+		challengeTTL:          challengeTTL,
+		sessionTokenGenerator: sessionTokenGenerator,
+		now:                   now,
+	}
+}
+
+// Run expects the challenge of the input to be set by the service of NewWithChallenge.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	createdSince := now.Add(-s.challengeTTL)
+	challenge := input.Challenge
+
+	totp, err := s.totpRepository.Get(ctx, challenge.UserID)
+	if errors.Is(err, user.ErrTOTPNotEnrolled) || (err == nil && !totp.IsEnabled()) {
+		s.log.Info(ctx, "TOTP was disabled after the challenge was created.", logging.Entry("userID", challenge.UserID))
+		return result, user.ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+
+	err = user.VerifyTwoFactorCode(ctx, s.totpRepository, s.totpAuthenticator, totp, input.Code, now)
+	if errors.Is(err, user.ErrInvalidTwoFactorCode) {
+		s.log.Info(ctx, "Invalid two-factor code.", logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+
+	// The challenge is popped only after a valid code, so a mistyped code does not
+	// require entering the password again.
+	_, err = s.challengeRepository.Pop(ctx, input.ChallengeToken, createdSince)
+	if errors.Is(err, user.ErrInvalidTwoFactorChallenge) {
+		s.log.Info(ctx, "Two-factor challenge is already used.", logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+
+	sessionToken := s.sessionTokenGenerator.GenerateSessionToken()
+	err = s.sessionRepository.Create(ctx, user.CreateSessionInput{
+		UserID:    challenge.UserID,
+		Token:     sessionToken,
+		CreatedAt: now,
+		UserAgent: input.UserAgent,
+		IP:        input.IP,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", challenge.UserID))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"User successfully authenticated with two-factor code, session token created.",
+		logging.Entry("userID", challenge.UserID),
+	)
+	return Result{Token: sessionToken}, nil
+}
@@ -0,0 +1,190 @@
+package loginwithtwofactor
+
+import (
+	"context"
+	"fmt"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	ratelimiting "remindme/internal/core/services/rate_limiting"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	TOTP_CODE       = "123456"
+	CHALLENGE_TOKEN = "test-challenge-token"
+	SESSION_TOKEN   = "test-session-token"
+	RECOVERY_CODE   = "abcdefghij"
+	CHALLENGE_TTL   = 5 * time.Minute
+)
+
+var NOW time.Time = time.Now().UTC()
+
+type testSuite struct {
+	suite.Suite
+	Logger              *logging.FakeLogger
+	UserRepository      *user.FakeUserRepository
+	SessionRepository   *user.FakeSessionRepository
+	TOTPRepository      *user.FakeTOTPRepository
+	ChallengeRepository *user.FakeTwoFactorChallengeRepository
+	RateLimiter         *ratelimiter.FakeRateLimiter
+	Service             services.Service[Input, Result]
+	User                user.User
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Logger = logging.NewFakeLogger()
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.SessionRepository = user.NewFakeSessionRepository(suite.UserRepository)
+	suite.TOTPRepository = user.NewFakeTOTPRepository()
+	suite.ChallengeRepository = user.NewFakeTwoFactorChallengeRepository()
+	suite.RateLimiter = ratelimiter.NewFakeRateLimiter(true)
+	now := func() time.Time { return NOW }
+	suite.Service = NewWithChallenge(
+		suite.Logger,
+		suite.ChallengeRepository,
+		CHALLENGE_TTL,
+		now,
+		ratelimiting.WithRateLimiting(
+			suite.Logger,
+			suite.RateLimiter,
+			ratelimiter.Limit{Interval: ratelimiter.Minute, Value: 5},
+			New(
+				suite.Logger,
+				suite.TOTPRepository,
+				suite.ChallengeRepository,
+				suite.SessionRepository,
+				user.NewFakeTOTPAuthenticator(TOTP_CODE),
+				CHALLENGE_TTL,
+				user.NewFakeSessionTokenGenerator(SESSION_TOKEN),
+				now,
+			),
+		),
+	)
+
+	u, err := suite.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail("test@test.test"), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+	})
+	suite.Require().Nil(err)
+	suite.User = u
+	suite.TOTPRepository.TOTPs[u.ID] = user.TOTP{
+		UserID:      u.ID,
+		Secret:      "test-secret",
+		CreatedAt:   NOW,
+		ConfirmedAt: c.NewOptional(NOW, true),
+	}
+	suite.TOTPRepository.RecoveryCodes[u.ID] = []user.RecoveryCode{RECOVERY_CODE}
+	suite.ChallengeRepository.Challenges = []user.TwoFactorChallenge{
+		{Token: CHALLENGE_TOKEN, UserID: u.ID, CreatedAt: NOW.Add(-time.Minute)},
+	}
+}
+
+func TestLogInWithTwoFactorService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccessWithTOTPCode() {
+	result, err := s.Service.Run(context.Background(), Input{
+		ChallengeToken: CHALLENGE_TOKEN,
+		Code:           TOTP_CODE,
+		UserAgent:      "test-agent",
+		IP:             "127.0.0.1",
+	})
+
+	s.Nil(err)
+	s.Equal(user.SessionToken(SESSION_TOKEN), result.Token)
+	s.Require().Len(s.SessionRepository.Sessions, 1)
+	s.Equal(s.User.ID, s.SessionRepository.Sessions[0].UserID)
+	s.Equal("test-agent", s.SessionRepository.Sessions[0].UserAgent)
+	s.Empty(s.ChallengeRepository.Challenges)
+	s.Equal(NOW.Unix()/30, s.TOTPRepository.TOTPs[s.User.ID].LastUsedStep)
+}
+
+func (s *testSuite) TestSuccessWithRecoveryCode() {
+	result, err := s.Service.Run(context.Background(), Input{
+		ChallengeToken: CHALLENGE_TOKEN,
+		Code:           "ABCDE-FGHIJ",
+	})
+
+	s.Nil(err)
+	s.Equal(user.SessionToken(SESSION_TOKEN), result.Token)
+	s.Empty(s.TOTPRepository.RecoveryCodes[s.User.ID])
+}
+
+func (s *testSuite) TestInvalidCodeKeepsChallenge() {
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: "654321"})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorCode)
+	s.Empty(s.SessionRepository.Sessions)
+	s.Len(s.ChallengeRepository.Challenges, 1)
+}
+
+func (s *testSuite) TestTOTPCodeIsAcceptedOnce() {
+	s.TOTPRepository.TOTPs[s.User.ID] = user.TOTP{
+		UserID:       s.User.ID,
+		ConfirmedAt:  c.NewOptional(NOW, true),
+		LastUsedStep: NOW.Unix() / 30,
+	}
+
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorCode)
+	s.Empty(s.SessionRepository.Sessions)
+}
+
+func (s *testSuite) TestExpiredChallenge() {
+	s.ChallengeRepository.Challenges[0].CreatedAt = NOW.Add(-CHALLENGE_TTL)
+
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorChallenge)
+	s.Empty(s.SessionRepository.Sessions)
+}
+
+func (s *testSuite) TestUnknownChallenge() {
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: "other-token", Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorChallenge)
+}
+
+func (s *testSuite) TestDisabledTOTP() {
+	delete(s.TOTPRepository.TOTPs, s.User.ID)
+
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: TOTP_CODE})
+
+	s.ErrorIs(err, user.ErrInvalidTwoFactorChallenge)
+	s.Empty(s.SessionRepository.Sessions)
+}
+
+func (s *testSuite) TestRateLimitedPerUser() {
+	s.ChallengeRepository.Challenges = append(
+		s.ChallengeRepository.Challenges,
+		user.TwoFactorChallenge{Token: "other-token", UserID: s.User.ID, CreatedAt: NOW},
+	)
+
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: "654321"})
+	s.Require().ErrorIs(err, user.ErrInvalidTwoFactorCode)
+	_, err = s.Service.Run(context.Background(), Input{ChallengeToken: "other-token", Code: "654321"})
+	s.Require().ErrorIs(err, user.ErrInvalidTwoFactorCode)
+
+	key := fmt.Sprintf("log-in-with-two-factor::%d", s.User.ID)
+	s.Equal([]string{key, key}, s.RateLimiter.Keys)
+}
+
+func (s *testSuite) TestRateLimitExceeded() {
+	s.RateLimiter.IsAllowed = false
+
+	_, err := s.Service.Run(context.Background(), Input{ChallengeToken: CHALLENGE_TOKEN, Code: TOTP_CODE})
+
+	s.ErrorIs(err, ratelimiter.ErrRateLimitExceeded)
+	s.Empty(s.SessionRepository.Sessions)
+	s.Len(s.ChallengeRepository.Challenges, 1)
+}
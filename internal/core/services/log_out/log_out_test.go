@@ -98,7 +98,11 @@ func (s *testSuite) createUserAndSession() user.User {
 
 func (s *testSuite) sessionExists(token user.SessionToken) bool {
 	s.T().Helper()
-	_, err := s.SessionRepository.GetUserByToken(context.Background(), user.SessionToken(SESSION_TOKEN))
+	_, err := s.SessionRepository.GetUserByToken(context.Background(), user.GetUserBySessionTokenInput{
+		Token:  token,
+		At:     NOW,
+		Expiry: user.SessionExpiry{Idle: time.Hour, Absolute: time.Hour},
+	})
 	if errors.Is(err, user.ErrUserDoesNotExist) {
 		return false
 	}
@@ -0,0 +1,136 @@
+package overridelimits
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	"time"
+)
+
+// Input holds the limits to override, a limit which is not present is removed, i.e. unlimited.
+type Input struct {
+	Actor  string
+	UserID user.ID
+
+	DoEmailChannelCountUpdate        bool
+	EmailChannelCount                c.Optional[uint32]
+	DoTelegramChannelCountUpdate     bool
+	TelegramChannelCount             c.Optional[uint32]
+	DoActiveReminderCountUpdate      bool
+	ActiveReminderCount              c.Optional[uint32]
+	DoMonthlySentReminderCountUpdate bool
+	MonthlySentReminderCount         c.Optional[uint32]
+	DoReminderEveryPerDayCountUpdate bool
+	ReminderEveryPerDayCount         c.Optional[float64]
+}
+
+func (i Input) WithAdmin(actor string) admin.Input {
+	i.Actor = actor
+	return i
+}
+
+func (i Input) apply(limits user.Limits) user.Limits {
+	if i.DoEmailChannelCountUpdate {
+		limits.EmailChannelCount = i.EmailChannelCount
+	}
+	if i.DoTelegramChannelCountUpdate {
+		limits.TelegramChannelCount = i.TelegramChannelCount
+	}
+	if i.DoActiveReminderCountUpdate {
+		limits.ActiveReminderCount = i.ActiveReminderCount
+	}
+	if i.DoMonthlySentReminderCountUpdate {
+		limits.MonthlySentReminderCount = i.MonthlySentReminderCount
+	}
+	if i.DoReminderEveryPerDayCountUpdate {
+		limits.ReminderEveryPerDayCount = i.ReminderEveryPerDayCount
+	}
+	return limits
+}
+
+type Result struct {
+	UserPlan user.UserPlan
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	now        func() time.Time
+}
+
+// New overrides individual user limits, the plan of the user is kept.
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		now:        now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	ctx = logging.WithUserID(ctx, int64(input.UserID))
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	before, err := uow.Limits().GetUserPlanWithLock(ctx, input.UserID)
+	if errors.Is(err, user.ErrLimitsDoNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	limits, err := uow.Limits().Update(ctx, user.UpdateLimitsInput{
+		UserID: input.UserID,
+		Plan:   before.Plan,
+		Limits: input.apply(before.Limits),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	_, err = uow.LimitsAudit().Create(ctx, user.CreateLimitsAuditEntryInput{
+		UserID:    input.UserID,
+		Actor:     input.Actor,
+		Action:    user.LimitsAuditActionOverrideLimits,
+		Plan:      before.Plan,
+		Before:    before.Limits,
+		After:     limits,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	s.log.Info(ctx, "User limits have been overridden.", logging.Entry("actor", input.Actor))
+	result.UserPlan = user.UserPlan{UserID: input.UserID, Plan: before.Plan, Limits: limits}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package overridelimits
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID = user.ID(1)
+	ACTOR   = "test-admin"
+)
+
+var (
+	NOW        = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	FreeLimits = user.Limits{
+		EmailChannelCount:   c.NewOptional(uint32(1), true),
+		ActiveReminderCount: c.NewOptional(uint32(10), true),
+	}
+)
+
+type testSuite struct {
+	suite.Suite
+	Uow     *uow.FakeUnitOfWork
+	Service services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Uow.Limits().Plan = c.NewOptional(user.PlanFree, true)
+	suite.Uow.Limits().Limits = FreeLimits
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestOverrideLimitsService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	expectedLimits := user.Limits{
+		EmailChannelCount:        c.NewOptional(uint32(1), true),
+		MonthlySentReminderCount: c.NewOptional(uint32(500), true),
+	}
+
+	result, err := s.Service.Run(context.Background(), Input{
+		Actor:                            ACTOR,
+		UserID:                           USER_ID,
+		DoActiveReminderCountUpdate:      true,
+		DoMonthlySentReminderCountUpdate: true,
+		MonthlySentReminderCount:         c.NewOptional(uint32(500), true),
+	})
+
+	s.Nil(err)
+	s.Equal(
+		user.UserPlan{UserID: USER_ID, Plan: c.NewOptional(user.PlanFree, true), Limits: expectedLimits},
+		result.UserPlan,
+	)
+	s.Equal(
+		[]user.UpdateLimitsInput{{
+			UserID: USER_ID,
+			Plan:   c.NewOptional(user.PlanFree, true),
+			Limits: expectedLimits,
+		}},
+		s.Uow.Limits().Updated,
+	)
+	s.Equal(
+		[]user.LimitsAuditEntry{{
+			ID:        1,
+			UserID:    USER_ID,
+			Actor:     ACTOR,
+			Action:    user.LimitsAuditActionOverrideLimits,
+			Plan:      c.NewOptional(user.PlanFree, true),
+			Before:    FreeLimits,
+			After:     expectedLimits,
+			CreatedAt: NOW,
+		}},
+		s.Uow.Context.LimitsAuditRepository.Entries,
+	)
+	s.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestUpdateFailed() {
+	s.Uow.Limits().ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{Actor: ACTOR, UserID: USER_ID})
+
+	s.NotNil(err)
+	s.Empty(s.Uow.Context.LimitsAuditRepository.Entries)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
@@ -0,0 +1,109 @@
+package pausereminder
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID     user.ID
+	ReminderID reminder.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
+type Result struct {
+	Reminder reminder.ReminderWithChannels
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	reminderRepository := uow.Reminders()
+	reminderRepository.Lock(ctx, input.ReminderID)
+	rem, err := reminderRepository.GetByID(ctx, input.ReminderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, reminder.ErrReminderDoesNotExist):
+			s.log.Info(ctx, "Reminder not found.", logging.Entry("input", input))
+		default:
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		}
+		return result, err
+	}
+
+	if rem.CreatedBy != input.UserID {
+		s.log.Info(ctx, "Reminder belongs to another user.", logging.Entry("input", input))
+		return result, reminder.ErrReminderPermission
+	}
+	update, err := reminder.PauseUpdate(rem.Reminder)
+	if err != nil {
+		s.log.Info(
+			ctx,
+			"Reminder can't be paused.",
+			logging.Entry("input", input),
+			logging.Entry("status", rem.Status),
+			logging.Entry("err", err),
+		)
+		return result, err
+	}
+
+	updatedReminder, err := reminderRepository.Update(ctx, update)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, updatedReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	s.log.Info(ctx, "Reminder has been successfully paused.", logging.Entry("input", input))
+	result.Reminder.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package pausereminder
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID     = user.ID(42)
+	REMINDER_ID = reminder.ID(77)
+)
+
+type testSuite struct {
+	suite.Suite
+	logger     *logging.FakeLogger
+	unitOfWork *uow.FakeUnitOfWork
+	service    services.Service[Input, Result]
+}
+
+func (s *testSuite) SetupTest() {
+	s.logger = logging.NewFakeLogger()
+	s.unitOfWork = uow.NewFakeUnitOfWork()
+	s.unitOfWork.Reminders().GetByIDReminder = reminder.ReminderWithChannels{
+		Reminder: reminder.Reminder{
+			CreatedBy: USER_ID,
+			Status:    reminder.StatusScheduled,
+			Every:     c.NewOptional(reminder.EveryDay, true),
+		},
+		ChannelIDs: []channel.ID{1},
+	}
+	s.unitOfWork.Reminders().ReminderBeforeUpdate = s.unitOfWork.Reminders().GetByIDReminder.Reminder
+	s.unitOfWork.Usage().ActiveCount = 1
+	s.service = New(s.logger, s.unitOfWork)
+}
+
+func TestPauseReminderService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestPauseSuccess() {
+	result, err := s.service.Run(context.Background(), Input{UserID: USER_ID, ReminderID: REMINDER_ID})
+
+	s.Nil(err)
+	s.Equal(REMINDER_ID, result.Reminder.ID)
+	s.Equal(reminder.StatusPaused, result.Reminder.Status)
+	s.False(result.Reminder.ScheduledAt.IsPresent)
+	s.Equal([]channel.ID{1}, result.Reminder.ChannelIDs)
+	s.Equal(uint(0), s.unitOfWork.Usage().ActiveCount)
+	s.Equal(uint(1), s.unitOfWork.Usage().PausedCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestPauseError() {
+	cases := []struct {
+		id            string
+		userID        user.ID
+		status        reminder.Status
+		every         c.Optional[reminder.Every]
+		expectedError error
+	}{
+		{
+			id:            "another user",
+			userID:        USER_ID + 1,
+			status:        reminder.StatusScheduled,
+			every:         c.NewOptional(reminder.EveryDay, true),
+			expectedError: reminder.ErrReminderPermission,
+		},
+		{
+			id:            "not active",
+			userID:        USER_ID,
+			status:        reminder.StatusPaused,
+			every:         c.NewOptional(reminder.EveryDay, true),
+			expectedError: reminder.ErrReminderNotActive,
+		},
+		{
+			id:            "not periodic",
+			userID:        USER_ID,
+			status:        reminder.StatusCreated,
+			expectedError: reminder.ErrReminderNotPeriodic,
+		},
+	}
+
+	for _, testcase := range cases {
+		s.Run(testcase.id, func() {
+			s.SetupTest()
+			s.unitOfWork.Reminders().GetByIDReminder.Status = testcase.status
+			s.unitOfWork.Reminders().GetByIDReminder.Every = testcase.every
+
+			_, err := s.service.Run(context.Background(), Input{UserID: testcase.userID, ReminderID: REMINDER_ID})
+
+			s.ErrorIs(err, testcase.expectedError)
+			s.False(s.unitOfWork.Context.WasCommitCalled)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package purgeaccounts
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct{}
+
+type Result struct {
+	Purged int
+	Failed int
+}
+
+type service struct {
+	log                logging.Logger
+	deletionRepository user.AccountDeletionRepository
+	unitOfWork         uow.UnitOfWork
+	batchSize          uint
+	now                func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	deletionRepository user.AccountDeletionRepository,
+	unitOfWork uow.UnitOfWork,
+	batchSize uint,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if deletionRepository == nil {
+		panic(e.NewNilArgumentError("deletionRepository"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if batchSize == 0 {
+		panic("batch size must be positive")
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                log,
+		deletionRepository: deletionRepository,
+		unitOfWork:         unitOfWork,
+		batchSize:          batchSize,
+		now:                now,
+	}
+}
+
+// Run purges a batch of accounts which deletion is due, every account is purged in its own transaction.
+// Accounts that could not be purged are retried by the next run.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	deletions, err := s.deletionRepository.ReadDue(ctx, now, s.batchSize)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	for _, deletion := range deletions {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		userCtx := logging.WithUserID(ctx, int64(deletion.UserID))
+		if err := s.purge(userCtx, deletion.UserID, now); err != nil {
+			logging.Error(userCtx, s.log, err)
+			result.Failed++
+			continue
+		}
+		result.Purged++
+	}
+	s.log.Info(
+		ctx,
+		"Accounts purge run finished.",
+		logging.Entry("purgedCount", result.Purged),
+		logging.Entry("failedCount", result.Failed),
+	)
+	return result, nil
+}
+
+// purge deletes the user with all the data. Queued reminders are locked first, so the reminders
+// being prepared for sending are handled before. The others are canceled by the deletion itself:
+// they are deleted with the user, so their queue messages are acked by the consumer without sending
+// as the sender skips reminders which do not exist anymore.
+func (s *service) purge(ctx context.Context, userID user.ID, now time.Time) error {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Rollback(ctx)
+
+	deletion, err := uow.AccountDeletions().GetWithLock(ctx, userID)
+	if errors.Is(err, user.ErrAccountDeletionNotRequested) {
+		s.log.Info(ctx, "Account deletion has been canceled, skip purging.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !deletion.IsDue(now) {
+		return nil
+	}
+
+	reminders, err := uow.Reminders().Read(ctx, reminder.ReadOptions{
+		CreatedByEquals: c.NewOptional(userID, true),
+		StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusScheduled, reminder.StatusSending}, true),
+	})
+	if err != nil {
+		return err
+	}
+	for _, rem := range reminders {
+		if err := uow.Reminders().Lock(ctx, rem.ID); err != nil {
+			return err
+		}
+	}
+	if err := uow.Users().Delete(ctx, userID); err != nil {
+		return err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		return err
+	}
+	s.log.Info(
+		ctx,
+		"Account has been purged.",
+		logging.Entry("requestedAt", deletion.RequestedAt),
+		logging.Entry("canceledReminderCount", len(reminders)),
+	)
+	return nil
+}
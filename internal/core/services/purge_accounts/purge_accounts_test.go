@@ -0,0 +1,98 @@
+package purgeaccounts
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	Uow     *uow.FakeUnitOfWork
+	Service services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow.AccountDeletions(),
+		suite.Uow,
+		10,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestPurgeAccountsService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestDueAccountPurged() {
+	u := s.createUser("test@test.test")
+	s.createDeletion(u.ID, NOW)
+	s.Uow.Reminders().ReadReminders = []reminder.ReminderWithChannels{
+		{Reminder: reminder.Reminder{ID: 1, CreatedBy: u.ID, Status: reminder.StatusScheduled}},
+	}
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{Purged: 1}, result)
+	s.Empty(s.Uow.Users().Users)
+	s.Equal([]reminder.ID{1}, s.Uow.Reminders().LockWith)
+	s.Equal(c.NewOptional(u.ID, true), s.Uow.Reminders().ReadWith[0].CreatedByEquals)
+	s.True(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestNotDueAccountKept() {
+	u := s.createUser("test@test.test")
+	s.createDeletion(u.ID, NOW.Add(time.Second))
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{}, result)
+	s.Len(s.Uow.Users().Users, 1)
+}
+
+func (s *testSuite) TestFailedPurgeCounted() {
+	u := s.createUser("test@test.test")
+	s.createDeletion(u.ID, NOW)
+	s.Uow.Users().ReturnError = true
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{Failed: 1}, result)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) createDeletion(userID user.ID, purgeAt time.Time) {
+	s.T().Helper()
+	err := s.Uow.AccountDeletions().Create(context.Background(), user.AccountDeletion{
+		UserID:      userID,
+		RequestedAt: NOW.Add(-time.Hour),
+		PurgeAt:     purgeAt,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.Uow.Users().Create(context.Background(), user.CreateUserInput{
+		Email:     c.NewOptional(c.NewEmail(email), true),
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	return u
+}
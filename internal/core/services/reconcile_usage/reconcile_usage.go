@@ -0,0 +1,109 @@
+package reconcileusage
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct{}
+
+type Result struct {
+	Users       uint
+	ActiveFixed uint
+	SentFixed   uint
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	batchSize  uint
+	now        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	batchSize uint,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if batchSize == 0 {
+		panic("batch size must be positive")
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		batchSize:  batchSize,
+		now:        now,
+	}
+}
+
+// Run recomputes usage counters of all users from reminders in batches, each batch in its own
+// transaction. Counters may drift only because of bugs or manual changes in the DB, so fixes are logged.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	sentAfter := s.now().Add(-reminder.SENT_USAGE_RETENTION)
+	afterUserID := user.ID(0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		batch, err := s.reconcileBatch(ctx, reminder.ReconcileUsageInput{
+			AfterUserID: afterUserID,
+			Limit:       s.batchSize,
+			SentAfter:   sentAfter,
+		})
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("afterUserID", afterUserID), logging.Entry("result", result))
+			return result, err
+		}
+		result.Users += uint(len(batch.UserIDs))
+		result.ActiveFixed += batch.ActiveFixed
+		result.SentFixed += batch.SentFixed
+		for _, userID := range batch.UserIDs {
+			if userID > afterUserID {
+				afterUserID = userID
+			}
+		}
+		if uint(len(batch.UserIDs)) < s.batchSize {
+			break
+		}
+	}
+
+	if result.ActiveFixed > 0 || result.SentFixed > 0 {
+		s.log.Warning(ctx, "Usage counters have been fixed.", logging.Entry("result", result))
+	} else {
+		s.log.Info(ctx, "Usage counters are consistent.", logging.Entry("result", result))
+	}
+	return result, nil
+}
+
+func (s *service) reconcileBatch(
+	ctx context.Context,
+	input reminder.ReconcileUsageInput,
+) (result reminder.ReconcileUsageResult, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	result, err = uow.Usage().Reconcile(ctx, input)
+	if err != nil {
+		return result, err
+	}
+	return result, uow.Commit(ctx)
+}
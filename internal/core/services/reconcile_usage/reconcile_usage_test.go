@@ -0,0 +1,69 @@
+package reconcileusage
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	UnitOfWork *uow.FakeUnitOfWork
+	Service    services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.UnitOfWork = uow.NewFakeUnitOfWork()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.UnitOfWork,
+		2,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestReconcileUsageService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestUsersReconciledInBatches() {
+	s.UnitOfWork.Usage().ReconcileResults = []reminder.ReconcileUsageResult{
+		{UserIDs: []user.ID{1, 3}, ActiveFixed: 1},
+		{UserIDs: []user.ID{4, 7}, SentFixed: 2},
+		{UserIDs: []user.ID{8}},
+	}
+
+	result, err := s.Service.Run(context.Background(), Input{})
+
+	s.Nil(err)
+	s.Equal(Result{Users: 5, ActiveFixed: 1, SentFixed: 2}, result)
+	sentAfter := NOW.Add(-reminder.SENT_USAGE_RETENTION)
+	s.Equal(
+		[]reminder.ReconcileUsageInput{
+			{AfterUserID: 0, Limit: 2, SentAfter: sentAfter},
+			{AfterUserID: 3, Limit: 2, SentAfter: sentAfter},
+			{AfterUserID: 7, Limit: 2, SentAfter: sentAfter},
+		},
+		s.UnitOfWork.Usage().ReconcileWith,
+	)
+	s.True(s.UnitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.UnitOfWork.Usage().Error = errors.New("usage error")
+
+	_, err := s.Service.Run(context.Background(), Input{})
+
+	s.NotNil(err)
+	s.False(s.UnitOfWork.Context.WasCommitCalled)
+}
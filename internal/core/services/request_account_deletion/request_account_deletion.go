@@ -0,0 +1,166 @@
+package requestaccountdeletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	User user.User
+	// Password confirms the deletion, it is not checked for users without a password.
+	Password user.RawPassword
+	// Token of the session the request is made with, users logging in with external identities
+	// confirm the deletion by logging in again, so the session must be created recently.
+	// Anonymous users have no way to log in again, their session is the only confirmation.
+	Token user.SessionToken
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("request-account-deletion::%d", i.User.ID)
+}
+
+type Result struct {
+	Deletion user.AccountDeletion
+}
+
+type service struct {
+	log                    logging.Logger
+	deletionRepository     user.AccountDeletionRepository
+	sessionRepository      user.SessionRepository
+	identityRepository     user.ExternalIdentityRepository
+	passwordHasher         user.PasswordHasher
+	sessionExpiry          user.SessionExpiry
+	reauthenticationPeriod time.Duration
+	gracePeriod            time.Duration
+	now                    func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	deletionRepository user.AccountDeletionRepository,
+	sessionRepository user.SessionRepository,
+	identityRepository user.ExternalIdentityRepository,
+	passwordHasher user.PasswordHasher,
+	sessionExpiry user.SessionExpiry,
+	reauthenticationPeriod time.Duration,
+	gracePeriod time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if deletionRepository == nil {
+		panic(e.NewNilArgumentError("deletionRepository"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	if identityRepository == nil {
+		panic(e.NewNilArgumentError("identityRepository"))
+	}
+	if passwordHasher == nil {
+		panic(e.NewNilArgumentError("passwordHasher"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                    log,
+		deletionRepository:     deletionRepository,
+		sessionRepository:      sessionRepository,
+		identityRepository:     identityRepository,
+		passwordHasher:         passwordHasher,
+		sessionExpiry:          sessionExpiry,
+		reauthenticationPeriod: reauthenticationPeriod,
+		gracePeriod:            gracePeriod,
+		now:                    now,
+	}
+}
+
+// Run schedules the account to be purged after the grace period,
+// the user can cancel the deletion until then.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	now := s.now()
+	if input.User.PasswordHash.IsPresent {
+		if !s.passwordHasher.ValidatePassword(input.Password, input.User.PasswordHash.Value) {
+			return result, user.ErrInvalidCredentials
+		}
+	} else if err := s.checkReauthenticated(ctx, input, now); err != nil {
+		return result, err
+	}
+
+	deletion := user.AccountDeletion{
+		UserID:      input.User.ID,
+		RequestedAt: now,
+		PurgeAt:     now.Add(s.gracePeriod),
+	}
+	err = s.deletionRepository.Create(ctx, deletion)
+	if errors.Is(err, user.ErrAccountDeletionAlreadyRequested) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Account deletion has been requested.",
+		logging.Entry("userID", input.User.ID),
+		logging.Entry("purgeAt", deletion.PurgeAt),
+	)
+	return Result{Deletion: deletion}, nil
+}
+
+// checkReauthenticated returns ErrReauthenticationRequired if the session the request
+// is made with was created before the reauthentication period. Users without external
+// identities are anonymous and cannot log in again, so they are not checked.
+func (s *service) checkReauthenticated(ctx context.Context, input Input, now time.Time) error {
+	identities, err := s.identityRepository.List(ctx, input.User.ID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return err
+	}
+	if len(identities) == 0 {
+		s.log.Info(
+			ctx,
+			"User is anonymous, account deletion is confirmed by the session.",
+			logging.Entry("userID", input.User.ID),
+		)
+		return nil
+	}
+
+	sessions, err := s.sessionRepository.List(ctx, user.ListSessionsInput{
+		UserID:       input.User.ID,
+		CurrentToken: input.Token,
+		At:           now,
+		Expiry:       s.sessionExpiry,
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return err
+	}
+	for _, session := range sessions {
+		if session.IsCurrent && session.CreatedAt.After(now.Add(-s.reauthenticationPeriod)) {
+			return nil
+		}
+	}
+	s.log.Info(
+		ctx,
+		"Account deletion requires reauthentication.",
+		logging.Entry("userID", input.User.ID),
+	)
+	return user.ErrReauthenticationRequired
+}
@@ -0,0 +1,163 @@
+package requestaccountdeletion
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID                 = 1
+	PASSWORD                = "test-password"
+	SESSION_TOKEN           = "test-session-token"
+	REAUTHENTICATION_PERIOD = 10 * time.Minute
+	GRACE_PERIOD            = 30 * 24 * time.Hour
+)
+
+var (
+	NOW    = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+)
+
+type testSuite struct {
+	suite.Suite
+	DeletionRepository *user.FakeAccountDeletionRepository
+	SessionRepository  *user.FakeSessionRepository
+	IdentityRepository *user.FakeExternalIdentityRepository
+	PasswordHasher     *user.FakePasswordHasher
+	Service            services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.DeletionRepository = user.NewFakeAccountDeletionRepository()
+	suite.SessionRepository = user.NewFakeSessionRepository(user.NewFakeUserRepository())
+	suite.IdentityRepository = user.NewFakeExternalIdentityRepository(user.NewFakeUserRepository())
+	suite.PasswordHasher = user.NewFakePasswordHasher()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.DeletionRepository,
+		suite.SessionRepository,
+		suite.IdentityRepository,
+		suite.PasswordHasher,
+		EXPIRY,
+		REAUTHENTICATION_PERIOD,
+		GRACE_PERIOD,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestRequestAccountDeletionService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{User: s.user(), Password: PASSWORD})
+
+	s.Nil(err)
+	expected := user.AccountDeletion{UserID: USER_ID, RequestedAt: NOW, PurgeAt: NOW.Add(GRACE_PERIOD)}
+	s.Equal(expected, result.Deletion)
+	s.Equal([]user.AccountDeletion{expected}, s.DeletionRepository.Deletions)
+}
+
+func (s *testSuite) TestInvalidPassword() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.user(), Password: "invalid-password"})
+
+	s.ErrorIs(err, user.ErrInvalidCredentials)
+	s.Empty(s.DeletionRepository.Deletions)
+}
+
+func (s *testSuite) TestUserWithoutPassword() {
+	u := s.user()
+	u.PasswordHash = c.NewOptional(user.PasswordHash(""), false)
+	s.linkIdentity()
+	s.createSession(NOW.Add(-REAUTHENTICATION_PERIOD + time.Second))
+
+	_, err := s.Service.Run(context.Background(), Input{User: u, Token: SESSION_TOKEN})
+
+	s.Nil(err)
+	s.Len(s.DeletionRepository.Deletions, 1)
+}
+
+func (s *testSuite) TestUserWithoutPasswordReauthenticationRequired() {
+	u := s.user()
+	u.PasswordHash = c.NewOptional(user.PasswordHash(""), false)
+	s.linkIdentity()
+	s.createSession(NOW.Add(-REAUTHENTICATION_PERIOD))
+
+	_, err := s.Service.Run(context.Background(), Input{User: u, Token: SESSION_TOKEN})
+
+	s.ErrorIs(err, user.ErrReauthenticationRequired)
+	s.Empty(s.DeletionRepository.Deletions)
+}
+
+func (s *testSuite) TestUserWithoutPasswordOtherSessionIsRecent() {
+	u := s.user()
+	u.PasswordHash = c.NewOptional(user.PasswordHash(""), false)
+	s.linkIdentity()
+	s.createSession(NOW.Add(-time.Hour + time.Second))
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    USER_ID,
+		Token:     "other-session-token",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(context.Background(), Input{User: u, Token: SESSION_TOKEN})
+
+	s.ErrorIs(err, user.ErrReauthenticationRequired)
+	s.Empty(s.DeletionRepository.Deletions)
+}
+
+func (s *testSuite) TestAnonymousUser() {
+	u := s.user()
+	u.PasswordHash = c.NewOptional(user.PasswordHash(""), false)
+	s.createSession(NOW.Add(-time.Hour + time.Second))
+
+	_, err := s.Service.Run(context.Background(), Input{User: u, Token: SESSION_TOKEN})
+
+	s.Nil(err)
+	s.Len(s.DeletionRepository.Deletions, 1)
+}
+
+func (s *testSuite) TestAlreadyRequested() {
+	_, err := s.Service.Run(context.Background(), Input{User: s.user(), Password: PASSWORD})
+	s.Require().Nil(err)
+
+	_, err = s.Service.Run(context.Background(), Input{User: s.user(), Password: PASSWORD})
+
+	s.ErrorIs(err, user.ErrAccountDeletionAlreadyRequested)
+}
+
+func (s *testSuite) createSession(createdAt time.Time) {
+	s.T().Helper()
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID:    USER_ID,
+		Token:     SESSION_TOKEN,
+		CreatedAt: createdAt,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) linkIdentity() {
+	s.T().Helper()
+	_, err := s.IdentityRepository.Create(context.Background(), user.CreateExternalIdentityInput{
+		UserID:    USER_ID,
+		Provider:  user.OIDCProviderName("google"),
+		Subject:   "test-subject",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) user() user.User {
+	s.T().Helper()
+	hash, err := s.PasswordHasher.HashPassword(PASSWORD)
+	s.Require().Nil(err)
+	return user.User{ID: USER_ID, PasswordHash: c.NewOptional(hash, true)}
+}
@@ -0,0 +1,139 @@
+package requestemailchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	User  user.User
+	Email c.Email
+	// Password confirms the change, it is not checked for users without a password.
+	Password user.RawPassword
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.User = u
+	return i
+}
+
+func (i Input) GetRateLimitKey() string {
+	return fmt.Sprintf("request-email-change::%d", i.User.ID)
+}
+
+type Result struct {
+	Change user.EmailChange
+}
+
+type service struct {
+	log                   logging.Logger
+	userRepository        user.UserRepository
+	emailChangeRepository user.EmailChangeRepository
+	passwordHasher        user.PasswordHasher
+	tokenGenerator        user.EmailChangeTokenGenerator
+	sender                user.EmailChangeSender
+	confirmationPeriod    time.Duration
+	now                   func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	userRepository user.UserRepository,
+	emailChangeRepository user.EmailChangeRepository,
+	passwordHasher user.PasswordHasher,
+	tokenGenerator user.EmailChangeTokenGenerator,
+	sender user.EmailChangeSender,
+	confirmationPeriod time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if userRepository == nil {
+		panic(e.NewNilArgumentError("userRepository"))
+	}
+	if emailChangeRepository == nil {
+		panic(e.NewNilArgumentError("emailChangeRepository"))
+	}
+	if passwordHasher == nil {
+		panic(e.NewNilArgumentError("passwordHasher"))
+	}
+	if tokenGenerator == nil {
+		panic(e.NewNilArgumentError("tokenGenerator"))
+	}
+	if sender == nil {
+		panic(e.NewNilArgumentError("sender"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                   log,
+		userRepository:        userRepository,
+		emailChangeRepository: emailChangeRepository,
+		passwordHasher:        passwordHasher,
+		tokenGenerator:        tokenGenerator,
+		sender:                sender,
+		confirmationPeriod:    confirmationPeriod,
+		now:                   now,
+	}
+}
+
+// Run sends the confirmation token to the new email, the email is changed once it is confirmed.
+// Anonymous users have no email to change and claim the account instead.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	if !input.User.Email.IsPresent || !input.User.IsActive() {
+		return result, user.ErrEmailIsNotSet
+	}
+	if input.User.PasswordHash.IsPresent &&
+		!s.passwordHasher.ValidatePassword(input.Password, input.User.PasswordHash.Value) {
+		return result, user.ErrInvalidCredentials
+	}
+	if input.Email == input.User.Email.Value {
+		return result, user.ErrEmailIsNotChanged
+	}
+
+	_, err = s.userRepository.GetByEmail(ctx, input.Email)
+	if err == nil {
+		return result, user.ErrEmailAlreadyExists
+	}
+	if !errors.Is(err, user.ErrUserDoesNotExist) {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	now := s.now()
+	token := s.tokenGenerator.GenerateEmailChangeToken()
+	change, err := s.emailChangeRepository.Create(ctx, user.CreateEmailChangeInput{
+		UserID:    input.User.ID,
+		OldEmail:  input.User.Email.Value,
+		NewEmail:  input.Email,
+		Token:     token,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.confirmationPeriod),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+	if err := s.sender.SendEmailChangeConfirmation(ctx, change, token); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.User.ID))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Email change has been requested.",
+		logging.Entry("userID", input.User.ID),
+		logging.Entry("changeID", change.ID),
+	)
+	return Result{Change: change}, nil
+}
@@ -0,0 +1,129 @@
+package requestemailchange
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	PASSWORD            = "test-password"
+	TOKEN               = "test-token"
+	CONFIRMATION_PERIOD = 24 * time.Hour
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	UserRepository        *user.FakeUserRepository
+	EmailChangeRepository *user.FakeEmailChangeRepository
+	PasswordHasher        *user.FakePasswordHasher
+	Sender                *user.FakeEmailChangeSender
+	Service               services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.EmailChangeRepository = user.NewFakeEmailChangeRepository()
+	suite.PasswordHasher = user.NewFakePasswordHasher()
+	suite.Sender = user.NewFakeEmailChangeSender()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.UserRepository,
+		suite.EmailChangeRepository,
+		suite.PasswordHasher,
+		user.NewFakeEmailChangeTokenGenerator(TOKEN),
+		suite.Sender,
+		CONFIRMATION_PERIOD,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestRequestEmailChangeService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	u := s.createUser("old@test.test")
+
+	result, err := s.Service.Run(
+		context.Background(),
+		Input{User: u, Email: "new@test.test", Password: PASSWORD},
+	)
+
+	s.Nil(err)
+	s.Equal(c.Email("old@test.test"), result.Change.OldEmail)
+	s.Equal(c.Email("new@test.test"), result.Change.NewEmail)
+	s.Equal(NOW.Add(CONFIRMATION_PERIOD), result.Change.ExpiresAt)
+	s.Equal([]user.EmailChange{result.Change}, s.EmailChangeRepository.Changes)
+	s.Equal([]user.EmailChangeToken{TOKEN}, s.Sender.Confirmations)
+	actual, err := s.UserRepository.GetByID(context.Background(), u.ID)
+	s.Require().Nil(err)
+	s.Equal(c.NewOptional(c.Email("old@test.test"), true), actual.Email)
+}
+
+func (s *testSuite) TestInvalidPassword() {
+	u := s.createUser("old@test.test")
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{User: u, Email: "new@test.test", Password: "invalid-password"},
+	)
+
+	s.ErrorIs(err, user.ErrInvalidCredentials)
+	s.Empty(s.EmailChangeRepository.Changes)
+	s.Empty(s.Sender.Confirmations)
+}
+
+func (s *testSuite) TestEmailAlreadyExists() {
+	u := s.createUser("old@test.test")
+	s.createUser("taken@test.test")
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{User: u, Email: "taken@test.test", Password: PASSWORD},
+	)
+
+	s.ErrorIs(err, user.ErrEmailAlreadyExists)
+	s.Empty(s.EmailChangeRepository.Changes)
+}
+
+func (s *testSuite) TestSameEmail() {
+	u := s.createUser("old@test.test")
+
+	_, err := s.Service.Run(
+		context.Background(),
+		Input{User: u, Email: "old@test.test", Password: PASSWORD},
+	)
+
+	s.ErrorIs(err, user.ErrEmailIsNotChanged)
+}
+
+func (s *testSuite) TestAnonymousUser() {
+	u := user.User{ID: 1, Identity: c.NewOptional(user.Identity("identity"), true)}
+
+	_, err := s.Service.Run(context.Background(), Input{User: u, Email: "new@test.test"})
+
+	s.ErrorIs(err, user.ErrEmailIsNotSet)
+}
+
+func (s *testSuite) createUser(email string) user.User {
+	s.T().Helper()
+	hash, err := s.PasswordHasher.HashPassword(PASSWORD)
+	s.Require().Nil(err)
+	u, err := s.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.Email(email), true),
+		PasswordHash: c.NewOptional(hash, true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+	return u
+}
@@ -0,0 +1,118 @@
+package resendactivationtoken
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct {
+	Email c.Email
+}
+
+func (i Input) GetRateLimitKey() string {
+	return "resend-activation-token::" + string(i.Email)
+}
+
+type Result struct {
+	User user.User
+}
+
+type service struct {
+	log                      logging.Logger
+	userRepository           user.UserRepository
+	activationTokenGenerator user.ActivationTokenGenerator
+	sender                   user.ActivationTokenSender
+	activationTokenTTL       time.Duration
+	now                      func() time.Time
+}
+
+// New issues a new activation token for a user who has not activated the email yet
+// and sends it, the previously sent token becomes invalid.
+func New(
+	log logging.Logger,
+	userRepository user.UserRepository,
+	activationTokenGenerator user.ActivationTokenGenerator,
+	sender user.ActivationTokenSender,
+	activationTokenTTL time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if userRepository == nil {
+		panic(e.NewNilArgumentError("userRepository"))
+	}
+	if activationTokenGenerator == nil {
+		panic(e.NewNilArgumentError("activationTokenGenerator"))
+	}
+	if sender == nil {
+		panic(e.NewNilArgumentError("sender"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                      log,
+		userRepository:           userRepository,
+		activationTokenGenerator: activationTokenGenerator,
+		sender:                   sender,
+		activationTokenTTL:       activationTokenTTL,
+		now:                      now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	u, err := s.userRepository.GetByEmail(ctx, input.Email)
+	if errors.Is(err, context.Canceled) {
+		return result, err
+	}
+	if errors.Is(err, user.ErrUserDoesNotExist) {
+		s.log.Info(ctx, "User not found for activation token resending.", logging.Entry("input", input))
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	ctx = logging.WithUserID(ctx, int64(u.ID))
+	if !u.ActivationToken.IsPresent {
+		s.log.Info(ctx, "User is already active, skip activation token resending.")
+		return result, user.ErrUserIsAlreadyActive
+	}
+
+	u, err = s.userRepository.SetActivationToken(ctx, user.SetActivationTokenInput{
+		ID:        u.ID,
+		Token:     s.activationTokenGenerator.GenerateActivationToken(),
+		ExpiresAt: s.now().Add(s.activationTokenTTL),
+	})
+	if errors.Is(err, context.Canceled) {
+		return result, err
+	}
+	if errors.Is(err, user.ErrUserDoesNotExist) {
+		// The user has been activated concurrently.
+		s.log.Info(ctx, "User is already active, skip activation token resending.")
+		return result, user.ErrUserIsAlreadyActive
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	err = s.sender.SendActivationToken(ctx, u)
+	if errors.Is(err, context.Canceled) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	s.log.Info(ctx, "Activation token has been resent.")
+	return Result{User: u}, nil
+}
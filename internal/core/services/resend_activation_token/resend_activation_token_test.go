@@ -0,0 +1,101 @@
+package resendactivationtoken
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	ACTIVATION_TOKEN     = "new-activation-token"
+	ACTIVATION_TOKEN_TTL = 48 * time.Hour
+	EMAIL                = c.Email("test@test.test")
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	UserRepository *user.FakeUserRepository
+	Sender         *user.FakeActivationTokenSender
+	Service        services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.UserRepository = user.NewFakeUserRepository()
+	suite.Sender = user.NewFakeActivationTokenSender()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.UserRepository,
+		user.NewFakeActivationTokenGenerator(ACTIVATION_TOKEN),
+		suite.Sender,
+		ACTIVATION_TOKEN_TTL,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestResendActivationTokenService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	u := s.createUser(c.NewOptional(user.ActivationToken("old-activation-token"), true))
+
+	result, err := s.Service.Run(context.Background(), Input{Email: EMAIL})
+
+	s.Require().Nil(err)
+	s.Equal(u.ID, result.User.ID)
+	s.Equal(c.NewOptional(user.ActivationToken(ACTIVATION_TOKEN), true), result.User.ActivationToken)
+	s.Equal(c.NewOptional(NOW.Add(ACTIVATION_TOKEN_TTL), true), result.User.ActivationTokenExpiresAt)
+	s.Equal(1, s.Sender.SentCount())
+	s.Equal(result.User, s.Sender.LastSentTo())
+
+	_, err = s.UserRepository.Activate(context.Background(), user.ActivationToken("old-activation-token"), NOW)
+	s.ErrorIs(err, user.ErrInvalidActivationToken)
+	_, err = s.UserRepository.Activate(context.Background(), user.ActivationToken(ACTIVATION_TOKEN), NOW)
+	s.Nil(err)
+}
+
+func (s *testSuite) TestUserDoesNotExist() {
+	_, err := s.Service.Run(context.Background(), Input{Email: EMAIL})
+
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+	s.Equal(0, s.Sender.SentCount())
+}
+
+func (s *testSuite) TestUserIsAlreadyActive() {
+	s.createUser(c.NewOptional(user.ActivationToken(""), false))
+
+	_, err := s.Service.Run(context.Background(), Input{Email: EMAIL})
+
+	s.ErrorIs(err, user.ErrUserIsAlreadyActive)
+	s.Equal(0, s.Sender.SentCount())
+}
+
+func (s *testSuite) TestSendingError() {
+	s.createUser(c.NewOptional(user.ActivationToken("old-activation-token"), true))
+	s.Sender.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{Email: EMAIL})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) createUser(token c.Optional[user.ActivationToken]) user.User {
+	s.T().Helper()
+	u, err := s.UserRepository.Create(context.Background(), user.CreateUserInput{
+		Email:           c.NewOptional(EMAIL, true),
+		PasswordHash:    c.NewOptional(user.PasswordHash("test"), true),
+		CreatedAt:       NOW,
+		ActivatedAt:     c.NewOptional(NOW, !token.IsPresent),
+		ActivationToken: token,
+	})
+	s.Require().Nil(err)
+	return u
+}
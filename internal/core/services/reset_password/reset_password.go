@@ -17,15 +17,17 @@ type Input struct {
 type Result struct{}
 
 type service struct {
-	log              logging.Logger
-	userRepository   user.UserRepository
-	passwordResetter user.PasswordResetter
-	passwordHasher   user.PasswordHasher
+	log               logging.Logger
+	userRepository    user.UserRepository
+	sessionRepository user.SessionRepository
+	passwordResetter  user.PasswordResetter
+	passwordHasher    user.PasswordHasher
 }
 
 func New(
 	log logging.Logger,
 	userRepository user.UserRepository,
+	sessionRepository user.SessionRepository,
 	passwordResetter user.PasswordResetter,
 	passwordHasher user.PasswordHasher,
 ) services.Service[Input, Result] {
@@ -35,6 +37,9 @@ func New(
 	if userRepository == nil {
 		panic(e.NewNilArgumentError("userRepository"))
 	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
 	if passwordResetter == nil {
 		panic(e.NewNilArgumentError("passwordResetter"))
 	}
@@ -42,10 +47,11 @@ func New(
 		panic(e.NewNilArgumentError("passwordHasher"))
 	}
 	return &service{
-		log:              log,
-		userRepository:   userRepository,
-		passwordResetter: passwordResetter,
-		passwordHasher:   passwordHasher,
+		log:               log,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		passwordResetter:  passwordResetter,
+		passwordHasher:    passwordHasher,
 	}
 }
 
@@ -96,9 +102,20 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, err
 	}
 
+	err = s.sessionRepository.DeleteAll(ctx, user.DeleteSessionsInput{UserID: u.ID})
+	if err != nil {
+		s.log.Error(
+			ctx,
+			"Could not revoke user sessions after password reset.",
+			logging.Entry("userID", userID),
+			logging.Entry("err", err),
+		)
+		return result, err
+	}
+
 	s.log.Info(
 		ctx,
-		"New password has been successfully set.",
+		"New password has been successfully set, all user sessions revoked.",
 		logging.Entry("userID", userID),
 	)
 	return result, nil
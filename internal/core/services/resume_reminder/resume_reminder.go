@@ -0,0 +1,142 @@
+package resumereminder
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	UserID     user.ID
+	ReminderID reminder.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
+type Result struct {
+	Reminder reminder.ReminderWithChannels
+}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	scheduler  reminder.Scheduler
+	now        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	scheduler reminder.Scheduler,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if scheduler == nil {
+		panic(e.NewNilArgumentError("scheduler"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		scheduler:  scheduler,
+		now:        now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	reminderRepository := uow.Reminders()
+	reminderRepository.Lock(ctx, input.ReminderID)
+	rem, err := reminderRepository.GetByID(ctx, input.ReminderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, reminder.ErrReminderDoesNotExist):
+			s.log.Info(ctx, "Reminder not found.", logging.Entry("input", input))
+		default:
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		}
+		return result, err
+	}
+
+	if rem.CreatedBy != input.UserID {
+		s.log.Info(ctx, "Reminder belongs to another user.", logging.Entry("input", input))
+		return result, reminder.ErrReminderPermission
+	}
+	update, err := reminder.ResumeUpdate(rem.Reminder, s.now())
+	if err != nil {
+		s.log.Info(ctx, "Reminder is not paused and can't be resumed.", logging.Entry("input", input))
+		return result, err
+	}
+
+	limits, err := uow.Limits().GetUserLimitsWithLock(ctx, input.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := reminder.CheckResumeLimit(ctx, uow.Usage(), input.UserID, limits); err != nil {
+		if errors.Is(err, user.ErrLimitActiveReminderCountExceeded) {
+			s.log.Info(ctx, "Active reminder count limit exceeded.", logging.Entry("input", input))
+		} else {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		}
+		return result, err
+	}
+
+	updatedReminder, err := reminderRepository.Update(ctx, update)
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, updatedReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	if updatedReminder.Status == reminder.StatusScheduled {
+		if err := s.scheduler.ScheduleReminder(ctx, updatedReminder); err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+			return result, err
+		}
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Reminder has been successfully resumed.",
+		logging.Entry("input", input),
+		logging.Entry("at", updatedReminder.At),
+	)
+	result.Reminder.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package resumereminder
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID     = user.ID(42)
+	REMINDER_ID = reminder.ID(77)
+)
+
+var Now = time.Date(2020, 1, 10, 12, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	logger     *logging.FakeLogger
+	unitOfWork *uow.FakeUnitOfWork
+	scheduler  *reminder.TestReminderScheduler
+	service    services.Service[Input, Result]
+}
+
+func (s *testSuite) SetupTest() {
+	s.logger = logging.NewFakeLogger()
+	s.unitOfWork = uow.NewFakeUnitOfWork()
+	s.unitOfWork.Reminders().GetByIDReminder = reminder.ReminderWithChannels{
+		Reminder: reminder.Reminder{
+			CreatedBy: USER_ID,
+			At:        time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+			Status:    reminder.StatusPaused,
+			Every:     c.NewOptional(reminder.EveryDay, true),
+		},
+		ChannelIDs: []channel.ID{1},
+	}
+	s.unitOfWork.Reminders().ReminderBeforeUpdate = s.unitOfWork.Reminders().GetByIDReminder.Reminder
+	s.unitOfWork.Limits().Limits = user.Limits{ActiveReminderCount: c.NewOptional(uint32(2), true)}
+	s.unitOfWork.Usage().ActiveCount = 1
+	s.unitOfWork.Usage().PausedCount = 1
+	s.scheduler = reminder.NewTestReminderScheduler()
+	s.service = New(s.logger, s.unitOfWork, s.scheduler, func() time.Time { return Now })
+}
+
+func TestResumeReminderService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestResumeSuccess() {
+	result, err := s.service.Run(context.Background(), Input{UserID: USER_ID, ReminderID: REMINDER_ID})
+
+	s.Nil(err)
+	s.Equal(REMINDER_ID, result.Reminder.ID)
+	s.Equal(time.Date(2020, 1, 11, 9, 0, 0, 0, time.UTC), result.Reminder.At)
+	s.Equal(reminder.StatusScheduled, result.Reminder.Status)
+	s.Equal(c.NewOptional(Now, true), result.Reminder.ScheduledAt)
+	s.Equal([]channel.ID{1}, result.Reminder.ChannelIDs)
+	s.Len(s.scheduler.Scheduled, 1)
+	s.Equal(uint(2), s.unitOfWork.Usage().ActiveCount)
+	s.Equal(uint(0), s.unitOfWork.Usage().PausedCount)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestResumeNotScheduled() {
+	s.unitOfWork.Reminders().GetByIDReminder.Every = c.NewOptional(reminder.EveryWeek, true)
+	s.unitOfWork.Reminders().ReminderBeforeUpdate.Every = c.NewOptional(reminder.EveryWeek, true)
+
+	result, err := s.service.Run(context.Background(), Input{UserID: USER_ID, ReminderID: REMINDER_ID})
+
+	s.Nil(err)
+	s.Equal(time.Date(2020, 1, 15, 9, 0, 0, 0, time.UTC), result.Reminder.At)
+	s.Equal(reminder.StatusCreated, result.Reminder.Status)
+	s.False(result.Reminder.ScheduledAt.IsPresent)
+	s.Empty(s.scheduler.Scheduled)
+}
+
+func (s *testSuite) TestResumeError() {
+	cases := []struct {
+		id            string
+		userID        user.ID
+		status        reminder.Status
+		activeCount   uint
+		expectedError error
+	}{
+		{
+			id:            "another user",
+			userID:        USER_ID + 1,
+			status:        reminder.StatusPaused,
+			expectedError: reminder.ErrReminderPermission,
+		},
+		{
+			id:            "not paused",
+			userID:        USER_ID,
+			status:        reminder.StatusScheduled,
+			expectedError: reminder.ErrReminderNotPaused,
+		},
+		{
+			id:            "active limit exceeded",
+			userID:        USER_ID,
+			status:        reminder.StatusPaused,
+			activeCount:   2,
+			expectedError: user.ErrLimitActiveReminderCountExceeded,
+		},
+	}
+
+	for _, testcase := range cases {
+		s.Run(testcase.id, func() {
+			s.SetupTest()
+			s.unitOfWork.Reminders().GetByIDReminder.Status = testcase.status
+			s.unitOfWork.Usage().ActiveCount = testcase.activeCount
+
+			_, err := s.service.Run(context.Background(), Input{UserID: testcase.userID, ReminderID: REMINDER_ID})
+
+			s.ErrorIs(err, testcase.expectedError)
+			s.Empty(s.scheduler.Scheduled)
+			s.False(s.unitOfWork.Context.WasCommitCalled)
+		})
+	}
+}
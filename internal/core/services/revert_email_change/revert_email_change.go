@@ -0,0 +1,118 @@
+package revertemailchange
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"time"
+)
+
+type Input struct {
+	Token user.EmailChangeToken
+}
+
+type Result struct{}
+
+type service struct {
+	log        logging.Logger
+	unitOfWork uow.UnitOfWork
+	now        func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	unitOfWork uow.UnitOfWork,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:        log,
+		unitOfWork: unitOfWork,
+		now:        now,
+	}
+}
+
+// Run restores the old email of the user with the token sent to it when the change was confirmed.
+// The change might have been made by someone else, so all the sessions and API tokens of the user are revoked.
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	defer uow.Rollback(ctx)
+
+	change, err := uow.EmailChanges().GetByRevertTokenWithLock(ctx, input.Token, s.now())
+	if errors.Is(err, user.ErrInvalidEmailChangeToken) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	ctx = logging.WithUserID(ctx, int64(change.UserID))
+
+	u, err := uow.Users().GetByID(ctx, change.UserID)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if !u.Email.IsPresent || u.Email.Value != change.NewEmail {
+		// The email has been changed again since, the newer change is reverted with its own token.
+		return result, user.ErrInvalidEmailChangeToken
+	}
+	_, err = uow.Users().Update(ctx, user.UpdateUserInput{
+		ID:            u.ID,
+		DoEmailUpdate: true,
+		Email:         change.OldEmail,
+	})
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	movedChannels, err := channel.MoveEmailChannels(ctx, uow.Channels(), u.ID, change.NewEmail, change.OldEmail)
+	if err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if err := uow.Sessions().DeleteAll(ctx, user.DeleteSessionsInput{UserID: u.ID}); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if err := uow.APITokens().DeleteAll(ctx, u.ID); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if err := uow.EmailChanges().Delete(ctx, change.ID); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		logging.Error(ctx, s.log, err)
+		return result, err
+	}
+
+	s.log.Info(
+		ctx,
+		"Email change has been reverted.",
+		logging.Entry("changeID", change.ID),
+		logging.Entry("movedChannelIDs", movedChannels),
+	)
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package revertemailchange
+
+import (
+	"context"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	uow "remindme/internal/core/domain/unit_of_work"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const REVERT_TOKEN = "test-revert-token"
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	Uow     *uow.FakeUnitOfWork
+	Service services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Uow = uow.NewFakeUnitOfWork()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		suite.Uow,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestRevertEmailChangeService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	u := s.createUser("new@test.test")
+	s.createConfirmedChange(u.ID, NOW.Add(time.Hour))
+	s.Require().Nil(s.Uow.Sessions().Create(context.Background(), user.CreateSessionInput{
+		UserID:    u.ID,
+		Token:     "session-token",
+		CreatedAt: NOW,
+	}))
+	_, err := s.Uow.APITokens().Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    u.ID,
+		Secret:    "api-token",
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	s.Uow.Channels().ReadChannels = []channel.Channel{{
+		ID:         1,
+		Type:       channel.Email,
+		Settings:   channel.NewEmailSettings("new@test.test"),
+		VerifiedAt: c.NewOptional(NOW, true),
+	}}
+
+	_, err = s.Service.Run(context.Background(), Input{Token: REVERT_TOKEN})
+
+	s.Nil(err)
+	s.True(s.Uow.Context.WasCommitCalled)
+	actual, err := s.Uow.Users().GetByID(context.Background(), u.ID)
+	s.Require().Nil(err)
+	s.Equal(c.NewOptional(c.Email("old@test.test"), true), actual.Email)
+	s.Equal(
+		[]channel.UpdateInput{{ID: 1, DoSettingsUpdate: true, Settings: channel.NewEmailSettings("old@test.test")}},
+		s.Uow.Channels().Updated,
+	)
+	s.Empty(s.Uow.Sessions().Sessions)
+	s.Empty(s.Uow.APITokens().Tokens)
+	s.Empty(s.Uow.EmailChanges().Changes)
+}
+
+func (s *testSuite) TestExpiredToken() {
+	u := s.createUser("new@test.test")
+	s.createConfirmedChange(u.ID, NOW)
+
+	_, err := s.Service.Run(context.Background(), Input{Token: REVERT_TOKEN})
+
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) TestEmailChangedAgain() {
+	u := s.createUser("newer@test.test")
+	s.createConfirmedChange(u.ID, NOW.Add(time.Hour))
+
+	_, err := s.Service.Run(context.Background(), Input{Token: REVERT_TOKEN})
+
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+	s.False(s.Uow.Context.WasCommitCalled)
+}
+
+func (s *testSuite) createConfirmedChange(userID user.ID, revertExpiresAt time.Time) {
+	s.T().Helper()
+	change, err := s.Uow.EmailChanges().Create(context.Background(), user.CreateEmailChangeInput{
+		UserID:    userID,
+		OldEmail:  "old@test.test",
+		NewEmail:  "new@test.test",
+		Token:     "test-token",
+		CreatedAt: NOW.Add(-time.Hour),
+		ExpiresAt: NOW,
+	})
+	s.Require().Nil(err)
+	_, err = s.Uow.EmailChanges().Confirm(context.Background(), user.ConfirmEmailChangeInput{
+		ID:              change.ID,
+		ConfirmedAt:     NOW.Add(-time.Minute),
+		RevertToken:     REVERT_TOKEN,
+		RevertExpiresAt: revertExpiresAt,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.Uow.Users().Create(context.Background(), user.CreateUserInput{
+		Email:       c.NewOptional(c.Email(email), true),
+		CreatedAt:   NOW,
+		ActivatedAt: c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+	return u
+}
@@ -0,0 +1,62 @@
+package revokeapitoken
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID     user.ID
+	APITokenID user.APITokenID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct{}
+
+type service struct {
+	log                logging.Logger
+	apiTokenRepository user.APITokenRepository
+}
+
+func New(
+	log logging.Logger,
+	apiTokenRepository user.APITokenRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if apiTokenRepository == nil {
+		panic(e.NewNilArgumentError("apiTokenRepository"))
+	}
+	return &service{
+		log:                log,
+		apiTokenRepository: apiTokenRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	err = s.apiTokenRepository.Delete(ctx, input.UserID, input.APITokenID)
+	if errors.Is(err, user.ErrAPITokenDoesNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"API token has been revoked.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("apiTokenID", input.APITokenID),
+	)
+	return Result{}, nil
+}
@@ -0,0 +1,62 @@
+package revokeapitoken
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	APITokenRepository *user.FakeAPITokenRepository
+	Service            services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.APITokenRepository = user.NewFakeAPITokenRepository(user.NewFakeUserRepository())
+	suite.Service = New(logging.NewFakeLogger(), suite.APITokenRepository)
+}
+
+func TestRevokeAPITokenService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	token := s.createToken(USER_ID)
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, APITokenID: token.ID})
+
+	s.Nil(err)
+	s.Empty(s.APITokenRepository.Tokens)
+}
+
+func (s *testSuite) TestOtherUserToken() {
+	token := s.createToken(USER_ID + 1)
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, APITokenID: token.ID})
+
+	s.ErrorIs(err, user.ErrAPITokenDoesNotExist)
+	s.Len(s.APITokenRepository.Tokens, 1)
+}
+
+func (s *testSuite) createToken(userID user.ID) user.APIToken {
+	s.T().Helper()
+	token, err := s.APITokenRepository.Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    userID,
+		Secret:    user.API_TOKEN_PREFIX + "test-secret",
+		Name:      "test",
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	return token
+}
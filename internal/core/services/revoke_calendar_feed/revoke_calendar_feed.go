@@ -0,0 +1,56 @@
+package revokecalendarfeed
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID user.ID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct{}
+
+type service struct {
+	log                    logging.Logger
+	calendarFeedRepository user.CalendarFeedRepository
+}
+
+func New(
+	log logging.Logger,
+	calendarFeedRepository user.CalendarFeedRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if calendarFeedRepository == nil {
+		panic(e.NewNilArgumentError("calendarFeedRepository"))
+	}
+	return &service{
+		log:                    log,
+		calendarFeedRepository: calendarFeedRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	err = s.calendarFeedRepository.Delete(ctx, input.UserID)
+	if errors.Is(err, user.ErrCalendarFeedDoesNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.log.Info(ctx, "Calendar feed has been revoked.", logging.Entry("userID", input.UserID))
+	return Result{}, nil
+}
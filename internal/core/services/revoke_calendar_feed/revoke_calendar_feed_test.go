@@ -0,0 +1,59 @@
+package revokecalendarfeed
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+	CalendarFeedRepository *user.FakeCalendarFeedRepository
+	Service                services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.CalendarFeedRepository = user.NewFakeCalendarFeedRepository(user.NewFakeUserRepository())
+	suite.Service = New(logging.NewFakeLogger(), suite.CalendarFeedRepository)
+}
+
+func TestRevokeCalendarFeedService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	s.createFeed(USER_ID)
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.Nil(err)
+	s.Empty(s.CalendarFeedRepository.Feeds)
+}
+
+func (s *testSuite) TestNoFeed() {
+	s.createFeed(USER_ID + 1)
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID})
+
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+	s.Len(s.CalendarFeedRepository.Feeds, 1)
+}
+
+func (s *testSuite) createFeed(userID user.ID) {
+	s.T().Helper()
+	_, err := s.CalendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    userID,
+		Token:     "test-calendar-token",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+}
@@ -0,0 +1,58 @@
+package revokeothersessions
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID user.ID
+	// Token of the session the request is made with, the session stays alive.
+	Token user.SessionToken
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct{}
+
+type service struct {
+	log               logging.Logger
+	sessionRepository user.SessionRepository
+}
+
+func New(
+	log logging.Logger,
+	sessionRepository user.SessionRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	return &service{
+		log:               log,
+		sessionRepository: sessionRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	err = s.sessionRepository.DeleteAll(ctx, user.DeleteSessionsInput{
+		UserID:      input.UserID,
+		ExceptToken: c.NewOptional(input.Token, true),
+	})
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("userID", input.UserID))
+		return result, err
+	}
+	s.log.Info(ctx, "Other user sessions have been revoked.", logging.Entry("userID", input.UserID))
+	return Result{}, nil
+}
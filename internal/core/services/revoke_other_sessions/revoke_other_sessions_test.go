@@ -0,0 +1,54 @@
+package revokeothersessions
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	USER_ID       = 1
+	SESSION_TOKEN = "test-session-token"
+)
+
+type testSuite struct {
+	suite.Suite
+	SessionRepository *user.FakeSessionRepository
+	Service           services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.SessionRepository = user.NewFakeSessionRepository(user.NewFakeUserRepository())
+	suite.Service = New(logging.NewFakeLogger(), suite.SessionRepository)
+}
+
+func TestRevokeOtherSessionsService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	s.createSession(USER_ID, SESSION_TOKEN)
+	s.createSession(USER_ID, "other-session-token")
+	s.createSession(USER_ID+1, "other-user-session-token")
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, Token: SESSION_TOKEN})
+
+	s.Nil(err)
+	s.Require().Len(s.SessionRepository.Sessions, 2)
+	s.Equal(user.ID(USER_ID), s.SessionRepository.Sessions[0].UserID)
+	s.Equal(user.SessionID(1), s.SessionRepository.Sessions[0].ID)
+	s.Equal(user.ID(USER_ID+1), s.SessionRepository.Sessions[1].UserID)
+}
+
+func (s *testSuite) createSession(userID user.ID, token string) {
+	s.T().Helper()
+	err := s.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+		UserID: userID,
+		Token:  user.SessionToken(token),
+	})
+	s.Require().Nil(err)
+}
@@ -0,0 +1,62 @@
+package revokesession
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+)
+
+type Input struct {
+	UserID    user.ID
+	SessionID user.SessionID
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = u.ID
+	return i
+}
+
+type Result struct{}
+
+type service struct {
+	log               logging.Logger
+	sessionRepository user.SessionRepository
+}
+
+func New(
+	log logging.Logger,
+	sessionRepository user.SessionRepository,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if sessionRepository == nil {
+		panic(e.NewNilArgumentError("sessionRepository"))
+	}
+	return &service{
+		log:               log,
+		sessionRepository: sessionRepository,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	err = s.sessionRepository.DeleteByID(ctx, input.UserID, input.SessionID)
+	if errors.Is(err, user.ErrSessionDoesNotExist) {
+		return result, err
+	}
+	if err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
+	s.log.Info(
+		ctx,
+		"User session has been revoked.",
+		logging.Entry("userID", input.UserID),
+		logging.Entry("sessionID", input.SessionID),
+	)
+	return Result{}, nil
+}
@@ -0,0 +1,55 @@
+package revokesession
+
+import (
+	"context"
+	"fmt"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const USER_ID = 1
+
+type testSuite struct {
+	suite.Suite
+	SessionRepository *user.FakeSessionRepository
+	Service           services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.SessionRepository = user.NewFakeSessionRepository(user.NewFakeUserRepository())
+	suite.Service = New(logging.NewFakeLogger(), suite.SessionRepository)
+	for _, userID := range []user.ID{USER_ID, USER_ID + 1} {
+		err := suite.SessionRepository.Create(context.Background(), user.CreateSessionInput{
+			UserID: userID,
+			Token:  user.SessionToken(fmt.Sprintf("session-token-%d", userID)),
+		})
+		suite.Require().Nil(err)
+	}
+}
+
+func TestRevokeSessionService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	sessionID := s.SessionRepository.Sessions[0].ID
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, SessionID: sessionID})
+
+	s.Nil(err)
+	s.Require().Len(s.SessionRepository.Sessions, 1)
+	s.NotEqual(sessionID, s.SessionRepository.Sessions[0].ID)
+}
+
+func (s *testSuite) TestOtherUserSessionNotRevoked() {
+	sessionID := s.SessionRepository.Sessions[1].ID
+
+	_, err := s.Service.Run(context.Background(), Input{UserID: USER_ID, SessionID: sessionID})
+
+	s.ErrorIs(err, user.ErrSessionDoesNotExist)
+	s.Len(s.SessionRepository.Sessions, 2)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/metrics"
 	"remindme/internal/core/domain/reminder"
 	uow "remindme/internal/core/domain/unit_of_work"
 	"remindme/internal/core/services"
@@ -12,12 +13,29 @@ import (
 
 type Input struct{}
 
-type Result struct{}
+// Result holds metrics of a scheduling run.
+type Result struct {
+	Batches   int
+	Scheduled int
+	Failed    int
+	Duration  time.Duration
+}
+
+type batchResult struct {
+	read      int
+	scheduled int
+	failed    int
+	last      reminder.Reminder
+	// The last scheduling error, returned if no reminder of the batch has been scheduled.
+	err error
+}
 
 type service struct {
 	log        logging.Logger
 	unitOfWork uow.UnitOfWork
 	scheduler  reminder.Scheduler
+	metrics    metrics.Metrics
+	batchSize  uint
 	now        func() time.Time
 }
 
@@ -25,6 +43,8 @@ func New(
 	log logging.Logger,
 	unitOfWork uow.UnitOfWork,
 	scheduler reminder.Scheduler,
+	metrics metrics.Metrics,
+	batchSize uint,
 	now func() time.Time,
 ) services.Service[Input, Result] {
 	if log == nil {
@@ -36,6 +56,12 @@ func New(
 	if scheduler == nil {
 		panic(e.NewNilArgumentError("scheduler"))
 	}
+	if metrics == nil {
+		panic(e.NewNilArgumentError("metrics"))
+	}
+	if batchSize == 0 {
+		panic("batch size must be positive")
+	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
@@ -43,65 +69,121 @@ func New(
 		log:        log,
 		unitOfWork: unitOfWork,
 		scheduler:  scheduler,
+		metrics:    metrics,
+		batchSize:  batchSize,
 		now:        now,
 	}
 }
 
+// Run schedules reminders of the scheduling window in batches, every batch is committed separately.
+// Reminders that could not be scheduled keep their status and are retried by the next run.
+// The run is stopped if no reminder of a batch could be scheduled.
 func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	startedAt := time.Now()
+	defer func() {
+		result.Duration = time.Since(startedAt)
+		s.log.Info(
+			ctx,
+			"Reminders scheduling run finished.",
+			logging.Entry("batches", result.Batches),
+			logging.Entry("scheduledCount", result.Scheduled),
+			logging.Entry("failedCount", result.Failed),
+			logging.Entry("duration", result.Duration),
+		)
+	}()
+
+	now := s.now()
+	readInput := reminder.ReadForSchedulingInput{
+		AtBefore: now.Add(reminder.DURATION_FOR_SCHEDULING),
+		Limit:    s.batchSize,
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		batch, err := s.runBatch(ctx, readInput, now)
+		if batch.read > 0 {
+			result.Batches++
+		}
+		result.Scheduled += batch.scheduled
+		result.Failed += batch.failed
+		s.metrics.RemindersScheduled(batch.scheduled, batch.failed)
+		if err != nil {
+			logging.Error(ctx, s.log, err, logging.Entry("batch", result.Batches))
+			return result, err
+		}
+		if batch.scheduled == 0 && batch.err != nil {
+			s.log.Error(
+				ctx,
+				"No reminder of the batch has been scheduled, stopping the run.",
+				logging.Entry("batch", result.Batches),
+				logging.Entry("err", batch.err),
+			)
+			return result, batch.err
+		}
+		if uint(batch.read) < s.batchSize {
+			return result, nil
+		}
+		readInput.AfterAt = batch.last.At
+		readInput.AfterID = batch.last.ID
+	}
+}
+
+func (s *service) runBatch(
+	ctx context.Context,
+	input reminder.ReadForSchedulingInput,
+	now time.Time,
+) (result batchResult, err error) {
 	uow, err := s.unitOfWork.Begin(ctx)
 	if err != nil {
-		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
 	defer uow.Rollback(ctx)
 
-	now := s.now()
-	scheduledReminders, err := uow.Reminders().Schedule(
-		ctx,
-		reminder.ScheduleInput{
-			AtBefore:    now.Add(reminder.DURATION_FOR_SCHEDULING),
-			ScheduledAt: now,
-		},
-	)
+	reminders, err := uow.Reminders().ReadForScheduling(ctx, input)
 	if err != nil {
-		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
+	result.read = len(reminders)
+	if result.read == 0 {
+		return result, nil
+	}
+	result.last = reminders[len(reminders)-1]
 
-	s.log.Info(
-		ctx,
-		"Got reminders for scheduling.",
-		logging.Entry("count", len(scheduledReminders)),
-	)
-	scheduledIDs := make([]reminder.ID, 0, len(scheduledReminders))
-	for ix, reminder := range scheduledReminders {
-		err := s.scheduler.ScheduleReminder(ctx, reminder)
-		if err != nil {
-			logging.Error(
-				ctx,
-				s.log,
-				err,
-				logging.Entry("index", ix),
-				logging.Entry("reminderID", reminder.ID),
-				logging.Entry("scheduledIDs", scheduledIDs),
-			)
-			return result, err
+	scheduledIDs := make([]reminder.ID, 0, len(reminders))
+	for _, rem := range reminders {
+		reminderCtx := logging.WithReminderID(ctx, int64(rem.ID))
+		if err := s.scheduler.ScheduleReminder(reminderCtx, rem); err != nil {
+			logging.Error(reminderCtx, s.log, err)
+			result.failed++
+			result.err = err
+			continue
 		}
-		scheduledIDs = append(scheduledIDs, reminder.ID)
+		scheduledIDs = append(scheduledIDs, rem.ID)
+	}
+	if len(scheduledIDs) == 0 {
+		return result, nil
 	}
 
+	_, err = uow.Reminders().Schedule(
+		ctx,
+		reminder.ScheduleInput{IDs: scheduledIDs, ScheduledAt: now},
+	)
+	if err != nil {
+		return result, err
+	}
 	if err := uow.Commit(ctx); err != nil {
-		logging.Error(ctx, s.log, err)
 		return result, err
 	}
+	result.scheduled = len(scheduledIDs)
 
-	if len(scheduledIDs) > 0 {
-		s.log.Info(
-			ctx,
-			"Reminders successfully scheduled.",
-			logging.Entry("scheduledCount", len(scheduledIDs)),
-			logging.Entry("scheduledIDs", scheduledIDs),
-		)
-	}
+	s.log.Info(
+		ctx,
+		"Reminders batch successfully scheduled.",
+		logging.Entry("scheduledCount", result.scheduled),
+		logging.Entry("failedCount", result.failed),
+		logging.Entry("scheduledIDs", scheduledIDs),
+	)
 	return result, nil
 }
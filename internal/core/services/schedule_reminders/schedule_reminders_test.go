@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/metrics"
 	"remindme/internal/core/domain/reminder"
 	uow "remindme/internal/core/domain/unit_of_work"
 	"remindme/internal/core/domain/user"
@@ -14,6 +15,8 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+const BATCH_SIZE = 2
+
 var (
 	Now = time.Now().UTC()
 )
@@ -23,6 +26,7 @@ type testSuite struct {
 	logger     *logging.FakeLogger
 	unitOfWork *uow.FakeUnitOfWork
 	scheduler  *reminder.TestReminderScheduler
+	metrics    *metrics.FakeMetrics
 	service    services.Service[Input, Result]
 }
 
@@ -30,10 +34,13 @@ func (suite *testSuite) SetupTest() {
 	suite.logger = logging.NewFakeLogger()
 	suite.unitOfWork = uow.NewFakeUnitOfWork()
 	suite.scheduler = reminder.NewTestReminderScheduler()
+	suite.metrics = metrics.NewFakeMetrics()
 	suite.service = New(
 		suite.logger,
 		suite.unitOfWork,
 		suite.scheduler,
+		suite.metrics,
+		BATCH_SIZE,
 		func() time.Time { return Now },
 	)
 }
@@ -48,23 +55,29 @@ func TestScheduleRemindersService(t *testing.T) {
 
 func (s *testSuite) TestSuccess() {
 	cases := []struct {
-		id        string
-		now       time.Time
-		reminders []reminder.Reminder
+		id              string
+		now             time.Time
+		reminders       []reminder.Reminder
+		expectedBatches int
+		expectedReads   int
 	}{
 		{
-			id:        "1",
-			now:       Now,
-			reminders: []reminder.Reminder{},
+			id:              "1",
+			now:             Now,
+			reminders:       []reminder.Reminder{},
+			expectedBatches: 0,
+			expectedReads:   1,
 		},
 		{
 			id:  "2",
 			now: Now,
 			reminders: []reminder.Reminder{
-				{ID: reminder.ID(1), CreatedBy: user.ID(100), Status: reminder.StatusCreated},
-				{ID: reminder.ID(2), CreatedBy: user.ID(200), Status: reminder.StatusCreated},
-				{ID: reminder.ID(3), CreatedBy: user.ID(200), Status: reminder.StatusCreated},
+				{ID: reminder.ID(1), CreatedBy: user.ID(100), At: Now, Status: reminder.StatusCreated},
+				{ID: reminder.ID(2), CreatedBy: user.ID(200), At: Now, Status: reminder.StatusCreated},
+				{ID: reminder.ID(3), CreatedBy: user.ID(200), At: Now, Status: reminder.StatusCreated},
 			},
+			expectedBatches: 2,
+			expectedReads:   2,
 		},
 		{
 			id:  "3",
@@ -72,6 +85,18 @@ func (s *testSuite) TestSuccess() {
 			reminders: []reminder.Reminder{
 				{ID: reminder.ID(1), CreatedBy: user.ID(100), Status: reminder.StatusCreated},
 			},
+			expectedBatches: 1,
+			expectedReads:   1,
+		},
+		{
+			id:  "4",
+			now: Now,
+			reminders: []reminder.Reminder{
+				{ID: reminder.ID(2), CreatedBy: user.ID(100), At: Now, Status: reminder.StatusCreated},
+				{ID: reminder.ID(1), CreatedBy: user.ID(200), At: Now.Add(time.Minute), Status: reminder.StatusCreated},
+			},
+			expectedBatches: 1,
+			expectedReads:   2,
 		},
 	}
 
@@ -85,28 +110,106 @@ func (s *testSuite) TestSuccess() {
 				logging.NewFakeLogger(),
 				unitOfWork,
 				scheduler,
+				metrics.NewFakeMetrics(),
+				BATCH_SIZE,
 				func() time.Time { return testcase.now },
 			)
 
 			// Exercise ---
-			_, err := service.Run(context.Background(), Input{})
+			result, err := service.Run(context.Background(), Input{})
 
 			// Verify ---
 			s.Nil(err)
 			s.ElementsMatch(testcase.reminders, scheduler.Scheduled)
-			s.Len(unitOfWork.Reminders().ScheduleWith, 1)
-			s.Equal(
-				unitOfWork.Reminders().ScheduleWith[0],
-				reminder.ScheduleInput{
-					ScheduledAt: testcase.now,
-					AtBefore:    testcase.now.Add(reminder.DURATION_FOR_SCHEDULING),
-				},
-			)
-			s.True(unitOfWork.Context.WasCommitCalled)
+			s.Equal(testcase.expectedBatches, result.Batches)
+			s.Equal(len(testcase.reminders), result.Scheduled)
+			s.Equal(0, result.Failed)
+			s.Len(unitOfWork.Reminders().ReadForSchedulingWith, testcase.expectedReads)
+			for _, input := range unitOfWork.Reminders().ReadForSchedulingWith {
+				s.Equal(testcase.now.Add(reminder.DURATION_FOR_SCHEDULING), input.AtBefore)
+				s.Equal(uint(BATCH_SIZE), input.Limit)
+			}
+			s.Len(unitOfWork.Reminders().ScheduleWith, testcase.expectedBatches)
+			for _, input := range unitOfWork.Reminders().ScheduleWith {
+				s.Equal(testcase.now, input.ScheduledAt)
+			}
+			s.Equal(testcase.expectedBatches > 0, unitOfWork.Context.WasCommitCalled)
 		})
 	}
 }
 
+func (s *testSuite) TestPagination() {
+	// Setup ---
+	at := time.Date(2020, 1, 1, 15, 0, 0, 0, time.UTC)
+	s.unitOfWork.Reminders().ScheduleResult = []reminder.Reminder{
+		{ID: reminder.ID(3), At: at},
+		{ID: reminder.ID(4), At: at},
+		{ID: reminder.ID(1), At: at.Add(time.Minute)},
+		{ID: reminder.ID(2), At: at.Add(time.Minute)},
+		{ID: reminder.ID(5), At: at.Add(time.Hour)},
+	}
+
+	// Exercise ---
+	result, err := s.service.Run(context.Background(), Input{})
+
+	// Verify ---
+	s.Nil(err)
+	s.Equal(3, result.Batches)
+	s.Equal(5, result.Scheduled)
+	s.Equal(
+		[]reminder.ReadForSchedulingInput{
+			{AtBefore: Now.Add(reminder.DURATION_FOR_SCHEDULING), Limit: BATCH_SIZE},
+			{AtBefore: Now.Add(reminder.DURATION_FOR_SCHEDULING), AfterAt: at, AfterID: reminder.ID(4), Limit: BATCH_SIZE},
+			{
+				AtBefore: Now.Add(reminder.DURATION_FOR_SCHEDULING),
+				AfterAt:  at.Add(time.Minute),
+				AfterID:  reminder.ID(2),
+				Limit:    BATCH_SIZE,
+			},
+		},
+		s.unitOfWork.Reminders().ReadForSchedulingWith,
+	)
+	s.Equal(
+		[]reminder.ScheduleInput{
+			{IDs: []reminder.ID{3, 4}, ScheduledAt: Now},
+			{IDs: []reminder.ID{1, 2}, ScheduledAt: Now},
+			{IDs: []reminder.ID{5}, ScheduledAt: Now},
+		},
+		s.unitOfWork.Reminders().ScheduleWith,
+	)
+}
+
+func (s *testSuite) TestPartialFailure() {
+	// Setup ---
+	s.unitOfWork.Reminders().ScheduleResult = []reminder.Reminder{
+		{ID: reminder.ID(1), At: Now},
+		{ID: reminder.ID(2), At: Now},
+		{ID: reminder.ID(3), At: Now},
+	}
+	scheduler := &failingScheduler{
+		TestReminderScheduler: reminder.NewTestReminderScheduler(),
+		failIDs:               map[reminder.ID]struct{}{reminder.ID(2): {}},
+	}
+	service := New(s.logger, s.unitOfWork, scheduler, s.metrics, BATCH_SIZE, func() time.Time { return Now })
+
+	// Exercise ---
+	result, err := service.Run(context.Background(), Input{})
+
+	// Verify ---
+	s.Nil(err)
+	s.Equal(Result{Batches: 2, Scheduled: 2, Failed: 1, Duration: result.Duration}, result)
+	s.Equal(2, s.metrics.Scheduled)
+	s.Equal(1, s.metrics.SchedulingFailed)
+	s.Equal(
+		[]reminder.ScheduleInput{
+			{IDs: []reminder.ID{1}, ScheduledAt: Now},
+			{IDs: []reminder.ID{3}, ScheduledAt: Now},
+		},
+		s.unitOfWork.Reminders().ScheduleWith,
+	)
+	s.True(s.unitOfWork.Context.WasCommitCalled)
+}
+
 func (s *testSuite) TestSchedulingError() {
 	s.T().Parallel()
 
@@ -119,15 +222,45 @@ func (s *testSuite) TestSchedulingError() {
 		logging.NewFakeLogger(),
 		unitOfWork,
 		scheduler,
+		metrics.NewFakeMetrics(),
+		BATCH_SIZE,
 		func() time.Time { return Now },
 	)
 
 	// Exercise ---
-	_, err := service.Run(context.Background(), Input{})
+	result, err := service.Run(context.Background(), Input{})
 
 	// Verify ---
 	assert := s.Require()
 	assert.ErrorIs(err, scheduler.Error)
+	assert.Equal(1, result.Failed)
+	assert.Equal(0, result.Scheduled)
+	assert.Empty(unitOfWork.Reminders().ScheduleWith)
 	assert.False(unitOfWork.Context.WasCommitCalled)
 	assert.True(unitOfWork.Context.WasRollbackCalled)
 }
+
+func (s *testSuite) TestReadError() {
+	// Setup ---
+	s.unitOfWork.Reminders().ReadForSchedulingError = errors.New("an error occured")
+
+	// Exercise ---
+	_, err := s.service.Run(context.Background(), Input{})
+
+	// Verify ---
+	s.ErrorIs(err, s.unitOfWork.Reminders().ReadForSchedulingError)
+	s.False(s.unitOfWork.Context.WasCommitCalled)
+	s.Empty(s.scheduler.Scheduled)
+}
+
+type failingScheduler struct {
+	*reminder.TestReminderScheduler
+	failIDs map[reminder.ID]struct{}
+}
+
+func (s *failingScheduler) ScheduleReminder(ctx context.Context, r reminder.Reminder) error {
+	if _, ok := s.failIDs[r.ID]; ok {
+		return errors.New("an error occured")
+	}
+	return s.TestReminderScheduler.ScheduleReminder(ctx, r)
+}
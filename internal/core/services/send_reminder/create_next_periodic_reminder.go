@@ -67,6 +67,15 @@ func (s *createNextPeriodicService) Run(ctx context.Context, input Input) (resul
 		)
 		return result, err
 	}
+	if !result.IsPrepared {
+		s.log.Info(
+			ctx,
+			"Reminder has not been prepared for sending, skip the next reminder creation.",
+			logging.Entry("input", input),
+			logging.Entry("status", result.Reminder.Status),
+		)
+		return result, nil
+	}
 
 	uow, err := s.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -94,6 +103,10 @@ func (s *createNextPeriodicService) Run(ctx context.Context, input Input) (resul
 		logging.Error(ctx, s.log, err, logging.Entry("result", result), logging.Entry("err", err))
 		return result, err
 	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), reminder.Reminder{}, nextReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("result", result), logging.Entry("err", err))
+		return result, err
+	}
 
 	nextReminderChannelIDs, err := uow.ReminderChannels().Create(
 		ctx,
@@ -107,6 +107,7 @@ func TestNewReminderCreated(t *testing.T) {
 			fixture.prepareService.result.Reminder.At = testcase.at
 			fixture.prepareService.result.Reminder.Status = testcase.status
 			fixture.prepareService.result.Reminder.Body = testcase.body
+			fixture.prepareService.result.IsPrepared = true
 			fixture.unitOfWork.Reminders().CreatedID = reminder.ID(123)
 			service := fixture.createService()
 
@@ -160,6 +161,7 @@ func TestNewReminderCreatedIfSendingLimitExceeded(t *testing.T) {
 	fixture := newFixture()
 	fixture.prepareService.result.Reminder.Every.IsPresent = true
 	fixture.prepareService.result.Reminder.Every.Value = reminder.EveryDay
+	fixture.prepareService.result.IsPrepared = true
 	fixture.prepareService.err = user.ErrLimitSentReminderCountExceeded
 	service := fixture.createService()
 
@@ -176,6 +178,7 @@ func TestNewReminderCreatedWithChannels(t *testing.T) {
 	fixture.prepareService.result.Reminder.Every.IsPresent = true
 	fixture.prepareService.result.Reminder.Every.Value = reminder.EveryDay
 	fixture.prepareService.result.Reminder.ChannelIDs = []channel.ID{channel.ID(10), channel.ID(20)}
+	fixture.prepareService.result.IsPrepared = true
 	service := fixture.createService()
 
 	_, err := service.Run(context.Background(), Input{})
@@ -185,3 +188,32 @@ func TestNewReminderCreatedWithChannels(t *testing.T) {
 	assert.True(fixture.unitOfWork.ReminderChannels().WasCreateCalled)
 	assert.Equal(reminder.ID(0), fixture.unitOfWork.ReminderChannels().CreatedForReminder)
 }
+
+func TestNewReminderIsNotCreatedIfReminderIsNotPrepared(t *testing.T) {
+	cases := []struct {
+		id     string
+		status reminder.Status
+	}{
+		{id: "paused", status: reminder.StatusPaused},
+		{id: "at changed", status: reminder.StatusScheduled},
+		{id: "already sending", status: reminder.StatusSending},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			fixture := newFixture()
+			fixture.prepareService.result.Reminder.Every = c.NewOptional(reminder.EveryHour, true)
+			fixture.prepareService.result.Reminder.Status = testcase.status
+			fixture.prepareService.result.IsPrepared = false
+			service := fixture.createService()
+
+			result, err := service.Run(context.Background(), Input{})
+
+			assert := require.New(t)
+			assert.Nil(err)
+			assert.Equal(testcase.status, result.Reminder.Status)
+			assert.Equal(0, fixture.unitOfWork.Reminders().CreatedCount)
+			assert.Len(fixture.scheduler.Scheduled, 0)
+		})
+	}
+}
@@ -21,6 +21,9 @@ type Input struct {
 
 type Result struct {
 	Reminder reminder.ReminderWithChannels
+	// IsPrepared is set if the run moved the reminder to sending or sent_limit_exceeded,
+	// the reminder is returned as is if it was paused, moved or already processed.
+	IsPrepared bool
 }
 
 type prepareService struct {
@@ -120,6 +123,10 @@ func (s *prepareService) Run(ctx context.Context, input Input) (result Result, e
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, updatedReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
 
 	if err := uow.Commit(ctx); err != nil {
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
@@ -127,6 +134,7 @@ func (s *prepareService) Run(ctx context.Context, input Input) (result Result, e
 	}
 
 	result.Reminder.FromReminderAndChannels(updatedReminder, rem.ChannelIDs)
+	result.IsPrepared = true
 	s.log.Info(
 		ctx,
 		"Reminder status has been successfully changed to 'sending'.",
@@ -154,25 +162,22 @@ func checkUserLimits(
 		return nil
 	}
 
-	sentAfter := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-	logEntries := []logging.LogEntry{
-		logging.Entry("userID", userID),
-		logging.Entry("sendAfter", sentAfter),
-		logging.Entry("limit", limits.MonthlySentReminderCount),
+	u, err := uow.Users().GetByID(ctx, userID)
+	if err != nil {
+		logging.Error(ctx, log, err, logging.Entry("userID", userID))
+		return err
 	}
-
-	count, err := uow.Reminders().Count(ctx, reminder.ReadOptions{
-		CreatedByEquals: c.NewOptional(userID, true),
-		SentAfter:       c.NewOptional(sentAfter, true),
-		StatusIn:        c.NewOptional([]reminder.Status{reminder.StatusSentSuccess}, true),
-	})
+	quota, err := reminder.GetSentQuota(ctx, uow.Usage(), userID, limits, u.TimeZone, now)
 	if err != nil {
-		logging.Error(ctx, log, err, logEntries...)
+		logging.Error(ctx, log, err, logging.Entry("userID", userID))
 		return err
 	}
 
-	logEntries = append(logEntries, logging.Entry("count", count))
-	if count >= uint(limits.MonthlySentReminderCount.Value) {
+	logEntries := []logging.LogEntry{
+		logging.Entry("userID", userID),
+		logging.Entry("quota", quota.Value),
+	}
+	if quota.Value.IsExceeded() {
 		log.Info(ctx, "Sent reminder count limit exceeded.", logEntries...)
 		return user.ErrLimitSentReminderCountExceeded
 	}
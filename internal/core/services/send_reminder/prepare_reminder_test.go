@@ -77,7 +77,9 @@ func TestReminderPreparedSuccessfully(t *testing.T) {
 			unitOfWork.Reminders().GetByIDReminder.Status = reminder.StatusScheduled
 			unitOfWork.Reminders().GetByIDReminder.At = testcase.reminderAt
 			unitOfWork.Reminders().GetByIDReminder.ChannelIDs = []channel.ID{CHANNEL_ID}
-			unitOfWork.Reminders().CountResult = testcase.sentCount
+			unitOfWork.Reminders().GetByIDReminder.CreatedBy = createUser(t, unitOfWork, time.UTC)
+			unitOfWork.Usage().SentBuckets[Now] = testcase.sentCount
+			unitOfWork.Usage().ActiveCount = 1
 			unitOfWork.Limits().Limits = testcase.userLimits
 			service := NewPrepareService(log, unitOfWork, func() time.Time { return Now })
 
@@ -88,6 +90,8 @@ func TestReminderPreparedSuccessfully(t *testing.T) {
 			assert := require.New(t)
 			assert.Nil(err)
 			assert.Equal(reminder.StatusSending, result.Reminder.Status)
+			assert.True(result.IsPrepared)
+			assert.Equal(uint(0), unitOfWork.Usage().ActiveCount)
 			assert.True(unitOfWork.Context.WasCommitCalled)
 		})
 	}
@@ -124,6 +128,27 @@ func TestReminderStatusIsNotScheduled(t *testing.T) {
 	assert := require.New(t)
 	assert.Nil(err)
 	assert.Equal(reminder.StatusCanceled, result.Reminder.Status)
+	assert.False(result.IsPrepared)
+	assert.False(unitOfWork.Context.WasCommitCalled)
+	assert.True(unitOfWork.Context.WasRollbackCalled)
+}
+
+func TestReminderIsPaused(t *testing.T) {
+	// Setup ---
+	log := logging.NewFakeLogger()
+	unitOfWork := uow.NewFakeUnitOfWork()
+	unitOfWork.Reminders().GetByIDReminder.Status = reminder.StatusPaused
+	unitOfWork.Reminders().GetByIDReminder.At = Now
+	service := NewPrepareService(log, unitOfWork, func() time.Time { return Now })
+
+	// Exercise ---
+	result, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
+
+	// Verify ---
+	assert := require.New(t)
+	assert.Nil(err)
+	assert.Equal(reminder.StatusPaused, result.Reminder.Status)
+	assert.False(result.IsPrepared)
 	assert.False(unitOfWork.Context.WasCommitCalled)
 	assert.True(unitOfWork.Context.WasRollbackCalled)
 }
@@ -143,6 +168,7 @@ func TestReminderStatusAtTimeChanged(t *testing.T) {
 	assert := require.New(t)
 	assert.Nil(err)
 	assert.Equal(reminder.StatusScheduled, result.Reminder.Status)
+	assert.False(result.IsPrepared)
 	assert.False(unitOfWork.Context.WasCommitCalled)
 	assert.True(unitOfWork.Context.WasRollbackCalled)
 }
@@ -153,8 +179,9 @@ func TestUserMonthlySentLimitExceeded(t *testing.T) {
 	unitOfWork := uow.NewFakeUnitOfWork()
 	unitOfWork.Reminders().GetByIDReminder.Status = reminder.StatusScheduled
 	unitOfWork.Reminders().GetByIDReminder.At = Now
+	unitOfWork.Reminders().GetByIDReminder.CreatedBy = createUser(t, unitOfWork, time.UTC)
 	unitOfWork.Limits().Limits.MonthlySentReminderCount = c.NewOptional(uint32(100), true)
-	unitOfWork.Reminders().CountResult = 100
+	unitOfWork.Usage().SentBuckets[Now] = 100
 	service := NewPrepareService(log, unitOfWork, func() time.Time { return Now })
 
 	// Exercise ---
@@ -164,6 +191,38 @@ func TestUserMonthlySentLimitExceeded(t *testing.T) {
 	assert := require.New(t)
 	assert.Nil(err)
 	assert.Equal(reminder.StatusSentLimitExceeded, result.Reminder.Status)
+	assert.True(result.IsPrepared)
 	assert.Equal(c.NewOptional(Now, true), result.Reminder.CanceledAt)
 	assert.True(unitOfWork.Context.WasCommitCalled)
 }
+
+func TestUserMonthlySentLimitCountedInUserTimeZone(t *testing.T) {
+	// Setup ---
+	log := logging.NewFakeLogger()
+	unitOfWork := uow.NewFakeUnitOfWork()
+	unitOfWork.Reminders().GetByIDReminder.Status = reminder.StatusScheduled
+	unitOfWork.Reminders().GetByIDReminder.At = Now
+	unitOfWork.Reminders().GetByIDReminder.CreatedBy = createUser(t, unitOfWork, time.FixedZone("UTC+10", 10*60*60))
+	unitOfWork.Limits().Limits.MonthlySentReminderCount = c.NewOptional(uint32(100), true)
+	service := NewPrepareService(log, unitOfWork, func() time.Time { return Now })
+
+	// Exercise ---
+	_, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
+
+	// Verify ---
+	assert := require.New(t)
+	assert.Nil(err)
+	assert.Equal([]time.Time{time.Date(2020, 5, 31, 14, 0, 0, 0, time.UTC)}, unitOfWork.Usage().CountSentWith)
+}
+
+func createUser(t *testing.T, unitOfWork *uow.FakeUnitOfWork, timeZone *time.Location) user.ID {
+	t.Helper()
+	u, err := unitOfWork.Users().Create(context.Background(), user.CreateUserInput{
+		Email:       c.NewOptional(c.NewEmail("test@test.test"), true),
+		CreatedAt:   Now,
+		ActivatedAt: c.NewOptional(Now, true),
+		TimeZone:    timeZone,
+	})
+	require.Nil(t, err)
+	return u.ID
+}
@@ -6,21 +6,22 @@ import (
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
 	"remindme/internal/core/services"
 	"time"
 )
 
 type sendService struct {
-	log                logging.Logger
-	reminderRepository reminder.ReminderRepository
-	sender             reminder.Sender
-	now                func() time.Time
-	prepareService     services.Service[Input, Result]
+	log            logging.Logger
+	unitOfWork     uow.UnitOfWork
+	sender         reminder.Sender
+	now            func() time.Time
+	prepareService services.Service[Input, Result]
 }
 
 func NewSendService(
 	log logging.Logger,
-	reminderRepository reminder.ReminderRepository,
+	unitOfWork uow.UnitOfWork,
 	sender reminder.Sender,
 	now func() time.Time,
 	prepareService services.Service[Input, Result],
@@ -28,8 +29,8 @@ func NewSendService(
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
 	}
-	if reminderRepository == nil {
-		panic(e.NewNilArgumentError("reminderRepository"))
+	if unitOfWork == nil {
+		panic(e.NewNilArgumentError("unitOfWork"))
 	}
 	if sender == nil {
 		panic(e.NewNilArgumentError("sender"))
@@ -41,11 +42,11 @@ func NewSendService(
 		panic(e.NewNilArgumentError("prepareService"))
 	}
 	return &sendService{
-		log:                log,
-		reminderRepository: reminderRepository,
-		sender:             sender,
-		now:                now,
-		prepareService:     prepareService,
+		log:            log,
+		unitOfWork:     unitOfWork,
+		sender:         sender,
+		now:            now,
+		prepareService: prepareService,
 	}
 }
 
@@ -55,10 +56,10 @@ func (s *sendService) Run(ctx context.Context, input Input) (result Result, err
 		return prepared, err
 	}
 
-	if prepared.Reminder.Status != reminder.StatusSending {
+	if !prepared.IsPrepared || prepared.Reminder.Status != reminder.StatusSending {
 		s.log.Info(
 			ctx,
-			"Reminder is skipped due to the status is not 'sending'.",
+			"Reminder is skipped due to it has not been moved to 'sending'.",
 			logging.Entry("input", input),
 			logging.Entry("status", prepared.Reminder.Status),
 		)
@@ -92,12 +93,35 @@ func (s *sendService) Run(ctx context.Context, input Input) (result Result, err
 		}
 	}
 
-	updatedReminder, err := s.reminderRepository.Update(ctx, update)
+	updatedReminder, err := s.update(ctx, prepared.Reminder.Reminder, update)
 	if err != nil {
 		logging.Error(ctx, s.log, err, logging.Entry("input", input), logging.Entry("reminder", prepared.Reminder))
 		return prepared, err
 	}
 
 	result.Reminder.FromReminderAndChannels(updatedReminder, prepared.Reminder.ChannelIDs)
+	result.IsPrepared = true
 	return result, err
 }
+
+// update saves the sending outcome together with the usage counters of the reminder creator.
+func (s *sendService) update(
+	ctx context.Context,
+	rem reminder.Reminder,
+	update reminder.UpdateInput,
+) (updatedReminder reminder.Reminder, err error) {
+	uow, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return updatedReminder, err
+	}
+	defer uow.Rollback(ctx)
+
+	updatedReminder, err = uow.Reminders().Update(ctx, update)
+	if err != nil {
+		return updatedReminder, err
+	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem, updatedReminder); err != nil {
+		return updatedReminder, err
+	}
+	return updatedReminder, uow.Commit(ctx)
+}
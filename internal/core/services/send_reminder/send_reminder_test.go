@@ -6,6 +6,7 @@ import (
 	c "remindme/internal/core/domain/common"
 	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/reminder"
+	uow "remindme/internal/core/domain/unit_of_work"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ func newStubPrepareService() *stubPrepareService {
 	service := &stubPrepareService{}
 	service.result.Reminder.Status = reminder.StatusSending
 	service.result.Reminder.At = Now
+	service.result.IsPrepared = true
 	return service
 }
 
@@ -31,10 +33,10 @@ func (s *stubPrepareService) Run(ctx context.Context, input Input) (Result, erro
 func TestReminderSentSuccessfully(t *testing.T) {
 	// Setup ---
 	log := logging.NewFakeLogger()
-	reminderRepo := reminder.NewTestReminderRepository()
+	unitOfWork := uow.NewFakeUnitOfWork()
 	sender := reminder.NewTestReminderSender()
 	prepareService := newStubPrepareService()
-	service := NewSendService(log, reminderRepo, sender, func() time.Time { return Now }, prepareService)
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
 
 	// Exercise ---
 	result, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
@@ -45,16 +47,18 @@ func TestReminderSentSuccessfully(t *testing.T) {
 	assert.Equal(reminder.StatusSentSuccess, result.Reminder.Status)
 	assert.Equal(c.NewOptional(Now, true), result.Reminder.SentAt)
 	assert.Len(sender.Sent, 1)
+	assert.Equal(map[time.Time]uint{reminder.SentUsageBucket(Now): 1}, unitOfWork.Usage().SentBuckets)
+	assert.True(unitOfWork.Context.WasCommitCalled)
 }
 
 func TestReminderNotSentIfStatusIsNotSending(t *testing.T) {
 	// Setup ---
 	log := logging.NewFakeLogger()
-	reminderRepo := reminder.NewTestReminderRepository()
+	unitOfWork := uow.NewFakeUnitOfWork()
 	sender := reminder.NewTestReminderSender()
 	prepareService := newStubPrepareService()
 	prepareService.result.Reminder.Status = reminder.StatusScheduled
-	service := NewSendService(log, reminderRepo, sender, func() time.Time { return Now }, prepareService)
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
 
 	// Exercise ---
 	result, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
@@ -67,14 +71,34 @@ func TestReminderNotSentIfStatusIsNotSending(t *testing.T) {
 	assert.Len(sender.Sent, 0)
 }
 
+func TestReminderNotSentIfNotPrepared(t *testing.T) {
+	// Setup ---
+	log := logging.NewFakeLogger()
+	unitOfWork := uow.NewFakeUnitOfWork()
+	sender := reminder.NewTestReminderSender()
+	prepareService := newStubPrepareService()
+	prepareService.result.IsPrepared = false
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
+
+	// Exercise ---
+	result, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
+
+	// Verify ---
+	assert := require.New(t)
+	assert.Nil(err)
+	assert.Equal(reminder.StatusSending, result.Reminder.Status)
+	assert.False(result.IsPrepared)
+	assert.Len(sender.Sent, 0)
+}
+
 func TestReminderNotSentIfInnerServiceReturnsError(t *testing.T) {
 	// Setup ---
 	log := logging.NewFakeLogger()
-	reminderRepo := reminder.NewTestReminderRepository()
+	unitOfWork := uow.NewFakeUnitOfWork()
 	sender := reminder.NewTestReminderSender()
 	prepareService := newStubPrepareService()
 	prepareService.err = errors.New("test error")
-	service := NewSendService(log, reminderRepo, sender, func() time.Time { return Now }, prepareService)
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
 
 	// Exercise ---
 	_, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
@@ -88,11 +112,11 @@ func TestReminderNotSentIfInnerServiceReturnsError(t *testing.T) {
 func TestReminderSendingError(t *testing.T) {
 	// Setup ---
 	log := logging.NewFakeLogger()
-	reminderRepo := reminder.NewTestReminderRepository()
+	unitOfWork := uow.NewFakeUnitOfWork()
 	sender := reminder.NewTestReminderSender()
 	sender.SentError = errors.New("test error")
 	prepareService := newStubPrepareService()
-	service := NewSendService(log, reminderRepo, sender, func() time.Time { return Now }, prepareService)
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
 
 	// Exercise ---
 	result, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
@@ -107,11 +131,11 @@ func TestReminderSendingError(t *testing.T) {
 func TestMaxSendingDelayExceeded(t *testing.T) {
 	// Setup ---
 	log := logging.NewFakeLogger()
-	reminderRepo := reminder.NewTestReminderRepository()
+	unitOfWork := uow.NewFakeUnitOfWork()
 	sender := reminder.NewTestReminderSender()
 	prepareService := newStubPrepareService()
 	prepareService.result.Reminder.At = Now.Add(-1 * (reminder.MAX_SENDING_DELAY + time.Second))
-	service := NewSendService(log, reminderRepo, sender, func() time.Time { return Now }, prepareService)
+	service := NewSendService(log, unitOfWork, sender, func() time.Time { return Now }, prepareService)
 
 	// Exercise ---
 	result, err := service.Run(
@@ -126,3 +150,23 @@ func TestMaxSendingDelayExceeded(t *testing.T) {
 	assert.Equal(c.NewOptional(Now, true), result.Reminder.CanceledAt)
 	assert.Len(sender.Sent, 0)
 }
+
+func TestPurgedReminderNotSent(t *testing.T) {
+	// Setup ---
+	log := logging.NewFakeLogger()
+	unitOfWork := uow.NewFakeUnitOfWork()
+	unitOfWork.Reminders().GetByIDError = reminder.ErrReminderDoesNotExist
+	sender := reminder.NewTestReminderSender()
+	now := func() time.Time { return Now }
+	service := NewSendService(log, unitOfWork, sender, now, NewPrepareService(log, unitOfWork, now))
+
+	// Exercise ---
+	_, err := service.Run(context.Background(), Input{ReminderID: REMINDER_ID, At: Now})
+
+	// Verify ---
+	assert := require.New(t)
+	assert.Nil(err, "the message of a purged reminder must be acked")
+	assert.Empty(sender.Sent)
+	assert.Empty(unitOfWork.Usage().SentBuckets)
+	assert.False(unitOfWork.Context.WasCommitCalled)
+}
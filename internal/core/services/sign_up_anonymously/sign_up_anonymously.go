@@ -15,8 +15,9 @@ import (
 )
 
 type Input struct {
-	IP       netip.Addr
-	TimeZone *time.Location
+	IP        netip.Addr
+	UserAgent string
+	TimeZone  *time.Location
 }
 
 type Result struct {
@@ -117,6 +118,8 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 			UserID:    createdUser.ID,
 			Token:     sessionToken,
 			CreatedAt: now,
+			UserAgent: input.UserAgent,
+			IP:        input.IP.String(),
 		},
 	)
 	if err != nil {
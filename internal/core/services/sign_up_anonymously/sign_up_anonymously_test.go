@@ -74,7 +74,11 @@ func (suite *testSuite) TestSuccess() {
 
 	assert.Equal(user.SessionToken(SESSION_TOKEN), sessionToken)
 
-	u, _ := suite.UnitOfWork.Context.SessionRepository.GetUserByToken(ctx, sessionToken)
+	u, _ := suite.UnitOfWork.Context.SessionRepository.GetUserByToken(ctx, user.GetUserBySessionTokenInput{
+		Token:  sessionToken,
+		At:     Now,
+		Expiry: user.SessionExpiry{Idle: time.Hour, Absolute: time.Hour},
+	})
 	assert.Equal(createdUser, u)
 
 	assert.True(suite.UnitOfWork.Context.WasCommitCalled)
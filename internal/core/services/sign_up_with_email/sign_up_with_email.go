@@ -27,6 +27,7 @@ type service struct {
 	unitOfWork               uow.UnitOfWork
 	passwordHasher           user.PasswordHasher
 	activationTokenGenerator user.ActivationTokenGenerator
+	activationTokenTTL       time.Duration
 	now                      func() time.Time
 }
 
@@ -35,6 +36,7 @@ func New(
 	unitOfWork uow.UnitOfWork,
 	passwordHasher user.PasswordHasher,
 	activationTokenGenerator user.ActivationTokenGenerator,
+	activationTokenTTL time.Duration,
 	now func() time.Time,
 ) services.Service[Input, Result] {
 	if unitOfWork == nil {
@@ -56,6 +58,7 @@ func New(
 		unitOfWork:               unitOfWork,
 		passwordHasher:           passwordHasher,
 		activationTokenGenerator: activationTokenGenerator,
+		activationTokenTTL:       activationTokenTTL,
 		log:                      log,
 		now:                      now,
 	}
@@ -82,12 +85,14 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	}
 	defer uow.Rollback(ctx)
 
+	now := s.now()
 	createdUser, err := uow.Users().Create(ctx, user.CreateUserInput{
-		Email:           c.NewOptional(input.Email, true),
-		PasswordHash:    c.NewOptional(passwordHash, true),
-		CreatedAt:       s.now(),
-		ActivationToken: c.NewOptional(s.activationTokenGenerator.GenerateActivationToken(), true),
-		TimeZone:        input.TimeZone,
+		Email:                    c.NewOptional(input.Email, true),
+		PasswordHash:             c.NewOptional(passwordHash, true),
+		CreatedAt:                now,
+		ActivationToken:          c.NewOptional(s.activationTokenGenerator.GenerateActivationToken(), true),
+		ActivationTokenExpiresAt: c.NewOptional(now.Add(s.activationTokenTTL), true),
+		TimeZone:                 input.TimeZone,
 	})
 	if errors.Is(err, context.Canceled) {
 		return result, err
@@ -20,6 +20,8 @@ const (
 	RAW_PASSWORD     = user.RawPassword("test-password")
 )
 
+const ACTIVATION_TOKEN_TTL = 48 * time.Hour
+
 var NOW time.Time = time.Now().UTC()
 
 type testSuite struct {
@@ -41,6 +43,7 @@ func (suite *testSuite) SetupTest() {
 		suite.UnitOfWork,
 		suite.PasswordHasher,
 		suite.ActivationTokenGenerator,
+		ACTIVATION_TOKEN_TTL,
 		func() time.Time { return NOW },
 	)
 }
@@ -62,6 +65,7 @@ func (suite *testSuite) TestSuccess() {
 	assert.True(result.User.PasswordHash.IsPresent)
 	assert.NotEqual(RAW_PASSWORD, result.User.PasswordHash.Value)
 	assert.False(result.User.Identity.IsPresent)
+	assert.Equal(c.NewOptional(NOW.Add(ACTIVATION_TOKEN_TTL), true), result.User.ActivationTokenExpiresAt)
 	assert.True(suite.UnitOfWork.Context.WasCommitCalled)
 }
 
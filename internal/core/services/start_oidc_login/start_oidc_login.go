@@ -0,0 +1,114 @@
+package startoidclogin
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/auth"
+	"time"
+)
+
+type Input struct {
+	Provider user.OIDCProviderName
+	// UserID is set when the external identity is linked to the logged in user.
+	UserID c.Optional[user.ID]
+}
+
+func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
+	i.UserID = c.NewOptional(u.ID, true)
+	return i
+}
+
+// Result has the state of the authorization, it is bound to the browser which
+// started the login and must be presented again with the callback.
+type Result struct {
+	AuthorizationURL string
+	State            user.OIDCState
+	ExpiresAt        time.Time
+}
+
+type service struct {
+	log                     logging.Logger
+	providers               map[user.OIDCProviderName]user.OIDCProvider
+	authorizationRepository user.OIDCAuthorizationRepository
+	authorizationGenerator  user.OIDCAuthorizationGenerator
+	authorizationTTL        time.Duration
+	now                     func() time.Time
+}
+
+func New(
+	log logging.Logger,
+	providers map[user.OIDCProviderName]user.OIDCProvider,
+	authorizationRepository user.OIDCAuthorizationRepository,
+	authorizationGenerator user.OIDCAuthorizationGenerator,
+	authorizationTTL time.Duration,
+	now func() time.Time,
+) services.Service[Input, Result] {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if providers == nil {
+		panic(e.NewNilArgumentError("providers"))
+	}
+	if authorizationRepository == nil {
+		panic(e.NewNilArgumentError("authorizationRepository"))
+	}
+	if authorizationGenerator == nil {
+		panic(e.NewNilArgumentError("authorizationGenerator"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &service{
+		log:                     log,
+		providers:               providers,
+		authorizationRepository: authorizationRepository,
+		authorizationGenerator:  authorizationGenerator,
+		authorizationTTL:        authorizationTTL,
+		now:                     now,
+	}
+}
+
+func (s *service) Run(ctx context.Context, input Input) (result Result, err error) {
+	provider, ok := s.providers[input.Provider]
+	if !ok {
+		s.log.Info(ctx, "Unknown OIDC provider.", logging.Entry("input", input))
+		return result, user.ErrUnknownOIDCProvider
+	}
+
+	authorization := user.OIDCAuthorization{
+		State:        s.authorizationGenerator.GenerateOIDCState(),
+		Provider:     input.Provider,
+		Nonce:        s.authorizationGenerator.GenerateOIDCNonce(),
+		CodeVerifier: s.authorizationGenerator.GenerateOIDCCodeVerifier(),
+		UserID:       input.UserID,
+		CreatedAt:    s.now(),
+	}
+	err = s.authorizationRepository.Create(ctx, authorization)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.log.Error(
+				ctx,
+				"Could not create OIDC authorization.",
+				logging.Entry("input", input),
+				logging.Entry("err", err),
+			)
+		}
+		return result, err
+	}
+
+	s.log.Info(ctx, "OIDC authorization started.", logging.Entry("input", input))
+	return Result{
+		AuthorizationURL: provider.AuthorizationURL(
+			authorization.State,
+			authorization.Nonce,
+			authorization.CodeVerifier.Challenge(),
+		),
+		State:     authorization.State,
+		ExpiresAt: authorization.CreatedAt.Add(s.authorizationTTL),
+	}, nil
+}
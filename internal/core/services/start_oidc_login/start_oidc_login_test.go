@@ -0,0 +1,106 @@
+package startoidclogin
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const AUTHORIZATION_TTL = 10 * time.Minute
+
+const (
+	PROVIDER      = user.OIDCProviderName("test")
+	STATE         = "test-state"
+	NONCE         = "test-nonce"
+	CODE_VERIFIER = "test-code-verifier"
+)
+
+type testSuite struct {
+	suite.Suite
+	Provider                *user.FakeOIDCProvider
+	AuthorizationRepository *user.FakeOIDCAuthorizationRepository
+	Service                 services.Service[Input, Result]
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Provider = user.NewFakeOIDCProvider("test-code", user.OIDCClaims{Subject: "test-subject"})
+	suite.AuthorizationRepository = user.NewFakeOIDCAuthorizationRepository()
+	suite.Service = New(
+		logging.NewFakeLogger(),
+		map[user.OIDCProviderName]user.OIDCProvider{PROVIDER: suite.Provider},
+		suite.AuthorizationRepository,
+		user.NewFakeOIDCAuthorizationGenerator(STATE, NONCE, CODE_VERIFIER),
+		AUTHORIZATION_TTL,
+		func() time.Time { return NOW },
+	)
+}
+
+func TestStartOIDCLoginService(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSuccess() {
+	result, err := s.Service.Run(context.Background(), Input{Provider: PROVIDER})
+
+	s.Nil(err)
+	s.Equal(
+		"https://oidc.test/authorize?state=test-state&nonce=test-nonce&code_challenge="+
+			user.OIDCCodeVerifier(CODE_VERIFIER).Challenge(),
+		result.AuthorizationURL,
+	)
+	s.Equal(user.OIDCState(STATE), result.State)
+	s.Equal(NOW.Add(AUTHORIZATION_TTL), result.ExpiresAt)
+	s.Equal(
+		[]user.OIDCAuthorization{
+			{
+				State:        STATE,
+				Provider:     PROVIDER,
+				Nonce:        NONCE,
+				CodeVerifier: CODE_VERIFIER,
+				CreatedAt:    NOW,
+			},
+		},
+		s.AuthorizationRepository.Authorizations,
+	)
+}
+
+func (s *testSuite) TestLinkToAuthenticatedUser() {
+	input := Input{Provider: PROVIDER}.WithAuthenticatedUser(user.User{ID: 1}).(Input)
+
+	_, err := s.Service.Run(context.Background(), input)
+
+	s.Nil(err)
+	s.Require().Len(s.AuthorizationRepository.Authorizations, 1)
+	s.Equal(c.NewOptional(user.ID(1), true), s.AuthorizationRepository.Authorizations[0].UserID)
+}
+
+func (s *testSuite) TestUnknownProvider() {
+	_, err := s.Service.Run(context.Background(), Input{Provider: "unknown"})
+
+	s.ErrorIs(err, user.ErrUnknownOIDCProvider)
+	s.Empty(s.AuthorizationRepository.Authorizations)
+}
+
+func (s *testSuite) TestRepositoryError() {
+	s.AuthorizationRepository.ReturnError = true
+
+	_, err := s.Service.Run(context.Background(), Input{Provider: PROVIDER})
+
+	s.NotNil(err)
+}
+
+func (s *testSuite) TestCodeChallenge() {
+	// RFC 7636 appendix B
+	s.Equal(
+		"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		user.OIDCCodeVerifier("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk").Challenge(),
+	)
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/tracing"
+	"remindme/internal/core/services"
+)
+
+type serviceWithTracing[T any, S any] struct {
+	tracer tracing.Tracer
+	name   string
+	inner  services.Service[T, S]
+}
+
+// WithTracing runs the inner service in a span named after the service.
+func WithTracing[T any, S any](
+	tracer tracing.Tracer,
+	name string,
+	inner services.Service[T, S],
+) services.Service[T, S] {
+	if tracer == nil {
+		panic(e.NewNilArgumentError("tracer"))
+	}
+	if name == "" {
+		panic("span name must not be empty")
+	}
+	if inner == nil {
+		panic(e.NewNilArgumentError("inner"))
+	}
+	return &serviceWithTracing[T, S]{
+		tracer: tracer,
+		name:   name,
+		inner:  inner,
+	}
+}
+
+func (s *serviceWithTracing[T, S]) Run(ctx context.Context, input T) (result S, err error) {
+	ctx, span := s.tracer.Start(ctx, s.name)
+	defer span.End()
+
+	result, err = s.inner.Run(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return result, err
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/tracing"
+	"remindme/internal/core/services"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type input struct{}
+
+type result struct{}
+
+type stubService struct {
+	Err       error
+	WasCalled bool
+}
+
+func (s *stubService) Run(ctx context.Context, input input) (result result, err error) {
+	s.WasCalled = true
+	return result, s.Err
+}
+
+type testTracingSuite struct {
+	suite.Suite
+	Tracer  *tracing.FakeTracer
+	Inner   *stubService
+	Service services.Service[input, result]
+}
+
+func (suite *testTracingSuite) SetupTest() {
+	suite.Tracer = tracing.NewFakeTracer()
+	suite.Inner = &stubService{}
+	suite.Service = WithTracing[input, result](suite.Tracer, "TestService", suite.Inner)
+}
+
+func TestTracingService(t *testing.T) {
+	suite.Run(t, new(testTracingSuite))
+}
+
+func (suite *testTracingSuite) TestSuccess() {
+	_, err := suite.Service.Run(context.Background(), input{})
+
+	assert := suite.Require()
+	assert.Nil(err)
+	assert.True(suite.Inner.WasCalled)
+	assert.Len(suite.Tracer.Spans, 1)
+	assert.Equal("TestService", suite.Tracer.Spans[0].Name)
+	assert.True(suite.Tracer.Spans[0].IsEnded)
+	assert.Empty(suite.Tracer.Spans[0].Errors)
+}
+
+func (suite *testTracingSuite) TestError() {
+	suite.Inner.Err = errors.New("an error occured")
+
+	_, err := suite.Service.Run(context.Background(), input{})
+
+	assert := suite.Require()
+	assert.ErrorIs(err, suite.Inner.Err)
+	assert.Len(suite.Tracer.Spans, 1)
+	assert.True(suite.Tracer.Spans[0].IsEnded)
+	assert.Equal([]error{suite.Inner.Err}, suite.Tracer.Spans[0].Errors)
+}
@@ -30,6 +30,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
 type Result struct {
 	Reminder reminder.ReminderWithChannels
 }
@@ -103,7 +107,7 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	doScheduledAtUpdate := false
 	scheduledAt := rem.ScheduledAt
 	if doAtUpdate(input, rem.At) {
-		if err := validateAt(input.At, now); err != nil {
+		if err := ValidateAt(input.At, now); err != nil {
 			return result, err
 		}
 		doStatusUpdate = true
@@ -143,6 +147,10 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return result, err
 	}
+	if err := reminder.UpdateUsage(ctx, uow.Usage(), rem.Reminder, updatedReminder); err != nil {
+		logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		return result, err
+	}
 
 	if doStatusUpdate && updatedReminder.Status == reminder.StatusScheduled {
 		if err := s.scheduler.ScheduleReminder(ctx, updatedReminder); err != nil {
@@ -167,7 +175,8 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	return result, nil
 }
 
-func validateAt(at time.Time, now time.Time) error {
+// ValidateAt checks that a new reminder time is in UTC and within the allowed range from now.
+func ValidateAt(at time.Time, now time.Time) error {
 	if at.Location() != time.UTC {
 		return reminder.ErrReminderAtTimeIsNotUTC
 	}
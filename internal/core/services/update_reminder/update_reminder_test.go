@@ -103,7 +103,7 @@ func (s *testSuite) TestValidateAt() {
 
 	for _, testcase := range cases {
 		s.Run(testcase.id, func() {
-			err := validateAt(testcase.at, Now)
+			err := ValidateAt(testcase.at, Now)
 			s.ErrorIs(err, testcase.expectedError)
 		})
 	}
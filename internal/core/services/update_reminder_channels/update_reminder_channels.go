@@ -26,6 +26,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeRemindersWrite
+}
+
 func (i Input) Validate() error {
 	if len(i.ChannelIDs) == 0 {
 		return reminder.ErrReminderChannelsNotSet
@@ -102,8 +106,11 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, reminder.ErrReminderNotActive
 	}
 
-	channelIDs, err := s.readChannels(ctx, uow, input)
+	channelIDs, err := ReadChannels(ctx, uow.Channels(), input.UserID, input.ChannelIDs)
 	if err != nil {
+		if !isChannelsError(err) {
+			logging.Error(ctx, s.log, err, logging.Entry("input", input))
+		}
 		return result, err
 	}
 
@@ -131,25 +138,26 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 	return Result{ChannelIDs: channelIDs}, nil
 }
 
-func (s *service) readChannels(
+// ReadChannels checks that the channels belong to the user and are verified.
+func ReadChannels(
 	ctx context.Context,
-	uow uow.Context,
-	input Input,
+	channelRepository channel.Repository,
+	userID user.ID,
+	inputChannelIDs reminder.ChannelIDs,
 ) ([]channel.ID, error) {
-	channelIDs := make([]channel.ID, 0, len(input.ChannelIDs))
-	for channelID := range input.ChannelIDs {
+	channelIDs := make([]channel.ID, 0, len(inputChannelIDs))
+	for channelID := range inputChannelIDs {
 		channelIDs = append(channelIDs, channelID)
 	}
 
-	channels, err := uow.Channels().Read(
+	channels, err := channelRepository.Read(
 		ctx,
 		channel.ReadOptions{
 			IDIn:         c.NewOptional(channelIDs, true),
-			UserIDEquals: c.NewOptional(input.UserID, true),
+			UserIDEquals: c.NewOptional(userID, true),
 		},
 	)
 	if err != nil {
-		logging.Error(ctx, s.log, err, logging.Entry("input", input))
 		return nil, err
 	}
 	readChannelIDs := make(map[channel.ID]struct{})
@@ -170,3 +178,8 @@ func (s *service) readChannels(
 
 	return resultChannelIDs, nil
 }
+
+func isChannelsError(err error) bool {
+	return errors.Is(err, reminder.ErrReminderChannelsNotVerified) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotValid)
+}
@@ -21,6 +21,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeProfileWrite
+}
+
 type Result struct {
 	User user.User
 }
@@ -25,6 +25,10 @@ func (i Input) WithAuthenticatedUser(u user.User) auth.Input {
 	return i
 }
 
+func (i Input) GetRequiredScope() user.Scope {
+	return user.ScopeChannelsWrite
+}
+
 func (i Input) GetRateLimitKey() string {
 	return fmt.Sprintf("verify-channel::%d", i.UserID)
 }
@@ -79,10 +83,21 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, err
 	}
 
+	isTokenValid, err := s.channelRepository.HasVerificationToken(ctx, input.ChannelID, input.VerificationToken)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.log.Error(
+				ctx,
+				"Could not check channel verification token due to unexpected error.",
+				logging.Entry("input", input),
+				logging.Entry("err", err),
+			)
+		}
+		return result, err
+	}
 	if !(existingChannel.CreatedBy == input.UserID &&
 		existingChannel.Type == channel.Email &&
-		existingChannel.VerificationToken.IsPresent &&
-		existingChannel.VerificationToken.Value == input.VerificationToken) {
+		isTokenValid) {
 		s.log.Info(
 			ctx,
 			"Invalid email channel verification data.",
@@ -68,9 +68,19 @@ func (s *service) Run(ctx context.Context, input Input) (result Result, err erro
 		return result, err
 	}
 
-	if !(existingChannel.Type == channel.Telegram &&
-		existingChannel.VerificationToken.IsPresent &&
-		existingChannel.VerificationToken.Value == input.VerificationToken) {
+	isTokenValid, err := s.channelRepository.HasVerificationToken(ctx, input.ChannelID, input.VerificationToken)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.log.Error(
+				ctx,
+				"Could not check channel verification token due to unexpected error.",
+				logging.Entry("input", input),
+				logging.Entry("err", err),
+			)
+		}
+		return result, err
+	}
+	if !(existingChannel.Type == channel.Telegram && isTokenValid) {
 		s.log.Info(
 			ctx,
 			"Invalid telegram channel verification data.",
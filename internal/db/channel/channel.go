@@ -9,6 +9,7 @@ import (
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
 	"remindme/internal/db/sqlcgen"
 	"strconv"
 
@@ -24,14 +25,18 @@ const (
 )
 
 type PgxChannelRepository struct {
-	queries *sqlcgen.Queries
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
 }
 
-func NewPgxChannelRepository(db sqlcgen.DBTX) *PgxChannelRepository {
+func NewPgxChannelRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxChannelRepository {
 	if db == nil {
 		panic(e.NewNilArgumentError("db"))
 	}
-	return &PgxChannelRepository{queries: sqlcgen.New(db)}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxChannelRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
 }
 
 func (r *PgxChannelRepository) Create(ctx context.Context, input channel.CreateInput) (c channel.Channel, err error) {
@@ -45,15 +50,12 @@ func (r *PgxChannelRepository) Create(ctx context.Context, input channel.CreateI
 	dbChannel, err := r.queries.CreateChannel(
 		ctx,
 		sqlcgen.CreateChannelParams{
-			UserID:    int64(input.CreatedBy),
-			CreatedAt: input.CreatedAt,
-			Type:      string(input.Type),
-			IsDefault: input.IsDefault,
-			Settings:  encodedSettings,
-			VerificationToken: sql.NullString{
-				String: string(input.VerificationToken.Value),
-				Valid:  input.VerificationToken.IsPresent,
-			},
+			UserID:            int64(input.CreatedBy),
+			CreatedAt:         input.CreatedAt,
+			Type:              string(input.Type),
+			IsDefault:         input.IsDefault,
+			Settings:          encodedSettings,
+			VerificationToken: r.encodeVerificationToken(input.VerificationToken),
 			VerifiedAt: sql.NullTime{
 				Time:  input.VerifiedAt.Value,
 				Valid: input.VerifiedAt.IsPresent,
@@ -64,7 +66,11 @@ func (r *PgxChannelRepository) Create(ctx context.Context, input channel.CreateI
 		return c, err
 	}
 	c, err = decodeChannel(dbChannel)
-	return c, err
+	if err != nil {
+		return c, err
+	}
+	c.VerificationToken = input.VerificationToken
+	return c, nil
 }
 
 func (r *PgxChannelRepository) Read(
@@ -90,8 +96,11 @@ func (r *PgxChannelRepository) Read(
 			IsDefaultEquals: options.IsDefaultEquals.Value,
 			OrderByIDAsc:    options.OrderBy == channel.OrderByIDAsc,
 			OrderByIDDesc:   options.OrderBy == channel.OrderByIDDesc,
+			AnyCursor:       !options.After.IsPresent,
+			CursorID:        int64(options.After.Value.ID),
 			AllRows:         !options.Limit.IsPresent,
 			Limit:           int32(options.Limit.Value),
+			Offset:          int32(options.Offset),
 		},
 	)
 	if err != nil {
@@ -125,6 +134,20 @@ func (r *PgxChannelRepository) GetByID(ctx context.Context, id channel.ID) (c ch
 	return c, err
 }
 
+func (r *PgxChannelRepository) HasVerificationToken(
+	ctx context.Context,
+	id channel.ID,
+	token channel.VerificationToken,
+) (bool, error) {
+	return r.queries.ChannelHasVerificationToken(
+		ctx,
+		sqlcgen.ChannelHasVerificationTokenParams{
+			ID:                int64(id),
+			VerificationToken: r.tokenHasher.Hash(string(token)),
+		},
+	)
+}
+
 func (r *PgxChannelRepository) Count(
 	ctx context.Context,
 	options channel.ReadOptions,
@@ -167,11 +190,8 @@ func (r *PgxChannelRepository) Update(
 		sqlcgen.UpdateChannelParams{
 			ID:                        int64(input.ID),
 			DoVerificationTokenUpdate: input.DoVerificationTokenUpdate,
-			VerificationToken: sql.NullString{
-				String: string(input.VerificationToken.Value),
-				Valid:  input.VerificationToken.IsPresent,
-			},
-			DoVerifiedAtUpdate: input.DoVerifiedAtUpdate,
+			VerificationToken:         r.encodeVerificationToken(input.VerificationToken),
+			DoVerifiedAtUpdate:        input.DoVerifiedAtUpdate,
 			VerifiedAt: sql.NullTime{
 				Time:  input.VerifiedAt.Value,
 				Valid: input.VerifiedAt.IsPresent,
@@ -190,9 +210,19 @@ func (r *PgxChannelRepository) Update(
 	if err != nil {
 		return c, err
 	}
+	if input.DoVerificationTokenUpdate {
+		domainChannel.VerificationToken = input.VerificationToken
+	}
 	return domainChannel, nil
 }
 
+func (r *PgxChannelRepository) encodeVerificationToken(token c.Optional[channel.VerificationToken]) sql.NullString {
+	if !token.IsPresent {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: r.tokenHasher.Hash(string(token.Value)), Valid: true}
+}
+
 func decodeChannel(dbChannel sqlcgen.Channel) (domainChannel channel.Channel, err error) {
 	channelType := channel.ParseType(dbChannel.Type)
 	if channelType == channel.Unknown {
@@ -202,18 +232,16 @@ func decodeChannel(dbChannel sqlcgen.Channel) (domainChannel channel.Channel, er
 	if err != nil {
 		return domainChannel, err
 	}
+	// Only the verification token hash is stored, so the decoded token is empty.
 	domainChannel = channel.Channel{
-		ID:        channel.ID(dbChannel.ID),
-		CreatedBy: user.ID(dbChannel.UserID),
-		CreatedAt: dbChannel.CreatedAt,
-		IsDefault: dbChannel.IsDefault,
-		Type:      channelType,
-		Settings:  settings,
-		VerificationToken: c.NewOptional(
-			channel.VerificationToken(dbChannel.VerificationToken.String),
-			dbChannel.VerificationToken.Valid,
-		),
-		VerifiedAt: c.NewOptional(dbChannel.VerifiedAt.Time, dbChannel.VerifiedAt.Valid),
+		ID:                channel.ID(dbChannel.ID),
+		CreatedBy:         user.ID(dbChannel.UserID),
+		CreatedAt:         dbChannel.CreatedAt,
+		IsDefault:         dbChannel.IsDefault,
+		Type:              channelType,
+		Settings:          settings,
+		VerificationToken: c.NewOptional(channel.VerificationToken(""), dbChannel.VerificationToken.Valid),
+		VerifiedAt:        c.NewOptional(dbChannel.VerifiedAt.Time, dbChannel.VerifiedAt.Valid),
 	}
 	err = domainChannel.Validate()
 	if err != nil {
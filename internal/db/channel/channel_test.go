@@ -93,8 +93,8 @@ type testSuite struct {
 
 func (suite *testSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
-	suite.repo = NewPgxChannelRepository(suite.pool)
-	suite.userRepo = dbuser.NewPgxRepository(suite.pool)
+	suite.repo = NewPgxChannelRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.userRepo = dbuser.NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testSuite) TearDownSuite() {
@@ -396,6 +396,36 @@ func (s *testSuite) TestReadAndCount() {
 			expectedIDs:   []channel.ID{channelIDs[3], channelIDs[0]},
 			expectedCount: 2,
 		},
+		{
+			id: "20",
+			options: channel.ReadOptions{
+				UserIDEquals: c.NewOptional(s.otherUser.ID, true),
+				Limit:        c.NewOptional(uint(2), true),
+				Offset:       1,
+			},
+			expectedIDs:   []channel.ID{channelIDs[3], channelIDs[4]},
+			expectedCount: 4,
+		},
+		{
+			id: "21",
+			options: channel.ReadOptions{
+				UserIDEquals: c.NewOptional(s.otherUser.ID, true),
+				After:        c.NewOptional(channel.Cursor{ID: channelIDs[3]}, true),
+			},
+			expectedIDs:   []channel.ID{channelIDs[4], channelIDs[6]},
+			expectedCount: 4,
+		},
+		{
+			id: "22",
+			options: channel.ReadOptions{
+				UserIDEquals: c.NewOptional(s.otherUser.ID, true),
+				OrderBy:      channel.OrderByIDDesc,
+				After:        c.NewOptional(channel.Cursor{OrderBy: channel.OrderByIDDesc, ID: channelIDs[4]}, true),
+				Limit:        c.NewOptional(uint(1), true),
+			},
+			expectedIDs:   []channel.ID{channelIDs[3]},
+			expectedCount: 4,
+		},
 	}
 	for _, testcase := range cases {
 		actualIDs := s.readChannelIDs(testcase.options)
@@ -484,7 +514,7 @@ func (s *testSuite) TestUpdate() {
 			tokenBefore:      c.NewOptional(channel.VerificationToken("test"), true),
 			verifiedAtBefore: c.NewOptional(Now, true),
 
-			expectedToken:      c.NewOptional(channel.VerificationToken("test"), true),
+			expectedToken:      c.NewOptional(channel.VerificationToken(""), true),
 			expectedVerifiedAt: c.NewOptional(Now, true),
 			expectedSettings:   channel.NewEmailSettings(c.NewEmail("test@test.test")),
 		},
@@ -511,7 +541,7 @@ func (s *testSuite) TestUpdate() {
 			doVerifiedAtUpdate: true,
 			verifiedAt:         c.NewOptional(Now, true),
 
-			expectedToken:      c.NewOptional(channel.VerificationToken("test"), true),
+			expectedToken:      c.NewOptional(channel.VerificationToken(""), true),
 			expectedVerifiedAt: c.NewOptional(Now, true),
 			expectedSettings:   channel.NewEmailSettings(c.NewEmail("test@test.test")),
 		},
@@ -556,7 +586,7 @@ func (s *testSuite) TestUpdate() {
 			doSettingsUpdate: true,
 			settings:         channel.NewTelegramSettings(channel.TelegramBot("test"), channel.TelegramChatID(1)),
 
-			expectedToken:    c.NewOptional(channel.VerificationToken("test"), true),
+			expectedToken:    c.NewOptional(channel.VerificationToken(""), true),
 			expectedSettings: channel.NewTelegramSettings(channel.TelegramBot("test"), channel.TelegramChatID(1)),
 		},
 	}
@@ -593,6 +623,30 @@ func (s *testSuite) TestUpdate() {
 	}
 }
 
+func (s *testSuite) TestHasVerificationToken() {
+	createdChannel, err := s.repo.Create(context.Background(), channel.CreateInput{
+		CreatedBy:         s.user.ID,
+		Type:              channel.Email,
+		Settings:          channel.NewEmailSettings(c.NewEmail("test@test.test")),
+		CreatedAt:         Now,
+		VerificationToken: c.NewOptional(channel.VerificationToken("test"), true),
+	})
+	assert := s.Require()
+	assert.Nil(err)
+
+	hasToken, err := s.repo.HasVerificationToken(context.Background(), createdChannel.ID, "test")
+	assert.Nil(err)
+	assert.True(hasToken)
+
+	hasToken, err = s.repo.HasVerificationToken(context.Background(), createdChannel.ID, "other")
+	assert.Nil(err)
+	assert.False(hasToken)
+
+	hasToken, err = s.repo.HasVerificationToken(context.Background(), createdChannel.ID+1, "test")
+	assert.Nil(err)
+	assert.False(hasToken)
+}
+
 func (s *testSuite) readChannelIDs(options channel.ReadOptions) []channel.ID {
 	s.T().Helper()
 	channels, err := s.repo.Read(context.Background(), options)
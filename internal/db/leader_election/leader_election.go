@@ -0,0 +1,254 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/db/sqlcgen"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const applicationNamePrefix = "remindme-leader:"
+
+type Status struct {
+	InstanceID string
+	IsLeader   bool
+	Leader     string
+}
+
+// PgxAdvisoryLockElector elects a leader among instances sharing the same lock ID.
+// The leader holds a session level Postgres advisory lock on a dedicated connection,
+// so leadership is released automatically as soon as the connection is lost.
+type PgxAdvisoryLockElector struct {
+	log        logging.Logger
+	db         *pgxpool.Pool
+	lockID     int64
+	instanceID string
+	period     time.Duration
+
+	// connLock serializes use of the lock connection by elections and leadership checks.
+	connLock sync.Mutex
+	conn     *pgxpool.Conn
+	status   Status
+	// leadership is done as soon as the instance is not the leader.
+	leadership       context.Context
+	cancelLeadership context.CancelFunc
+	lock             sync.RWMutex
+}
+
+func NewPgxAdvisoryLockElector(
+	log logging.Logger,
+	db *pgxpool.Pool,
+	lockID int64,
+	instanceID string,
+	period time.Duration,
+) *PgxAdvisoryLockElector {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if instanceID == "" {
+		panic("instance ID must not be empty")
+	}
+	if period <= 0 {
+		panic("election period must be positive")
+	}
+	leadership, cancelLeadership := context.WithCancel(context.Background())
+	cancelLeadership()
+	return &PgxAdvisoryLockElector{
+		log:              log,
+		db:               db,
+		lockID:           lockID,
+		instanceID:       instanceID,
+		period:           period,
+		status:           Status{InstanceID: instanceID},
+		leadership:       leadership,
+		cancelLeadership: cancelLeadership,
+	}
+}
+
+// Run takes part in the election every period until ctx is done,
+// then resigns if the instance is the leader.
+func (el *PgxAdvisoryLockElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(el.period)
+	defer ticker.Stop()
+
+	for {
+		el.Elect(ctx)
+		select {
+		case <-ctx.Done():
+			el.Resign(context.Background())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Elect makes a single election round: the leader checks that its lock connection is alive,
+// a follower tries to acquire the lock and refreshes the current leader identity.
+func (el *PgxAdvisoryLockElector) Elect(ctx context.Context) {
+	el.connLock.Lock()
+	defer el.connLock.Unlock()
+	if el.IsLeader() {
+		if err := el.conn.Ping(ctx); err != nil {
+			el.log.Error(
+				ctx,
+				"Leader lock connection lost, stepping down.",
+				logging.Entry("instanceID", el.instanceID),
+				logging.Entry("err", err),
+			)
+			el.stepDown()
+		}
+		return
+	}
+
+	isAcquired, err := el.tryAcquire(ctx)
+	if err != nil {
+		logging.Error(ctx, el.log, err, logging.Entry("instanceID", el.instanceID))
+		return
+	}
+	if isAcquired {
+		el.setStatus(true, el.instanceID)
+		el.log.Info(ctx, "Instance became the leader.", logging.Entry("instanceID", el.instanceID))
+		return
+	}
+
+	leader, err := el.readLeader(ctx)
+	if err != nil {
+		logging.Error(ctx, el.log, err, logging.Entry("instanceID", el.instanceID))
+		return
+	}
+	if leader != el.Status().Leader {
+		el.log.Info(
+			ctx,
+			"Instance is a follower.",
+			logging.Entry("instanceID", el.instanceID),
+			logging.Entry("leader", leader),
+		)
+	}
+	el.setStatus(false, leader)
+}
+
+// Resign releases the leadership if the instance is the leader.
+func (el *PgxAdvisoryLockElector) Resign(ctx context.Context) {
+	el.connLock.Lock()
+	defer el.connLock.Unlock()
+	if !el.IsLeader() {
+		return
+	}
+	if _, err := sqlcgen.New(el.conn).AdvisoryUnlock(ctx, el.lockID); err != nil {
+		logging.Error(ctx, el.log, err, logging.Entry("instanceID", el.instanceID))
+	}
+	el.stepDown()
+	el.log.Info(ctx, "Instance resigned the leadership.", logging.Entry("instanceID", el.instanceID))
+}
+
+// VerifyLeadership checks on the lock connection that the lock is still held and steps down
+// if it is not. The status is refreshed only once per election period, so jobs which must
+// not run on two instances at once verify the leadership right before every run.
+func (el *PgxAdvisoryLockElector) VerifyLeadership(ctx context.Context) Status {
+	el.connLock.Lock()
+	defer el.connLock.Unlock()
+	if !el.IsLeader() {
+		return el.Status()
+	}
+	isHeld, err := sqlcgen.New(el.conn).IsAdvisoryLockHeld(ctx, el.lockID)
+	if err != nil {
+		el.log.Error(
+			ctx,
+			"Could not verify the leader lock, stepping down.",
+			logging.Entry("instanceID", el.instanceID),
+			logging.Entry("err", err),
+		)
+		el.stepDown()
+	} else if !isHeld {
+		el.log.Error(ctx, "Leader lock is not held, stepping down.", logging.Entry("instanceID", el.instanceID))
+		el.stepDown()
+	}
+	return el.Status()
+}
+
+func (el *PgxAdvisoryLockElector) IsLeader() bool {
+	return el.Status().IsLeader
+}
+
+func (el *PgxAdvisoryLockElector) Status() Status {
+	el.lock.RLock()
+	defer el.lock.RUnlock()
+	return el.status
+}
+
+// LeadershipContext returns a context which is done as soon as the instance steps down,
+// so that the jobs of the leader stop when the leadership is lost. The context is done
+// already if the instance is not the leader.
+func (el *PgxAdvisoryLockElector) LeadershipContext() context.Context {
+	el.lock.RLock()
+	defer el.lock.RUnlock()
+	return el.leadership
+}
+
+func (el *PgxAdvisoryLockElector) tryAcquire(ctx context.Context) (bool, error) {
+	conn, err := el.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	queries := sqlcgen.New(conn)
+	isLocked, err := queries.TryAdvisoryLock(ctx, el.lockID)
+	if err != nil || !isLocked {
+		conn.Release()
+		return false, err
+	}
+	if err := queries.SetApplicationName(ctx, applicationNamePrefix+el.instanceID); err != nil {
+		queries.AdvisoryUnlock(ctx, el.lockID)
+		conn.Release()
+		return false, err
+	}
+	el.conn = conn
+	return true, nil
+}
+
+func (el *PgxAdvisoryLockElector) readLeader(ctx context.Context) (string, error) {
+	applicationName, err := sqlcgen.New(el.db).GetAdvisoryLockHolder(ctx, el.lockID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(applicationName, applicationNamePrefix) {
+		return "", fmt.Errorf("lock %d is held by unknown application %q", el.lockID, applicationName)
+	}
+	return strings.TrimPrefix(applicationName, applicationNamePrefix), nil
+}
+
+func (el *PgxAdvisoryLockElector) stepDown() {
+	// Closing the connection instead of releasing it to the pool
+	// guarantees the session level lock is not kept by a pooled connection.
+	if el.conn != nil {
+		el.conn.Conn().Close(context.Background())
+		el.conn.Release()
+		el.conn = nil
+	}
+	el.setStatus(false, "")
+}
+
+func (el *PgxAdvisoryLockElector) setStatus(isLeader bool, leader string) {
+	el.lock.Lock()
+	defer el.lock.Unlock()
+	if isLeader && !el.status.IsLeader {
+		el.leadership, el.cancelLeadership = context.WithCancel(context.Background())
+	} else if !isLeader {
+		el.cancelLeadership()
+	}
+	el.status.IsLeader = isLeader
+	el.status.Leader = leader
+}
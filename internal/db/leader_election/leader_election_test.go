@@ -0,0 +1,154 @@
+package leaderelection
+
+import (
+	"context"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	LOCK_ID    = 100500
+	INSTANCE_1 = "instance-1"
+	INSTANCE_2 = "instance-2"
+)
+
+type testSuite struct {
+	suite.Suite
+	pool      *pgxpool.Pool
+	elector1  *PgxAdvisoryLockElector
+	elector2  *PgxAdvisoryLockElector
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+}
+
+func (suite *testSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+}
+
+func (suite *testSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.elector1 = NewPgxAdvisoryLockElector(logging.NewFakeLogger(), suite.pool, LOCK_ID, INSTANCE_1, time.Second)
+	suite.elector2 = NewPgxAdvisoryLockElector(logging.NewFakeLogger(), suite.pool, LOCK_ID, INSTANCE_2, time.Second)
+	suite.ctx, suite.cancelCtx = context.WithCancel(context.Background())
+}
+
+func (suite *testSuite) TearDownTest() {
+	suite.cancelCtx()
+	suite.elector1.Resign(context.Background())
+	suite.elector2.Resign(context.Background())
+}
+
+func TestPgxAdvisoryLockElector(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestOnlyOneLeader() {
+	s.elector1.Elect(s.ctx)
+	s.elector2.Elect(s.ctx)
+
+	s.Equal(Status{InstanceID: INSTANCE_1, IsLeader: true, Leader: INSTANCE_1}, s.elector1.Status())
+	s.Equal(Status{InstanceID: INSTANCE_2, IsLeader: false, Leader: INSTANCE_1}, s.elector2.Status())
+
+	s.elector1.Elect(s.ctx)
+	s.elector2.Elect(s.ctx)
+
+	s.True(s.elector1.IsLeader())
+	s.False(s.elector2.IsLeader())
+}
+
+func (s *testSuite) TestFailoverAfterResign() {
+	s.elector1.Elect(s.ctx)
+	s.elector2.Elect(s.ctx)
+	s.True(s.elector1.IsLeader())
+
+	s.elector1.Resign(s.ctx)
+	s.False(s.elector1.IsLeader())
+
+	s.elector2.Elect(s.ctx)
+	s.elector1.Elect(s.ctx)
+
+	s.Equal(Status{InstanceID: INSTANCE_2, IsLeader: true, Leader: INSTANCE_2}, s.elector2.Status())
+	s.Equal(Status{InstanceID: INSTANCE_1, IsLeader: false, Leader: INSTANCE_2}, s.elector1.Status())
+}
+
+func (s *testSuite) TestFailoverAfterConnectionLoss() {
+	s.elector1.Elect(s.ctx)
+	s.True(s.elector1.IsLeader())
+
+	s.elector1.conn.Conn().Close(context.Background())
+	s.elector1.Elect(s.ctx)
+	s.False(s.elector1.IsLeader())
+
+	s.elector2.Elect(s.ctx)
+	s.True(s.elector2.IsLeader())
+}
+
+func (s *testSuite) TestVerifyLeadership() {
+	s.elector1.Elect(s.ctx)
+	s.elector2.Elect(s.ctx)
+
+	s.True(s.elector1.VerifyLeadership(s.ctx).IsLeader)
+	s.Equal(
+		Status{InstanceID: INSTANCE_2, IsLeader: false, Leader: INSTANCE_1},
+		s.elector2.VerifyLeadership(s.ctx),
+	)
+}
+
+func (s *testSuite) TestVerifyLeadershipAfterLockLoss() {
+	s.elector1.Elect(s.ctx)
+	s.True(s.elector1.IsLeader())
+
+	_, err := sqlcgen.New(s.elector1.conn).AdvisoryUnlock(s.ctx, LOCK_ID)
+	s.Require().Nil(err)
+	s.elector2.Elect(s.ctx)
+	s.True(s.elector2.IsLeader())
+
+	s.False(s.elector1.VerifyLeadership(s.ctx).IsLeader)
+	s.True(s.elector2.VerifyLeadership(s.ctx).IsLeader)
+}
+
+func (s *testSuite) TestVerifyLeadershipAfterConnectionLoss() {
+	s.elector1.Elect(s.ctx)
+	s.True(s.elector1.IsLeader())
+
+	s.elector1.conn.Conn().Close(context.Background())
+
+	s.False(s.elector1.VerifyLeadership(s.ctx).IsLeader)
+}
+
+func (s *testSuite) TestLeadershipContext() {
+	s.Error(s.elector1.LeadershipContext().Err())
+
+	s.elector1.Elect(s.ctx)
+	leadership := s.elector1.LeadershipContext()
+	s.Nil(leadership.Err())
+
+	s.elector1.conn.Conn().Close(context.Background())
+	s.False(s.elector1.VerifyLeadership(s.ctx).IsLeader)
+	s.ErrorIs(leadership.Err(), context.Canceled)
+}
+
+func (s *testSuite) TestRunResignsOnCancel() {
+	done := make(chan struct{})
+	go func() {
+		s.elector1.Run(s.ctx)
+		close(done)
+	}()
+
+	s.Eventually(s.elector1.IsLeader, time.Second, 10*time.Millisecond)
+	s.cancelCtx()
+	<-done
+
+	s.False(s.elector1.IsLeader())
+	s.elector2.Elect(context.Background())
+	s.True(s.elector2.IsLeader())
+}
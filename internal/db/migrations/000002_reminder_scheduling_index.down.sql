@@ -0,0 +1 @@
+DROP INDEX IF EXISTS reminder_scheduling_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS reminder_scheduling_idx ON reminder (at, id) WHERE status = 'created';
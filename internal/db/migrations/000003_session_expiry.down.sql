@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS session_user_id_idx;
+ALTER TABLE session DROP COLUMN IF EXISTS ip;
+ALTER TABLE session DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE session DROP COLUMN IF EXISTS last_seen_at;
@@ -0,0 +1,6 @@
+ALTER TABLE session ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP;
+UPDATE session SET last_seen_at = created_at WHERE last_seen_at IS NULL;
+ALTER TABLE session ALTER COLUMN last_seen_at SET NOT NULL;
+ALTER TABLE session ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN IF NOT EXISTS ip TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS session_user_id_idx ON session (user_id);
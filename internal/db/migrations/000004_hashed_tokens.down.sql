@@ -0,0 +1,3 @@
+-- Hashed tokens can not be restored, sessions are dropped so users log in again.
+-- Pending activation and channel verification tokens have to be requested again.
+DELETE FROM session;
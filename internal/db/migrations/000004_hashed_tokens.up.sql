@@ -0,0 +1,28 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- Tokens are replaced with hex encoded HMAC-SHA256 keyed by the application SECRET.
+-- The secret is read from the remindme.token_secret setting, it can be passed with the
+-- connection URL, e.g. ?options=-c%20remindme.token_secret%3D<SECRET>.
+DO $$
+DECLARE
+    secret TEXT := current_setting('remindme.token_secret', true);
+BEGIN
+    IF coalesce(secret, '') = '' THEN
+        IF EXISTS (SELECT 1 FROM session)
+            OR EXISTS (SELECT 1 FROM "user" WHERE activation_token IS NOT NULL)
+            OR EXISTS (SELECT 1 FROM channel WHERE verification_token IS NOT NULL)
+        THEN
+            RAISE EXCEPTION 'remindme.token_secret must be set to hash existing tokens';
+        END IF;
+        RETURN;
+    END IF;
+
+    UPDATE session
+    SET token = encode(hmac(token, secret, 'sha256'), 'hex');
+    UPDATE "user"
+    SET activation_token = encode(hmac(activation_token, secret, 'sha256'), 'hex')
+    WHERE activation_token IS NOT NULL;
+    UPDATE channel
+    SET verification_token = encode(hmac(verification_token, secret, 'sha256'), 'hex')
+    WHERE verification_token IS NOT NULL;
+END $$;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS oidc_authorization;
+DROP TABLE IF EXISTS external_identity;
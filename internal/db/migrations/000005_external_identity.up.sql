@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS external_identity (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS external_identity_provider_subject_idx ON external_identity (provider, subject);
+CREATE INDEX IF NOT EXISTS external_identity_user_id_idx ON external_identity (user_id);
+
+CREATE TABLE IF NOT EXISTS oidc_authorization (
+    state TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    user_id BIGINT REFERENCES "user" (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS oidc_authorization_created_at_idx ON oidc_authorization (created_at);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS totp (
+    user_id BIGINT PRIMARY KEY REFERENCES "user" (id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS recovery_code (
+    user_id BIGINT NOT NULL REFERENCES totp (user_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    PRIMARY KEY (user_id, code)
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_challenge (
+    token TEXT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS two_factor_challenge_created_at_idx ON two_factor_challenge (created_at);
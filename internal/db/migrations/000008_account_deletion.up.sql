@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS account_deletion (
+    user_id BIGINT PRIMARY KEY REFERENCES "user" (id) ON DELETE CASCADE,
+    requested_at TIMESTAMP NOT NULL,
+    purge_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS account_deletion_purge_at_idx ON account_deletion (purge_at);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS email_change (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    old_email TEXT NOT NULL,
+    new_email TEXT NOT NULL,
+    token TEXT UNIQUE,
+    revert_token TEXT UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    revert_expires_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS email_change_user_id_idx ON email_change (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS user_not_activated_created_at_idx;
+ALTER TABLE "user" DROP COLUMN IF EXISTS activation_token_expires_at;
@@ -0,0 +1,2 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS activation_token_expires_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS user_not_activated_created_at_idx ON "user" (created_at) WHERE activated_at IS NULL;
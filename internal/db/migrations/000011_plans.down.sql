@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS limits_audit;
+ALTER TABLE limits DROP COLUMN IF EXISTS plan;
+DROP TABLE IF EXISTS plan;
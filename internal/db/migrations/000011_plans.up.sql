@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS plan (
+    name TEXT PRIMARY KEY,
+    email_channel_count INTEGER CONSTRAINT plan_email_channel_count_positive CHECK (email_channel_count >= 0),
+    telegram_channel_count INTEGER
+        CONSTRAINT plan_telegram_channel_count_positive CHECK (telegram_channel_count >= 0),
+    active_reminder_count INTEGER CONSTRAINT plan_active_reminder_count_positive CHECK (active_reminder_count >= 0),
+    monthly_sent_reminder_count INTEGER
+        CONSTRAINT plan_monthly_sent_reminder_count_positive CHECK (monthly_sent_reminder_count >= 0),
+    reminder_every_per_day_count REAL
+        CONSTRAINT plan_reminder_every_per_day_count_positive CHECK (reminder_every_per_day_count >= 0)
+);
+INSERT INTO plan (
+    name,
+    email_channel_count,
+    telegram_channel_count,
+    active_reminder_count,
+    monthly_sent_reminder_count,
+    reminder_every_per_day_count
+)
+VALUES
+    ('free', 1, 1, 10, 100, 1),
+    ('pro', 5, 5, 100, 3000, 24),
+    ('team', 25, 25, 1000, 30000, 96)
+ON CONFLICT (name) DO NOTHING;
+
+
+ALTER TABLE limits ADD COLUMN IF NOT EXISTS plan TEXT REFERENCES plan (name);
+UPDATE limits SET plan = 'free'
+FROM "user"
+WHERE "user".id = limits.user_id AND "user".email IS NOT NULL AND "user".activation_token IS NULL;
+
+
+CREATE TABLE IF NOT EXISTS limits_audit (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    plan TEXT,
+    limits_before JSONB NOT NULL,
+    limits_after JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS limits_audit_user_id_idx ON limits_audit (user_id);
@@ -0,0 +1,2 @@
+ALTER TABLE limits DROP COLUMN IF EXISTS sent_reminder_quota_window;
+ALTER TABLE plan DROP COLUMN IF EXISTS sent_reminder_quota_window;
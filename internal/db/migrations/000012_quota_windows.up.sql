@@ -0,0 +1,2 @@
+ALTER TABLE plan ADD COLUMN IF NOT EXISTS sent_reminder_quota_window TEXT NOT NULL DEFAULT 'calendar_month';
+ALTER TABLE limits ADD COLUMN IF NOT EXISTS sent_reminder_quota_window TEXT NOT NULL DEFAULT 'calendar_month';
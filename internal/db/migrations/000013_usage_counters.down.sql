@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sent_reminder_usage;
+DROP TABLE IF EXISTS reminder_usage;
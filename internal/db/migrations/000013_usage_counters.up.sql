@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS reminder_usage (
+    user_id BIGINT PRIMARY KEY REFERENCES "user" (id) ON DELETE CASCADE,
+    active_reminder_count INTEGER NOT NULL DEFAULT 0
+);
+INSERT INTO reminder_usage (user_id, active_reminder_count)
+SELECT user_id, COUNT(id) FROM reminder
+WHERE status IN ('created', 'scheduled')
+GROUP BY user_id
+ON CONFLICT (user_id) DO NOTHING;
+
+
+CREATE TABLE IF NOT EXISTS sent_reminder_usage (
+    user_id BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    bucket TIMESTAMP NOT NULL,
+    sent_reminder_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, bucket)
+);
+INSERT INTO sent_reminder_usage (user_id, bucket, sent_reminder_count)
+SELECT user_id, date_bin('15 minutes', sent_at, TIMESTAMP '2000-01-01'), COUNT(id) FROM reminder
+WHERE status = 'sent_success' AND sent_at >= (now() AT TIME ZONE 'UTC') - INTERVAL '35 days'
+GROUP BY 1, 2
+ON CONFLICT (user_id, bucket) DO NOTHING;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS calendar_feed (
+    user_id BIGINT PRIMARY KEY REFERENCES "user" (id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP
+);
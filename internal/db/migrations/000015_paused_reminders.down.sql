@@ -0,0 +1,4 @@
+UPDATE reminder SET status = 'canceled', canceled_at = now() AT TIME ZONE 'UTC' WHERE status = 'paused';
+ALTER TABLE reminder_usage DROP COLUMN IF EXISTS paused_reminder_count;
+ALTER TABLE limits DROP COLUMN IF EXISTS paused_reminders_count_as_active;
+ALTER TABLE plan DROP COLUMN IF EXISTS paused_reminders_count_as_active;
@@ -0,0 +1,3 @@
+ALTER TABLE plan ADD COLUMN IF NOT EXISTS paused_reminders_count_as_active BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE limits ADD COLUMN IF NOT EXISTS paused_reminders_count_as_active BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE reminder_usage ADD COLUMN IF NOT EXISTS paused_reminder_count INTEGER NOT NULL DEFAULT 0;
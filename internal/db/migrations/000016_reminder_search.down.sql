@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS reminder_channel_channel_id_idx;
+DROP INDEX IF EXISTS reminder_user_id_created_at_idx;
+DROP INDEX IF EXISTS reminder_user_id_at_idx;
+DROP INDEX IF EXISTS reminder_body_search_idx;
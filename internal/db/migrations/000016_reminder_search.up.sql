@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS reminder_body_search_idx ON reminder USING GIN (to_tsvector('simple', body));
+CREATE INDEX IF NOT EXISTS reminder_user_id_at_idx ON reminder (user_id, at);
+CREATE INDEX IF NOT EXISTS reminder_user_id_created_at_idx ON reminder (user_id, created_at);
+CREATE INDEX IF NOT EXISTS reminder_channel_channel_id_idx ON reminder_channel (channel_id);
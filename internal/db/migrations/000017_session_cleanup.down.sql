@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS session_created_at_idx;
+DROP INDEX IF EXISTS session_last_seen_at_idx;
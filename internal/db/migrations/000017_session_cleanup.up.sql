@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS session_last_seen_at_idx ON session (last_seen_at);
+CREATE INDEX IF NOT EXISTS session_created_at_idx ON session (created_at);
@@ -96,19 +96,36 @@ func (r *PgxReminderRepository) Read(
 	dbReminders, err := r.queries.ReadReminders(
 		ctx,
 		sqlcgen.ReadRemindersParams{
-			AnyUserID:     !options.CreatedByEquals.IsPresent,
-			UserIDEquals:  int64(options.CreatedByEquals.Value),
-			AnySentAt:     !options.SentAfter.IsPresent,
-			SentAfter:     options.SentAfter.Value,
-			AnyStatus:     !options.StatusIn.IsPresent,
-			StatusIn:      statusIn,
-			OrderByIDAsc:  options.OrderBy == reminder.OrderByIDAsc,
-			OrderByIDDesc: options.OrderBy == reminder.OrderByIDDesc,
-			OrderByAtAsc:  options.OrderBy == reminder.OrderByAtAsc,
-			OrderByAtDesc: options.OrderBy == reminder.OrderByAtDesc,
-			AllRows:       !options.Limit.IsPresent,
-			Limit:         int32(options.Limit.Value),
-			Offset:        int32(options.Offset),
+			AnyUserID:        !options.CreatedByEquals.IsPresent,
+			UserIDEquals:     int64(options.CreatedByEquals.Value),
+			AnySentAt:        !options.SentAfter.IsPresent,
+			SentAfter:        options.SentAfter.Value,
+			AnyStatus:        !options.StatusIn.IsPresent,
+			StatusIn:         statusIn,
+			AnyBodySearch:    !options.BodySearch.IsPresent,
+			BodySearch:       options.BodySearch.Value,
+			AnyAtAfter:       !options.AtAfter.IsPresent,
+			AtAfter:          options.AtAfter.Value,
+			AnyAtBefore:      !options.AtBefore.IsPresent,
+			AtBefore:         options.AtBefore.Value,
+			AnyEvery:         !options.IsPeriodic.IsPresent,
+			IsPeriodic:       options.IsPeriodic.Value,
+			AnyChannelID:     !options.ChannelIDEquals.IsPresent,
+			ChannelIDEquals:  int64(options.ChannelIDEquals.Value),
+			AnyCreatedAfter:  !options.CreatedAfter.IsPresent,
+			CreatedAfter:     options.CreatedAfter.Value,
+			AnyCreatedBefore: !options.CreatedBefore.IsPresent,
+			CreatedBefore:    options.CreatedBefore.Value,
+			AnyCursor:        !options.After.IsPresent,
+			CursorID:         int64(options.After.Value.ID),
+			CursorAt:         options.After.Value.At,
+			OrderByIDAsc:     options.OrderBy == reminder.OrderByIDAsc,
+			OrderByIDDesc:    options.OrderBy == reminder.OrderByIDDesc,
+			OrderByAtAsc:     options.OrderBy == reminder.OrderByAtAsc,
+			OrderByAtDesc:    options.OrderBy == reminder.OrderByAtDesc,
+			AllRows:          !options.Limit.IsPresent,
+			Limit:            int32(options.Limit.Value),
+			Offset:           int32(options.Offset),
 		},
 	)
 	if err != nil {
@@ -142,12 +159,26 @@ func (r *PgxReminderRepository) Count(ctx context.Context, options reminder.Read
 	count, err := r.queries.CountReminders(
 		ctx,
 		sqlcgen.CountRemindersParams{
-			AnyUserID:    !options.CreatedByEquals.IsPresent,
-			UserIDEquals: int64(options.CreatedByEquals.Value),
-			AnySentAt:    !options.SentAfter.IsPresent,
-			SentAfter:    options.SentAfter.Value,
-			AnyStatus:    !options.StatusIn.IsPresent,
-			StatusIn:     statusIn,
+			AnyUserID:        !options.CreatedByEquals.IsPresent,
+			UserIDEquals:     int64(options.CreatedByEquals.Value),
+			AnySentAt:        !options.SentAfter.IsPresent,
+			SentAfter:        options.SentAfter.Value,
+			AnyStatus:        !options.StatusIn.IsPresent,
+			StatusIn:         statusIn,
+			AnyBodySearch:    !options.BodySearch.IsPresent,
+			BodySearch:       options.BodySearch.Value,
+			AnyAtAfter:       !options.AtAfter.IsPresent,
+			AtAfter:          options.AtAfter.Value,
+			AnyAtBefore:      !options.AtBefore.IsPresent,
+			AtBefore:         options.AtBefore.Value,
+			AnyEvery:         !options.IsPeriodic.IsPresent,
+			IsPeriodic:       options.IsPeriodic.Value,
+			AnyChannelID:     !options.ChannelIDEquals.IsPresent,
+			ChannelIDEquals:  int64(options.ChannelIDEquals.Value),
+			AnyCreatedAfter:  !options.CreatedAfter.IsPresent,
+			CreatedAfter:     options.CreatedAfter.Value,
+			AnyCreatedBefore: !options.CreatedBefore.IsPresent,
+			CreatedBefore:    options.CreatedBefore.Value,
 		},
 	)
 	if err != nil {
@@ -206,25 +237,38 @@ func (r *PgxReminderRepository) Schedule(
 	ctx context.Context,
 	input reminder.ScheduleInput,
 ) (reminders []reminder.Reminder, err error) {
+	ids := make([]int64, 0, len(input.IDs))
+	for _, id := range input.IDs {
+		ids = append(ids, int64(id))
+	}
 	dbReminders, err := r.queries.ScheduleReminders(ctx, sqlcgen.ScheduleRemindersParams{
 		Status:                string(reminder.StatusScheduled),
-		AtBefore:              input.AtBefore,
 		ScheduledAt:           input.ScheduledAt,
+		Ids:                   ids,
 		StatusesForScheduling: []string{string(reminder.StatusCreated)},
 	})
 	if err != nil {
 		return reminders, err
 	}
-	reminders = make([]reminder.Reminder, 0, len(dbReminders))
-	for _, dbReminder := range dbReminders {
-		rem, err := decodeReminder(dbReminder)
-		if err != nil {
-			return reminders, err
-		}
-		reminders = append(reminders, rem)
-	}
+	return decodeReminders(dbReminders)
+}
 
-	return reminders, nil
+// ReadForScheduling locks the selected reminders until the end of the transaction,
+// reminders locked by a concurrent transaction are skipped.
+func (r *PgxReminderRepository) ReadForScheduling(
+	ctx context.Context,
+	input reminder.ReadForSchedulingInput,
+) (reminders []reminder.Reminder, err error) {
+	dbReminders, err := r.queries.ReadRemindersForScheduling(ctx, sqlcgen.ReadRemindersForSchedulingParams{
+		AtBefore: input.AtBefore,
+		AfterAt:  input.AfterAt,
+		AfterID:  int64(input.AfterID),
+		Limit:    int32(input.Limit),
+	})
+	if err != nil {
+		return reminders, err
+	}
+	return decodeReminders(dbReminders)
 }
 
 func (r *PgxReminderRepository) Delete(
@@ -238,6 +282,18 @@ func (r *PgxReminderRepository) Delete(
 	return err
 }
 
+func decodeReminders(dbReminders []sqlcgen.Reminder) ([]reminder.Reminder, error) {
+	reminders := make([]reminder.Reminder, 0, len(dbReminders))
+	for _, dbReminder := range dbReminders {
+		rem, err := decodeReminder(dbReminder)
+		if err != nil {
+			return reminders, err
+		}
+		reminders = append(reminders, rem)
+	}
+	return reminders, nil
+}
+
 func decodeReminder(dbReminder sqlcgen.Reminder) (rem reminder.Reminder, err error) {
 	rem.ID = reminder.ID(dbReminder.ID)
 	rem.CreatedBy = user.ID(dbReminder.UserID)
@@ -25,7 +25,7 @@ type testScheduleSuite struct {
 func (suite *testScheduleSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
 	suite.repo = NewPgxReminderRepository(suite.pool)
-	suite.userRepo = dbuser.NewPgxRepository(suite.pool)
+	suite.userRepo = dbuser.NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testScheduleSuite) TearDownSuite() {
@@ -131,8 +131,14 @@ func (s *testScheduleSuite) TestSchedule() {
 
 			reminderIDs := s.createReminders(testcase.reminders)
 
-			reminders, err := s.repo.Schedule(context.Background(), reminder.ScheduleInput{
-				AtBefore:    testcase.atBefore,
+			reminders, err := s.repo.ReadForScheduling(context.Background(), reminder.ReadForSchedulingInput{
+				AtBefore: testcase.atBefore,
+				Limit:    100,
+			})
+			s.Nil(err, testcase.id)
+
+			reminders, err = s.repo.Schedule(context.Background(), reminder.ScheduleInput{
+				IDs:         getIDs(reminders),
 				ScheduledAt: testcase.now,
 			})
 
@@ -142,6 +148,58 @@ func (s *testScheduleSuite) TestSchedule() {
 	}
 }
 
+func (s *testScheduleSuite) TestReadForSchedulingPages() {
+	reminderIDs := s.createReminders([]reminder.CreateInput{
+		{At: dt("2020-05-05T15:02:00Z"), Status: reminder.StatusCreated},
+		{At: dt("2020-05-05T15:01:00Z"), Status: reminder.StatusCreated},
+		{At: dt("2020-05-05T15:02:00Z"), Status: reminder.StatusCreated},
+		{At: dt("2020-05-05T15:03:00Z"), Status: reminder.StatusScheduled},
+		{At: dt("2020-05-05T15:04:00Z"), Status: reminder.StatusCreated},
+	})
+	input := reminder.ReadForSchedulingInput{AtBefore: dt("2020-05-06T00:00:00Z"), Limit: 2}
+
+	page1, err := s.repo.ReadForScheduling(context.Background(), input)
+	s.Nil(err)
+	s.Equal([]reminder.ID{reminderIDs[1], reminderIDs[0]}, getIDs(page1))
+
+	input.AfterAt = page1[1].At
+	input.AfterID = page1[1].ID
+	page2, err := s.repo.ReadForScheduling(context.Background(), input)
+	s.Nil(err)
+	s.Equal([]reminder.ID{reminderIDs[2], reminderIDs[4]}, getIDs(page2))
+
+	input.AfterAt = page2[1].At
+	input.AfterID = page2[1].ID
+	page3, err := s.repo.ReadForScheduling(context.Background(), input)
+	s.Nil(err)
+	s.Empty(page3)
+}
+
+func (s *testScheduleSuite) TestScheduleSkipsNotCreated() {
+	reminderIDs := s.createReminders([]reminder.CreateInput{
+		{At: dt("2020-05-05T15:01:00Z"), Status: reminder.StatusCreated},
+		{At: dt("2020-05-05T15:02:00Z"), Status: reminder.StatusCanceled},
+		{At: dt("2020-05-05T15:03:00Z"), Status: reminder.StatusPaused},
+	})
+	now := dt("2020-05-05T14:41:00Z")
+
+	reminders, err := s.repo.Schedule(context.Background(), reminder.ScheduleInput{
+		IDs:         reminderIDs,
+		ScheduledAt: now,
+	})
+
+	s.Nil(err)
+	s.assertScheduledCorrectly("1", reminderIDs, []int{0}, now, reminders)
+}
+
+func getIDs(reminders []reminder.Reminder) []reminder.ID {
+	ids := make([]reminder.ID, 0, len(reminders))
+	for _, rem := range reminders {
+		ids = append(ids, rem.ID)
+	}
+	return ids
+}
+
 func (s *testScheduleSuite) truncateReminderTable() {
 	s.T().Helper()
 
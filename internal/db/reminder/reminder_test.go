@@ -28,6 +28,7 @@ type testSuite struct {
 	pool                *pgxpool.Pool
 	repo                *PgxReminderRepository
 	reminderChannelRepo *PgxReminderChannelRepository
+	usageRepo           *PgxUsageRepository
 	userRepo            *dbuser.PgxUserRepository
 	channelRepo         *dbchannel.PgxChannelRepository
 	user                user.User
@@ -41,8 +42,9 @@ func (suite *testSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
 	suite.repo = NewPgxReminderRepository(suite.pool)
 	suite.reminderChannelRepo = NewPgxReminderChannelRepository(suite.pool)
-	suite.userRepo = dbuser.NewPgxRepository(suite.pool)
-	suite.channelRepo = dbchannel.NewPgxChannelRepository(suite.pool)
+	suite.usageRepo = NewPgxUsageRepository(suite.pool)
+	suite.userRepo = dbuser.NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.channelRepo = dbchannel.NewPgxChannelRepository(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testSuite) TearDownSuite() {
@@ -534,6 +536,218 @@ func (s *testSuite) TestReadAndCount() {
 	}
 }
 
+func (s *testSuite) TestReadAndCountFilters() {
+	every := c.NewOptional(reminder.EveryDay, true)
+	inputs := []struct {
+		input      reminder.CreateInput
+		channelIDs []channel.ID
+	}{
+		{
+			// 0
+			input: reminder.CreateInput{
+				CreatedBy: s.user.ID,
+				CreatedAt: Now.Add(-2 * time.Hour),
+				At:        At,
+				Body:      "Call mom about the weekend",
+				Status:    reminder.StatusCreated,
+			},
+			channelIDs: []channel.ID{s.channel.ID},
+		},
+		{
+			// 1
+			input: reminder.CreateInput{
+				CreatedBy: s.user.ID,
+				CreatedAt: Now.Add(-time.Hour),
+				At:        At.Add(time.Hour),
+				Body:      "Pay the rent",
+				Every:     every,
+				Status:    reminder.StatusCreated,
+			},
+			channelIDs: []channel.ID{s.otherChannel.ID},
+		},
+		{
+			// 2
+			input: reminder.CreateInput{
+				CreatedBy: s.user.ID,
+				CreatedAt: Now,
+				At:        At.Add(2 * time.Hour),
+				Body:      "Call the dentist",
+				Every:     every,
+				Status:    reminder.StatusPaused,
+			},
+			channelIDs: []channel.ID{s.channel.ID, s.otherChannel.ID},
+		},
+		{
+			// 3, has no channels left, e.g. after they are deleted, so it is neither read nor counted
+			input: reminder.CreateInput{
+				CreatedBy: s.user.ID,
+				CreatedAt: Now,
+				At:        At,
+				Body:      "Call mom",
+				Status:    reminder.StatusCreated,
+			},
+		},
+	}
+	reminderIDs := make([]reminder.ID, 0, len(inputs))
+	for _, input := range inputs {
+		rem, err := s.repo.Create(context.Background(), input.input)
+		s.Nil(err)
+		reminderIDs = append(reminderIDs, rem.ID)
+		if len(input.channelIDs) > 0 {
+			_, err = s.reminderChannelRepo.Create(
+				context.Background(),
+				reminder.NewCreateChannelsInput(rem.ID, input.channelIDs...),
+			)
+			s.Nil(err)
+		}
+	}
+
+	cases := []struct {
+		id            string
+		options       reminder.ReadOptions
+		expectedIxs   []int
+		expectedCount uint
+	}{
+		{
+			id:            "no filters",
+			options:       reminder.ReadOptions{},
+			expectedIxs:   []int{0, 1, 2},
+			expectedCount: 3,
+		},
+		{
+			id:            "body search",
+			options:       reminder.ReadOptions{BodySearch: c.NewOptional("call", true)},
+			expectedIxs:   []int{0, 2},
+			expectedCount: 2,
+		},
+		{
+			id:            "body search with exclusion",
+			options:       reminder.ReadOptions{BodySearch: c.NewOptional("call -dentist", true)},
+			expectedIxs:   []int{0},
+			expectedCount: 1,
+		},
+		{
+			id: "at range",
+			options: reminder.ReadOptions{
+				AtAfter:  c.NewOptional(At.Add(time.Hour), true),
+				AtBefore: c.NewOptional(At.Add(2*time.Hour), true),
+			},
+			expectedIxs:   []int{1},
+			expectedCount: 1,
+		},
+		{
+			id:            "periodic",
+			options:       reminder.ReadOptions{IsPeriodic: c.NewOptional(true, true)},
+			expectedIxs:   []int{1, 2},
+			expectedCount: 2,
+		},
+		{
+			id:            "one-off",
+			options:       reminder.ReadOptions{IsPeriodic: c.NewOptional(false, true)},
+			expectedIxs:   []int{0},
+			expectedCount: 1,
+		},
+		{
+			id:            "channel",
+			options:       reminder.ReadOptions{ChannelIDEquals: c.NewOptional(s.otherChannel.ID, true)},
+			expectedIxs:   []int{1, 2},
+			expectedCount: 2,
+		},
+		{
+			id: "created range",
+			options: reminder.ReadOptions{
+				CreatedAfter:  c.NewOptional(Now.Add(-time.Hour), true),
+				CreatedBefore: c.NewOptional(Now, true),
+			},
+			expectedIxs:   []int{1},
+			expectedCount: 1,
+		},
+		{
+			id: "combined with pagination",
+			options: reminder.ReadOptions{
+				CreatedByEquals: c.NewOptional(s.user.ID, true),
+				ChannelIDEquals: c.NewOptional(s.channel.ID, true),
+				BodySearch:      c.NewOptional("call", true),
+				OrderBy:         reminder.OrderByIDDesc,
+				Limit:           c.NewOptional(uint(1), true),
+			},
+			expectedIxs:   []int{2},
+			expectedCount: 2,
+		},
+	}
+	for _, testcase := range cases {
+		reminders, err := s.repo.Read(context.Background(), testcase.options)
+		s.Nil(err, testcase.id)
+		s.assertReminderIDsEqual(testcase.id, reminderIDs, testcase.expectedIxs, reminders)
+
+		count, err := s.repo.Count(context.Background(), testcase.options)
+		s.Nil(err, testcase.id)
+		s.Equal(testcase.expectedCount, count, testcase.id)
+	}
+}
+
+func (s *testSuite) TestReadAfterCursor() {
+	reminderIDs := s.createReminders([]reminder.CreateInput{
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At.Add(time.Hour), Status: reminder.StatusCreated},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusCreated},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusCreated},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At.Add(-time.Hour), Status: reminder.StatusCreated},
+	})
+	cursorAt := func(orderBy reminder.OrderBy, ix int, at time.Time) c.Optional[reminder.Cursor] {
+		return c.NewOptional(reminder.Cursor{OrderBy: orderBy, At: at, ID: reminderIDs[ix]}, true)
+	}
+
+	cases := []struct {
+		id          string
+		options     reminder.ReadOptions
+		expectedIxs []int
+	}{
+		{
+			id:          "not set",
+			options:     reminder.ReadOptions{After: cursorAt(reminder.OrderByNotSet, 1, At)},
+			expectedIxs: []int{2, 3},
+		},
+		{
+			id: "id asc",
+			options: reminder.ReadOptions{
+				OrderBy: reminder.OrderByIDAsc,
+				After:   cursorAt(reminder.OrderByIDAsc, 0, At.Add(time.Hour)),
+				Limit:   c.NewOptional(uint(2), true),
+			},
+			expectedIxs: []int{1, 2},
+		},
+		{
+			id: "id desc",
+			options: reminder.ReadOptions{
+				OrderBy: reminder.OrderByIDDesc,
+				After:   cursorAt(reminder.OrderByIDDesc, 2, At),
+			},
+			expectedIxs: []int{1, 0},
+		},
+		{
+			id: "at asc",
+			options: reminder.ReadOptions{
+				OrderBy: reminder.OrderByAtAsc,
+				After:   cursorAt(reminder.OrderByAtAsc, 1, At),
+			},
+			expectedIxs: []int{2, 0},
+		},
+		{
+			id: "at desc",
+			options: reminder.ReadOptions{
+				OrderBy: reminder.OrderByAtDesc,
+				After:   cursorAt(reminder.OrderByAtDesc, 1, At),
+			},
+			expectedIxs: []int{2, 3},
+		},
+	}
+	for _, testcase := range cases {
+		reminders, err := s.repo.Read(context.Background(), testcase.options)
+		s.Nil(err, testcase.id)
+		s.assertReminderIDsEqual(testcase.id, reminderIDs, testcase.expectedIxs, reminders)
+	}
+}
+
 func (s *testSuite) TestReadReminderChannels() {
 	r1 := s.createReminder()
 	_, err := s.reminderChannelRepo.Create(context.Background(), reminder.NewCreateChannelsInput(r1.ID, s.channel.ID))
@@ -0,0 +1,157 @@
+package remidner
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type PgxUsageRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPgxUsageRepository(db sqlcgen.DBTX) *PgxUsageRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	return &PgxUsageRepository{queries: sqlcgen.New(db)}
+}
+
+func (r *PgxUsageRepository) AddActive(ctx context.Context, userID user.ID, delta int) error {
+	return r.queries.AddActiveReminderUsage(ctx, sqlcgen.AddActiveReminderUsageParams{
+		UserID: int64(userID),
+		Delta:  int32(delta),
+	})
+}
+
+func (r *PgxUsageRepository) AddPaused(ctx context.Context, userID user.ID, delta int) error {
+	return r.queries.AddPausedReminderUsage(ctx, sqlcgen.AddPausedReminderUsageParams{
+		UserID: int64(userID),
+		Delta:  int32(delta),
+	})
+}
+
+func (r *PgxUsageRepository) AddSent(ctx context.Context, userID user.ID, at time.Time, delta int) error {
+	return r.queries.AddSentReminderUsage(ctx, sqlcgen.AddSentReminderUsageParams{
+		UserID: int64(userID),
+		Bucket: reminder.SentUsageBucket(at),
+		Delta:  int32(delta),
+	})
+}
+
+func (r *PgxUsageRepository) GetActiveCount(ctx context.Context, userID user.ID) (uint, error) {
+	count, err := r.queries.GetActiveReminderUsage(ctx, int64(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return uint(count), nil
+}
+
+func (r *PgxUsageRepository) GetPausedCount(ctx context.Context, userID user.ID) (uint, error) {
+	count, err := r.queries.GetPausedReminderUsage(ctx, int64(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return uint(count), nil
+}
+
+func (r *PgxUsageRepository) CountSent(ctx context.Context, userID user.ID, since time.Time) (uint, error) {
+	count, err := r.queries.CountSentReminderUsage(ctx, sqlcgen.CountSentReminderUsageParams{
+		UserID: int64(userID),
+		Since:  reminder.SentUsageBucket(since),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(count), nil
+}
+
+func (r *PgxUsageRepository) GetFirstSentBucket(
+	ctx context.Context,
+	userID user.ID,
+	since time.Time,
+) (first c.Optional[time.Time], err error) {
+	bucket, err := r.queries.GetFirstSentReminderUsageBucket(ctx, sqlcgen.GetFirstSentReminderUsageBucketParams{
+		UserID: int64(userID),
+		Since:  reminder.SentUsageBucket(since),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return first, nil
+		}
+		return first, err
+	}
+	return c.NewOptional(bucket, true), nil
+}
+
+func (r *PgxUsageRepository) Reconcile(
+	ctx context.Context,
+	input reminder.ReconcileUsageInput,
+) (result reminder.ReconcileUsageResult, err error) {
+	// Counter rows of the batch are locked first, so that reminder changes committed concurrently
+	// are either seen by the recount or applied to the recomputed counters after it.
+	dbUserIDs, err := r.queries.LockReminderUsage(ctx, sqlcgen.LockReminderUsageParams{
+		AfterUserID: int64(input.AfterUserID),
+		Limit:       int32(input.Limit),
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(dbUserIDs) == 0 {
+		return result, nil
+	}
+
+	activeFixed, err := r.queries.ReconcileActiveReminderUsage(ctx, sqlcgen.ReconcileActiveReminderUsageParams{
+		ActiveStatuses: []string{string(reminder.StatusCreated), string(reminder.StatusScheduled)},
+		PausedStatus:   string(reminder.StatusPaused),
+		UserIds:        dbUserIDs,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	sentAfter := reminder.SentUsageBucket(input.SentAfter)
+	if err := r.queries.DeleteSentReminderUsageBefore(ctx, sqlcgen.DeleteSentReminderUsageBeforeParams{
+		UserIds:   dbUserIDs,
+		SentAfter: sentAfter,
+	}); err != nil {
+		return result, err
+	}
+	sentFixed, err := r.queries.ReconcileSentReminderUsage(ctx, sqlcgen.ReconcileSentReminderUsageParams{
+		UserIds:   dbUserIDs,
+		Status:    string(reminder.StatusSentSuccess),
+		SentAfter: sentAfter,
+	})
+	if err != nil {
+		return result, err
+	}
+	orphansDeleted, err := r.queries.DeleteOrphanSentReminderUsage(ctx, sqlcgen.DeleteOrphanSentReminderUsageParams{
+		UserIds:   dbUserIDs,
+		SentAfter: sentAfter,
+		Status:    string(reminder.StatusSentSuccess),
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.UserIDs = make([]user.ID, 0, len(dbUserIDs))
+	for _, id := range dbUserIDs {
+		result.UserIDs = append(result.UserIDs, user.ID(id))
+	}
+	result.ActiveFixed = uint(activeFixed)
+	result.SentFixed = uint(sentFixed + orphansDeleted)
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package remidner
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"time"
+)
+
+func (s *testSuite) TestUsageCounters() {
+	ctx := context.Background()
+	s.Require().Nil(s.usageRepo.AddActive(ctx, s.user.ID, 1))
+	s.Require().Nil(s.usageRepo.AddActive(ctx, s.user.ID, 1))
+	s.Require().Nil(s.usageRepo.AddActive(ctx, s.user.ID, -1))
+	s.Require().Nil(s.usageRepo.AddActive(ctx, s.otherUser.ID, -1))
+	s.Require().Nil(s.usageRepo.AddPaused(ctx, s.user.ID, 2))
+	s.Require().Nil(s.usageRepo.AddPaused(ctx, s.user.ID, -1))
+
+	count, err := s.usageRepo.GetActiveCount(ctx, s.user.ID)
+	s.Nil(err)
+	s.Equal(uint(1), count)
+	count, err = s.usageRepo.GetActiveCount(ctx, s.otherUser.ID)
+	s.Nil(err)
+	s.Equal(uint(0), count)
+	count, err = s.usageRepo.GetPausedCount(ctx, s.user.ID)
+	s.Nil(err)
+	s.Equal(uint(1), count)
+	count, err = s.usageRepo.GetPausedCount(ctx, s.otherUser.ID)
+	s.Nil(err)
+	s.Equal(uint(0), count)
+
+	first := Now.Add(-48 * time.Hour)
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.user.ID, first, 1))
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.user.ID, Now, 1))
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.user.ID, Now, 1))
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.otherUser.ID, Now, 1))
+
+	count, err = s.usageRepo.CountSent(ctx, s.user.ID, reminder.SentUsageBucket(first))
+	s.Nil(err)
+	s.Equal(uint(3), count)
+	count, err = s.usageRepo.CountSent(ctx, s.user.ID, reminder.SentUsageBucket(Now))
+	s.Nil(err)
+	s.Equal(uint(2), count)
+	// The bucket containing since is counted as a whole.
+	lastSecond := reminder.SentUsageBucket(first).Add(reminder.SENT_USAGE_BUCKET_DURATION - time.Second)
+	count, err = s.usageRepo.CountSent(ctx, s.user.ID, lastSecond)
+	s.Nil(err)
+	s.Equal(uint(3), count)
+
+	firstBucket, err := s.usageRepo.GetFirstSentBucket(ctx, s.user.ID, Now.Add(-72*time.Hour))
+	s.Nil(err)
+	s.Equal(c.NewOptional(reminder.SentUsageBucket(first), true), firstBucket)
+	firstBucket, err = s.usageRepo.GetFirstSentBucket(ctx, s.user.ID, lastSecond)
+	s.Nil(err)
+	s.Equal(c.NewOptional(reminder.SentUsageBucket(first), true), firstBucket)
+	firstBucket, err = s.usageRepo.GetFirstSentBucket(ctx, s.user.ID, Now.Add(time.Hour))
+	s.Nil(err)
+	s.False(firstBucket.IsPresent)
+}
+
+func (s *testSuite) TestUsageReconcile() {
+	ctx := context.Background()
+	sentAt := Now.Add(-time.Hour)
+	s.createReminders([]reminder.CreateInput{
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusCreated},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusScheduled},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusCanceled},
+		{CreatedBy: s.user.ID, CreatedAt: Now, At: At, Status: reminder.StatusPaused},
+		{
+			CreatedBy: s.user.ID,
+			CreatedAt: Now,
+			At:        sentAt,
+			Status:    reminder.StatusSentSuccess,
+			SentAt:    c.NewOptional(sentAt, true),
+		},
+	})
+	s.Require().Nil(s.usageRepo.AddActive(ctx, s.user.ID, 5))
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.user.ID, Now.Add(-72*time.Hour), 2))
+	s.Require().Nil(s.usageRepo.AddSent(ctx, s.user.ID, Now.Add(-60*24*time.Hour), 2))
+	input := reminder.ReconcileUsageInput{
+		Limit:     10,
+		SentAfter: Now.Add(-reminder.SENT_USAGE_RETENTION),
+	}
+
+	result, err := s.usageRepo.Reconcile(ctx, input)
+	s.Nil(err)
+	s.Equal(reminder.ReconcileUsageResult{
+		UserIDs:     []user.ID{s.user.ID, s.otherUser.ID},
+		ActiveFixed: 1,
+		SentFixed:   2,
+	}, result)
+
+	count, err := s.usageRepo.GetActiveCount(ctx, s.user.ID)
+	s.Nil(err)
+	s.Equal(uint(2), count)
+	count, err = s.usageRepo.GetPausedCount(ctx, s.user.ID)
+	s.Nil(err)
+	s.Equal(uint(1), count)
+	count, err = s.usageRepo.CountSent(ctx, s.user.ID, time.Time{})
+	s.Nil(err)
+	s.Equal(uint(1), count)
+
+	result, err = s.usageRepo.Reconcile(ctx, input)
+	s.Nil(err)
+	s.Equal(uint(0), result.ActiveFixed)
+	s.Equal(uint(0), result.SentFixed)
+
+	input.AfterUserID = s.user.ID
+	result, err = s.usageRepo.Reconcile(ctx, input)
+	s.Nil(err)
+	s.Equal([]user.ID{s.otherUser.ID}, result.UserIDs)
+}
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretBox(t *testing.T) {
+	box := NewSecretBox("key")
+
+	sealed := box.Seal("secret")
+	assert.NotContains(t, sealed, "secret")
+	assert.NotEqual(t, sealed, box.Seal("secret"))
+
+	opened, err := box.Open(sealed)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", opened)
+
+	_, err = NewSecretBox("other key").Open(sealed)
+	assert.NotNil(t, err)
+	_, err = box.Open("c2hvcnQ=")
+	assert.NotNil(t, err)
+	assert.Panics(t, func() { NewSecretBox("") })
+}
@@ -0,0 +1,19 @@
+-- name: CreateAccountDeletion :execrows
+INSERT INTO account_deletion (user_id, requested_at, purge_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: GetAccountDeletion :one
+SELECT * FROM account_deletion WHERE user_id = $1;
+
+-- name: GetAccountDeletionWithLock :one
+SELECT * FROM account_deletion WHERE user_id = $1 FOR UPDATE;
+
+-- name: ReadDueAccountDeletions :many
+SELECT * FROM account_deletion
+WHERE purge_at <= @purge_at::timestamp
+ORDER BY purge_at, user_id
+LIMIT @batch_size::integer;
+
+-- name: DeleteAccountDeletion :execrows
+DELETE FROM account_deletion WHERE user_id = $1;
@@ -0,0 +1,34 @@
+-- name: CreateAPIToken :one
+INSERT INTO api_token (user_id, token, name, scopes, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetUserByAPIToken :one
+WITH active_token AS (
+    SELECT id, user_id, name, scopes, created_at, expires_at, last_used_at FROM api_token
+    WHERE token = @token::text AND expires_at > @last_used_at::timestamp
+), used_token AS (
+    UPDATE api_token
+    SET last_used_at = @last_used_at::timestamp
+    FROM active_token
+    WHERE api_token.id = active_token.id
+        AND (active_token.last_used_at IS NULL OR active_token.last_used_at <= @used_before::timestamp)
+)
+SELECT
+    "user".*,
+    active_token.id AS token_id,
+    active_token.name AS token_name,
+    active_token.scopes AS token_scopes,
+    active_token.created_at AS token_created_at,
+    active_token.expires_at AS token_expires_at
+FROM "user"
+JOIN active_token ON "user".id = active_token.user_id;
+
+-- name: ListUserAPITokens :many
+SELECT * FROM api_token WHERE user_id = $1 ORDER BY id;
+
+-- name: DeleteAPIToken :execrows
+DELETE FROM api_token WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteUserAPITokens :exec
+DELETE FROM api_token WHERE user_id = $1;
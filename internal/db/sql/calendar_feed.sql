@@ -0,0 +1,20 @@
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feed (user_id, token, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET token = EXCLUDED.token, created_at = EXCLUDED.created_at, last_used_at = NULL
+RETURNING *;
+
+-- name: GetUserByCalendarFeedToken :one
+WITH feed AS (
+    UPDATE calendar_feed
+    SET last_used_at = @last_used_at::timestamp
+    WHERE token = @token::text
+    RETURNING user_id
+)
+SELECT "user".*
+FROM "user"
+JOIN feed ON "user".id = feed.user_id;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feed WHERE user_id = $1;
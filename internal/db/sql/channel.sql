@@ -9,15 +9,25 @@ SELECT * FROM channel WHERE
     AND (@all_user_ids::boolean OR user_id = @user_id_equals::bigint)
     AND (@all_types::boolean OR type = @type_equals::text)
     AND (@all_is_default::boolean OR is_default = @is_default_equals::boolean)
+    AND (@any_cursor::boolean OR CASE
+        WHEN @order_by_id_desc::boolean THEN id < @cursor_id::bigint
+        ELSE id > @cursor_id::bigint
+    END)
 ORDER BY 
     CASE WHEN @order_by_id_asc::boolean THEN channel.id ELSE null END ASC,
     CASE WHEN @order_by_id_desc::boolean THEN channel.id ELSE null END DESC,
     id ASC
-LIMIT CASE WHEN @all_rows::boolean THEN null ELSE @limit_::integer END;
+LIMIT CASE WHEN @all_rows::boolean THEN null ELSE @limit_::integer END
+OFFSET @offset_::integer;
 
 -- name: GetChannelByID :one
 SELECT * FROM channel WHERE id = $1;
 
+-- name: ChannelHasVerificationToken :one
+SELECT EXISTS (
+    SELECT 1 FROM channel WHERE id = @id::bigint AND verification_token = @verification_token::text
+);
+
 -- name: CountChannels :one
 SELECT COUNT(id) FROM channel WHERE
     (@all_channel_ids::boolean OR id = ANY(@id_in::bigint[])) 
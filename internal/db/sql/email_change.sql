@@ -0,0 +1,30 @@
+-- name: CreateEmailChange :one
+WITH pending AS (
+    DELETE FROM email_change WHERE email_change.user_id = $1 AND email_change.confirmed_at IS NULL
+)
+INSERT INTO email_change (user_id, old_email, new_email, token, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetEmailChangeByTokenWithLock :one
+SELECT * FROM email_change
+WHERE token = @token::text AND confirmed_at IS NULL AND expires_at > @at::timestamp
+FOR UPDATE;
+
+-- name: ConfirmEmailChange :one
+UPDATE email_change
+SET
+    token = NULL,
+    confirmed_at = @confirmed_at::timestamp,
+    revert_token = @revert_token::text,
+    revert_expires_at = @revert_expires_at::timestamp
+WHERE id = @id::bigint
+RETURNING *;
+
+-- name: GetEmailChangeByRevertTokenWithLock :one
+SELECT * FROM email_change
+WHERE revert_token = @revert_token::text AND revert_expires_at > @at::timestamp
+FOR UPDATE;
+
+-- name: DeleteEmailChange :execrows
+DELETE FROM email_change WHERE id = $1;
@@ -0,0 +1,33 @@
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(@lock_id::bigint);
+
+
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(@lock_id::bigint);
+
+
+-- name: SetApplicationName :exec
+SELECT set_config('application_name', @application_name::text, false);
+
+
+-- name: GetAdvisoryLockHolder :one
+SELECT activity.application_name::text FROM pg_locks AS lock
+JOIN pg_stat_activity AS activity ON activity.pid = lock.pid
+WHERE 
+    lock.locktype = 'advisory' 
+    AND lock.granted 
+    AND lock.objsubid = 1
+    AND ((lock.classid::bigint << 32) | lock.objid::bigint) = @lock_id::bigint
+LIMIT 1;
+
+
+-- name: IsAdvisoryLockHeld :one
+SELECT EXISTS (
+    SELECT 1 FROM pg_locks
+    WHERE
+        locktype = 'advisory'
+        AND granted
+        AND objsubid = 1
+        AND ((classid::bigint << 32) | objid::bigint) = @lock_id::bigint
+        AND pid = pg_backend_pid()
+);
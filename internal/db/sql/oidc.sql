@@ -0,0 +1,23 @@
+-- name: CreateExternalIdentity :one
+INSERT INTO external_identity (user_id, provider, subject, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetUserByExternalIdentity :one
+SELECT "user".* FROM "user"
+JOIN external_identity ON "user".id = external_identity.user_id
+WHERE external_identity.provider = $1 AND external_identity.subject = $2;
+
+-- name: CreateOIDCAuthorization :exec
+INSERT INTO oidc_authorization (state, provider, nonce, code_verifier, user_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteOIDCAuthorizations :many
+DELETE FROM oidc_authorization
+WHERE state = @state::text OR created_at <= @created_since::timestamp
+RETURNING *;
+
+-- name: ListUserExternalIdentities :many
+SELECT * FROM external_identity
+WHERE user_id = $1
+ORDER BY id;
@@ -0,0 +1,13 @@
+-- name: GetPlan :one
+SELECT * FROM plan WHERE name = $1;
+
+-- name: ListPlans :many
+SELECT * FROM plan ORDER BY name;
+
+-- name: CreateLimitsAuditEntry :one
+INSERT INTO limits_audit (user_id, actor, action, plan, limits_before, limits_after, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListLimitsAuditEntries :many
+SELECT * FROM limits_audit WHERE user_id = $1 ORDER BY created_at DESC, id DESC;
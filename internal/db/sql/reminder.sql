@@ -12,6 +12,23 @@ WHERE
     (@any_user_id::boolean OR reminder.user_id = @user_id_equals::bigint)
     AND (@any_sent_at::boolean OR reminder.sent_at >= @sent_after::timestamp)
     AND (@any_status::boolean OR reminder.status = ANY(@status_in::text[]))
+    AND (@any_body_search::boolean OR to_tsvector('simple', reminder.body) @@ websearch_to_tsquery('simple', @body_search::text))
+    AND (@any_at_after::boolean OR reminder.at >= @at_after::timestamp)
+    AND (@any_at_before::boolean OR reminder.at < @at_before::timestamp)
+    AND (@any_every::boolean OR (reminder.every IS NOT NULL) = @is_periodic::boolean)
+    AND (@any_channel_id::boolean OR EXISTS (
+        SELECT 1 FROM reminder_channel AS rc
+        WHERE rc.reminder_id = reminder.id AND rc.channel_id = @channel_id_equals::bigint
+    ))
+    AND (@any_created_after::boolean OR reminder.created_at >= @created_after::timestamp)
+    AND (@any_created_before::boolean OR reminder.created_at < @created_before::timestamp)
+    AND (@any_cursor::boolean OR CASE
+        WHEN @order_by_id_desc::boolean THEN reminder.id < @cursor_id::bigint
+        WHEN @order_by_at_asc::boolean THEN (reminder.at, reminder.id) > (@cursor_at::timestamp, @cursor_id::bigint)
+        WHEN @order_by_at_desc::boolean THEN reminder.at < @cursor_at::timestamp
+            OR (reminder.at = @cursor_at::timestamp AND reminder.id > @cursor_id::bigint)
+        ELSE reminder.id > @cursor_id::bigint
+    END)
 GROUP BY reminder.id
 ORDER BY 
     CASE WHEN @order_by_id_asc::boolean THEN reminder.id ELSE null END,
@@ -27,7 +44,18 @@ OFFSET @offset_::integer;
 SELECT COUNT(id) FROM reminder WHERE 
     (@any_user_id::boolean OR user_id = @user_id_equals::bigint)
     AND (@any_sent_at::boolean OR sent_at >= @sent_after::timestamp)
-    AND (@any_status::boolean OR status = ANY(@status_in::text[]));
+    AND (@any_status::boolean OR status = ANY(@status_in::text[]))
+    AND (@any_body_search::boolean OR to_tsvector('simple', body) @@ websearch_to_tsquery('simple', @body_search::text))
+    AND (@any_at_after::boolean OR at >= @at_after::timestamp)
+    AND (@any_at_before::boolean OR at < @at_before::timestamp)
+    AND (@any_every::boolean OR (every IS NOT NULL) = @is_periodic::boolean)
+    AND (@any_channel_id::boolean OR EXISTS (
+        SELECT 1 FROM reminder_channel AS rc
+        WHERE rc.reminder_id = reminder.id AND rc.channel_id = @channel_id_equals::bigint
+    ))
+    AND (@any_created_after::boolean OR created_at >= @created_after::timestamp)
+    AND (@any_created_before::boolean OR created_at < @created_before::timestamp)
+    AND EXISTS (SELECT 1 FROM reminder_channel WHERE reminder_channel.reminder_id = reminder.id);
 
 
 -- name: LockReminder :exec
@@ -64,10 +92,22 @@ WHERE id = $1
 RETURNING *;
 
 
+-- name: ReadRemindersForScheduling :many
+-- The status is a literal to match the predicate of the partial reminder_scheduling_idx.
+SELECT * FROM reminder
+WHERE 
+    at < @at_before
+    AND status = 'created'
+    AND (at, id) > (@after_at::timestamp, @after_id::bigint)
+ORDER BY at, id
+LIMIT @limit_::integer
+FOR UPDATE SKIP LOCKED;
+
+
 -- name: ScheduleReminders :many
 UPDATE reminder
 SET status = @status, scheduled_at = @scheduled_at::timestamp
-WHERE at < @at_before AND status = ANY(@statuses_for_scheduling::text[])
+WHERE id = ANY(@ids::bigint[]) AND status = ANY(@statuses_for_scheduling::text[])
 RETURNING *;
 
 
@@ -0,0 +1,42 @@
+-- name: GetTOTP :one
+SELECT * FROM totp WHERE user_id = $1;
+
+-- name: CreateTOTP :execrows
+INSERT INTO totp (user_id, secret, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at
+WHERE totp.confirmed_at IS NULL;
+
+-- name: ConfirmTOTP :execrows
+WITH confirmed AS (
+    UPDATE totp SET confirmed_at = @confirmed_at::timestamp, last_used_step = @step::bigint
+    WHERE totp.user_id = @user_id::bigint AND totp.confirmed_at IS NULL
+    RETURNING totp.user_id
+), codes AS (
+    INSERT INTO recovery_code (user_id, code)
+    SELECT confirmed.user_id, unnest(@codes::text[]) FROM confirmed
+)
+SELECT user_id FROM confirmed;
+
+-- name: UseTOTPStep :execrows
+UPDATE totp SET last_used_step = $2
+WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2;
+
+-- name: DeleteRecoveryCode :execrows
+DELETE FROM recovery_code WHERE user_id = $1 AND code = $2;
+
+-- name: DeleteTOTP :exec
+DELETE FROM totp WHERE user_id = $1;
+
+-- name: CreateTwoFactorChallenge :exec
+INSERT INTO two_factor_challenge (token, user_id, created_at)
+VALUES ($1, $2, $3);
+
+-- name: GetTwoFactorChallenge :one
+SELECT * FROM two_factor_challenge WHERE token = $1 AND created_at > $2;
+
+-- name: DeleteTwoFactorChallenges :many
+DELETE FROM two_factor_challenge
+WHERE token = @token::text OR created_at <= @created_since::timestamp
+RETURNING *;
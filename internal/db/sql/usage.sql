@@ -0,0 +1,101 @@
+-- name: AddActiveReminderUsage :exec
+INSERT INTO reminder_usage (user_id, active_reminder_count)
+VALUES (@user_id, GREATEST(@delta::integer, 0))
+ON CONFLICT (user_id) DO UPDATE
+SET active_reminder_count = GREATEST(reminder_usage.active_reminder_count + @delta::integer, 0);
+
+
+-- name: AddPausedReminderUsage :exec
+INSERT INTO reminder_usage (user_id, paused_reminder_count)
+VALUES (@user_id, GREATEST(@delta::integer, 0))
+ON CONFLICT (user_id) DO UPDATE
+SET paused_reminder_count = GREATEST(reminder_usage.paused_reminder_count + @delta::integer, 0);
+
+
+-- name: AddSentReminderUsage :exec
+INSERT INTO sent_reminder_usage (user_id, bucket, sent_reminder_count)
+VALUES (@user_id, @bucket, GREATEST(@delta::integer, 0))
+ON CONFLICT (user_id, bucket) DO UPDATE
+SET sent_reminder_count = GREATEST(sent_reminder_usage.sent_reminder_count + @delta::integer, 0);
+
+
+-- name: GetActiveReminderUsage :one
+SELECT active_reminder_count FROM reminder_usage WHERE user_id = $1;
+
+
+-- name: GetPausedReminderUsage :one
+SELECT paused_reminder_count FROM reminder_usage WHERE user_id = $1;
+
+
+-- name: CountSentReminderUsage :one
+SELECT COALESCE(SUM(sent_reminder_count), 0)::integer FROM sent_reminder_usage
+WHERE user_id = @user_id AND bucket >= @since::timestamp;
+
+
+-- name: GetFirstSentReminderUsageBucket :one
+SELECT bucket FROM sent_reminder_usage
+WHERE user_id = @user_id AND bucket >= @since::timestamp AND sent_reminder_count > 0
+ORDER BY bucket
+LIMIT 1;
+
+
+-- name: LockReminderUsage :many
+INSERT INTO reminder_usage (user_id, active_reminder_count)
+SELECT id, 0 FROM "user"
+WHERE id > @after_user_id::bigint
+ORDER BY id
+LIMIT @limit_::integer
+ON CONFLICT (user_id) DO UPDATE SET active_reminder_count = reminder_usage.active_reminder_count
+RETURNING user_id;
+
+
+-- name: ReconcileActiveReminderUsage :execrows
+UPDATE reminder_usage
+SET
+    active_reminder_count = actual.active_reminder_count,
+    paused_reminder_count = actual.paused_reminder_count
+FROM (
+    SELECT
+        reminder_usage.user_id,
+        COUNT(reminder.id) FILTER (WHERE reminder.status = ANY(@active_statuses::text[]))::integer
+            AS active_reminder_count,
+        COUNT(reminder.id) FILTER (WHERE reminder.status = @paused_status::text)::integer AS paused_reminder_count
+    FROM reminder_usage
+    LEFT JOIN reminder ON reminder.user_id = reminder_usage.user_id
+        AND (reminder.status = ANY(@active_statuses::text[]) OR reminder.status = @paused_status::text)
+    WHERE reminder_usage.user_id = ANY(@user_ids::bigint[])
+    GROUP BY reminder_usage.user_id
+) AS actual
+WHERE reminder_usage.user_id = actual.user_id
+    AND (
+        reminder_usage.active_reminder_count <> actual.active_reminder_count
+        OR reminder_usage.paused_reminder_count <> actual.paused_reminder_count
+    );
+
+
+-- name: ReconcileSentReminderUsage :execrows
+INSERT INTO sent_reminder_usage (user_id, bucket, sent_reminder_count)
+SELECT user_id, date_bin('15 minutes', sent_at, TIMESTAMP '2000-01-01'), COUNT(id)::integer FROM reminder
+WHERE user_id = ANY(@user_ids::bigint[]) AND status = @status AND sent_at >= @sent_after::timestamp
+GROUP BY 1, 2
+ON CONFLICT (user_id, bucket) DO UPDATE SET sent_reminder_count = EXCLUDED.sent_reminder_count
+WHERE sent_reminder_usage.sent_reminder_count <> EXCLUDED.sent_reminder_count;
+
+
+-- name: DeleteOrphanSentReminderUsage :execrows
+DELETE FROM sent_reminder_usage
+WHERE user_id = ANY(@user_ids::bigint[])
+    AND bucket >= @sent_after::timestamp
+    AND sent_reminder_count > 0
+    AND NOT EXISTS (
+        SELECT 1 FROM reminder
+        WHERE reminder.user_id = sent_reminder_usage.user_id
+            AND reminder.status = @status
+            AND reminder.sent_at >= sent_reminder_usage.bucket
+            AND reminder.sent_at < sent_reminder_usage.bucket + INTERVAL '15 minutes'
+    );
+
+
+-- name: DeleteSentReminderUsageBefore :exec
+DELETE FROM sent_reminder_usage
+WHERE user_id = ANY(@user_ids::bigint[]) AND bucket < @sent_after::timestamp;
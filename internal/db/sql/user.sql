@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO "user" (email, identity, password_hash, created_at, timezone, activated_at, activation_token) 
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO "user" (email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetUserByID :one
@@ -10,14 +10,33 @@ SELECT * FROM "user" WHERE id = $1;
 SELECT * FROM "user" WHERE email = $1;
 
 -- name: GetUserBySessionToken :one
-SELECT "user".* FROM "user" 
-JOIN session ON "user".id = session.user_id
-WHERE session.token = $1;
+WITH active_session AS (
+    SELECT id, user_id, last_seen_at FROM session
+    WHERE token = @token::text
+        AND last_seen_at > @idle_since::timestamp
+        AND created_at > @created_since::timestamp
+), seen_session AS (
+    UPDATE session
+    SET last_seen_at = @last_seen_at::timestamp
+    FROM active_session
+    WHERE session.id = active_session.id AND active_session.last_seen_at <= @seen_before::timestamp
+)
+SELECT "user".* FROM "user"
+JOIN active_session ON "user".id = active_session.user_id;
 
 -- name: ActivateUser :one
 UPDATE "user" 
-SET activated_at = @activated_at::timestamp, activation_token = null
+SET activated_at = @activated_at::timestamp, activation_token = null, activation_token_expires_at = null
 WHERE activation_token = @activation_token::text
+    AND (activation_token_expires_at IS NULL OR activation_token_expires_at > @activated_at::timestamp)
+RETURNING *;
+
+-- name: SetUserActivationToken :one
+UPDATE "user"
+SET
+    activation_token = @activation_token::text,
+    activation_token_expires_at = @activation_token_expires_at::timestamp
+WHERE id = @id::bigint AND activation_token IS NOT NULL
 RETURNING *;
 
 -- name: SetPassword :one
@@ -30,18 +49,43 @@ RETURNING id;
 UPDATE "user" 
 SET 
     timezone = CASE WHEN @do_timezone_update::boolean THEN @timezone
-        ELSE timezone END
+        ELSE timezone END,
+    email = CASE WHEN @do_email_update::boolean THEN @email
+        ELSE email END
 WHERE id = $1
 RETURNING *;
 
 -- name: CreateSession :one
-INSERT INTO session (token, user_id, created_at)
-VALUES ($1, $2, $3)
+INSERT INTO session (token, user_id, created_at, last_seen_at, user_agent, ip)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: DeleteSessionByToken :one
 DELETE FROM session WHERE token = $1 RETURNING user_id;
 
+-- name: ListUserSessions :many
+SELECT id, user_id, created_at, last_seen_at, user_agent, ip, (token = @current_token::text)::boolean AS is_current
+FROM session
+WHERE user_id = @user_id::bigint
+    AND last_seen_at > @idle_since::timestamp
+    AND created_at > @created_since::timestamp
+ORDER BY last_seen_at DESC, id DESC;
+
+-- name: DeleteUserSession :one
+DELETE FROM session WHERE id = @id::bigint AND user_id = @user_id::bigint RETURNING id;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM session
+WHERE id IN (
+    SELECT id FROM session
+    WHERE last_seen_at <= @idle_since::timestamp OR created_at <= @created_since::timestamp
+    ORDER BY id
+    LIMIT @batch_size::integer
+);
+
+-- name: DeleteUserSessions :exec
+DELETE FROM session WHERE user_id = @user_id::bigint AND token <> @except_token::text;
+
 -- name: CreateLimits :one
 INSERT INTO limits (
     user_id, 
@@ -49,13 +93,56 @@ INSERT INTO limits (
     telegram_channel_count, 
     active_reminder_count, 
     monthly_sent_reminder_count,
-    reminder_every_per_day_count
+    reminder_every_per_day_count,
+    plan,
+    sent_reminder_quota_window,
+    paused_reminders_count_as_active
 ) 
-VALUES ($1, $2, $3, $4, $5, $6)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetUserLimits :one
 SELECT * FROM limits WHERE user_id = $1;
 
 -- name: GetUserLimitsWithLock :one
-SELECT * FROM limits WHERE user_id = $1 FOR UPDATE;
\ No newline at end of file
+SELECT * FROM limits WHERE user_id = $1 FOR UPDATE;
+
+-- name: ClaimUser :one
+UPDATE "user"
+SET
+    email = @email::text,
+    password_hash = @password_hash::text,
+    activation_token = @activation_token::text,
+    activation_token_expires_at = @activation_token_expires_at::timestamp
+WHERE
+    id = @id::bigint
+    AND identity IS NOT NULL
+    AND (email IS NULL OR activation_token IS NOT NULL)
+    AND NOT EXISTS (SELECT 1 FROM external_identity WHERE external_identity.user_id = "user".id)
+RETURNING *;
+
+-- name: UpdateLimits :one
+UPDATE limits
+SET
+    email_channel_count = $2,
+    telegram_channel_count = $3,
+    active_reminder_count = $4,
+    monthly_sent_reminder_count = $5,
+    reminder_every_per_day_count = $6,
+    plan = $7,
+    sent_reminder_quota_window = $8,
+    paused_reminders_count_as_active = $9
+WHERE user_id = $1
+RETURNING *;
+
+-- name: DeleteUser :execrows
+DELETE FROM "user" WHERE id = $1;
+
+-- name: DeleteInactiveUsers :execrows
+DELETE FROM "user"
+WHERE id IN (
+    SELECT id FROM "user"
+    WHERE activated_at IS NULL AND created_at < @created_before::timestamp
+    ORDER BY created_at
+    LIMIT @batch_size::integer
+);
@@ -13,6 +13,24 @@ import (
 	"github.com/jackc/pgtype"
 )
 
+const channelHasVerificationToken = `-- name: ChannelHasVerificationToken :one
+SELECT EXISTS (
+    SELECT 1 FROM channel WHERE id = $1::bigint AND verification_token = $2::text
+)
+`
+
+type ChannelHasVerificationTokenParams struct {
+	ID                int64
+	VerificationToken string
+}
+
+func (q *Queries) ChannelHasVerificationToken(ctx context.Context, arg ChannelHasVerificationTokenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, channelHasVerificationToken, arg.ID, arg.VerificationToken)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const countChannels = `-- name: CountChannels :one
 SELECT COUNT(id) FROM channel WHERE
     ($1::boolean OR id = ANY($2::bigint[])) 
@@ -114,11 +132,16 @@ SELECT id, user_id, created_at, is_default, type, settings, verification_token,
     AND ($3::boolean OR user_id = $4::bigint)
     AND ($5::boolean OR type = $6::text)
     AND ($7::boolean OR is_default = $8::boolean)
+    AND ($9::boolean OR CASE
+        WHEN $10::boolean THEN id < $11::bigint
+        ELSE id > $11::bigint
+    END)
 ORDER BY 
-    CASE WHEN $9::boolean THEN channel.id ELSE null END ASC,
+    CASE WHEN $12::boolean THEN channel.id ELSE null END ASC,
     CASE WHEN $10::boolean THEN channel.id ELSE null END DESC,
     id ASC
-LIMIT CASE WHEN $11::boolean THEN null ELSE $12::integer END
+LIMIT CASE WHEN $14::boolean THEN null ELSE $15::integer END
+OFFSET $13::integer
 `
 
 type ReadChanelsParams struct {
@@ -130,8 +153,11 @@ type ReadChanelsParams struct {
 	TypeEquals      string
 	AllIsDefault    bool
 	IsDefaultEquals bool
-	OrderByIDAsc    bool
+	AnyCursor       bool
 	OrderByIDDesc   bool
+	CursorID        int64
+	OrderByIDAsc    bool
+	Offset          int32
 	AllRows         bool
 	Limit           int32
 }
@@ -146,8 +172,11 @@ func (q *Queries) ReadChanels(ctx context.Context, arg ReadChanelsParams) ([]Cha
 		arg.TypeEquals,
 		arg.AllIsDefault,
 		arg.IsDefaultEquals,
-		arg.OrderByIDAsc,
+		arg.AnyCursor,
 		arg.OrderByIDDesc,
+		arg.CursorID,
+		arg.OrderByIDAsc,
+		arg.Offset,
 		arg.AllRows,
 		arg.Limit,
 	)
@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgtype"
 )
 
+type AccountDeletion struct {
+	UserID      int64
+	RequestedAt time.Time
+	PurgeAt     time.Time
+}
+
+type ApiToken struct {
+	ID         int64
+	UserID     int64
+	Token      string
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt sql.NullTime
+}
+
+type CalendarFeed struct {
+	UserID     int64
+	Token      string
+	CreatedAt  time.Time
+	LastUsedAt sql.NullTime
+}
+
 type Channel struct {
 	ID                int64
 	UserID            int64
@@ -22,14 +46,74 @@ type Channel struct {
 	VerifiedAt        sql.NullTime
 }
 
+type EmailChange struct {
+	ID              int64
+	UserID          int64
+	OldEmail        string
+	NewEmail        string
+	Token           sql.NullString
+	RevertToken     sql.NullString
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+	ConfirmedAt     sql.NullTime
+	RevertExpiresAt sql.NullTime
+}
+
+type ExternalIdentity struct {
+	ID        int64
+	UserID    int64
+	Provider  string
+	Subject   string
+	CreatedAt time.Time
+}
+
 type Limit struct {
-	ID                       int64
-	UserID                   int64
-	EmailChannelCount        sql.NullInt32
-	TelegramChannelCount     sql.NullInt32
-	ActiveReminderCount      sql.NullInt32
-	MonthlySentReminderCount sql.NullInt32
-	ReminderEveryPerDayCount sql.NullFloat64
+	ID                           int64
+	UserID                       int64
+	EmailChannelCount            sql.NullInt32
+	TelegramChannelCount         sql.NullInt32
+	ActiveReminderCount          sql.NullInt32
+	MonthlySentReminderCount     sql.NullInt32
+	ReminderEveryPerDayCount     sql.NullFloat64
+	Plan                         sql.NullString
+	SentReminderQuotaWindow      string
+	PausedRemindersCountAsActive bool
+}
+
+type LimitsAudit struct {
+	ID           int64
+	UserID       int64
+	Actor        string
+	Action       string
+	Plan         sql.NullString
+	LimitsBefore pgtype.JSONB
+	LimitsAfter  pgtype.JSONB
+	CreatedAt    time.Time
+}
+
+type OidcAuthorization struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	UserID       sql.NullInt64
+	CreatedAt    time.Time
+}
+
+type Plan struct {
+	Name                         string
+	EmailChannelCount            sql.NullInt32
+	TelegramChannelCount         sql.NullInt32
+	ActiveReminderCount          sql.NullInt32
+	MonthlySentReminderCount     sql.NullInt32
+	ReminderEveryPerDayCount     sql.NullFloat64
+	SentReminderQuotaWindow      string
+	PausedRemindersCountAsActive bool
+}
+
+type RecoveryCode struct {
+	UserID int64
+	Code   string
 }
 
 type Reminder struct {
@@ -51,20 +135,50 @@ type ReminderChannel struct {
 	ChannelID  int64
 }
 
+type ReminderUsage struct {
+	UserID              int64
+	ActiveReminderCount int32
+	PausedReminderCount int32
+}
+
+type SentReminderUsage struct {
+	UserID            int64
+	Bucket            time.Time
+	SentReminderCount int32
+}
+
 type Session struct {
-	ID        int64
+	ID         int64
+	Token      string
+	UserID     int64
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	Ip         string
+}
+
+type Totp struct {
+	UserID       int64
+	Secret       string
+	CreatedAt    time.Time
+	ConfirmedAt  sql.NullTime
+	LastUsedStep int64
+}
+
+type TwoFactorChallenge struct {
 	Token     string
 	UserID    int64
 	CreatedAt time.Time
 }
 
 type User struct {
-	ID              int64
-	Email           sql.NullString
-	Identity        sql.NullString
-	PasswordHash    sql.NullString
-	CreatedAt       time.Time
-	Timezone        string
-	ActivatedAt     sql.NullTime
-	ActivationToken sql.NullString
+	ID                       int64
+	Email                    sql.NullString
+	Identity                 sql.NullString
+	PasswordHash             sql.NullString
+	CreatedAt                time.Time
+	Timezone                 string
+	ActivatedAt              sql.NullTime
+	ActivationToken          sql.NullString
+	ActivationTokenExpiresAt sql.NullTime
 }
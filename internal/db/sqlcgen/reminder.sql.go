@@ -16,15 +16,40 @@ SELECT COUNT(id) FROM reminder WHERE
     ($1::boolean OR user_id = $2::bigint)
     AND ($3::boolean OR sent_at >= $4::timestamp)
     AND ($5::boolean OR status = ANY($6::text[]))
+    AND ($7::boolean OR to_tsvector('simple', body) @@ websearch_to_tsquery('simple', $8::text))
+    AND ($9::boolean OR at >= $10::timestamp)
+    AND ($11::boolean OR at < $12::timestamp)
+    AND ($13::boolean OR (every IS NOT NULL) = $14::boolean)
+    AND ($15::boolean OR EXISTS (
+        SELECT 1 FROM reminder_channel AS rc
+        WHERE rc.reminder_id = reminder.id AND rc.channel_id = $16::bigint
+    ))
+    AND ($17::boolean OR created_at >= $18::timestamp)
+    AND ($19::boolean OR created_at < $20::timestamp)
+    AND EXISTS (SELECT 1 FROM reminder_channel WHERE reminder_channel.reminder_id = reminder.id)
 `
 
 type CountRemindersParams struct {
-	AnyUserID    bool
-	UserIDEquals int64
-	AnySentAt    bool
-	SentAfter    time.Time
-	AnyStatus    bool
-	StatusIn     []string
+	AnyUserID        bool
+	UserIDEquals     int64
+	AnySentAt        bool
+	SentAfter        time.Time
+	AnyStatus        bool
+	StatusIn         []string
+	AnyBodySearch    bool
+	BodySearch       string
+	AnyAtAfter       bool
+	AtAfter          time.Time
+	AnyAtBefore      bool
+	AtBefore         time.Time
+	AnyEvery         bool
+	IsPeriodic       bool
+	AnyChannelID     bool
+	ChannelIDEquals  int64
+	AnyCreatedAfter  bool
+	CreatedAfter     time.Time
+	AnyCreatedBefore bool
+	CreatedBefore    time.Time
 }
 
 func (q *Queries) CountReminders(ctx context.Context, arg CountRemindersParams) (int64, error) {
@@ -35,6 +60,20 @@ func (q *Queries) CountReminders(ctx context.Context, arg CountRemindersParams)
 		arg.SentAfter,
 		arg.AnyStatus,
 		arg.StatusIn,
+		arg.AnyBodySearch,
+		arg.BodySearch,
+		arg.AnyAtAfter,
+		arg.AtAfter,
+		arg.AnyAtBefore,
+		arg.AtBefore,
+		arg.AnyEvery,
+		arg.IsPeriodic,
+		arg.AnyChannelID,
+		arg.ChannelIDEquals,
+		arg.AnyCreatedAfter,
+		arg.CreatedAfter,
+		arg.AnyCreatedBefore,
+		arg.CreatedBefore,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -171,31 +210,65 @@ WHERE
     ($1::boolean OR reminder.user_id = $2::bigint)
     AND ($3::boolean OR reminder.sent_at >= $4::timestamp)
     AND ($5::boolean OR reminder.status = ANY($6::text[]))
+    AND ($7::boolean OR to_tsvector('simple', reminder.body) @@ websearch_to_tsquery('simple', $8::text))
+    AND ($9::boolean OR reminder.at >= $10::timestamp)
+    AND ($11::boolean OR reminder.at < $12::timestamp)
+    AND ($13::boolean OR (reminder.every IS NOT NULL) = $14::boolean)
+    AND ($15::boolean OR EXISTS (
+        SELECT 1 FROM reminder_channel AS rc
+        WHERE rc.reminder_id = reminder.id AND rc.channel_id = $16::bigint
+    ))
+    AND ($17::boolean OR reminder.created_at >= $18::timestamp)
+    AND ($19::boolean OR reminder.created_at < $20::timestamp)
+    AND ($21::boolean OR CASE
+        WHEN $22::boolean THEN reminder.id < $23::bigint
+        WHEN $24::boolean THEN (reminder.at, reminder.id) > ($25::timestamp, $23::bigint)
+        WHEN $26::boolean THEN reminder.at < $25::timestamp
+            OR (reminder.at = $25::timestamp AND reminder.id > $23::bigint)
+        ELSE reminder.id > $23::bigint
+    END)
 GROUP BY reminder.id
 ORDER BY 
-    CASE WHEN $7::boolean THEN reminder.id ELSE null END,
-    CASE WHEN $8::boolean THEN reminder.id ELSE null END DESC,
-    CASE WHEN $9::boolean THEN reminder.at ELSE null END,
-    CASE WHEN $10::boolean THEN reminder.at ELSE null END DESC,
+    CASE WHEN $27::boolean THEN reminder.id ELSE null END,
+    CASE WHEN $22::boolean THEN reminder.id ELSE null END DESC,
+    CASE WHEN $24::boolean THEN reminder.at ELSE null END,
+    CASE WHEN $26::boolean THEN reminder.at ELSE null END DESC,
     id ASC
-LIMIT CASE WHEN $12::boolean THEN null ELSE $13::integer END
-OFFSET $11::integer
+LIMIT CASE WHEN $29::boolean THEN null ELSE $30::integer END
+OFFSET $28::integer
 `
 
 type ReadRemindersParams struct {
-	AnyUserID     bool
-	UserIDEquals  int64
-	AnySentAt     bool
-	SentAfter     time.Time
-	AnyStatus     bool
-	StatusIn      []string
-	OrderByIDAsc  bool
-	OrderByIDDesc bool
-	OrderByAtAsc  bool
-	OrderByAtDesc bool
-	Offset        int32
-	AllRows       bool
-	Limit         int32
+	AnyUserID        bool
+	UserIDEquals     int64
+	AnySentAt        bool
+	SentAfter        time.Time
+	AnyStatus        bool
+	StatusIn         []string
+	AnyBodySearch    bool
+	BodySearch       string
+	AnyAtAfter       bool
+	AtAfter          time.Time
+	AnyAtBefore      bool
+	AtBefore         time.Time
+	AnyEvery         bool
+	IsPeriodic       bool
+	AnyChannelID     bool
+	ChannelIDEquals  int64
+	AnyCreatedAfter  bool
+	CreatedAfter     time.Time
+	AnyCreatedBefore bool
+	CreatedBefore    time.Time
+	AnyCursor        bool
+	OrderByIDDesc    bool
+	CursorID         int64
+	OrderByAtAsc     bool
+	CursorAt         time.Time
+	OrderByAtDesc    bool
+	OrderByIDAsc     bool
+	Offset           int32
+	AllRows          bool
+	Limit            int32
 }
 
 type ReadRemindersRow struct {
@@ -220,10 +293,27 @@ func (q *Queries) ReadReminders(ctx context.Context, arg ReadRemindersParams) ([
 		arg.SentAfter,
 		arg.AnyStatus,
 		arg.StatusIn,
-		arg.OrderByIDAsc,
+		arg.AnyBodySearch,
+		arg.BodySearch,
+		arg.AnyAtAfter,
+		arg.AtAfter,
+		arg.AnyAtBefore,
+		arg.AtBefore,
+		arg.AnyEvery,
+		arg.IsPeriodic,
+		arg.AnyChannelID,
+		arg.ChannelIDEquals,
+		arg.AnyCreatedAfter,
+		arg.CreatedAfter,
+		arg.AnyCreatedBefore,
+		arg.CreatedBefore,
+		arg.AnyCursor,
 		arg.OrderByIDDesc,
+		arg.CursorID,
 		arg.OrderByAtAsc,
+		arg.CursorAt,
 		arg.OrderByAtDesc,
+		arg.OrderByIDAsc,
 		arg.Offset,
 		arg.AllRows,
 		arg.Limit,
@@ -258,17 +348,72 @@ func (q *Queries) ReadReminders(ctx context.Context, arg ReadRemindersParams) ([
 	return items, nil
 }
 
+const readRemindersForScheduling = `-- name: ReadRemindersForScheduling :many
+SELECT id, user_id, created_at, at, body, status, every, scheduled_at, sent_at, canceled_at FROM reminder
+WHERE 
+    at < $1
+    AND status = 'created'
+    AND (at, id) > ($2::timestamp, $3::bigint)
+ORDER BY at, id
+LIMIT $4::integer
+FOR UPDATE SKIP LOCKED
+`
+
+type ReadRemindersForSchedulingParams struct {
+	AtBefore time.Time
+	AfterAt  time.Time
+	AfterID  int64
+	Limit    int32
+}
+
+// The status is a literal to match the predicate of the partial reminder_scheduling_idx.
+func (q *Queries) ReadRemindersForScheduling(ctx context.Context, arg ReadRemindersForSchedulingParams) ([]Reminder, error) {
+	rows, err := q.db.Query(ctx, readRemindersForScheduling,
+		arg.AtBefore,
+		arg.AfterAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reminder
+	for rows.Next() {
+		var i Reminder
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.At,
+			&i.Body,
+			&i.Status,
+			&i.Every,
+			&i.ScheduledAt,
+			&i.SentAt,
+			&i.CanceledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const scheduleReminders = `-- name: ScheduleReminders :many
 UPDATE reminder
 SET status = $1, scheduled_at = $2::timestamp
-WHERE at < $3 AND status = ANY($4::text[])
+WHERE id = ANY($3::bigint[]) AND status = ANY($4::text[])
 RETURNING id, user_id, created_at, at, body, status, every, scheduled_at, sent_at, canceled_at
 `
 
 type ScheduleRemindersParams struct {
 	Status                string
 	ScheduledAt           time.Time
-	AtBefore              time.Time
+	Ids                   []int64
 	StatusesForScheduling []string
 }
 
@@ -276,7 +421,7 @@ func (q *Queries) ScheduleReminders(ctx context.Context, arg ScheduleRemindersPa
 	rows, err := q.db.Query(ctx, scheduleReminders,
 		arg.Status,
 		arg.ScheduledAt,
-		arg.AtBefore,
+		arg.Ids,
 		arg.StatusesForScheduling,
 	)
 	if err != nil {
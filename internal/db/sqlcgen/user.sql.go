@@ -13,9 +13,10 @@ import (
 
 const activateUser = `-- name: ActivateUser :one
 UPDATE "user" 
-SET activated_at = $1::timestamp, activation_token = null
+SET activated_at = $1::timestamp, activation_token = null, activation_token_expires_at = null
 WHERE activation_token = $2::text
-RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token
+    AND (activation_token_expires_at IS NULL OR activation_token_expires_at > $1::timestamp)
+RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at
 `
 
 type ActivateUserParams struct {
@@ -35,6 +36,53 @@ func (q *Queries) ActivateUser(ctx context.Context, arg ActivateUserParams) (Use
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
+	)
+	return i, err
+}
+
+const claimUser = `-- name: ClaimUser :one
+UPDATE "user"
+SET
+    email = $1::text,
+    password_hash = $2::text,
+    activation_token = $3::text,
+    activation_token_expires_at = $4::timestamp
+WHERE
+    id = $5::bigint
+    AND identity IS NOT NULL
+    AND (email IS NULL OR activation_token IS NOT NULL)
+    AND NOT EXISTS (SELECT 1 FROM external_identity WHERE external_identity.user_id = "user".id)
+RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at
+`
+
+type ClaimUserParams struct {
+	Email                    string
+	PasswordHash             string
+	ActivationToken          string
+	ActivationTokenExpiresAt time.Time
+	ID                       int64
+}
+
+func (q *Queries) ClaimUser(ctx context.Context, arg ClaimUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, claimUser,
+		arg.Email,
+		arg.PasswordHash,
+		arg.ActivationToken,
+		arg.ActivationTokenExpiresAt,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Identity,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Timezone,
+		&i.ActivatedAt,
+		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
@@ -46,19 +94,25 @@ INSERT INTO limits (
     telegram_channel_count, 
     active_reminder_count, 
     monthly_sent_reminder_count,
-    reminder_every_per_day_count
+    reminder_every_per_day_count,
+    plan,
+    sent_reminder_quota_window,
+    paused_reminders_count_as_active
 ) 
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count, plan, sent_reminder_quota_window, paused_reminders_count_as_active
 `
 
 type CreateLimitsParams struct {
-	UserID                   int64
-	EmailChannelCount        sql.NullInt32
-	TelegramChannelCount     sql.NullInt32
-	ActiveReminderCount      sql.NullInt32
-	MonthlySentReminderCount sql.NullInt32
-	ReminderEveryPerDayCount sql.NullFloat64
+	UserID                       int64
+	EmailChannelCount            sql.NullInt32
+	TelegramChannelCount         sql.NullInt32
+	ActiveReminderCount          sql.NullInt32
+	MonthlySentReminderCount     sql.NullInt32
+	ReminderEveryPerDayCount     sql.NullFloat64
+	Plan                         sql.NullString
+	SentReminderQuotaWindow      string
+	PausedRemindersCountAsActive bool
 }
 
 func (q *Queries) CreateLimits(ctx context.Context, arg CreateLimitsParams) (Limit, error) {
@@ -69,6 +123,9 @@ func (q *Queries) CreateLimits(ctx context.Context, arg CreateLimitsParams) (Lim
 		arg.ActiveReminderCount,
 		arg.MonthlySentReminderCount,
 		arg.ReminderEveryPerDayCount,
+		arg.Plan,
+		arg.SentReminderQuotaWindow,
+		arg.PausedRemindersCountAsActive,
 	)
 	var i Limit
 	err := row.Scan(
@@ -79,48 +136,65 @@ func (q *Queries) CreateLimits(ctx context.Context, arg CreateLimitsParams) (Lim
 		&i.ActiveReminderCount,
 		&i.MonthlySentReminderCount,
 		&i.ReminderEveryPerDayCount,
+		&i.Plan,
+		&i.SentReminderQuotaWindow,
+		&i.PausedRemindersCountAsActive,
 	)
 	return i, err
 }
 
 const createSession = `-- name: CreateSession :one
-INSERT INTO session (token, user_id, created_at)
-VALUES ($1, $2, $3)
-RETURNING id, token, user_id, created_at
+INSERT INTO session (token, user_id, created_at, last_seen_at, user_agent, ip)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, token, user_id, created_at, last_seen_at, user_agent, ip
 `
 
 type CreateSessionParams struct {
-	Token     string
-	UserID    int64
-	CreatedAt time.Time
+	Token      string
+	UserID     int64
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	Ip         string
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
-	row := q.db.QueryRow(ctx, createSession, arg.Token, arg.UserID, arg.CreatedAt)
+	row := q.db.QueryRow(ctx, createSession,
+		arg.Token,
+		arg.UserID,
+		arg.CreatedAt,
+		arg.LastSeenAt,
+		arg.UserAgent,
+		arg.Ip,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.Token,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.UserAgent,
+		&i.Ip,
 	)
 	return i, err
 }
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO "user" (email, identity, password_hash, created_at, timezone, activated_at, activation_token) 
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token
+INSERT INTO "user" (email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at
 `
 
 type CreateUserParams struct {
-	Email           sql.NullString
-	Identity        sql.NullString
-	PasswordHash    sql.NullString
-	CreatedAt       time.Time
-	Timezone        string
-	ActivatedAt     sql.NullTime
-	ActivationToken sql.NullString
+	Email                    sql.NullString
+	Identity                 sql.NullString
+	PasswordHash             sql.NullString
+	CreatedAt                time.Time
+	Timezone                 string
+	ActivatedAt              sql.NullTime
+	ActivationToken          sql.NullString
+	ActivationTokenExpiresAt sql.NullTime
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -132,6 +206,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Timezone,
 		arg.ActivatedAt,
 		arg.ActivationToken,
+		arg.ActivationTokenExpiresAt,
 	)
 	var i User
 	err := row.Scan(
@@ -143,10 +218,58 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
 
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM session
+WHERE id IN (
+    SELECT id FROM session
+    WHERE last_seen_at <= $1::timestamp OR created_at <= $2::timestamp
+    ORDER BY id
+    LIMIT $3::integer
+)
+`
+
+type DeleteExpiredSessionsParams struct {
+	IdleSince    time.Time
+	CreatedSince time.Time
+	BatchSize    int32
+}
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, arg DeleteExpiredSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredSessions, arg.IdleSince, arg.CreatedSince, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteInactiveUsers = `-- name: DeleteInactiveUsers :execrows
+DELETE FROM "user"
+WHERE id IN (
+    SELECT id FROM "user"
+    WHERE activated_at IS NULL AND created_at < $1::timestamp
+    ORDER BY created_at
+    LIMIT $2::integer
+)
+`
+
+type DeleteInactiveUsersParams struct {
+	CreatedBefore time.Time
+	BatchSize     int32
+}
+
+func (q *Queries) DeleteInactiveUsers(ctx context.Context, arg DeleteInactiveUsersParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteInactiveUsers, arg.CreatedBefore, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteSessionByToken = `-- name: DeleteSessionByToken :one
 DELETE FROM session WHERE token = $1 RETURNING user_id
 `
@@ -158,8 +281,50 @@ func (q *Queries) DeleteSessionByToken(ctx context.Context, token string) (int64
 	return user_id, err
 }
 
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE FROM "user" WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserSession = `-- name: DeleteUserSession :one
+DELETE FROM session WHERE id = $1::bigint AND user_id = $2::bigint RETURNING id
+`
+
+type DeleteUserSessionParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, deleteUserSession, arg.ID, arg.UserID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE FROM session WHERE user_id = $1::bigint AND token <> $2::text
+`
+
+type DeleteUserSessionsParams struct {
+	UserID      int64
+	ExceptToken string
+}
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, arg DeleteUserSessionsParams) error {
+	_, err := q.db.Exec(ctx, deleteUserSessions, arg.UserID, arg.ExceptToken)
+	return err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, identity, password_hash, created_at, timezone, activated_at, activation_token FROM "user" WHERE email = $1
+SELECT id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at FROM "user" WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email sql.NullString) (User, error) {
@@ -174,12 +339,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email sql.NullString) (Use
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, identity, password_hash, created_at, timezone, activated_at, activation_token FROM "user" WHERE id = $1
+SELECT id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at FROM "user" WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
@@ -194,18 +360,43 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
 
 const getUserBySessionToken = `-- name: GetUserBySessionToken :one
-SELECT "user".id, "user".email, "user".identity, "user".password_hash, "user".created_at, "user".timezone, "user".activated_at, "user".activation_token FROM "user" 
-JOIN session ON "user".id = session.user_id
-WHERE session.token = $1
+WITH active_session AS (
+    SELECT id, user_id, last_seen_at FROM session
+    WHERE token = $1::text
+        AND last_seen_at > $2::timestamp
+        AND created_at > $3::timestamp
+), seen_session AS (
+    UPDATE session
+    SET last_seen_at = $4::timestamp
+    FROM active_session
+    WHERE session.id = active_session.id AND active_session.last_seen_at <= $5::timestamp
+)
+SELECT "user".id, "user".email, "user".identity, "user".password_hash, "user".created_at, "user".timezone, "user".activated_at, "user".activation_token, "user".activation_token_expires_at FROM "user"
+JOIN active_session ON "user".id = active_session.user_id
 `
 
-func (q *Queries) GetUserBySessionToken(ctx context.Context, token string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserBySessionToken, token)
+type GetUserBySessionTokenParams struct {
+	Token        string
+	IdleSince    time.Time
+	CreatedSince time.Time
+	LastSeenAt   time.Time
+	SeenBefore   time.Time
+}
+
+func (q *Queries) GetUserBySessionToken(ctx context.Context, arg GetUserBySessionTokenParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserBySessionToken,
+		arg.Token,
+		arg.IdleSince,
+		arg.CreatedSince,
+		arg.LastSeenAt,
+		arg.SeenBefore,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -216,12 +407,13 @@ func (q *Queries) GetUserBySessionToken(ctx context.Context, token string) (User
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
 
 const getUserLimits = `-- name: GetUserLimits :one
-SELECT id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count FROM limits WHERE user_id = $1
+SELECT id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count, plan, sent_reminder_quota_window, paused_reminders_count_as_active FROM limits WHERE user_id = $1
 `
 
 func (q *Queries) GetUserLimits(ctx context.Context, userID int64) (Limit, error) {
@@ -235,12 +427,15 @@ func (q *Queries) GetUserLimits(ctx context.Context, userID int64) (Limit, error
 		&i.ActiveReminderCount,
 		&i.MonthlySentReminderCount,
 		&i.ReminderEveryPerDayCount,
+		&i.Plan,
+		&i.SentReminderQuotaWindow,
+		&i.PausedRemindersCountAsActive,
 	)
 	return i, err
 }
 
 const getUserLimitsWithLock = `-- name: GetUserLimitsWithLock :one
-SELECT id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count FROM limits WHERE user_id = $1 FOR UPDATE
+SELECT id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count, plan, sent_reminder_quota_window, paused_reminders_count_as_active FROM limits WHERE user_id = $1 FOR UPDATE
 `
 
 func (q *Queries) GetUserLimitsWithLock(ctx context.Context, userID int64) (Limit, error) {
@@ -254,10 +449,72 @@ func (q *Queries) GetUserLimitsWithLock(ctx context.Context, userID int64) (Limi
 		&i.ActiveReminderCount,
 		&i.MonthlySentReminderCount,
 		&i.ReminderEveryPerDayCount,
+		&i.Plan,
+		&i.SentReminderQuotaWindow,
+		&i.PausedRemindersCountAsActive,
 	)
 	return i, err
 }
 
+const listUserSessions = `-- name: ListUserSessions :many
+SELECT id, user_id, created_at, last_seen_at, user_agent, ip, (token = $1::text)::boolean AS is_current
+FROM session
+WHERE user_id = $2::bigint
+    AND last_seen_at > $3::timestamp
+    AND created_at > $4::timestamp
+ORDER BY last_seen_at DESC, id DESC
+`
+
+type ListUserSessionsParams struct {
+	CurrentToken string
+	UserID       int64
+	IdleSince    time.Time
+	CreatedSince time.Time
+}
+
+type ListUserSessionsRow struct {
+	ID         int64
+	UserID     int64
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	Ip         string
+	IsCurrent  bool
+}
+
+func (q *Queries) ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]ListUserSessionsRow, error) {
+	rows, err := q.db.Query(ctx, listUserSessions,
+		arg.CurrentToken,
+		arg.UserID,
+		arg.IdleSince,
+		arg.CreatedSince,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserSessionsRow
+	for rows.Next() {
+		var i ListUserSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.UserAgent,
+			&i.Ip,
+			&i.IsCurrent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setPassword = `-- name: SetPassword :one
 UPDATE "user"
 SET password_hash = $1::text
@@ -277,23 +534,120 @@ func (q *Queries) SetPassword(ctx context.Context, arg SetPasswordParams) (int64
 	return id, err
 }
 
+const setUserActivationToken = `-- name: SetUserActivationToken :one
+UPDATE "user"
+SET
+    activation_token = $1::text,
+    activation_token_expires_at = $2::timestamp
+WHERE id = $3::bigint AND activation_token IS NOT NULL
+RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at
+`
+
+type SetUserActivationTokenParams struct {
+	ActivationToken          string
+	ActivationTokenExpiresAt time.Time
+	ID                       int64
+}
+
+func (q *Queries) SetUserActivationToken(ctx context.Context, arg SetUserActivationTokenParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserActivationToken, arg.ActivationToken, arg.ActivationTokenExpiresAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Identity,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Timezone,
+		&i.ActivatedAt,
+		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
+	)
+	return i, err
+}
+
+const updateLimits = `-- name: UpdateLimits :one
+UPDATE limits
+SET
+    email_channel_count = $2,
+    telegram_channel_count = $3,
+    active_reminder_count = $4,
+    monthly_sent_reminder_count = $5,
+    reminder_every_per_day_count = $6,
+    plan = $7,
+    sent_reminder_quota_window = $8,
+    paused_reminders_count_as_active = $9
+WHERE user_id = $1
+RETURNING id, user_id, email_channel_count, telegram_channel_count, active_reminder_count, monthly_sent_reminder_count, reminder_every_per_day_count, plan, sent_reminder_quota_window, paused_reminders_count_as_active
+`
+
+type UpdateLimitsParams struct {
+	UserID                       int64
+	EmailChannelCount            sql.NullInt32
+	TelegramChannelCount         sql.NullInt32
+	ActiveReminderCount          sql.NullInt32
+	MonthlySentReminderCount     sql.NullInt32
+	ReminderEveryPerDayCount     sql.NullFloat64
+	Plan                         sql.NullString
+	SentReminderQuotaWindow      string
+	PausedRemindersCountAsActive bool
+}
+
+func (q *Queries) UpdateLimits(ctx context.Context, arg UpdateLimitsParams) (Limit, error) {
+	row := q.db.QueryRow(ctx, updateLimits,
+		arg.UserID,
+		arg.EmailChannelCount,
+		arg.TelegramChannelCount,
+		arg.ActiveReminderCount,
+		arg.MonthlySentReminderCount,
+		arg.ReminderEveryPerDayCount,
+		arg.Plan,
+		arg.SentReminderQuotaWindow,
+		arg.PausedRemindersCountAsActive,
+	)
+	var i Limit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EmailChannelCount,
+		&i.TelegramChannelCount,
+		&i.ActiveReminderCount,
+		&i.MonthlySentReminderCount,
+		&i.ReminderEveryPerDayCount,
+		&i.Plan,
+		&i.SentReminderQuotaWindow,
+		&i.PausedRemindersCountAsActive,
+	)
+	return i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE "user" 
 SET 
     timezone = CASE WHEN $2::boolean THEN $3
-        ELSE timezone END
+        ELSE timezone END,
+    email = CASE WHEN $4::boolean THEN $5
+        ELSE email END
 WHERE id = $1
-RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token
+RETURNING id, email, identity, password_hash, created_at, timezone, activated_at, activation_token, activation_token_expires_at
 `
 
 type UpdateUserParams struct {
 	ID               int64
 	DoTimezoneUpdate bool
 	Timezone         string
+	DoEmailUpdate    bool
+	Email            sql.NullString
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUser, arg.ID, arg.DoTimezoneUpdate, arg.Timezone)
+	row := q.db.QueryRow(ctx, updateUser,
+		arg.ID,
+		arg.DoTimezoneUpdate,
+		arg.Timezone,
+		arg.DoEmailUpdate,
+		arg.Email,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -304,6 +658,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Timezone,
 		&i.ActivatedAt,
 		&i.ActivationToken,
+		&i.ActivationTokenExpiresAt,
 	)
 	return i, err
 }
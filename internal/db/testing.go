@@ -45,7 +45,7 @@ func CreateTestPool() *pgxpool.Pool {
 }
 
 func TruncateTables(pool *pgxpool.Pool) {
-	_, err := pool.Exec(context.Background(), "DELETE FROM \"user\";")
+	_, err := pool.Exec(context.Background(), "DELETE FROM \"user\"; DELETE FROM oidc_authorization;")
 	if err != nil {
 		panic(fmt.Errorf("could not truncate DB tables %w", err))
 	}
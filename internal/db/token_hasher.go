@@ -0,0 +1,27 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// TokenHasher computes keyed hashes of secret tokens. Only hashes of session, activation
+// and channel verification tokens are stored, so a DB dump can not be used to authenticate.
+// Hashes must match the ones computed by the 000004 migration: hex encoded HMAC-SHA256.
+type TokenHasher struct {
+	key []byte
+}
+
+func NewTokenHasher(secret string) *TokenHasher {
+	if secret == "" {
+		panic("secret must not be empty")
+	}
+	return &TokenHasher{key: []byte(secret)}
+}
+
+func (h *TokenHasher) Hash(token string) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,18 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenHasher(t *testing.T) {
+	hasher := NewTokenHasher("key")
+	assert.Equal(
+		t,
+		"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		hasher.Hash("The quick brown fox jumps over the lazy dog"),
+	)
+	assert.NotEqual(t, hasher.Hash("token"), NewTokenHasher("other key").Hash("token"))
+	assert.Panics(t, func() { NewTokenHasher("") })
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	dt "remindme/internal/core/domain/tracing"
+	"remindme/internal/db/sqlcgen"
+	"remindme/internal/tracing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var queryNameRegexp = regexp.MustCompile(`^-- name: (\w+)`)
+
+// Pool is the part of a connection pool used by repositories and units of work.
+type Pool interface {
+	sqlcgen.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// TracingPool traces queries in client spans. pgx v4 has no tracing hooks, so the pool
+// and the transactions begun from it are wrapped. Only queries run within a traced operation
+// are traced and query arguments are never recorded.
+type TracingPool struct {
+	pool   Pool
+	tracer queryTracer
+}
+
+func NewTracingPool(pool Pool, tracer *tracing.Tracer) *TracingPool {
+	if pool == nil {
+		panic("pool must not be nil")
+	}
+	if tracer == nil {
+		panic("tracer must not be nil")
+	}
+	return &TracingPool{pool: pool, tracer: queryTracer{tracer: tracer}}
+}
+
+func (p *TracingPool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return p.tracer.exec(ctx, p.pool, sql, args...)
+}
+
+func (p *TracingPool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return p.tracer.query(ctx, p.pool, sql, args...)
+}
+
+func (p *TracingPool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return p.tracer.queryRow(ctx, p.pool, sql, args...)
+}
+
+func (p *TracingPool) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return p.tracer.copyFrom(ctx, p.pool, tableName, columnNames, rowSrc)
+}
+
+func (p *TracingPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingTx{Tx: tx, tracer: p.tracer}, nil
+}
+
+type tracingTx struct {
+	pgx.Tx
+	tracer queryTracer
+}
+
+func (tx *tracingTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	nested, err := tx.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingTx{Tx: nested, tracer: tx.tracer}, nil
+}
+
+func (tx *tracingTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.tracer.exec(ctx, tx.Tx, sql, args...)
+}
+
+func (tx *tracingTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return tx.tracer.query(ctx, tx.Tx, sql, args...)
+}
+
+func (tx *tracingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.tracer.queryRow(ctx, tx.Tx, sql, args...)
+}
+
+func (tx *tracingTx) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return tx.tracer.copyFrom(ctx, tx.Tx, tableName, columnNames, rowSrc)
+}
+
+type queryTracer struct {
+	tracer *tracing.Tracer
+}
+
+func (t queryTracer) exec(
+	ctx context.Context,
+	db sqlcgen.DBTX,
+	sql string,
+	args ...interface{},
+) (pgconn.CommandTag, error) {
+	span := t.start(ctx, sql)
+	tag, err := db.Exec(ctx, sql, args...)
+	endQuerySpan(span, err)
+	return tag, err
+}
+
+func (t queryTracer) query(ctx context.Context, db sqlcgen.DBTX, sql string, args ...interface{}) (pgx.Rows, error) {
+	span := t.start(ctx, sql)
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil || span == nil {
+		endQuerySpan(span, err)
+		return rows, err
+	}
+	return &tracingRows{Rows: rows, span: span}, nil
+}
+
+func (t queryTracer) queryRow(ctx context.Context, db sqlcgen.DBTX, sql string, args ...interface{}) pgx.Row {
+	span := t.start(ctx, sql)
+	row := db.QueryRow(ctx, sql, args...)
+	if span == nil {
+		return row
+	}
+	return &tracingRow{row: row, span: span}
+}
+
+func (t queryTracer) copyFrom(
+	ctx context.Context,
+	db sqlcgen.DBTX,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	var span *tracing.Span
+	if tracing.HasSpan(ctx) {
+		_, span = t.tracer.StartSpan(
+			ctx,
+			"db.CopyFrom",
+			trace.SpanKindClient,
+			dt.Attribute("db.system", "postgresql"),
+			dt.Attribute("db.sql.table", tableName.Sanitize()),
+		)
+	}
+	count, err := db.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	endQuerySpan(span, err)
+	return count, err
+}
+
+// start returns nil if ctx has no span, the span is named by the sqlc query name.
+func (t queryTracer) start(ctx context.Context, sql string) *tracing.Span {
+	if !tracing.HasSpan(ctx) {
+		return nil
+	}
+	name := "db.Query"
+	if match := queryNameRegexp.FindStringSubmatch(sql); match != nil {
+		name = "db." + match[1]
+	}
+	_, span := t.tracer.StartSpan(
+		ctx,
+		name,
+		trace.SpanKindClient,
+		dt.Attribute("db.system", "postgresql"),
+		dt.Attribute("db.statement", sql),
+	)
+	return span
+}
+
+// endQuerySpan ends span if it is not nil, pgx.ErrNoRows is an expected result and is not recorded.
+func endQuerySpan(span *tracing.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// tracingRows ends the span of the query when rows are closed.
+type tracingRows struct {
+	pgx.Rows
+	span     *tracing.Span
+	isClosed bool
+}
+
+func (r *tracingRows) Close() {
+	r.Rows.Close()
+	if !r.isClosed {
+		r.isClosed = true
+		endQuerySpan(r.span, r.Rows.Err())
+	}
+}
+
+// tracingRow ends the span of the query when the row is scanned, pgx runs the query on Scan.
+type tracingRow struct {
+	row  pgx.Row
+	span *tracing.Span
+}
+
+func (r *tracingRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	endQuerySpan(r.span, err)
+	return err
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/tracing"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const QUERY = "-- name: GetUser :one\nSELECT 1"
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	return r.err
+}
+
+type fakePool struct {
+	err error
+}
+
+func (p *fakePool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return nil, p.err
+}
+
+func (p *fakePool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, p.err
+}
+
+func (p *fakePool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return fakeRow{err: p.err}
+}
+
+func (p *fakePool) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return 0, p.err
+}
+
+func (p *fakePool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{pool: p}, nil
+}
+
+type fakeTx struct {
+	pgx.Tx
+	pool *fakePool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.pool.Exec(ctx, sql, args...)
+}
+
+func newTracingPool(pool Pool) (*TracingPool, *tracing.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := tracing.NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return NewTracingPool(pool, tracer), tracer, recorder
+}
+
+func TestTracingPoolNamesSpanByQuery(t *testing.T) {
+	pool, tracer, recorder := newTracingPool(&fakePool{})
+	ctx, parent := tracer.StartSpan(context.Background(), "parent", trace.SpanKindInternal)
+
+	_, err := pool.Exec(ctx, QUERY)
+	parent.End()
+
+	require.Nil(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db.GetUser", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestTracingPoolIgnoresUntracedQueries(t *testing.T) {
+	pool, _, recorder := newTracingPool(&fakePool{})
+
+	_, err := pool.Exec(context.Background(), QUERY)
+
+	assert.Nil(t, err)
+	assert.Empty(t, recorder.Ended())
+}
+
+func TestTracingPoolRecordsErrors(t *testing.T) {
+	for _, testcase := range []struct {
+		err    error
+		status codes.Code
+	}{
+		{err: errors.New("test error"), status: codes.Error},
+		{err: pgx.ErrNoRows, status: codes.Unset},
+	} {
+		pool, tracer, recorder := newTracingPool(&fakePool{err: testcase.err})
+		ctx, parent := tracer.StartSpan(context.Background(), "parent", trace.SpanKindInternal)
+
+		err := pool.QueryRow(ctx, QUERY).Scan()
+		parent.End()
+
+		assert.ErrorIs(t, err, testcase.err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, testcase.status, spans[0].Status().Code, testcase.err)
+	}
+}
+
+func TestTracingPoolTracesTransactions(t *testing.T) {
+	pool, tracer, recorder := newTracingPool(&fakePool{})
+	ctx, parent := tracer.StartSpan(context.Background(), "parent", trace.SpanKindInternal)
+
+	tx, err := pool.Begin(ctx)
+	require.Nil(t, err)
+	_, err = tx.Exec(ctx, QUERY)
+	parent.End()
+
+	require.Nil(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db.GetUser", spans[0].Name())
+}
@@ -6,21 +6,23 @@ import (
 	"remindme/internal/core/domain/reminder"
 	uow "remindme/internal/core/domain/unit_of_work"
 	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
 	dbchannel "remindme/internal/db/channel"
 	dbreminder "remindme/internal/db/reminder"
 	dbuser "remindme/internal/db/user"
 
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 type pgxUnitOfWorkContext struct {
-	tx pgx.Tx
+	tx          pgx.Tx
+	tokenHasher *db.TokenHasher
 }
 
-func newPgxUnitOfWorkContext(tx pgx.Tx) *pgxUnitOfWorkContext {
+func newPgxUnitOfWorkContext(tx pgx.Tx, tokenHasher *db.TokenHasher) *pgxUnitOfWorkContext {
 	return &pgxUnitOfWorkContext{
-		tx: tx,
+		tx:          tx,
+		tokenHasher: tokenHasher,
 	}
 }
 
@@ -33,19 +35,39 @@ func (c *pgxUnitOfWorkContext) Rollback(ctx context.Context) error {
 }
 
 func (c *pgxUnitOfWorkContext) Users() user.UserRepository {
-	return dbuser.NewPgxRepository(c.tx)
+	return dbuser.NewPgxRepository(c.tx, c.tokenHasher)
 }
 
 func (c *pgxUnitOfWorkContext) Sessions() user.SessionRepository {
-	return dbuser.NewPgxSessionRepository(c.tx)
+	return dbuser.NewPgxSessionRepository(c.tx, c.tokenHasher)
+}
+
+func (c *pgxUnitOfWorkContext) APITokens() user.APITokenRepository {
+	return dbuser.NewPgxAPITokenRepository(c.tx, c.tokenHasher)
 }
 
 func (c *pgxUnitOfWorkContext) Limits() user.LimitsRepository {
 	return dbuser.NewPgxLimitsRepository(c.tx)
 }
 
+func (c *pgxUnitOfWorkContext) LimitsAudit() user.LimitsAuditRepository {
+	return dbuser.NewPgxLimitsAuditRepository(c.tx)
+}
+
+func (c *pgxUnitOfWorkContext) ExternalIdentities() user.ExternalIdentityRepository {
+	return dbuser.NewPgxExternalIdentityRepository(c.tx)
+}
+
+func (c *pgxUnitOfWorkContext) AccountDeletions() user.AccountDeletionRepository {
+	return dbuser.NewPgxAccountDeletionRepository(c.tx)
+}
+
+func (c *pgxUnitOfWorkContext) EmailChanges() user.EmailChangeRepository {
+	return dbuser.NewPgxEmailChangeRepository(c.tx, c.tokenHasher)
+}
+
 func (c *pgxUnitOfWorkContext) Channels() channel.Repository {
-	return dbchannel.NewPgxChannelRepository(c.tx)
+	return dbchannel.NewPgxChannelRepository(c.tx, c.tokenHasher)
 }
 
 func (c *pgxUnitOfWorkContext) Reminders() reminder.ReminderRepository {
@@ -56,15 +78,23 @@ func (c *pgxUnitOfWorkContext) ReminderChannels() reminder.ReminderChannelReposi
 	return dbreminder.NewPgxReminderChannelRepository(c.tx)
 }
 
+func (c *pgxUnitOfWorkContext) Usage() reminder.UsageRepository {
+	return dbreminder.NewPgxUsageRepository(c.tx)
+}
+
 type PgxUnitOfWork struct {
-	db *pgxpool.Pool
+	db          db.Pool
+	tokenHasher *db.TokenHasher
 }
 
-func NewPgxUnitOfWork(db *pgxpool.Pool) *PgxUnitOfWork {
+func NewPgxUnitOfWork(db db.Pool, tokenHasher *db.TokenHasher) *PgxUnitOfWork {
 	if db == nil {
 		panic("Argument db must not be nil.")
 	}
-	return &PgxUnitOfWork{db: db}
+	if tokenHasher == nil {
+		panic("Argument tokenHasher must not be nil.")
+	}
+	return &PgxUnitOfWork{db: db, tokenHasher: tokenHasher}
 }
 
 func (u *PgxUnitOfWork) Begin(ctx context.Context) (uow.Context, error) {
@@ -72,5 +102,5 @@ func (u *PgxUnitOfWork) Begin(ctx context.Context) (uow.Context, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newPgxUnitOfWorkContext(tx), nil
+	return newPgxUnitOfWorkContext(tx, u.tokenHasher), nil
 }
@@ -27,7 +27,7 @@ type testSuite struct {
 
 func (suite *testSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
-	suite.uow = NewPgxUnitOfWork(suite.pool)
+	suite.uow = NewPgxUnitOfWork(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testSuite) TearDownSuite() {
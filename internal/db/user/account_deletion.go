@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type PgxAccountDeletionRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPgxAccountDeletionRepository(db sqlcgen.DBTX) *PgxAccountDeletionRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	return &PgxAccountDeletionRepository{queries: sqlcgen.New(db)}
+}
+
+func (r *PgxAccountDeletionRepository) Create(ctx context.Context, deletion user.AccountDeletion) error {
+	rows, err := r.queries.CreateAccountDeletion(ctx, sqlcgen.CreateAccountDeletionParams{
+		UserID:      int64(deletion.UserID),
+		RequestedAt: deletion.RequestedAt,
+		PurgeAt:     deletion.PurgeAt,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrAccountDeletionAlreadyRequested
+	}
+	return nil
+}
+
+func (r *PgxAccountDeletionRepository) Get(ctx context.Context, userID user.ID) (user.AccountDeletion, error) {
+	dbDeletion, err := r.queries.GetAccountDeletion(ctx, int64(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.AccountDeletion{}, user.ErrAccountDeletionNotRequested
+	}
+	if err != nil {
+		return user.AccountDeletion{}, err
+	}
+	return decodeAccountDeletion(dbDeletion), nil
+}
+
+func (r *PgxAccountDeletionRepository) GetWithLock(
+	ctx context.Context,
+	userID user.ID,
+) (user.AccountDeletion, error) {
+	dbDeletion, err := r.queries.GetAccountDeletionWithLock(ctx, int64(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.AccountDeletion{}, user.ErrAccountDeletionNotRequested
+	}
+	if err != nil {
+		return user.AccountDeletion{}, err
+	}
+	return decodeAccountDeletion(dbDeletion), nil
+}
+
+func (r *PgxAccountDeletionRepository) ReadDue(
+	ctx context.Context,
+	at time.Time,
+	limit uint,
+) ([]user.AccountDeletion, error) {
+	dbDeletions, err := r.queries.ReadDueAccountDeletions(ctx, sqlcgen.ReadDueAccountDeletionsParams{
+		PurgeAt:   at,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	deletions := make([]user.AccountDeletion, 0, len(dbDeletions))
+	for _, dbDeletion := range dbDeletions {
+		deletions = append(deletions, decodeAccountDeletion(dbDeletion))
+	}
+	return deletions, nil
+}
+
+func (r *PgxAccountDeletionRepository) Delete(ctx context.Context, userID user.ID) error {
+	rows, err := r.queries.DeleteAccountDeletion(ctx, int64(userID))
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrAccountDeletionNotRequested
+	}
+	return nil
+}
+
+func decodeAccountDeletion(d sqlcgen.AccountDeletion) user.AccountDeletion {
+	return user.AccountDeletion{
+		UserID:      user.ID(d.UserID),
+		RequestedAt: d.RequestedAt,
+		PurgeAt:     d.PurgeAt,
+	}
+}
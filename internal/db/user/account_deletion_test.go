@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type testAccountDeletionSuite struct {
+	suite.Suite
+	pool               *pgxpool.Pool
+	userRepository     *PgxUserRepository
+	deletionRepository *PgxAccountDeletionRepository
+}
+
+func (suite *testAccountDeletionSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.deletionRepository = NewPgxAccountDeletionRepository(suite.pool)
+}
+
+func (suite *testAccountDeletionSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testAccountDeletionSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxAccountDeletionRepository(t *testing.T) {
+	suite.Run(t, new(testAccountDeletionSuite))
+}
+
+func (s *testAccountDeletionSuite) TestCreateAndGet() {
+	u := s.createUser("test@test.test")
+	deletion := user.AccountDeletion{UserID: u.ID, RequestedAt: NOW, PurgeAt: NOW.Add(time.Hour)}
+
+	s.Require().Nil(s.deletionRepository.Create(context.Background(), deletion))
+	err := s.deletionRepository.Create(context.Background(), deletion)
+	s.ErrorIs(err, user.ErrAccountDeletionAlreadyRequested)
+
+	actual, err := s.deletionRepository.Get(context.Background(), u.ID)
+	s.Nil(err)
+	s.Equal(deletion, actual)
+}
+
+func (s *testAccountDeletionSuite) TestReadDue() {
+	first := s.createUser("first@test.test")
+	second := s.createUser("second@test.test")
+	notDue := s.createUser("not-due@test.test")
+	s.createDeletion(second.ID, NOW.Add(-time.Minute))
+	s.createDeletion(first.ID, NOW.Add(-time.Hour))
+	s.createDeletion(notDue.ID, NOW.Add(time.Minute))
+
+	deletions, err := s.deletionRepository.ReadDue(context.Background(), NOW, 10)
+
+	s.Nil(err)
+	s.Require().Len(deletions, 2)
+	s.Equal(first.ID, deletions[0].UserID)
+	s.Equal(second.ID, deletions[1].UserID)
+
+	deletions, err = s.deletionRepository.ReadDue(context.Background(), NOW, 1)
+	s.Nil(err)
+	s.Len(deletions, 1)
+}
+
+func (s *testAccountDeletionSuite) TestDelete() {
+	u := s.createUser("test@test.test")
+	s.createDeletion(u.ID, NOW)
+
+	s.Nil(s.deletionRepository.Delete(context.Background(), u.ID))
+
+	_, err := s.deletionRepository.Get(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrAccountDeletionNotRequested)
+	err = s.deletionRepository.Delete(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrAccountDeletionNotRequested)
+}
+
+func (s *testAccountDeletionSuite) TestUserDeletionCascades() {
+	u := s.createUser("test@test.test")
+	s.createDeletion(u.ID, NOW)
+
+	s.Nil(s.userRepository.Delete(context.Background(), u.ID))
+
+	_, err := s.userRepository.GetByID(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+	_, err = s.deletionRepository.Get(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrAccountDeletionNotRequested)
+	err = s.userRepository.Delete(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testAccountDeletionSuite) createDeletion(userID user.ID, purgeAt time.Time) {
+	s.T().Helper()
+	err := s.deletionRepository.Create(context.Background(), user.AccountDeletion{
+		UserID:      userID,
+		RequestedAt: NOW,
+		PurgeAt:     purgeAt,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *testAccountDeletionSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(email), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+		TimeZone:     time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
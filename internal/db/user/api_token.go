@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PgxAPITokenRepository stores hashes of API tokens, like session tokens.
+type PgxAPITokenRepository struct {
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxAPITokenRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxAPITokenRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxAPITokenRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
+}
+
+func (r *PgxAPITokenRepository) Create(
+	ctx context.Context,
+	input user.CreateAPITokenInput,
+) (token user.APIToken, err error) {
+	dbToken, err := r.queries.CreateAPIToken(ctx, sqlcgen.CreateAPITokenParams{
+		UserID:    int64(input.UserID),
+		Token:     r.tokenHasher.Hash(string(input.Secret)),
+		Name:      input.Name,
+		Scopes:    encodeScopes(input.Scopes),
+		CreatedAt: input.CreatedAt,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		return token, err
+	}
+	return decodeAPIToken(dbToken), nil
+}
+
+func (r *PgxAPITokenRepository) GetUserByToken(
+	ctx context.Context,
+	secret user.APITokenSecret,
+	at time.Time,
+) (u user.User, token user.APIToken, err error) {
+	row, err := r.queries.GetUserByAPIToken(ctx, sqlcgen.GetUserByAPITokenParams{
+		Token:      r.tokenHasher.Hash(string(secret)),
+		LastUsedAt: at,
+		UsedBefore: user.SeenBefore(at),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return u, token, user.ErrUserDoesNotExist
+	}
+	if err != nil {
+		return u, token, err
+	}
+	u, err = decodeUser(sqlcgen.User{
+		ID:                       row.ID,
+		Email:                    row.Email,
+		Identity:                 row.Identity,
+		PasswordHash:             row.PasswordHash,
+		CreatedAt:                row.CreatedAt,
+		Timezone:                 row.Timezone,
+		ActivatedAt:              row.ActivatedAt,
+		ActivationToken:          row.ActivationToken,
+		ActivationTokenExpiresAt: row.ActivationTokenExpiresAt,
+	})
+	if err != nil {
+		return u, token, err
+	}
+	err = u.Validate()
+	if err != nil {
+		return u, token, err
+	}
+	return u, user.APIToken{
+		ID:         user.APITokenID(row.TokenID),
+		UserID:     u.ID,
+		Name:       row.TokenName,
+		Scopes:     decodeScopes(row.TokenScopes),
+		CreatedAt:  row.TokenCreatedAt,
+		ExpiresAt:  row.TokenExpiresAt,
+		LastUsedAt: c.NewOptional(at, true),
+	}, nil
+}
+
+func (r *PgxAPITokenRepository) List(ctx context.Context, userID user.ID) ([]user.APIToken, error) {
+	dbTokens, err := r.queries.ListUserAPITokens(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]user.APIToken, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		tokens = append(tokens, decodeAPIToken(dbToken))
+	}
+	return tokens, nil
+}
+
+func (r *PgxAPITokenRepository) Delete(ctx context.Context, userID user.ID, id user.APITokenID) error {
+	rows, err := r.queries.DeleteAPIToken(ctx, sqlcgen.DeleteAPITokenParams{
+		ID:     int64(id),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrAPITokenDoesNotExist
+	}
+	return nil
+}
+
+func (r *PgxAPITokenRepository) DeleteAll(ctx context.Context, userID user.ID) error {
+	return r.queries.DeleteUserAPITokens(ctx, int64(userID))
+}
+
+func decodeAPIToken(t sqlcgen.ApiToken) user.APIToken {
+	return user.APIToken{
+		ID:         user.APITokenID(t.ID),
+		UserID:     user.ID(t.UserID),
+		Name:       t.Name,
+		Scopes:     decodeScopes(t.Scopes),
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: c.NewOptional(t.LastUsedAt.Time, t.LastUsedAt.Valid),
+	}
+}
+
+func encodeScopes(scopes []user.Scope) []string {
+	encoded := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		encoded = append(encoded, string(scope))
+	}
+	return encoded
+}
+
+func decodeScopes(scopes []string) []user.Scope {
+	decoded := make([]user.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		decoded = append(decoded, user.Scope(scope))
+	}
+	return decoded
+}
@@ -0,0 +1,158 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+const API_TOKEN_SECRET = user.APITokenSecret("rmpat_test-secret")
+
+type testAPITokenSuite struct {
+	suite.Suite
+	pool               *pgxpool.Pool
+	userRepository     *PgxUserRepository
+	apiTokenRepository *PgxAPITokenRepository
+}
+
+func (suite *testAPITokenSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.apiTokenRepository = NewPgxAPITokenRepository(suite.pool, db.NewTokenHasher("test-secret"))
+}
+
+func (suite *testAPITokenSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testAPITokenSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxAPITokenRepository(t *testing.T) {
+	suite.Run(t, new(testAPITokenSuite))
+}
+
+func (s *testAPITokenSuite) TestCreateAndList() {
+	u := s.createUser()
+	token := s.createToken(u.ID, NOW.Add(time.Hour))
+
+	tokens, err := s.apiTokenRepository.List(context.Background(), u.ID)
+
+	s.Nil(err)
+	s.Equal([]user.APIToken{token}, tokens)
+	s.Equal("ci", token.Name)
+	s.Equal([]user.Scope{user.ScopeRemindersRead, user.ScopeRemindersWrite}, token.Scopes)
+	s.False(token.LastUsedAt.IsPresent)
+}
+
+func (s *testAPITokenSuite) TestGetUserByToken() {
+	u := s.createUser()
+	token := s.createToken(u.ID, NOW.Add(time.Hour))
+
+	actualUser, actualToken, err := s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, NOW)
+
+	s.Nil(err)
+	s.Equal(u.ID, actualUser.ID)
+	token.LastUsedAt = c.NewOptional(NOW, true)
+	s.Equal(token, actualToken)
+	tokens, err := s.apiTokenRepository.List(context.Background(), u.ID)
+	s.Nil(err)
+	s.Equal([]user.APIToken{token}, tokens)
+}
+
+func (s *testAPITokenSuite) TestLastUsedUpdatedOncePerPrecision() {
+	u := s.createUser()
+	s.createToken(u.ID, NOW.Add(time.Hour))
+	_, _, err := s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, NOW)
+	s.Require().Nil(err)
+
+	_, _, err = s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, NOW.Add(30*time.Second))
+	s.Require().Nil(err)
+	tokens, err := s.apiTokenRepository.List(context.Background(), u.ID)
+	s.Require().Nil(err)
+	s.Equal(c.NewOptional(NOW, true), tokens[0].LastUsedAt)
+
+	at := NOW.Add(user.LAST_SEEN_PRECISION)
+	_, _, err = s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, at)
+	s.Require().Nil(err)
+	tokens, err = s.apiTokenRepository.List(context.Background(), u.ID)
+	s.Require().Nil(err)
+	s.Equal(c.NewOptional(at, true), tokens[0].LastUsedAt)
+}
+
+func (s *testAPITokenSuite) TestGetUserByExpiredToken() {
+	u := s.createUser()
+	s.createToken(u.ID, NOW)
+
+	_, _, err := s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, NOW)
+
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testAPITokenSuite) TestGetUserByUnknownToken() {
+	u := s.createUser()
+	s.createToken(u.ID, NOW.Add(time.Hour))
+
+	_, _, err := s.apiTokenRepository.GetUserByToken(context.Background(), "rmpat_other-secret", NOW)
+
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testAPITokenSuite) TestDelete() {
+	u := s.createUser()
+	token := s.createToken(u.ID, NOW.Add(time.Hour))
+
+	err := s.apiTokenRepository.Delete(context.Background(), u.ID+1, token.ID)
+	s.ErrorIs(err, user.ErrAPITokenDoesNotExist)
+
+	err = s.apiTokenRepository.Delete(context.Background(), u.ID, token.ID)
+	s.Nil(err)
+	_, _, err = s.apiTokenRepository.GetUserByToken(context.Background(), API_TOKEN_SECRET, NOW)
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testAPITokenSuite) TestDeleteAll() {
+	u := s.createUser()
+	s.createToken(u.ID, NOW.Add(time.Hour))
+
+	err := s.apiTokenRepository.DeleteAll(context.Background(), u.ID)
+
+	s.Nil(err)
+	tokens, err := s.apiTokenRepository.List(context.Background(), u.ID)
+	s.Nil(err)
+	s.Empty(tokens)
+}
+
+func (s *testAPITokenSuite) createToken(userID user.ID, expiresAt time.Time) user.APIToken {
+	s.T().Helper()
+	token, err := s.apiTokenRepository.Create(context.Background(), user.CreateAPITokenInput{
+		UserID:    userID,
+		Secret:    API_TOKEN_SECRET,
+		Name:      "ci",
+		Scopes:    []user.Scope{user.ScopeRemindersRead, user.ScopeRemindersWrite},
+		CreatedAt: NOW,
+		ExpiresAt: expiresAt,
+	})
+	s.Require().Nil(err)
+	return token
+}
+
+func (s *testAPITokenSuite) createUser() user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(EMAIL), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+		TimeZone:     time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
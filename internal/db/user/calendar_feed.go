@@ -0,0 +1,82 @@
+package user
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PgxCalendarFeedRepository stores hashes of calendar feed tokens, like API tokens.
+type PgxCalendarFeedRepository struct {
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxCalendarFeedRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxCalendarFeedRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxCalendarFeedRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
+}
+
+func (r *PgxCalendarFeedRepository) Create(
+	ctx context.Context,
+	input user.CreateCalendarFeedInput,
+) (feed user.CalendarFeed, err error) {
+	dbFeed, err := r.queries.CreateCalendarFeed(ctx, sqlcgen.CreateCalendarFeedParams{
+		UserID:    int64(input.UserID),
+		Token:     r.tokenHasher.Hash(string(input.Token)),
+		CreatedAt: input.CreatedAt,
+	})
+	if err != nil {
+		return feed, err
+	}
+	return user.CalendarFeed{
+		UserID:     user.ID(dbFeed.UserID),
+		CreatedAt:  dbFeed.CreatedAt,
+		LastUsedAt: c.NewOptional(dbFeed.LastUsedAt.Time, dbFeed.LastUsedAt.Valid),
+	}, nil
+}
+
+func (r *PgxCalendarFeedRepository) GetUserByToken(
+	ctx context.Context,
+	token user.CalendarFeedToken,
+	at time.Time,
+) (u user.User, err error) {
+	dbUser, err := r.queries.GetUserByCalendarFeedToken(ctx, sqlcgen.GetUserByCalendarFeedTokenParams{
+		LastUsedAt: at,
+		Token:      r.tokenHasher.Hash(string(token)),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return u, user.ErrCalendarFeedDoesNotExist
+	}
+	if err != nil {
+		return u, err
+	}
+	u, err = decodeUser(dbUser)
+	if err != nil {
+		return u, err
+	}
+	return u, u.Validate()
+}
+
+func (r *PgxCalendarFeedRepository) Delete(ctx context.Context, userID user.ID) error {
+	rows, err := r.queries.DeleteCalendarFeed(ctx, int64(userID))
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrCalendarFeedDoesNotExist
+	}
+	return nil
+}
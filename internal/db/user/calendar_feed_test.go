@@ -0,0 +1,115 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+const CALENDAR_FEED_TOKEN = user.CalendarFeedToken("test-calendar-token")
+
+type testCalendarFeedSuite struct {
+	suite.Suite
+	pool                   *pgxpool.Pool
+	userRepository         *PgxUserRepository
+	calendarFeedRepository *PgxCalendarFeedRepository
+}
+
+func (suite *testCalendarFeedSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.calendarFeedRepository = NewPgxCalendarFeedRepository(suite.pool, db.NewTokenHasher("test-secret"))
+}
+
+func (suite *testCalendarFeedSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testCalendarFeedSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxCalendarFeedRepository(t *testing.T) {
+	suite.Run(t, new(testCalendarFeedSuite))
+}
+
+func (s *testCalendarFeedSuite) TestCreateAndGetUserByToken() {
+	u := s.createUser()
+
+	feed, err := s.calendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    u.ID,
+		Token:     CALENDAR_FEED_TOKEN,
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	s.Equal(user.CalendarFeed{UserID: u.ID, CreatedAt: NOW}, feed)
+
+	actualUser, err := s.calendarFeedRepository.GetUserByToken(context.Background(), CALENDAR_FEED_TOKEN, NOW)
+	s.Nil(err)
+	s.Equal(u.ID, actualUser.ID)
+	_, err = s.calendarFeedRepository.GetUserByToken(context.Background(), "other-calendar-token", NOW)
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+}
+
+func (s *testCalendarFeedSuite) TestCreateRotatesToken() {
+	u := s.createUser()
+	_, err := s.calendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    u.ID,
+		Token:     CALENDAR_FEED_TOKEN,
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	_, err = s.calendarFeedRepository.GetUserByToken(context.Background(), CALENDAR_FEED_TOKEN, NOW)
+	s.Require().Nil(err)
+
+	feed, err := s.calendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    u.ID,
+		Token:     "new-calendar-token",
+		CreatedAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	s.Equal(user.CalendarFeed{UserID: u.ID, CreatedAt: NOW.Add(time.Hour)}, feed)
+
+	_, err = s.calendarFeedRepository.GetUserByToken(context.Background(), CALENDAR_FEED_TOKEN, NOW)
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+	actualUser, err := s.calendarFeedRepository.GetUserByToken(context.Background(), "new-calendar-token", NOW)
+	s.Nil(err)
+	s.Equal(u.ID, actualUser.ID)
+}
+
+func (s *testCalendarFeedSuite) TestDelete() {
+	u := s.createUser()
+	err := s.calendarFeedRepository.Delete(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+	_, err = s.calendarFeedRepository.Create(context.Background(), user.CreateCalendarFeedInput{
+		UserID:    u.ID,
+		Token:     CALENDAR_FEED_TOKEN,
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	err = s.calendarFeedRepository.Delete(context.Background(), u.ID)
+
+	s.Nil(err)
+	_, err = s.calendarFeedRepository.GetUserByToken(context.Background(), CALENDAR_FEED_TOKEN, NOW)
+	s.ErrorIs(err, user.ErrCalendarFeedDoesNotExist)
+}
+
+func (s *testCalendarFeedSuite) createUser() user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(EMAIL), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+		TimeZone:     time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
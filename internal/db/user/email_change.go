@@ -0,0 +1,130 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type PgxEmailChangeRepository struct {
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxEmailChangeRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxEmailChangeRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxEmailChangeRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
+}
+
+func (r *PgxEmailChangeRepository) Create(
+	ctx context.Context,
+	input user.CreateEmailChangeInput,
+) (user.EmailChange, error) {
+	dbChange, err := r.queries.CreateEmailChange(ctx, sqlcgen.CreateEmailChangeParams{
+		UserID:    int64(input.UserID),
+		OldEmail:  string(input.OldEmail),
+		NewEmail:  string(input.NewEmail),
+		Token:     sql.NullString{String: r.tokenHasher.Hash(string(input.Token)), Valid: true},
+		CreatedAt: input.CreatedAt,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		return user.EmailChange{}, err
+	}
+	return decodeEmailChange(dbChange), nil
+}
+
+func (r *PgxEmailChangeRepository) GetByTokenWithLock(
+	ctx context.Context,
+	token user.EmailChangeToken,
+	at time.Time,
+) (user.EmailChange, error) {
+	dbChange, err := r.queries.GetEmailChangeByTokenWithLock(ctx, sqlcgen.GetEmailChangeByTokenWithLockParams{
+		Token: r.tokenHasher.Hash(string(token)),
+		At:    at,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EmailChange{}, user.ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return user.EmailChange{}, err
+	}
+	return decodeEmailChange(dbChange), nil
+}
+
+func (r *PgxEmailChangeRepository) Confirm(
+	ctx context.Context,
+	input user.ConfirmEmailChangeInput,
+) (user.EmailChange, error) {
+	dbChange, err := r.queries.ConfirmEmailChange(ctx, sqlcgen.ConfirmEmailChangeParams{
+		ConfirmedAt:     input.ConfirmedAt,
+		RevertToken:     r.tokenHasher.Hash(string(input.RevertToken)),
+		RevertExpiresAt: input.RevertExpiresAt,
+		ID:              int64(input.ID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EmailChange{}, user.ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return user.EmailChange{}, err
+	}
+	return decodeEmailChange(dbChange), nil
+}
+
+func (r *PgxEmailChangeRepository) GetByRevertTokenWithLock(
+	ctx context.Context,
+	token user.EmailChangeToken,
+	at time.Time,
+) (user.EmailChange, error) {
+	dbChange, err := r.queries.GetEmailChangeByRevertTokenWithLock(
+		ctx,
+		sqlcgen.GetEmailChangeByRevertTokenWithLockParams{
+			RevertToken: r.tokenHasher.Hash(string(token)),
+			At:          at,
+		},
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EmailChange{}, user.ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return user.EmailChange{}, err
+	}
+	return decodeEmailChange(dbChange), nil
+}
+
+func (r *PgxEmailChangeRepository) Delete(ctx context.Context, id user.EmailChangeID) error {
+	rows, err := r.queries.DeleteEmailChange(ctx, int64(id))
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrInvalidEmailChangeToken
+	}
+	return nil
+}
+
+func decodeEmailChange(ch sqlcgen.EmailChange) user.EmailChange {
+	return user.EmailChange{
+		ID:              user.EmailChangeID(ch.ID),
+		UserID:          user.ID(ch.UserID),
+		OldEmail:        c.Email(ch.OldEmail),
+		NewEmail:        c.Email(ch.NewEmail),
+		CreatedAt:       ch.CreatedAt,
+		ExpiresAt:       ch.ExpiresAt,
+		ConfirmedAt:     c.NewOptional(ch.ConfirmedAt.Time, ch.ConfirmedAt.Valid),
+		RevertExpiresAt: c.NewOptional(ch.RevertExpiresAt.Time, ch.RevertExpiresAt.Valid),
+	}
+}
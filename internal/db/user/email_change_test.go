@@ -0,0 +1,140 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type testEmailChangeSuite struct {
+	suite.Suite
+	pool             *pgxpool.Pool
+	userRepository   *PgxUserRepository
+	changeRepository *PgxEmailChangeRepository
+}
+
+func (suite *testEmailChangeSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	tokenHasher := db.NewTokenHasher("test-secret")
+	suite.userRepository = NewPgxRepository(suite.pool, tokenHasher)
+	suite.changeRepository = NewPgxEmailChangeRepository(suite.pool, tokenHasher)
+}
+
+func (suite *testEmailChangeSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testEmailChangeSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxEmailChangeRepository(t *testing.T) {
+	suite.Run(t, new(testEmailChangeSuite))
+}
+
+func (s *testEmailChangeSuite) TestCreateReplacesPendingChange() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "first@test.test", "first-token")
+	second := s.createChange(u.ID, "second@test.test", "second-token")
+
+	_, err := s.changeRepository.GetByTokenWithLock(context.Background(), "first-token", NOW)
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+
+	actual, err := s.changeRepository.GetByTokenWithLock(context.Background(), "second-token", NOW)
+	s.Nil(err)
+	s.Equal(second, actual)
+	s.Equal(c.Email("old@test.test"), actual.OldEmail)
+	s.Equal(c.Email("second@test.test"), actual.NewEmail)
+}
+
+func (s *testEmailChangeSuite) TestExpiredTokenIsInvalid() {
+	u := s.createUser("old@test.test")
+	s.createChange(u.ID, "new@test.test", "token")
+
+	_, err := s.changeRepository.GetByTokenWithLock(context.Background(), "token", NOW.Add(time.Hour))
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+}
+
+func (s *testEmailChangeSuite) TestConfirmAndRevert() {
+	u := s.createUser("old@test.test")
+	change := s.createChange(u.ID, "new@test.test", "token")
+
+	confirmed, err := s.changeRepository.Confirm(context.Background(), user.ConfirmEmailChangeInput{
+		ID:              change.ID,
+		ConfirmedAt:     NOW,
+		RevertToken:     "revert-token",
+		RevertExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Nil(err)
+	s.Equal(c.NewOptional(NOW, true), confirmed.ConfirmedAt)
+	s.Equal(c.NewOptional(NOW.Add(time.Hour), true), confirmed.RevertExpiresAt)
+
+	_, err = s.changeRepository.GetByTokenWithLock(context.Background(), "token", NOW)
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+	actual, err := s.changeRepository.GetByRevertTokenWithLock(context.Background(), "revert-token", NOW)
+	s.Nil(err)
+	s.Equal(confirmed, actual)
+	_, err = s.changeRepository.GetByRevertTokenWithLock(
+		context.Background(),
+		"revert-token",
+		NOW.Add(2*time.Hour),
+	)
+	s.ErrorIs(err, user.ErrInvalidEmailChangeToken)
+
+	s.Nil(s.changeRepository.Delete(context.Background(), change.ID))
+	s.ErrorIs(s.changeRepository.Delete(context.Background(), change.ID), user.ErrInvalidEmailChangeToken)
+}
+
+func (s *testEmailChangeSuite) TestUpdateUserEmail() {
+	u := s.createUser("old@test.test")
+	s.createUser("taken@test.test")
+
+	updated, err := s.userRepository.Update(context.Background(), user.UpdateUserInput{
+		ID:            u.ID,
+		DoEmailUpdate: true,
+		Email:         "new@test.test",
+	})
+	s.Nil(err)
+	s.Equal(c.NewOptional(c.Email("new@test.test"), true), updated.Email)
+	s.Equal(time.UTC, updated.TimeZone)
+
+	_, err = s.userRepository.Update(context.Background(), user.UpdateUserInput{
+		ID:            u.ID,
+		DoEmailUpdate: true,
+		Email:         "taken@test.test",
+	})
+	s.ErrorIs(err, user.ErrEmailAlreadyExists)
+}
+
+func (s *testEmailChangeSuite) createChange(userID user.ID, email string, token string) user.EmailChange {
+	s.T().Helper()
+	change, err := s.changeRepository.Create(context.Background(), user.CreateEmailChangeInput{
+		UserID:    userID,
+		OldEmail:  "old@test.test",
+		NewEmail:  c.Email(email),
+		Token:     user.EmailChangeToken(token),
+		CreatedAt: NOW,
+		ExpiresAt: NOW.Add(time.Minute),
+	})
+	s.Require().Nil(err)
+	return change
+}
+
+func (s *testEmailChangeSuite) createUser(email string) user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(email), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+		TimeZone:     time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
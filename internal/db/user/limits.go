@@ -3,10 +3,13 @@ package user
 import (
 	"context"
 	"database/sql"
+	"errors"
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/user"
 	"remindme/internal/db/sqlcgen"
+
+	"github.com/jackc/pgx/v4"
 )
 
 type PgxLimitsRepository struct {
@@ -21,29 +24,18 @@ func NewPgxLimitsRepository(db sqlcgen.DBTX) *PgxLimitsRepository {
 }
 
 func (r *PgxLimitsRepository) Create(ctx context.Context, input user.CreateLimitsInput) (user.Limits, error) {
-	dbLimits, err := r.queries.CreateLimits(ctx, sqlcgen.CreateLimitsParams{
-		UserID: int64(input.UserID),
-		EmailChannelCount: sql.NullInt32{
-			Int32: int32(input.Limits.EmailChannelCount.Value),
-			Valid: input.Limits.EmailChannelCount.IsPresent,
-		},
-		TelegramChannelCount: sql.NullInt32{
-			Int32: int32(input.Limits.TelegramChannelCount.Value),
-			Valid: input.Limits.TelegramChannelCount.IsPresent,
-		},
-		ActiveReminderCount: sql.NullInt32{
-			Int32: int32(input.Limits.ActiveReminderCount.Value),
-			Valid: input.Limits.ActiveReminderCount.IsPresent,
-		},
-		MonthlySentReminderCount: sql.NullInt32{
-			Int32: int32(input.Limits.MonthlySentReminderCount.Value),
-			Valid: input.Limits.MonthlySentReminderCount.IsPresent,
-		},
-		ReminderEveryPerDayCount: sql.NullFloat64{
-			Float64: input.Limits.ReminderEveryPerDayCount.Value,
-			Valid:   input.Limits.ReminderEveryPerDayCount.IsPresent,
-		},
-	})
+	dbLimits, err := r.queries.CreateLimits(ctx, encodeLimits(input.UserID, input.Plan, input.Limits))
+	return decodeLimits(dbLimits), err
+}
+
+func (r *PgxLimitsRepository) Update(ctx context.Context, input user.UpdateLimitsInput) (user.Limits, error) {
+	dbLimits, err := r.queries.UpdateLimits(
+		ctx,
+		sqlcgen.UpdateLimitsParams(encodeLimits(input.UserID, input.Plan, input.Limits)),
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.Limits{}, user.ErrLimitsDoNotExist
+	}
 	return decodeLimits(dbLimits), err
 }
 
@@ -57,6 +49,61 @@ func (r *PgxLimitsRepository) GetUserLimitsWithLock(ctx context.Context, userID
 	return decodeLimits(dbLimits), err
 }
 
+func (r *PgxLimitsRepository) GetUserPlan(ctx context.Context, userID user.ID) (p user.UserPlan, err error) {
+	dbLimits, err := r.queries.GetUserLimits(ctx, int64(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, user.ErrLimitsDoNotExist
+	}
+	if err != nil {
+		return p, err
+	}
+	return decodeUserPlan(dbLimits), nil
+}
+
+func (r *PgxLimitsRepository) GetUserPlanWithLock(ctx context.Context, userID user.ID) (p user.UserPlan, err error) {
+	dbLimits, err := r.queries.GetUserLimitsWithLock(ctx, int64(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, user.ErrLimitsDoNotExist
+	}
+	if err != nil {
+		return p, err
+	}
+	return decodeUserPlan(dbLimits), nil
+}
+
+func encodeLimits(userID user.ID, plan c.Optional[user.PlanName], limits user.Limits) sqlcgen.CreateLimitsParams {
+	window := limits.SentReminderQuotaWindow
+	if window == "" {
+		window = user.QuotaWindowCalendarMonth
+	}
+	return sqlcgen.CreateLimitsParams{
+		UserID: int64(userID),
+		EmailChannelCount: sql.NullInt32{
+			Int32: int32(limits.EmailChannelCount.Value),
+			Valid: limits.EmailChannelCount.IsPresent,
+		},
+		TelegramChannelCount: sql.NullInt32{
+			Int32: int32(limits.TelegramChannelCount.Value),
+			Valid: limits.TelegramChannelCount.IsPresent,
+		},
+		ActiveReminderCount: sql.NullInt32{
+			Int32: int32(limits.ActiveReminderCount.Value),
+			Valid: limits.ActiveReminderCount.IsPresent,
+		},
+		MonthlySentReminderCount: sql.NullInt32{
+			Int32: int32(limits.MonthlySentReminderCount.Value),
+			Valid: limits.MonthlySentReminderCount.IsPresent,
+		},
+		ReminderEveryPerDayCount: sql.NullFloat64{
+			Float64: limits.ReminderEveryPerDayCount.Value,
+			Valid:   limits.ReminderEveryPerDayCount.IsPresent,
+		},
+		Plan:                         sql.NullString{String: string(plan.Value), Valid: plan.IsPresent},
+		SentReminderQuotaWindow:      string(window),
+		PausedRemindersCountAsActive: limits.PausedRemindersCountAsActive,
+	}
+}
+
 func decodeLimits(l sqlcgen.Limit) user.Limits {
 	return user.Limits{
 		EmailChannelCount:    c.NewOptional(uint32(l.EmailChannelCount.Int32), l.EmailChannelCount.Valid),
@@ -70,5 +117,15 @@ func decodeLimits(l sqlcgen.Limit) user.Limits {
 			l.ReminderEveryPerDayCount.Float64,
 			l.ReminderEveryPerDayCount.Valid,
 		),
+		SentReminderQuotaWindow:      user.QuotaWindow(l.SentReminderQuotaWindow),
+		PausedRemindersCountAsActive: l.PausedRemindersCountAsActive,
+	}
+}
+
+func decodeUserPlan(l sqlcgen.Limit) user.UserPlan {
+	return user.UserPlan{
+		UserID: user.ID(l.UserID),
+		Plan:   c.NewOptional(user.PlanName(l.Plan.String), l.Plan.Valid),
+		Limits: decodeLimits(l),
 	}
 }
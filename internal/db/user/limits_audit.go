@@ -0,0 +1,146 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db/sqlcgen"
+
+	"github.com/jackc/pgtype"
+)
+
+type PgxLimitsAuditRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPgxLimitsAuditRepository(db sqlcgen.DBTX) *PgxLimitsAuditRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	return &PgxLimitsAuditRepository{queries: sqlcgen.New(db)}
+}
+
+func (r *PgxLimitsAuditRepository) Create(
+	ctx context.Context,
+	input user.CreateLimitsAuditEntryInput,
+) (entry user.LimitsAuditEntry, err error) {
+	before, err := encodeLimitsJSONB(input.Before)
+	if err != nil {
+		return entry, err
+	}
+	after, err := encodeLimitsJSONB(input.After)
+	if err != nil {
+		return entry, err
+	}
+	dbEntry, err := r.queries.CreateLimitsAuditEntry(ctx, sqlcgen.CreateLimitsAuditEntryParams{
+		UserID:       int64(input.UserID),
+		Actor:        input.Actor,
+		Action:       string(input.Action),
+		Plan:         sql.NullString{String: string(input.Plan.Value), Valid: input.Plan.IsPresent},
+		LimitsBefore: before,
+		LimitsAfter:  after,
+		CreatedAt:    input.CreatedAt,
+	})
+	if err != nil {
+		return entry, err
+	}
+	return decodeLimitsAuditEntry(dbEntry)
+}
+
+func (r *PgxLimitsAuditRepository) List(ctx context.Context, userID user.ID) ([]user.LimitsAuditEntry, error) {
+	dbEntries, err := r.queries.ListLimitsAuditEntries(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]user.LimitsAuditEntry, 0, len(dbEntries))
+	for _, dbEntry := range dbEntries {
+		entry, err := decodeLimitsAuditEntry(dbEntry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// limitsJSON is the limits representation stored in the audit,
+// absent limits are stored as nulls.
+type limitsJSON struct {
+	EmailChannelCount            *uint32  `json:"email_channel_count"`
+	TelegramChannelCount         *uint32  `json:"telegram_channel_count"`
+	ActiveReminderCount          *uint32  `json:"active_reminder_count"`
+	MonthlySentReminderCount     *uint32  `json:"monthly_sent_reminder_count"`
+	ReminderEveryPerDayCount     *float64 `json:"reminder_every_per_day_count"`
+	SentReminderQuotaWindow      string   `json:"sent_reminder_quota_window,omitempty"`
+	PausedRemindersCountAsActive bool     `json:"paused_reminders_count_as_active,omitempty"`
+}
+
+func encodeLimitsJSONB(limits user.Limits) (encoded pgtype.JSONB, err error) {
+	value := limitsJSON{
+		EmailChannelCount:            optionalToPointer(limits.EmailChannelCount),
+		TelegramChannelCount:         optionalToPointer(limits.TelegramChannelCount),
+		ActiveReminderCount:          optionalToPointer(limits.ActiveReminderCount),
+		MonthlySentReminderCount:     optionalToPointer(limits.MonthlySentReminderCount),
+		ReminderEveryPerDayCount:     optionalToPointer(limits.ReminderEveryPerDayCount),
+		SentReminderQuotaWindow:      string(limits.SentReminderQuotaWindow),
+		PausedRemindersCountAsActive: limits.PausedRemindersCountAsActive,
+	}
+	if err := encoded.Set(value); err != nil {
+		return encoded, fmt.Errorf("could not encode limits due to error: %w", err)
+	}
+	return encoded, nil
+}
+
+func decodeLimitsJSONB(encoded pgtype.JSONB) (limits user.Limits, err error) {
+	value := limitsJSON{}
+	if err := encoded.AssignTo(&value); err != nil {
+		return limits, fmt.Errorf("could not decode limits due to error: %w", err)
+	}
+	return user.Limits{
+		EmailChannelCount:            pointerToOptional(value.EmailChannelCount),
+		TelegramChannelCount:         pointerToOptional(value.TelegramChannelCount),
+		ActiveReminderCount:          pointerToOptional(value.ActiveReminderCount),
+		MonthlySentReminderCount:     pointerToOptional(value.MonthlySentReminderCount),
+		ReminderEveryPerDayCount:     pointerToOptional(value.ReminderEveryPerDayCount),
+		SentReminderQuotaWindow:      user.QuotaWindow(value.SentReminderQuotaWindow),
+		PausedRemindersCountAsActive: value.PausedRemindersCountAsActive,
+	}, nil
+}
+
+func decodeLimitsAuditEntry(entry sqlcgen.LimitsAudit) (decoded user.LimitsAuditEntry, err error) {
+	before, err := decodeLimitsJSONB(entry.LimitsBefore)
+	if err != nil {
+		return decoded, err
+	}
+	after, err := decodeLimitsJSONB(entry.LimitsAfter)
+	if err != nil {
+		return decoded, err
+	}
+	return user.LimitsAuditEntry{
+		ID:        user.LimitsAuditEntryID(entry.ID),
+		UserID:    user.ID(entry.UserID),
+		Actor:     entry.Actor,
+		Action:    user.LimitsAuditAction(entry.Action),
+		Plan:      c.NewOptional(user.PlanName(entry.Plan.String), entry.Plan.Valid),
+		Before:    before,
+		After:     after,
+		CreatedAt: entry.CreatedAt,
+	}, nil
+}
+
+func optionalToPointer[T any](value c.Optional[T]) *T {
+	if !value.IsPresent {
+		return nil
+	}
+	return &value.Value
+}
+
+func pointerToOptional[T any](value *T) c.Optional[T] {
+	if value == nil {
+		return c.NewOptional(*new(T), false)
+	}
+	return c.NewOptional(*value, true)
+}
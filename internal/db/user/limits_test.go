@@ -20,7 +20,7 @@ type testLimitsSuite struct {
 
 func (suite *testLimitsSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
-	suite.userRepository = NewPgxRepository(suite.pool)
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
 	suite.limitsRepository = NewPgxLimitsRepository(suite.pool)
 }
 
@@ -78,27 +78,111 @@ func (s *testLimitsSuite) TestCreateAndGet() {
 			MonthlySentReminderCount: c.NewOptional(uint32(1000), true),
 			ReminderEveryPerDayCount: c.NewOptional(1.0, true),
 		}},
+		{ID: "13", Limits: user.Limits{
+			MonthlySentReminderCount: c.NewOptional(uint32(100), true),
+			SentReminderQuotaWindow:  user.QuotaWindowRolling30Days,
+		}},
 	}
 
 	for _, testCase := range cases {
 		db.TruncateTables(s.pool)
 		activeUser := s.createActiveUser()
+		expectedLimits := withDefaultQuotaWindow(testCase.Limits)
 
 		createdLimits, err := s.limitsRepository.Create(context.Background(), user.CreateLimitsInput{
 			UserID: activeUser.ID,
 			Limits: testCase.Limits,
 		})
 		s.Nil(err, testCase.ID)
-		s.Equal(testCase.Limits, createdLimits, testCase.ID)
+		s.Equal(expectedLimits, createdLimits, testCase.ID)
 
 		readLimits, err := s.limitsRepository.GetUserLimits(context.Background(), activeUser.ID)
 		s.Nil(err, testCase.ID)
-		s.Equal(testCase.Limits, readLimits, testCase.ID)
+		s.Equal(expectedLimits, readLimits, testCase.ID)
 
 		readLimits, err = s.limitsRepository.GetUserLimitsWithLock(context.Background(), activeUser.ID)
 		s.Nil(err)
-		s.Equal(testCase.Limits, readLimits, testCase.ID)
+		s.Equal(expectedLimits, readLimits, testCase.ID)
+	}
+}
+
+func (s *testLimitsSuite) TestUpdate() {
+	activeUser := s.createActiveUser()
+	_, err := s.limitsRepository.Create(context.Background(), user.CreateLimitsInput{
+		UserID: activeUser.ID,
+		Limits: user.Limits{
+			EmailChannelCount:   c.NewOptional(uint32(1), true),
+			ActiveReminderCount: c.NewOptional(uint32(5), true),
+		},
+	})
+	s.Require().Nil(err)
+
+	newLimits := user.Limits{
+		EmailChannelCount:        c.NewOptional(uint32(2), true),
+		TelegramChannelCount:     c.NewOptional(uint32(1), true),
+		MonthlySentReminderCount: c.NewOptional(uint32(100), true),
+	}
+	updatedLimits, err := s.limitsRepository.Update(context.Background(), user.UpdateLimitsInput{
+		UserID: activeUser.ID,
+		Limits: newLimits,
+	})
+	s.Require().Nil(err)
+	s.Equal(withDefaultQuotaWindow(newLimits), updatedLimits)
+
+	readLimits, err := s.limitsRepository.GetUserLimits(context.Background(), activeUser.ID)
+	s.Nil(err)
+	s.Equal(withDefaultQuotaWindow(newLimits), readLimits)
+}
+
+func (s *testLimitsSuite) TestUserPlan() {
+	activeUser := s.createActiveUser()
+	_, err := s.limitsRepository.GetUserPlan(context.Background(), activeUser.ID)
+	s.ErrorIs(err, user.ErrLimitsDoNotExist)
+
+	limits := user.Limits{
+		EmailChannelCount:       c.NewOptional(uint32(1), true),
+		SentReminderQuotaWindow: user.QuotaWindowCalendarMonth,
+	}
+	_, err = s.limitsRepository.Create(context.Background(), user.CreateLimitsInput{
+		UserID: activeUser.ID,
+		Limits: limits,
+	})
+	s.Require().Nil(err)
+	plan, err := s.limitsRepository.GetUserPlan(context.Background(), activeUser.ID)
+	s.Nil(err)
+	s.Equal(user.UserPlan{UserID: activeUser.ID, Limits: limits}, plan)
+
+	_, err = s.limitsRepository.Update(context.Background(), user.UpdateLimitsInput{
+		UserID: activeUser.ID,
+		Plan:   c.NewOptional(user.PlanPro, true),
+		Limits: limits,
+	})
+	s.Require().Nil(err)
+	plan, err = s.limitsRepository.GetUserPlanWithLock(context.Background(), activeUser.ID)
+	s.Nil(err)
+	s.Equal(
+		user.UserPlan{UserID: activeUser.ID, Plan: c.NewOptional(user.PlanPro, true), Limits: limits},
+		plan,
+	)
+}
+
+func (s *testLimitsSuite) TestUpdateReturnsErrorIfLimitsDoNotExist() {
+	activeUser := s.createActiveUser()
+
+	_, err := s.limitsRepository.Update(context.Background(), user.UpdateLimitsInput{
+		UserID: activeUser.ID,
+		Limits: user.Limits{},
+	})
+
+	s.ErrorIs(err, user.ErrLimitsDoNotExist)
+}
+
+// withDefaultQuotaWindow sets the window the DB stores for limits created without one.
+func withDefaultQuotaWindow(limits user.Limits) user.Limits {
+	if limits.SentReminderQuotaWindow == "" {
+		limits.SentReminderQuotaWindow = user.QuotaWindowCalendarMonth
 	}
+	return limits
 }
 
 func (s *testLimitsSuite) createActiveUser() user.User {
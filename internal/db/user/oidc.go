@@ -0,0 +1,157 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const EXTERNAL_IDENTITY_CONSTRAINT_NAME = "external_identity_provider_subject_idx"
+
+type PgxExternalIdentityRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPgxExternalIdentityRepository(db sqlcgen.DBTX) *PgxExternalIdentityRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	return &PgxExternalIdentityRepository{queries: sqlcgen.New(db)}
+}
+
+func (r *PgxExternalIdentityRepository) Create(
+	ctx context.Context,
+	input user.CreateExternalIdentityInput,
+) (identity user.ExternalIdentity, err error) {
+	dbIdentity, err := r.queries.CreateExternalIdentity(ctx, sqlcgen.CreateExternalIdentityParams{
+		UserID:    int64(input.UserID),
+		Provider:  string(input.Provider),
+		Subject:   input.Subject,
+		CreatedAt: input.CreatedAt,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == PG_UNIQUE_CONSTRAINT_ERR_CODE &&
+		pgErr.ConstraintName == EXTERNAL_IDENTITY_CONSTRAINT_NAME {
+		return identity, user.ErrExternalIdentityAlreadyLinked
+	}
+	if err != nil {
+		return identity, err
+	}
+	return decodeExternalIdentity(dbIdentity), nil
+}
+
+func (r *PgxExternalIdentityRepository) GetUser(
+	ctx context.Context,
+	provider user.OIDCProviderName,
+	subject string,
+) (u user.User, err error) {
+	dbuser, err := r.queries.GetUserByExternalIdentity(ctx, sqlcgen.GetUserByExternalIdentityParams{
+		Provider: string(provider),
+		Subject:  subject,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return u, user.ErrUserDoesNotExist
+	}
+	if err != nil {
+		return u, err
+	}
+	u, err = decodeUser(dbuser)
+	if err != nil {
+		return u, err
+	}
+	err = u.Validate()
+	if err != nil {
+		return u, err
+	}
+	return u, nil
+}
+
+func (r *PgxExternalIdentityRepository) List(ctx context.Context, userID user.ID) ([]user.ExternalIdentity, error) {
+	dbIdentities, err := r.queries.ListUserExternalIdentities(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]user.ExternalIdentity, len(dbIdentities))
+	for ix, dbIdentity := range dbIdentities {
+		identities[ix] = decodeExternalIdentity(dbIdentity)
+	}
+	return identities, nil
+}
+
+func decodeExternalIdentity(dbIdentity sqlcgen.ExternalIdentity) user.ExternalIdentity {
+	return user.ExternalIdentity{
+		ID:        user.ExternalIdentityID(dbIdentity.ID),
+		UserID:    user.ID(dbIdentity.UserID),
+		Provider:  user.OIDCProviderName(dbIdentity.Provider),
+		Subject:   dbIdentity.Subject,
+		CreatedAt: dbIdentity.CreatedAt,
+	}
+}
+
+// PgxOIDCAuthorizationRepository stores hashes of states, like other tokens.
+type PgxOIDCAuthorizationRepository struct {
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxOIDCAuthorizationRepository(
+	db sqlcgen.DBTX,
+	tokenHasher *db.TokenHasher,
+) *PgxOIDCAuthorizationRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxOIDCAuthorizationRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
+}
+
+func (r *PgxOIDCAuthorizationRepository) Create(ctx context.Context, authorization user.OIDCAuthorization) error {
+	return r.queries.CreateOIDCAuthorization(ctx, sqlcgen.CreateOIDCAuthorizationParams{
+		State:        r.tokenHasher.Hash(string(authorization.State)),
+		Provider:     string(authorization.Provider),
+		Nonce:        string(authorization.Nonce),
+		CodeVerifier: string(authorization.CodeVerifier),
+		UserID:       sql.NullInt64{Int64: int64(authorization.UserID.Value), Valid: authorization.UserID.IsPresent},
+		CreatedAt:    authorization.CreatedAt,
+	})
+}
+
+func (r *PgxOIDCAuthorizationRepository) Pop(
+	ctx context.Context,
+	state user.OIDCState,
+	createdSince time.Time,
+) (a user.OIDCAuthorization, err error) {
+	hashedState := r.tokenHasher.Hash(string(state))
+	dbAuthorizations, err := r.queries.DeleteOIDCAuthorizations(ctx, sqlcgen.DeleteOIDCAuthorizationsParams{
+		State:        hashedState,
+		CreatedSince: createdSince,
+	})
+	if err != nil {
+		return a, err
+	}
+	for _, dbAuthorization := range dbAuthorizations {
+		if dbAuthorization.State == hashedState && dbAuthorization.CreatedAt.After(createdSince) {
+			return user.OIDCAuthorization{
+				State:        state,
+				Provider:     user.OIDCProviderName(dbAuthorization.Provider),
+				Nonce:        user.OIDCNonce(dbAuthorization.Nonce),
+				CodeVerifier: user.OIDCCodeVerifier(dbAuthorization.CodeVerifier),
+				UserID:       c.NewOptional(user.ID(dbAuthorization.UserID.Int64), dbAuthorization.UserID.Valid),
+				CreatedAt:    dbAuthorization.CreatedAt,
+			}, nil
+		}
+	}
+	return a, user.ErrInvalidOIDCState
+}
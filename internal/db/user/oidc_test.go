@@ -0,0 +1,162 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	OIDC_PROVIDER = user.OIDCProviderName("test")
+	OIDC_SUBJECT  = "test-subject"
+	OIDC_STATE    = user.OIDCState("test-state")
+)
+
+type testOIDCSuite struct {
+	suite.Suite
+	pool                    *pgxpool.Pool
+	userRepository          *PgxUserRepository
+	identityRepository      *PgxExternalIdentityRepository
+	authorizationRepository *PgxOIDCAuthorizationRepository
+}
+
+func (suite *testOIDCSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.identityRepository = NewPgxExternalIdentityRepository(suite.pool)
+	suite.authorizationRepository = NewPgxOIDCAuthorizationRepository(suite.pool, db.NewTokenHasher("test-secret"))
+}
+
+func (suite *testOIDCSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testOIDCSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxOIDCRepositories(t *testing.T) {
+	suite.Run(t, new(testOIDCSuite))
+}
+
+func (s *testOIDCSuite) TestCreateAndGetUser() {
+	u := s.createUser()
+
+	identity, err := s.identityRepository.Create(context.Background(), user.CreateExternalIdentityInput{
+		UserID:    u.ID,
+		Provider:  OIDC_PROVIDER,
+		Subject:   OIDC_SUBJECT,
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	s.Equal(u.ID, identity.UserID)
+	s.Equal(OIDC_PROVIDER, identity.Provider)
+	s.Equal(OIDC_SUBJECT, identity.Subject)
+	s.Equal(NOW, identity.CreatedAt)
+
+	identityUser, err := s.identityRepository.GetUser(context.Background(), OIDC_PROVIDER, OIDC_SUBJECT)
+	s.Nil(err)
+	s.Equal(u.ID, identityUser.ID)
+
+	_, err = s.identityRepository.GetUser(context.Background(), "other", OIDC_SUBJECT)
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testOIDCSuite) TestCreateAlreadyLinked() {
+	u := s.createUser()
+	input := user.CreateExternalIdentityInput{
+		UserID:    u.ID,
+		Provider:  OIDC_PROVIDER,
+		Subject:   OIDC_SUBJECT,
+		CreatedAt: NOW,
+	}
+	_, err := s.identityRepository.Create(context.Background(), input)
+	s.Require().Nil(err)
+
+	_, err = s.identityRepository.Create(context.Background(), input)
+
+	s.ErrorIs(err, user.ErrExternalIdentityAlreadyLinked)
+}
+
+func (s *testOIDCSuite) TestList() {
+	u := s.createUser()
+	for _, provider := range []user.OIDCProviderName{OIDC_PROVIDER, "other"} {
+		_, err := s.identityRepository.Create(context.Background(), user.CreateExternalIdentityInput{
+			UserID:    u.ID,
+			Provider:  provider,
+			Subject:   OIDC_SUBJECT,
+			CreatedAt: NOW,
+		})
+		s.Require().Nil(err)
+	}
+
+	identities, err := s.identityRepository.List(context.Background(), u.ID)
+
+	s.Nil(err)
+	s.Len(identities, 2)
+	s.Equal(OIDC_PROVIDER, identities[0].Provider)
+	s.Equal(user.OIDCProviderName("other"), identities[1].Provider)
+
+	identities, err = s.identityRepository.List(context.Background(), u.ID+1)
+
+	s.Nil(err)
+	s.Empty(identities)
+}
+
+func (s *testOIDCSuite) TestPopAuthorization() {
+	u := s.createUser()
+	authorization := user.OIDCAuthorization{
+		State:        OIDC_STATE,
+		Provider:     OIDC_PROVIDER,
+		Nonce:        "test-nonce",
+		CodeVerifier: "test-code-verifier",
+		UserID:       c.NewOptional(u.ID, true),
+		CreatedAt:    NOW,
+	}
+	s.Require().Nil(s.authorizationRepository.Create(context.Background(), authorization))
+
+	popped, err := s.authorizationRepository.Pop(context.Background(), OIDC_STATE, NOW.Add(-time.Minute))
+	s.Nil(err)
+	s.Equal(authorization, popped)
+
+	_, err = s.authorizationRepository.Pop(context.Background(), OIDC_STATE, NOW.Add(-time.Minute))
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+}
+
+func (s *testOIDCSuite) TestPopDeletesExpiredAuthorizations() {
+	for _, state := range []user.OIDCState{OIDC_STATE, "test-other-state"} {
+		err := s.authorizationRepository.Create(context.Background(), user.OIDCAuthorization{
+			State:        state,
+			Provider:     OIDC_PROVIDER,
+			Nonce:        "test-nonce",
+			CodeVerifier: "test-code-verifier",
+			CreatedAt:    NOW,
+		})
+		s.Require().Nil(err)
+	}
+
+	_, err := s.authorizationRepository.Pop(context.Background(), OIDC_STATE, NOW)
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+
+	_, err = s.authorizationRepository.Pop(context.Background(), "test-other-state", NOW.Add(-time.Minute))
+	s.ErrorIs(err, user.ErrInvalidOIDCState)
+}
+
+func (s *testOIDCSuite) createUser() user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:       c.NewOptional(c.NewEmail(EMAIL), true),
+		Identity:    c.NewOptional(user.Identity("test-identity"), true),
+		CreatedAt:   NOW,
+		ActivatedAt: c.NewOptional(NOW, true),
+		TimeZone:    time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+	"errors"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db/sqlcgen"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type PgxPlanRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPgxPlanRepository(db sqlcgen.DBTX) *PgxPlanRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	return &PgxPlanRepository{queries: sqlcgen.New(db)}
+}
+
+func (r *PgxPlanRepository) Get(ctx context.Context, name user.PlanName) (user.Plan, error) {
+	dbPlan, err := r.queries.GetPlan(ctx, string(name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.Plan{}, user.ErrPlanDoesNotExist
+	}
+	if err != nil {
+		return user.Plan{}, err
+	}
+	return decodePlan(dbPlan), nil
+}
+
+func (r *PgxPlanRepository) List(ctx context.Context) ([]user.Plan, error) {
+	dbPlans, err := r.queries.ListPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plans := make([]user.Plan, 0, len(dbPlans))
+	for _, dbPlan := range dbPlans {
+		plans = append(plans, decodePlan(dbPlan))
+	}
+	return plans, nil
+}
+
+func decodePlan(p sqlcgen.Plan) user.Plan {
+	return user.Plan{
+		Name: user.PlanName(p.Name),
+		Limits: decodeLimits(sqlcgen.Limit{
+			EmailChannelCount:            p.EmailChannelCount,
+			TelegramChannelCount:         p.TelegramChannelCount,
+			ActiveReminderCount:          p.ActiveReminderCount,
+			MonthlySentReminderCount:     p.MonthlySentReminderCount,
+			ReminderEveryPerDayCount:     p.ReminderEveryPerDayCount,
+			SentReminderQuotaWindow:      p.SentReminderQuotaWindow,
+			PausedRemindersCountAsActive: p.PausedRemindersCountAsActive,
+		}),
+	}
+}
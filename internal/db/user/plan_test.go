@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type testPlanSuite struct {
+	suite.Suite
+	pool                  *pgxpool.Pool
+	userRepository        *PgxUserRepository
+	planRepository        *PgxPlanRepository
+	limitsAuditRepository *PgxLimitsAuditRepository
+}
+
+func (suite *testPlanSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.planRepository = NewPgxPlanRepository(suite.pool)
+	suite.limitsAuditRepository = NewPgxLimitsAuditRepository(suite.pool)
+}
+
+func (suite *testPlanSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testPlanSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxPlanRepositories(t *testing.T) {
+	suite.Run(t, new(testPlanSuite))
+}
+
+func (s *testPlanSuite) TestGetAndList() {
+	plans, err := s.planRepository.List(context.Background())
+	s.Require().Nil(err)
+	names := make([]user.PlanName, 0, len(plans))
+	for _, plan := range plans {
+		names = append(names, plan.Name)
+	}
+	s.Equal([]user.PlanName{user.PlanFree, user.PlanPro, user.PlanTeam}, names)
+
+	plan, err := s.planRepository.Get(context.Background(), user.PlanFree)
+	s.Nil(err)
+	s.Equal(user.Limits{
+		EmailChannelCount:        c.NewOptional(uint32(1), true),
+		TelegramChannelCount:     c.NewOptional(uint32(1), true),
+		ActiveReminderCount:      c.NewOptional(uint32(10), true),
+		MonthlySentReminderCount: c.NewOptional(uint32(100), true),
+		ReminderEveryPerDayCount: c.NewOptional(1.0, true),
+		SentReminderQuotaWindow:  user.QuotaWindowCalendarMonth,
+	}, plan.Limits)
+
+	_, err = s.planRepository.Get(context.Background(), user.PlanName("unknown"))
+	s.ErrorIs(err, user.ErrPlanDoesNotExist)
+}
+
+func (s *testPlanSuite) TestAuditCreateAndList() {
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(EMAIL), true),
+		PasswordHash: c.NewOptional(user.PasswordHash(PASSWORD_HASH), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+	})
+	s.Require().Nil(err)
+
+	first, err := s.limitsAuditRepository.Create(context.Background(), user.CreateLimitsAuditEntryInput{
+		UserID: u.ID,
+		Actor:  "admin",
+		Action: user.LimitsAuditActionAssignPlan,
+		Plan:   c.NewOptional(user.PlanPro, true),
+		Before: user.Limits{EmailChannelCount: c.NewOptional(uint32(1), true)},
+		After: user.Limits{
+			EmailChannelCount:        c.NewOptional(uint32(5), true),
+			ReminderEveryPerDayCount: c.NewOptional(24.0, true),
+		},
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+	s.Equal(u.ID, first.UserID)
+	s.Equal(c.NewOptional(user.PlanPro, true), first.Plan)
+	s.Equal(c.NewOptional(uint32(5), true), first.After.EmailChannelCount)
+	s.False(first.After.TelegramChannelCount.IsPresent)
+
+	second, err := s.limitsAuditRepository.Create(context.Background(), user.CreateLimitsAuditEntryInput{
+		UserID:    u.ID,
+		Actor:     "admin",
+		Action:    user.LimitsAuditActionOverrideLimits,
+		Before:    first.After,
+		After:     user.Limits{},
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	entries, err := s.limitsAuditRepository.List(context.Background(), u.ID)
+	s.Nil(err)
+	s.Equal([]user.LimitsAuditEntry{second, first}, entries)
+}
@@ -5,33 +5,50 @@ import (
 	"errors"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
 	"remindme/internal/db/sqlcgen"
 
 	"github.com/jackc/pgx/v4"
 )
 
 type PgxSessionRepository struct {
-	queries *sqlcgen.Queries
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
 }
 
-func NewPgxSessionRepository(db sqlcgen.DBTX) *PgxSessionRepository {
+func NewPgxSessionRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxSessionRepository {
 	if db == nil {
 		panic(e.NewNilArgumentError("db"))
 	}
-	return &PgxSessionRepository{queries: sqlcgen.New(db)}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxSessionRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
 }
 
 func (r *PgxSessionRepository) Create(ctx context.Context, input user.CreateSessionInput) error {
 	_, err := r.queries.CreateSession(ctx, sqlcgen.CreateSessionParams{
-		Token:     string(input.Token),
-		UserID:    int64(input.UserID),
-		CreatedAt: input.CreatedAt,
+		Token:      r.tokenHasher.Hash(string(input.Token)),
+		UserID:     int64(input.UserID),
+		CreatedAt:  input.CreatedAt,
+		LastSeenAt: input.CreatedAt,
+		UserAgent:  input.UserAgent,
+		Ip:         input.IP,
 	})
 	return err
 }
 
-func (r *PgxSessionRepository) GetUserByToken(ctx context.Context, token user.SessionToken) (u user.User, err error) {
-	dbuser, err := r.queries.GetUserBySessionToken(ctx, string(token))
+func (r *PgxSessionRepository) GetUserByToken(
+	ctx context.Context,
+	input user.GetUserBySessionTokenInput,
+) (u user.User, err error) {
+	dbuser, err := r.queries.GetUserBySessionToken(ctx, sqlcgen.GetUserBySessionTokenParams{
+		Token:        r.tokenHasher.Hash(string(input.Token)),
+		IdleSince:    input.Expiry.IdleSince(input.At),
+		CreatedSince: input.Expiry.CreatedSince(input.At),
+		LastSeenAt:   input.At,
+		SeenBefore:   user.SeenBefore(input.At),
+	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return u, user.ErrUserDoesNotExist
 	}
@@ -49,10 +66,73 @@ func (r *PgxSessionRepository) GetUserByToken(ctx context.Context, token user.Se
 	return u, nil
 }
 
+func (r *PgxSessionRepository) List(ctx context.Context, input user.ListSessionsInput) ([]user.Session, error) {
+	dbsessions, err := r.queries.ListUserSessions(ctx, sqlcgen.ListUserSessionsParams{
+		CurrentToken: r.tokenHasher.Hash(string(input.CurrentToken)),
+		UserID:       int64(input.UserID),
+		IdleSince:    input.Expiry.IdleSince(input.At),
+		CreatedSince: input.Expiry.CreatedSince(input.At),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]user.Session, 0, len(dbsessions))
+	for _, dbsession := range dbsessions {
+		sessions = append(sessions, user.Session{
+			ID:         user.SessionID(dbsession.ID),
+			UserID:     user.ID(dbsession.UserID),
+			CreatedAt:  dbsession.CreatedAt,
+			LastSeenAt: dbsession.LastSeenAt,
+			UserAgent:  dbsession.UserAgent,
+			IP:         dbsession.Ip,
+			IsCurrent:  dbsession.IsCurrent,
+		})
+	}
+	return sessions, nil
+}
+
 func (r *PgxSessionRepository) Delete(ctx context.Context, token user.SessionToken) (userID user.ID, err error) {
-	rawUserID, err := r.queries.DeleteSessionByToken(ctx, string(token))
+	rawUserID, err := r.queries.DeleteSessionByToken(ctx, r.tokenHasher.Hash(string(token)))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return userID, user.ErrSessionDoesNotExist
 	}
 	return user.ID(rawUserID), nil
 }
+
+func (r *PgxSessionRepository) DeleteByID(ctx context.Context, userID user.ID, id user.SessionID) error {
+	_, err := r.queries.DeleteUserSession(ctx, sqlcgen.DeleteUserSessionParams{
+		ID:     int64(id),
+		UserID: int64(userID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.ErrSessionDoesNotExist
+	}
+	return err
+}
+
+func (r *PgxSessionRepository) DeleteAll(ctx context.Context, input user.DeleteSessionsInput) error {
+	// Hashes are never empty, so an empty hash excepts no session.
+	exceptToken := ""
+	if input.ExceptToken.IsPresent {
+		exceptToken = r.tokenHasher.Hash(string(input.ExceptToken.Value))
+	}
+	return r.queries.DeleteUserSessions(ctx, sqlcgen.DeleteUserSessionsParams{
+		UserID:      int64(input.UserID),
+		ExceptToken: exceptToken,
+	})
+}
+
+func (r *PgxSessionRepository) DeleteExpired(
+	ctx context.Context,
+	input user.DeleteExpiredSessionsInput,
+) (uint, error) {
+	rows, err := r.queries.DeleteExpiredSessions(ctx, sqlcgen.DeleteExpiredSessionsParams{
+		IdleSince:    input.Expiry.IdleSince(input.At),
+		CreatedSince: input.Expiry.CreatedSince(input.At),
+		BatchSize:    int32(input.Limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(rows), nil
+}
@@ -7,15 +7,19 @@ import (
 	"remindme/internal/core/domain/user"
 	"remindme/internal/db"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/suite"
 )
 
 const (
-	SESSION_TOKEN = "test-session-token"
+	SESSION_TOKEN       = "test-session-token"
+	OTHER_SESSION_TOKEN = "test-other-session-token"
 )
 
+var SESSION_EXPIRY = user.SessionExpiry{Idle: time.Hour, Absolute: 24 * time.Hour}
+
 type testSessionSuite struct {
 	suite.Suite
 	pool              *pgxpool.Pool
@@ -25,8 +29,8 @@ type testSessionSuite struct {
 
 func (suite *testSessionSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
-	suite.userRepository = NewPgxRepository(suite.pool)
-	suite.sessionRepository = NewPgxSessionRepository(suite.pool)
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.sessionRepository = NewPgxSessionRepository(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testSessionSuite) TearDownSuite() {
@@ -50,9 +54,11 @@ func (s *testSessionSuite) TestCreate() {
 			UserID:    activeUser.ID,
 			Token:     user.SessionToken(SESSION_TOKEN),
 			CreatedAt: NOW,
+			UserAgent: "test-agent",
+			IP:        "127.0.0.1",
 		},
 	)
-	u, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN))
+	u, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW)
 	s.Nil(err)
 	s.True(ok)
 	s.Equal(activeUser.ID, u.ID)
@@ -74,16 +80,160 @@ func (s *testSessionSuite) TestDeleteSuccess() {
 	userID, err := s.sessionRepository.Delete(context.Background(), user.SessionToken(SESSION_TOKEN))
 	s.Nil(err)
 	s.Equal(activeUser.ID, userID)
-	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN))
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW)
+	s.False(ok)
+}
+
+func (s *testSessionSuite) TestIdleExpiry() {
+	activeUser := s.createActiveUser()
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(50*time.Minute))
+	s.True(ok)
+	_, ok = s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(100*time.Minute))
+	s.True(ok, "session use must postpone idle expiry")
+	_, ok = s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(161*time.Minute))
+	s.False(ok)
+}
+
+func (s *testSessionSuite) TestAbsoluteExpiry() {
+	activeUser := s.createActiveUser()
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+
+	at := NOW
+	for at.Add(50 * time.Minute).Before(NOW.Add(SESSION_EXPIRY.Absolute)) {
+		at = at.Add(50 * time.Minute)
+		_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), at)
+		s.True(ok)
+	}
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(SESSION_EXPIRY.Absolute))
+	s.False(ok)
+}
+
+func (s *testSessionSuite) TestLastSeenUpdatedOncePerPrecision() {
+	activeUser := s.createActiveUser()
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(30*time.Second))
+	s.True(ok)
+	s.Equal(NOW, s.listSessions(activeUser.ID)[0].LastSeenAt)
+
+	_, ok = s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(user.LAST_SEEN_PRECISION))
+	s.True(ok)
+	s.Equal(NOW.Add(user.LAST_SEEN_PRECISION), s.listSessions(activeUser.ID)[0].LastSeenAt)
+}
+
+func (s *testSessionSuite) TestDeleteExpired() {
+	activeUser := s.createActiveUser()
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+	s.createSession(activeUser.ID, OTHER_SESSION_TOKEN)
+	_, ok := s.getUserByToken(user.SessionToken(OTHER_SESSION_TOKEN), NOW.Add(30*time.Minute))
+	s.True(ok)
+
+	deleted, err := s.sessionRepository.DeleteExpired(context.Background(), user.DeleteExpiredSessionsInput{
+		At:     NOW.Add(SESSION_EXPIRY.Idle),
+		Expiry: SESSION_EXPIRY,
+		Limit:  10,
+	})
+
+	s.Nil(err)
+	s.Equal(uint(1), deleted)
+	_, ok = s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW)
+	s.False(ok)
+	_, ok = s.getUserByToken(user.SessionToken(OTHER_SESSION_TOKEN), NOW.Add(SESSION_EXPIRY.Idle))
+	s.True(ok)
+}
+
+func (s *testSessionSuite) TestList() {
+	activeUser := s.createActiveUser()
+	otherUser := s.createUser("other@test.test")
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+	s.createSession(activeUser.ID, OTHER_SESSION_TOKEN)
+	s.createSession(otherUser.ID, "test-another-user-session-token")
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW.Add(time.Minute))
+	s.True(ok)
+
+	sessions, err := s.sessionRepository.List(context.Background(), user.ListSessionsInput{
+		UserID:       activeUser.ID,
+		CurrentToken: user.SessionToken(SESSION_TOKEN),
+		At:           NOW.Add(time.Minute),
+		Expiry:       SESSION_EXPIRY,
+	})
+	s.Nil(err)
+	s.Require().Len(sessions, 2)
+	s.Equal(activeUser.ID, sessions[0].UserID)
+	s.Equal(NOW.Add(time.Minute), sessions[0].LastSeenAt)
+	s.Equal(NOW, sessions[0].CreatedAt)
+	s.Equal("test-agent", sessions[0].UserAgent)
+	s.Equal("127.0.0.1", sessions[0].IP)
+	s.True(sessions[0].IsCurrent)
+	s.Equal(NOW, sessions[1].LastSeenAt)
+	s.False(sessions[1].IsCurrent)
+
+	sessions, err = s.sessionRepository.List(context.Background(), user.ListSessionsInput{
+		UserID:       activeUser.ID,
+		CurrentToken: user.SessionToken(SESSION_TOKEN),
+		At:           NOW.Add(SESSION_EXPIRY.Idle + 30*time.Second),
+		Expiry:       SESSION_EXPIRY,
+	})
+	s.Nil(err)
+	s.Require().Len(sessions, 1, "expired sessions must not be listed")
+	s.True(sessions[0].IsCurrent)
+}
+
+func (s *testSessionSuite) TestDeleteByID() {
+	activeUser := s.createActiveUser()
+	otherUser := s.createUser("other@test.test")
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+	s.createSession(activeUser.ID, OTHER_SESSION_TOKEN)
+	sessions := s.listSessions(activeUser.ID)
+	s.Require().Len(sessions, 2)
+
+	err := s.sessionRepository.DeleteByID(context.Background(), otherUser.ID, sessions[0].ID)
+	s.ErrorIs(err, user.ErrSessionDoesNotExist)
+	err = s.sessionRepository.DeleteByID(context.Background(), activeUser.ID, sessions[0].ID)
+	s.Nil(err)
+	err = s.sessionRepository.DeleteByID(context.Background(), activeUser.ID, sessions[0].ID)
+	s.ErrorIs(err, user.ErrSessionDoesNotExist)
+
+	s.Equal([]user.Session{sessions[1]}, s.listSessions(activeUser.ID))
+}
+
+func (s *testSessionSuite) TestDeleteAll() {
+	activeUser := s.createActiveUser()
+	otherUser := s.createUser("other@test.test")
+	s.createSession(activeUser.ID, SESSION_TOKEN)
+	s.createSession(activeUser.ID, OTHER_SESSION_TOKEN)
+	s.createSession(otherUser.ID, "test-another-user-session-token")
+
+	err := s.sessionRepository.DeleteAll(context.Background(), user.DeleteSessionsInput{
+		UserID:      activeUser.ID,
+		ExceptToken: c.NewOptional(user.SessionToken(SESSION_TOKEN), true),
+	})
+	s.Nil(err)
+	_, ok := s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW)
+	s.True(ok)
+	_, ok = s.getUserByToken(user.SessionToken(OTHER_SESSION_TOKEN), NOW)
 	s.False(ok)
+
+	err = s.sessionRepository.DeleteAll(context.Background(), user.DeleteSessionsInput{UserID: activeUser.ID})
+	s.Nil(err)
+	_, ok = s.getUserByToken(user.SessionToken(SESSION_TOKEN), NOW)
+	s.False(ok)
+	s.Len(s.listSessions(otherUser.ID), 1)
 }
 
 func (s *testSessionSuite) createActiveUser() user.User {
+	s.T().Helper()
+	return s.createUser(EMAIL)
+}
+
+func (s *testSessionSuite) createUser(email string) user.User {
 	s.T().Helper()
 	u, err := s.userRepository.Create(
 		context.Background(),
 		user.CreateUserInput{
-			Email:        c.NewOptional(c.NewEmail(EMAIL), true),
+			Email:        c.NewOptional(c.NewEmail(email), true),
 			PasswordHash: c.NewOptional(user.PasswordHash(PASSWORD_HASH), true),
 			CreatedAt:    NOW,
 			ActivatedAt:  c.NewOptional(NOW, true),
@@ -95,8 +245,42 @@ func (s *testSessionSuite) createActiveUser() user.User {
 	return u
 }
 
-func (s *testSessionSuite) getUserByToken(token user.SessionToken) (u user.User, ok bool) {
-	u, err := s.sessionRepository.GetUserByToken(context.Background(), token)
+func (s *testSessionSuite) createSession(userID user.ID, token string) {
+	s.T().Helper()
+	err := s.sessionRepository.Create(
+		context.Background(),
+		user.CreateSessionInput{
+			UserID:    userID,
+			Token:     user.SessionToken(token),
+			CreatedAt: NOW,
+			UserAgent: "test-agent",
+			IP:        "127.0.0.1",
+		},
+	)
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+}
+
+func (s *testSessionSuite) listSessions(userID user.ID) []user.Session {
+	s.T().Helper()
+	sessions, err := s.sessionRepository.List(context.Background(), user.ListSessionsInput{
+		UserID: userID,
+		At:     NOW,
+		Expiry: SESSION_EXPIRY,
+	})
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+	return sessions
+}
+
+func (s *testSessionSuite) getUserByToken(token user.SessionToken, at time.Time) (u user.User, ok bool) {
+	u, err := s.sessionRepository.GetUserByToken(context.Background(), user.GetUserBySessionTokenInput{
+		Token:  token,
+		At:     at,
+		Expiry: SESSION_EXPIRY,
+	})
 	if errors.Is(err, user.ErrUserDoesNotExist) {
 		return u, false
 	}
@@ -0,0 +1,200 @@
+package user
+
+import (
+	"context"
+	"errors"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"remindme/internal/db/sqlcgen"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PgxTOTPRepository stores encrypted secrets and hashes of recovery codes.
+type PgxTOTPRepository struct {
+	queries     *sqlcgen.Queries
+	secretBox   *db.SecretBox
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxTOTPRepository(
+	db sqlcgen.DBTX,
+	secretBox *db.SecretBox,
+	tokenHasher *db.TokenHasher,
+) *PgxTOTPRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if secretBox == nil {
+		panic(e.NewNilArgumentError("secretBox"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxTOTPRepository{queries: sqlcgen.New(db), secretBox: secretBox, tokenHasher: tokenHasher}
+}
+
+func (r *PgxTOTPRepository) Get(ctx context.Context, userID user.ID) (totp user.TOTP, err error) {
+	dbTOTP, err := r.queries.GetTOTP(ctx, int64(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return totp, user.ErrTOTPNotEnrolled
+	}
+	if err != nil {
+		return totp, err
+	}
+	secret, err := r.secretBox.Open(dbTOTP.Secret)
+	if err != nil {
+		return totp, err
+	}
+	return user.TOTP{
+		UserID:       user.ID(dbTOTP.UserID),
+		Secret:       user.TOTPSecret(secret),
+		CreatedAt:    dbTOTP.CreatedAt,
+		ConfirmedAt:  c.NewOptional(dbTOTP.ConfirmedAt.Time, dbTOTP.ConfirmedAt.Valid),
+		LastUsedStep: dbTOTP.LastUsedStep,
+	}, nil
+}
+
+func (r *PgxTOTPRepository) Create(ctx context.Context, totp user.TOTP) error {
+	rows, err := r.queries.CreateTOTP(ctx, sqlcgen.CreateTOTPParams{
+		UserID:    int64(totp.UserID),
+		Secret:    r.secretBox.Seal(string(totp.Secret)),
+		CreatedAt: totp.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *PgxTOTPRepository) Confirm(ctx context.Context, input user.ConfirmTOTPInput) error {
+	codes := make([]string, 0, len(input.RecoveryCodes))
+	for _, code := range input.RecoveryCodes {
+		codes = append(codes, r.tokenHasher.Hash(string(code)))
+	}
+	rows, err := r.queries.ConfirmTOTP(ctx, sqlcgen.ConfirmTOTPParams{
+		ConfirmedAt: input.ConfirmedAt,
+		Step:        input.Step,
+		UserID:      int64(input.UserID),
+		Codes:       codes,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrTOTPNotEnrolled
+	}
+	return nil
+}
+
+func (r *PgxTOTPRepository) UseStep(ctx context.Context, userID user.ID, step int64) error {
+	rows, err := r.queries.UseTOTPStep(ctx, sqlcgen.UseTOTPStepParams{
+		UserID:       int64(userID),
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (r *PgxTOTPRepository) UseRecoveryCode(ctx context.Context, userID user.ID, code user.RecoveryCode) error {
+	rows, err := r.queries.DeleteRecoveryCode(ctx, sqlcgen.DeleteRecoveryCodeParams{
+		UserID: int64(userID),
+		Code:   r.tokenHasher.Hash(string(code)),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (r *PgxTOTPRepository) Delete(ctx context.Context, userID user.ID) error {
+	return r.queries.DeleteTOTP(ctx, int64(userID))
+}
+
+// PgxTwoFactorChallengeRepository stores hashes of challenge tokens, like other tokens.
+type PgxTwoFactorChallengeRepository struct {
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
+}
+
+func NewPgxTwoFactorChallengeRepository(
+	db sqlcgen.DBTX,
+	tokenHasher *db.TokenHasher,
+) *PgxTwoFactorChallengeRepository {
+	if db == nil {
+		panic(e.NewNilArgumentError("db"))
+	}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxTwoFactorChallengeRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
+}
+
+func (r *PgxTwoFactorChallengeRepository) Create(ctx context.Context, challenge user.TwoFactorChallenge) error {
+	return r.queries.CreateTwoFactorChallenge(ctx, sqlcgen.CreateTwoFactorChallengeParams{
+		Token:     r.tokenHasher.Hash(string(challenge.Token)),
+		UserID:    int64(challenge.UserID),
+		CreatedAt: challenge.CreatedAt,
+	})
+}
+
+func (r *PgxTwoFactorChallengeRepository) Get(
+	ctx context.Context,
+	token user.TwoFactorChallengeToken,
+	createdSince time.Time,
+) (challenge user.TwoFactorChallenge, err error) {
+	dbChallenge, err := r.queries.GetTwoFactorChallenge(ctx, sqlcgen.GetTwoFactorChallengeParams{
+		Token:     r.tokenHasher.Hash(string(token)),
+		CreatedAt: createdSince,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return challenge, user.ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return challenge, err
+	}
+	return user.TwoFactorChallenge{
+		Token:     token,
+		UserID:    user.ID(dbChallenge.UserID),
+		CreatedAt: dbChallenge.CreatedAt,
+	}, nil
+}
+
+func (r *PgxTwoFactorChallengeRepository) Pop(
+	ctx context.Context,
+	token user.TwoFactorChallengeToken,
+	createdSince time.Time,
+) (challenge user.TwoFactorChallenge, err error) {
+	hashedToken := r.tokenHasher.Hash(string(token))
+	dbChallenges, err := r.queries.DeleteTwoFactorChallenges(ctx, sqlcgen.DeleteTwoFactorChallengesParams{
+		Token:        hashedToken,
+		CreatedSince: createdSince,
+	})
+	if err != nil {
+		return challenge, err
+	}
+	for _, dbChallenge := range dbChallenges {
+		if dbChallenge.Token == hashedToken && dbChallenge.CreatedAt.After(createdSince) {
+			return user.TwoFactorChallenge{
+				Token:     token,
+				UserID:    user.ID(dbChallenge.UserID),
+				CreatedAt: dbChallenge.CreatedAt,
+			}, nil
+		}
+	}
+	return challenge, user.ErrInvalidTwoFactorChallenge
+}
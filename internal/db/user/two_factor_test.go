@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	TOTP_SECRET               = user.TOTPSecret("JBSWY3DPEHPK3PXP")
+	TWO_FACTOR_CHALLENGE      = user.TwoFactorChallengeToken("test-challenge")
+	TWO_FACTOR_RECOVERY_CODE1 = user.RecoveryCode("recovery-code-1")
+	TWO_FACTOR_RECOVERY_CODE2 = user.RecoveryCode("recovery-code-2")
+)
+
+type testTwoFactorSuite struct {
+	suite.Suite
+	pool                *pgxpool.Pool
+	userRepository      *PgxUserRepository
+	totpRepository      *PgxTOTPRepository
+	challengeRepository *PgxTwoFactorChallengeRepository
+}
+
+func (suite *testTwoFactorSuite) SetupSuite() {
+	suite.pool = db.CreateTestPool()
+	suite.userRepository = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
+	suite.totpRepository = NewPgxTOTPRepository(
+		suite.pool,
+		db.NewSecretBox("test-secret"),
+		db.NewTokenHasher("test-secret"),
+	)
+	suite.challengeRepository = NewPgxTwoFactorChallengeRepository(suite.pool, db.NewTokenHasher("test-secret"))
+}
+
+func (suite *testTwoFactorSuite) TearDownSuite() {
+	suite.pool.Close()
+}
+
+func (suite *testTwoFactorSuite) TearDownTest() {
+	db.TruncateTables(suite.pool)
+}
+
+func TestPgxTwoFactorRepositories(t *testing.T) {
+	suite.Run(t, new(testTwoFactorSuite))
+}
+
+func (s *testTwoFactorSuite) TestCreateAndConfirmTOTP() {
+	u := s.createUser()
+	_, err := s.totpRepository.Get(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrTOTPNotEnrolled)
+
+	s.Require().Nil(s.totpRepository.Create(context.Background(), user.TOTP{
+		UserID:    u.ID,
+		Secret:    "other-secret",
+		CreatedAt: NOW,
+	}))
+	// Not confirmed TOTP is replaced.
+	s.Require().Nil(s.totpRepository.Create(context.Background(), user.TOTP{
+		UserID:    u.ID,
+		Secret:    TOTP_SECRET,
+		CreatedAt: NOW,
+	}))
+	err = s.totpRepository.Confirm(context.Background(), user.ConfirmTOTPInput{
+		UserID:        u.ID,
+		ConfirmedAt:   NOW,
+		Step:          10,
+		RecoveryCodes: []user.RecoveryCode{TWO_FACTOR_RECOVERY_CODE1, TWO_FACTOR_RECOVERY_CODE2},
+	})
+	s.Require().Nil(err)
+
+	totp, err := s.totpRepository.Get(context.Background(), u.ID)
+	s.Nil(err)
+	s.Equal(user.TOTP{
+		UserID:       u.ID,
+		Secret:       TOTP_SECRET,
+		CreatedAt:    NOW,
+		ConfirmedAt:  c.NewOptional(NOW, true),
+		LastUsedStep: 10,
+	}, totp)
+
+	err = s.totpRepository.Create(context.Background(), user.TOTP{UserID: u.ID, Secret: TOTP_SECRET, CreatedAt: NOW})
+	s.ErrorIs(err, user.ErrTOTPAlreadyEnabled)
+	err = s.totpRepository.Confirm(context.Background(), user.ConfirmTOTPInput{UserID: u.ID, ConfirmedAt: NOW})
+	s.ErrorIs(err, user.ErrTOTPNotEnrolled)
+}
+
+func (s *testTwoFactorSuite) TestSecretIsEncrypted() {
+	u := s.createUser()
+	s.Require().Nil(s.totpRepository.Create(context.Background(), user.TOTP{
+		UserID:    u.ID,
+		Secret:    TOTP_SECRET,
+		CreatedAt: NOW,
+	}))
+
+	var secret string
+	err := s.pool.QueryRow(context.Background(), "SELECT secret FROM totp WHERE user_id = $1", u.ID).Scan(&secret)
+	s.Require().Nil(err)
+	s.NotContains(secret, string(TOTP_SECRET))
+}
+
+func (s *testTwoFactorSuite) TestUseStep() {
+	u := s.createConfirmedTOTP()
+
+	s.ErrorIs(s.totpRepository.UseStep(context.Background(), u.ID, 10), user.ErrInvalidTwoFactorCode)
+	s.Nil(s.totpRepository.UseStep(context.Background(), u.ID, 11))
+	s.ErrorIs(s.totpRepository.UseStep(context.Background(), u.ID, 11), user.ErrInvalidTwoFactorCode)
+}
+
+func (s *testTwoFactorSuite) TestUseRecoveryCode() {
+	u := s.createConfirmedTOTP()
+
+	s.Nil(s.totpRepository.UseRecoveryCode(context.Background(), u.ID, TWO_FACTOR_RECOVERY_CODE1))
+	s.ErrorIs(
+		s.totpRepository.UseRecoveryCode(context.Background(), u.ID, TWO_FACTOR_RECOVERY_CODE1),
+		user.ErrInvalidTwoFactorCode,
+	)
+	s.ErrorIs(
+		s.totpRepository.UseRecoveryCode(context.Background(), u.ID, "other-code"),
+		user.ErrInvalidTwoFactorCode,
+	)
+}
+
+func (s *testTwoFactorSuite) TestDeleteTOTP() {
+	u := s.createConfirmedTOTP()
+
+	s.Require().Nil(s.totpRepository.Delete(context.Background(), u.ID))
+
+	_, err := s.totpRepository.Get(context.Background(), u.ID)
+	s.ErrorIs(err, user.ErrTOTPNotEnrolled)
+	s.ErrorIs(
+		s.totpRepository.UseRecoveryCode(context.Background(), u.ID, TWO_FACTOR_RECOVERY_CODE2),
+		user.ErrInvalidTwoFactorCode,
+	)
+}
+
+func (s *testTwoFactorSuite) TestGetAndPopChallenge() {
+	u := s.createUser()
+	challenge := user.TwoFactorChallenge{Token: TWO_FACTOR_CHALLENGE, UserID: u.ID, CreatedAt: NOW}
+	s.Require().Nil(s.challengeRepository.Create(context.Background(), challenge))
+
+	actual, err := s.challengeRepository.Get(context.Background(), TWO_FACTOR_CHALLENGE, NOW.Add(-time.Minute))
+	s.Nil(err)
+	s.Equal(challenge, actual)
+	_, err = s.challengeRepository.Get(context.Background(), TWO_FACTOR_CHALLENGE, NOW)
+	s.ErrorIs(err, user.ErrInvalidTwoFactorChallenge)
+
+	popped, err := s.challengeRepository.Pop(context.Background(), TWO_FACTOR_CHALLENGE, NOW.Add(-time.Minute))
+	s.Nil(err)
+	s.Equal(challenge, popped)
+	_, err = s.challengeRepository.Pop(context.Background(), TWO_FACTOR_CHALLENGE, NOW.Add(-time.Minute))
+	s.ErrorIs(err, user.ErrInvalidTwoFactorChallenge)
+}
+
+func (s *testTwoFactorSuite) createConfirmedTOTP() user.User {
+	s.T().Helper()
+	u := s.createUser()
+	s.Require().Nil(s.totpRepository.Create(context.Background(), user.TOTP{
+		UserID:    u.ID,
+		Secret:    TOTP_SECRET,
+		CreatedAt: NOW,
+	}))
+	err := s.totpRepository.Confirm(context.Background(), user.ConfirmTOTPInput{
+		UserID:        u.ID,
+		ConfirmedAt:   NOW,
+		Step:          10,
+		RecoveryCodes: []user.RecoveryCode{TWO_FACTOR_RECOVERY_CODE1, TWO_FACTOR_RECOVERY_CODE2},
+	})
+	s.Require().Nil(err)
+	return u
+}
+
+func (s *testTwoFactorSuite) createUser() user.User {
+	s.T().Helper()
+	u, err := s.userRepository.Create(context.Background(), user.CreateUserInput{
+		Email:        c.NewOptional(c.NewEmail(EMAIL), true),
+		PasswordHash: c.NewOptional(user.PasswordHash("test-password-hash"), true),
+		CreatedAt:    NOW,
+		ActivatedAt:  c.NewOptional(NOW, true),
+		TimeZone:     time.UTC,
+	})
+	s.Require().Nil(err)
+	return u
+}
@@ -7,6 +7,7 @@ import (
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/user"
+	"remindme/internal/db"
 	"remindme/internal/db/sqlcgen"
 	"time"
 
@@ -18,35 +19,35 @@ const PG_UNIQUE_CONSTRAINT_ERR_CODE = "23505"
 const EMAIL_CONSTRAINT_NAME = "user_email_idx"
 
 type PgxUserRepository struct {
-	queries *sqlcgen.Queries
+	queries     *sqlcgen.Queries
+	tokenHasher *db.TokenHasher
 }
 
-func NewPgxRepository(db sqlcgen.DBTX) *PgxUserRepository {
+func NewPgxRepository(db sqlcgen.DBTX, tokenHasher *db.TokenHasher) *PgxUserRepository {
 	if db == nil {
 		panic(e.NewNilArgumentError("db"))
 	}
-	return &PgxUserRepository{queries: sqlcgen.New(db)}
+	if tokenHasher == nil {
+		panic(e.NewNilArgumentError("tokenHasher"))
+	}
+	return &PgxUserRepository{queries: sqlcgen.New(db), tokenHasher: tokenHasher}
 }
 
 func (r *PgxUserRepository) Create(ctx context.Context, input user.CreateUserInput) (u user.User, err error) {
 	dbuser, err := r.queries.CreateUser(ctx, sqlcgen.CreateUserParams{
-		Email:           encodeEmail(input.Email),
-		Identity:        encodeIdentity(input.Identity),
-		PasswordHash:    encodePasswordHash(input.PasswordHash),
-		CreatedAt:       input.CreatedAt,
-		ActivatedAt:     encodeOptionalTime(input.ActivatedAt),
-		ActivationToken: encodeActivationToken(input.ActivationToken),
-		Timezone:        input.TimeZone.String(),
+		Email:                    encodeEmail(input.Email),
+		Identity:                 encodeIdentity(input.Identity),
+		PasswordHash:             encodePasswordHash(input.PasswordHash),
+		CreatedAt:                input.CreatedAt,
+		ActivatedAt:              encodeOptionalTime(input.ActivatedAt),
+		ActivationToken:          r.encodeActivationToken(input.ActivationToken),
+		ActivationTokenExpiresAt: encodeOptionalTime(input.ActivationTokenExpiresAt),
+		Timezone:                 input.TimeZone.String(),
 	})
 
-	var errEmailUniqueConstraint *pgconn.PgError
-	if errors.As(err, &errEmailUniqueConstraint) {
-		if errEmailUniqueConstraint.Code == PG_UNIQUE_CONSTRAINT_ERR_CODE &&
-			errEmailUniqueConstraint.ConstraintName == EMAIL_CONSTRAINT_NAME {
-			return u, user.ErrEmailAlreadyExists
-		}
+	if isEmailUniqueConstraintError(err) {
+		return u, user.ErrEmailAlreadyExists
 	}
-
 	if err != nil {
 		return u, err
 	}
@@ -54,6 +55,7 @@ func (r *PgxUserRepository) Create(ctx context.Context, input user.CreateUserInp
 	if err != nil {
 		return u, err
 	}
+	u.ActivationToken = input.ActivationToken
 	err = u.Validate()
 	if err != nil {
 		return u, err
@@ -106,7 +108,7 @@ func (r *PgxUserRepository) Activate(
 ) (u user.User, err error) {
 	dbuser, err := r.queries.ActivateUser(
 		ctx,
-		sqlcgen.ActivateUserParams{ActivationToken: string(token), ActivatedAt: at},
+		sqlcgen.ActivateUserParams{ActivationToken: r.tokenHasher.Hash(string(token)), ActivatedAt: at},
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return u, user.ErrInvalidActivationToken
@@ -121,6 +123,58 @@ func (r *PgxUserRepository) Activate(
 	return domainUser, nil
 }
 
+func (r *PgxUserRepository) SetActivationToken(
+	ctx context.Context,
+	input user.SetActivationTokenInput,
+) (u user.User, err error) {
+	dbuser, err := r.queries.SetUserActivationToken(ctx, sqlcgen.SetUserActivationTokenParams{
+		ActivationToken:          r.tokenHasher.Hash(string(input.Token)),
+		ActivationTokenExpiresAt: input.ExpiresAt,
+		ID:                       int64(input.ID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return u, user.ErrUserDoesNotExist
+	}
+	if err != nil {
+		return u, err
+	}
+	u, err = decodeUser(dbuser)
+	if err != nil {
+		return u, err
+	}
+	u.ActivationToken = c.NewOptional(input.Token, true)
+	return u, nil
+}
+
+func (r *PgxUserRepository) Claim(ctx context.Context, input user.ClaimUserInput) (u user.User, err error) {
+	dbuser, err := r.queries.ClaimUser(ctx, sqlcgen.ClaimUserParams{
+		ID:                       int64(input.ID),
+		Email:                    string(input.Email),
+		PasswordHash:             string(input.PasswordHash),
+		ActivationToken:          r.tokenHasher.Hash(string(input.ActivationToken)),
+		ActivationTokenExpiresAt: input.ActivationTokenExpiresAt,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return u, user.ErrUserIsNotAnonymous
+	}
+	if isEmailUniqueConstraintError(err) {
+		return u, user.ErrEmailAlreadyExists
+	}
+	if err != nil {
+		return u, err
+	}
+	u, err = decodeUser(dbuser)
+	if err != nil {
+		return u, err
+	}
+	u.ActivationToken = c.NewOptional(input.ActivationToken, true)
+	err = u.Validate()
+	if err != nil {
+		return u, err
+	}
+	return u, nil
+}
+
 func (r *PgxUserRepository) SetPassword(
 	ctx context.Context,
 	id user.ID,
@@ -146,14 +200,54 @@ func (r *PgxUserRepository) Update(
 			ID:               int64(input.ID),
 			DoTimezoneUpdate: input.DoTimeZoneUpdate,
 			Timezone:         input.TimeZone.String(),
+			DoEmailUpdate:    input.DoEmailUpdate,
+			Email:            encodeEmail(c.NewOptional(input.Email, input.DoEmailUpdate)),
 		},
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return u, user.ErrUserDoesNotExist
 	}
+	if isEmailUniqueConstraintError(err) {
+		return u, user.ErrEmailAlreadyExists
+	}
+	if err != nil {
+		return u, err
+	}
 	return decodeUser(dbUser)
 }
 
+func (r *PgxUserRepository) Delete(ctx context.Context, id user.ID) error {
+	rows, err := r.queries.DeleteUser(ctx, int64(id))
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return user.ErrUserDoesNotExist
+	}
+	return nil
+}
+
+func (r *PgxUserRepository) DeleteInactive(
+	ctx context.Context,
+	input user.DeleteInactiveUsersInput,
+) (uint, error) {
+	rows, err := r.queries.DeleteInactiveUsers(ctx, sqlcgen.DeleteInactiveUsersParams{
+		CreatedBefore: input.CreatedBefore,
+		BatchSize:     int32(input.Limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(rows), nil
+}
+
+func isEmailUniqueConstraintError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == PG_UNIQUE_CONSTRAINT_ERR_CODE &&
+		pgErr.ConstraintName == EMAIL_CONSTRAINT_NAME
+}
+
 func encodeEmail(email c.Optional[c.Email]) sql.NullString {
 	return sql.NullString{String: string(email.Value), Valid: email.IsPresent}
 }
@@ -166,8 +260,11 @@ func encodePasswordHash(ph c.Optional[user.PasswordHash]) sql.NullString {
 	return sql.NullString{String: string(ph.Value), Valid: ph.IsPresent}
 }
 
-func encodeActivationToken(token c.Optional[user.ActivationToken]) sql.NullString {
-	return sql.NullString{String: string(token.Value), Valid: token.IsPresent}
+func (r *PgxUserRepository) encodeActivationToken(token c.Optional[user.ActivationToken]) sql.NullString {
+	if !token.IsPresent {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: r.tokenHasher.Hash(string(token.Value)), Valid: true}
 }
 
 func encodeOptionalTime(at c.Optional[time.Time]) sql.NullTime {
@@ -179,6 +276,7 @@ func decodeUser(u sqlcgen.User) (domainUser user.User, err error) {
 	if err != nil {
 		return domainUser, err
 	}
+	// Only the activation token hash is stored, so the decoded token is empty.
 	return user.User{
 		ID:              user.ID(u.ID),
 		Email:           c.NewOptional(c.Email(u.Email.String), u.Email.Valid),
@@ -186,7 +284,11 @@ func decodeUser(u sqlcgen.User) (domainUser user.User, err error) {
 		PasswordHash:    c.NewOptional(user.PasswordHash(u.PasswordHash.String), u.PasswordHash.Valid),
 		CreatedAt:       u.CreatedAt,
 		ActivatedAt:     c.NewOptional(u.ActivatedAt.Time, u.ActivatedAt.Valid),
-		ActivationToken: c.NewOptional(user.ActivationToken(u.ActivationToken.String), u.ActivationToken.Valid),
-		TimeZone:        tz,
+		ActivationToken: c.NewOptional(user.ActivationToken(""), u.ActivationToken.Valid),
+		ActivationTokenExpiresAt: c.NewOptional(
+			u.ActivationTokenExpiresAt.Time,
+			u.ActivationTokenExpiresAt.Valid,
+		),
+		TimeZone: tz,
 	}, nil
 }
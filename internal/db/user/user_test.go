@@ -29,7 +29,7 @@ type testSuite struct {
 
 func (suite *testSuite) SetupSuite() {
 	suite.pool = db.CreateTestPool()
-	suite.repo = NewPgxRepository(suite.pool)
+	suite.repo = NewPgxRepository(suite.pool, db.NewTokenHasher("test-secret"))
 }
 
 func (suite *testSuite) TearDownSuite() {
@@ -151,6 +151,72 @@ func (s *testSuite) TestActivationFailsIfUserAlreadyActivated() {
 	s.True(errors.Is(err, user.ErrInvalidActivationToken))
 }
 
+func (s *testSuite) TestActivationFailsIfTokenIsExpired() {
+	inactiveUser := s.createInactiveUser()
+	_, err := s.repo.SetActivationToken(context.Background(), user.SetActivationTokenInput{
+		ID:        inactiveUser.ID,
+		Token:     user.ActivationToken(ACTIVATION_TOKEN),
+		ExpiresAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.repo.Activate(context.Background(), user.ActivationToken(ACTIVATION_TOKEN), NOW)
+	s.ErrorIs(err, user.ErrInvalidActivationToken)
+	u := s.getUserByID(inactiveUser.ID)
+	s.False(u.IsActive())
+}
+
+func (s *testSuite) TestSetActivationToken() {
+	inactiveUser := s.createInactiveUser()
+	newToken := user.ActivationToken("new-activation-token")
+
+	u, err := s.repo.SetActivationToken(context.Background(), user.SetActivationTokenInput{
+		ID:        inactiveUser.ID,
+		Token:     newToken,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	s.Equal(c.NewOptional(newToken, true), u.ActivationToken)
+	s.Equal(c.NewOptional(NOW.Add(time.Hour), true), u.ActivationTokenExpiresAt)
+
+	_, err = s.repo.Activate(context.Background(), user.ActivationToken(ACTIVATION_TOKEN), NOW)
+	s.ErrorIs(err, user.ErrInvalidActivationToken)
+	activatedUser, err := s.repo.Activate(context.Background(), newToken, NOW)
+	s.Require().Nil(err)
+	s.True(activatedUser.IsActive())
+	s.False(activatedUser.ActivationTokenExpiresAt.IsPresent)
+
+	_, err = s.repo.SetActivationToken(context.Background(), user.SetActivationTokenInput{
+		ID:        inactiveUser.ID,
+		Token:     newToken,
+		ExpiresAt: NOW.Add(time.Hour),
+	})
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+}
+
+func (s *testSuite) TestDeleteInactive() {
+	inactiveUser := s.createInactiveUser()
+	anonymousUser := s.createAnonymousUser()
+
+	deleted, err := s.repo.DeleteInactive(context.Background(), user.DeleteInactiveUsersInput{
+		CreatedBefore: NOW,
+		Limit:         10,
+	})
+	s.Require().Nil(err)
+	s.Equal(uint(0), deleted)
+
+	deleted, err = s.repo.DeleteInactive(context.Background(), user.DeleteInactiveUsersInput{
+		CreatedBefore: NOW.Add(time.Second),
+		Limit:         10,
+	})
+	s.Require().Nil(err)
+	s.Equal(uint(1), deleted)
+
+	_, err = s.repo.GetByID(context.Background(), inactiveUser.ID)
+	s.ErrorIs(err, user.ErrUserDoesNotExist)
+	s.Equal(anonymousUser.ID, s.getUserByID(anonymousUser.ID).ID)
+}
+
 func (s *testSuite) TestSetPassword() {
 	u := s.createInactiveUser()
 	s.True(u.PasswordHash.IsPresent)
@@ -192,9 +258,106 @@ func (s *testSuite) TestSetPasswordReturnsErrorIfUserDoesNotExist() {
 	s.True(errors.Is(err, user.ErrUserDoesNotExist))
 
 	userAfterUpdate := s.getUserByID(u.ID)
+	// Stored activation token is hashed and is not returned on reads.
+	u.ActivationToken.Value = ""
 	s.Equal(u, userAfterUpdate)
 }
 
+func (s *testSuite) TestClaimAndActivate() {
+	anonymousUser := s.createAnonymousUser()
+
+	claimedUser, err := s.repo.Claim(context.Background(), user.ClaimUserInput{
+		ID:                       anonymousUser.ID,
+		Email:                    c.NewEmail(EMAIL),
+		PasswordHash:             user.PasswordHash(PASSWORD_HASH),
+		ActivationToken:          user.ActivationToken(ACTIVATION_TOKEN),
+		ActivationTokenExpiresAt: NOW.Add(time.Hour),
+	})
+	s.Require().Nil(err)
+	s.Equal(anonymousUser.ID, claimedUser.ID)
+	s.Equal(anonymousUser.Identity, claimedUser.Identity)
+	s.Equal(c.NewOptional(c.NewEmail(EMAIL), true), claimedUser.Email)
+	s.Equal(c.NewOptional(user.PasswordHash(PASSWORD_HASH), true), claimedUser.PasswordHash)
+	s.False(claimedUser.IsActive())
+	s.True(claimedUser.CanBeClaimed())
+
+	activatedUser, err := s.repo.Activate(context.Background(), user.ActivationToken(ACTIVATION_TOKEN), NOW)
+	s.Require().Nil(err)
+	s.Equal(anonymousUser.ID, activatedUser.ID)
+	s.True(activatedUser.IsActive())
+	s.False(activatedUser.CanBeClaimed())
+
+	_, err = s.repo.Claim(context.Background(), user.ClaimUserInput{
+		ID:              anonymousUser.ID,
+		Email:           c.NewEmail("other@test.test"),
+		PasswordHash:    user.PasswordHash(PASSWORD_HASH),
+		ActivationToken: user.ActivationToken("other-activation-token"),
+	})
+	s.ErrorIs(err, user.ErrUserIsNotAnonymous)
+}
+
+func (s *testSuite) TestClaimFailsIfExternalIdentityIsLinked() {
+	anonymousUser := s.createAnonymousUser()
+	_, err := NewPgxExternalIdentityRepository(s.pool).Create(context.Background(), user.CreateExternalIdentityInput{
+		UserID:    anonymousUser.ID,
+		Provider:  "test",
+		Subject:   "test-subject",
+		CreatedAt: NOW,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.repo.Claim(context.Background(), user.ClaimUserInput{
+		ID:                       anonymousUser.ID,
+		Email:                    c.NewEmail(EMAIL),
+		PasswordHash:             user.PasswordHash(PASSWORD_HASH),
+		ActivationToken:          user.ActivationToken(ACTIVATION_TOKEN),
+		ActivationTokenExpiresAt: NOW.Add(time.Hour),
+	})
+
+	s.ErrorIs(err, user.ErrUserIsNotAnonymous)
+}
+
+func (s *testSuite) TestClaimFailsIfEmailAlreadyExists() {
+	s.createInactiveUser()
+	anonymousUser := s.createAnonymousUser()
+
+	_, err := s.repo.Claim(context.Background(), user.ClaimUserInput{
+		ID:              anonymousUser.ID,
+		Email:           c.NewEmail(EMAIL),
+		PasswordHash:    user.PasswordHash(PASSWORD_HASH),
+		ActivationToken: user.ActivationToken("other-activation-token"),
+	})
+	s.ErrorIs(err, user.ErrEmailAlreadyExists)
+}
+
+func (s *testSuite) TestClaimFailsIfUserIsNotAnonymous() {
+	u := s.createInactiveUser()
+
+	_, err := s.repo.Claim(context.Background(), user.ClaimUserInput{
+		ID:              u.ID,
+		Email:           c.NewEmail("other@test.test"),
+		PasswordHash:    user.PasswordHash(PASSWORD_HASH),
+		ActivationToken: user.ActivationToken("other-activation-token"),
+	})
+	s.ErrorIs(err, user.ErrUserIsNotAnonymous)
+}
+
+func (s *testSuite) createAnonymousUser() user.User {
+	s.T().Helper()
+	u, err := s.repo.Create(
+		context.Background(),
+		user.CreateUserInput{
+			Identity:    c.NewOptional(user.Identity("test-identity"), true),
+			CreatedAt:   NOW,
+			ActivatedAt: c.NewOptional(NOW, true),
+		},
+	)
+	if err != nil {
+		s.FailNowf("could not create user", "err: %v", err)
+	}
+	return u
+}
+
 func (s *testSuite) createInactiveUser() user.User {
 	s.T().Helper()
 	u, err := s.repo.Create(
@@ -0,0 +1,91 @@
+package assignplan
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	service "remindme/internal/core/services/assign_plan"
+	"remindme/internal/http/handlers/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	Plan string `json:"plan"`
+}
+
+type Result struct {
+	UserPlan response.UserPlan `json:"user_plan"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Plan, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rawUserID := chi.URLParam(r, "userID")
+	userID, err := strconv.ParseInt(rawUserID, 10, 64)
+	if err != nil {
+		response.RenderError(rw, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		service.Input{UserID: user.ID(userID), Plan: user.PlanName(input.Plan)},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrInvalidAdminToken):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrLimitsDoNotExist):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		case errors.Is(err, user.ErrPlanDoesNotExist):
+			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	rs := Result{}
+	rs.UserPlan.FromDomainType(result.UserPlan)
+	response.Render(rw, rs, http.StatusOK)
+}
@@ -0,0 +1,62 @@
+package getuserplan
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	service "remindme/internal/core/services/get_user_plan"
+	"remindme/internal/http/handlers/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	UserPlan response.UserPlan           `json:"user_plan"`
+	Audit    []response.LimitsAuditEntry `json:"audit"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rawUserID := chi.URLParam(r, "userID")
+	userID, err := strconv.ParseInt(rawUserID, 10, 64)
+	if err != nil {
+		response.RenderError(rw, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), service.Input{UserID: user.ID(userID)})
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrInvalidAdminToken):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrLimitsDoNotExist):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	rs := Result{Audit: make([]response.LimitsAuditEntry, len(result.Audit))}
+	rs.UserPlan.FromDomainType(result.UserPlan)
+	for i, entry := range result.Audit {
+		rs.Audit[i].FromDomainType(entry)
+	}
+	response.Render(rw, rs, http.StatusOK)
+}
@@ -0,0 +1,47 @@
+package listplans
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	service "remindme/internal/core/services/list_plans"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	Plans []response.Plan `json:"plans"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Run(r.Context(), service.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrInvalidAdminToken):
+			response.RenderUnauthorized(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	plans := make([]response.Plan, len(result.Plans))
+	for i, p := range result.Plans {
+		plans[i].FromDomainType(p)
+	}
+	response.Render(rw, Result{Plans: plans}, http.StatusOK)
+}
@@ -0,0 +1,119 @@
+package overridelimits
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	"remindme/internal/core/services/admin"
+	service "remindme/internal/core/services/override_limits"
+	"remindme/internal/http/handlers/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Input overrides the limits whose do_*_update flag is set, a null value removes the limit.
+type Input struct {
+	DoEmailChannelCountUpdate        bool     `json:"do_email_channel_count_update"`
+	EmailChannelCount                *uint32  `json:"email_channel_count"`
+	DoTelegramChannelCountUpdate     bool     `json:"do_telegram_channel_count_update"`
+	TelegramChannelCount             *uint32  `json:"telegram_channel_count"`
+	DoActiveReminderCountUpdate      bool     `json:"do_active_reminder_count_update"`
+	ActiveReminderCount              *uint32  `json:"active_reminder_count"`
+	DoMonthlySentReminderCountUpdate bool     `json:"do_monthly_sent_reminder_count_update"`
+	MonthlySentReminderCount         *uint32  `json:"monthly_sent_reminder_count"`
+	DoReminderEveryPerDayCountUpdate bool     `json:"do_reminder_every_per_day_count_update"`
+	ReminderEveryPerDayCount         *float64 `json:"reminder_every_per_day_count"`
+}
+
+type Result struct {
+	UserPlan response.UserPlan `json:"user_plan"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.ReminderEveryPerDayCount, validation.Min(0.0)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rawUserID := chi.URLParam(r, "userID")
+	userID, err := strconv.ParseInt(rawUserID, 10, 64)
+	if err != nil {
+		response.RenderError(rw, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		service.Input{
+			UserID:                           user.ID(userID),
+			DoEmailChannelCountUpdate:        input.DoEmailChannelCountUpdate,
+			EmailChannelCount:                pointerToOptional(input.EmailChannelCount),
+			DoTelegramChannelCountUpdate:     input.DoTelegramChannelCountUpdate,
+			TelegramChannelCount:             pointerToOptional(input.TelegramChannelCount),
+			DoActiveReminderCountUpdate:      input.DoActiveReminderCountUpdate,
+			ActiveReminderCount:              pointerToOptional(input.ActiveReminderCount),
+			DoMonthlySentReminderCountUpdate: input.DoMonthlySentReminderCountUpdate,
+			MonthlySentReminderCount:         pointerToOptional(input.MonthlySentReminderCount),
+			DoReminderEveryPerDayCountUpdate: input.DoReminderEveryPerDayCountUpdate,
+			ReminderEveryPerDayCount:         pointerToOptional(input.ReminderEveryPerDayCount),
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrInvalidAdminToken):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrLimitsDoNotExist):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	rs := Result{}
+	rs.UserPlan.FromDomainType(result.UserPlan)
+	response.Render(rw, rs, http.StatusOK)
+}
+
+func pointerToOptional[T any](p *T) c.Optional[T] {
+	if p == nil {
+		return c.NewOptional(*new(T), false)
+	}
+	return c.NewOptional(*p, true)
+}
@@ -2,15 +2,22 @@ package auth
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"net/netip"
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services/auth"
 	"strings"
+	"time"
 )
 
 const (
 	AUTH_TOKEN_PREFIX  = "Bearer "
 	AUTH_TOKEN_MAX_LEN = 1024
+	USER_AGENT_MAX_LEN = 512
+	IP_HEADER          = "X-Real-IP"
+	OIDC_STATE_COOKIE  = "oidc_state"
+	OIDC_STATE_MAX_LEN = 256
 )
 
 func ParseToken(r *http.Request) (token user.SessionToken, ok bool) {
@@ -38,3 +45,60 @@ func SetAuthTokenToContext(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// UserAgent returns the request user agent truncated to USER_AGENT_MAX_LEN bytes.
+func UserAgent(r *http.Request) string {
+	userAgent := r.UserAgent()
+	if len(userAgent) > USER_AGENT_MAX_LEN {
+		return userAgent[:USER_AGENT_MAX_LEN]
+	}
+	return userAgent
+}
+
+// ClientIP returns the IP set by the proxy in IP_HEADER or the remote address of the request.
+func ClientIP(r *http.Request) string {
+	if ip, err := netip.ParseAddr(r.Header.Get(IP_HEADER)); err == nil {
+		return ip.String()
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// SetOIDCStateCookie binds the OIDC state to the browser which started the login,
+// the callback is accepted only with the same state in the cookie.
+func SetOIDCStateCookie(rw http.ResponseWriter, state user.OIDCState, expiresAt time.Time) {
+	http.SetCookie(rw, &http.Cookie{
+		Name:     OIDC_STATE_COOKIE,
+		Value:    string(state),
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ParseOIDCStateCookie returns the state set by SetOIDCStateCookie.
+func ParseOIDCStateCookie(r *http.Request) (state user.OIDCState, ok bool) {
+	cookie, err := r.Cookie(OIDC_STATE_COOKIE)
+	if err != nil || cookie.Value == "" || len(cookie.Value) > OIDC_STATE_MAX_LEN {
+		return state, false
+	}
+	return user.OIDCState(cookie.Value), true
+}
+
+// ClearOIDCStateCookie removes the cookie, the state can be used only once anyway.
+func ClearOIDCStateCookie(rw http.ResponseWriter) {
+	http.SetCookie(rw, &http.Cookie{
+		Name:     OIDC_STATE_COOKIE,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
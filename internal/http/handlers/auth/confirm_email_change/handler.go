@@ -0,0 +1,75 @@
+package confirmemailchange
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	confirmemailchange "remindme/internal/core/services/confirm_email_change"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[confirmemailchange.Input, confirmemailchange.Result]
+}
+
+func New(
+	service services.Service[confirmemailchange.Input, confirmemailchange.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	Token string `json:"token"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Token, validation.Required, validation.Length(0, 128)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		confirmemailchange.Input{Token: user.EmailChangeToken(input.Token)},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidEmailChangeToken):
+			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, user.ErrEmailAlreadyExists):
+			response.RenderError(rw, "email already exists", http.StatusConflict)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := response.User{}
+	res.FromDomainUser(result.User)
+	response.Render(rw, res, http.StatusOK)
+}
@@ -11,6 +11,7 @@ import (
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services"
 	loginwithemail "remindme/internal/core/services/log_in_with_email"
+	"remindme/internal/http/handlers/auth"
 	"remindme/internal/http/handlers/response"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -35,8 +36,12 @@ type Input struct {
 	Password string `json:"password"`
 }
 
+// Result has challenge_token instead of token if two_factor_required is set,
+// it is exchanged for a session token with a two-factor code.
 type Result struct {
-	Token string `json:"token"`
+	Token             string `json:"token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
 }
 
 func (i *Input) FromJSON(r io.Reader) error {
@@ -64,7 +69,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	result, err := h.service.Run(
 		r.Context(),
-		loginwithemail.Input{Email: c.NewEmail(input.Email), Password: user.RawPassword(input.Password)},
+		loginwithemail.Input{
+			Email:     c.NewEmail(input.Email),
+			Password:  user.RawPassword(input.Password),
+			UserAgent: auth.UserAgent(r),
+			IP:        auth.ClientIP(r),
+		},
 	)
 	if errors.Is(err, ratelimiter.ErrRateLimitExceeded) {
 		response.RenderRateLimitExceeded(rw)
@@ -83,5 +93,13 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.Render(rw, Result{Token: string(result.Token)}, http.StatusOK)
+	response.Render(
+		rw,
+		Result{
+			Token:             string(result.Token),
+			TwoFactorRequired: result.ChallengeToken.IsPresent,
+			ChallengeToken:    string(result.ChallengeToken.Value),
+		},
+		http.StatusOK,
+	)
 }
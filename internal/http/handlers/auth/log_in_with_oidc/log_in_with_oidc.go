@@ -0,0 +1,137 @@
+package loginwithoidc
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	loginwithoidc "remindme/internal/core/services/log_in_with_oidc"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Handler completes the login with a provider, it completes linking the provider
+// to the logged in user when the service requires authentication.
+type Handler struct {
+	service services.Service[loginwithoidc.Input, loginwithoidc.Result]
+}
+
+func New(
+	service services.Service[loginwithoidc.Input, loginwithoidc.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	TimeZone string `json:"timezone"`
+}
+
+// Result has challenge_token instead of token if two_factor_required is set,
+// like the result of the login with email.
+type Result struct {
+	Token             string `json:"token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	IsNewUser         bool   `json:"is_new_user"`
+	IsLinked          bool   `json:"is_linked"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Code, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&i.State, validation.Required, validation.Length(1, 256)),
+		validation.Field(&i.TimeZone, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+	tz, err := time.LoadLocation(input.TimeZone)
+	if err != nil {
+		response.RenderError(rw, "invalid timezone", http.StatusBadRequest)
+		return
+	}
+	cookieState, ok := auth.ParseOIDCStateCookie(r)
+	if !ok {
+		response.RenderError(rw, "invalid or expired state", http.StatusBadRequest)
+		return
+	}
+	auth.ClearOIDCStateCookie(rw)
+
+	result, err := h.service.Run(
+		r.Context(),
+		loginwithoidc.Input{
+			Provider:    user.OIDCProviderName(chi.URLParam(r, "provider")),
+			Code:        user.OIDCAuthorizationCode(input.Code),
+			State:       user.OIDCState(input.State),
+			CookieState: cookieState,
+			UserAgent:   auth.UserAgent(r),
+			IP:          auth.ClientIP(r),
+			TimeZone:    tz,
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, user.ErrUnknownOIDCProvider):
+			response.RenderError(rw, "unknown provider", http.StatusNotFound)
+		case errors.Is(err, user.ErrInvalidOIDCState):
+			response.RenderError(rw, "invalid or expired state", http.StatusBadRequest)
+		case errors.Is(err, user.ErrOIDCAuthenticationFailed):
+			response.RenderError(rw, "authentication failed", http.StatusUnauthorized)
+		case errors.Is(err, user.ErrEmailAlreadyExists):
+			response.RenderError(
+				rw,
+				"user with this email already exists, log in and link the provider in the profile",
+				http.StatusConflict,
+			)
+		case errors.Is(err, user.ErrExternalIdentityAlreadyLinked):
+			response.RenderError(rw, "identity is already linked to another user", http.StatusConflict)
+		case errors.Is(err, user.ErrUserIsNotActive):
+			response.RenderError(rw, "user is not active", http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	response.Render(
+		rw,
+		Result{
+			Token:             string(result.Token),
+			TwoFactorRequired: result.ChallengeToken.IsPresent,
+			ChallengeToken:    string(result.ChallengeToken.Value),
+			IsNewUser:         result.IsNewUser,
+			IsLinked:          result.IsLinked,
+		},
+		http.StatusOK,
+	)
+}
@@ -0,0 +1,88 @@
+package loginwithtwofactor
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	loginwithtwofactor "remindme/internal/core/services/log_in_with_two_factor"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[loginwithtwofactor.Input, loginwithtwofactor.Result]
+}
+
+func New(
+	service services.Service[loginwithtwofactor.Input, loginwithtwofactor.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type Result struct {
+	Token string `json:"token"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.ChallengeToken, validation.Required, validation.Length(1, 256)),
+		validation.Field(&i.Code, validation.Required, validation.Length(1, 32)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		loginwithtwofactor.Input{
+			ChallengeToken: user.TwoFactorChallengeToken(input.ChallengeToken),
+			Code:           user.TwoFactorCode(input.Code),
+			UserAgent:      auth.UserAgent(r),
+			IP:             auth.ClientIP(r),
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case errors.Is(err, user.ErrInvalidTwoFactorChallenge):
+			response.RenderError(rw, "invalid or expired challenge, log in again", http.StatusUnauthorized)
+		case errors.Is(err, user.ErrInvalidTwoFactorCode):
+			response.RenderError(rw, "invalid code", http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	response.Render(rw, Result{Token: string(result.Token)}, http.StatusOK)
+}
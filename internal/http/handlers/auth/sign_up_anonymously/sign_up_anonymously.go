@@ -9,6 +9,7 @@ import (
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/services"
 	signupanonymously "remindme/internal/core/services/sign_up_anonymously"
+	"remindme/internal/http/handlers/auth"
 	"remindme/internal/http/handlers/response"
 	"time"
 
@@ -76,7 +77,11 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.Run(r.Context(), signupanonymously.Input{IP: ip, TimeZone: tz})
+	result, err := h.service.Run(r.Context(), signupanonymously.Input{
+		IP:        ip,
+		UserAgent: auth.UserAgent(r),
+		TimeZone:  tz,
+	})
 	if err != nil {
 		response.RenderInternalError(rw)
 		return
@@ -0,0 +1,56 @@
+package startoidclogin
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	startoidclogin "remindme/internal/core/services/start_oidc_login"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler starts the login with a provider, it also links the provider to
+// the logged in user when the service requires authentication.
+type Handler struct {
+	service services.Service[startoidclogin.Input, startoidclogin.Result]
+}
+
+func New(
+	service services.Service[startoidclogin.Input, startoidclogin.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Run(
+		r.Context(),
+		startoidclogin.Input{Provider: user.OIDCProviderName(chi.URLParam(r, "provider"))},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, user.ErrUnknownOIDCProvider):
+			response.RenderError(rw, "unknown provider", http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	auth.SetOIDCStateCookie(rw, result.State, result.ExpiresAt)
+	response.Render(rw, Result{AuthorizationURL: result.AuthorizationURL}, http.StatusOK)
+}
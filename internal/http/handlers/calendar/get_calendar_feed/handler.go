@@ -0,0 +1,60 @@
+package getcalendarfeed
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/get_calendar_feed"
+	"remindme/internal/http/handlers/response"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const MAX_TOKEN_LEN = 128
+
+// Handler serves the ICS subscription of a user, it is authenticated by the token in the URL,
+// because calendar apps can't send authentication headers.
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+	now     func() time.Time
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+	now func() time.Time,
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	return &Handler{service: service, now: now}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" || len(token) > MAX_TOKEN_LEN {
+		response.RenderError(rw, "calendar feed does not exist", http.StatusNotFound)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), service.Input{Token: user.CalendarFeedToken(token)})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrCalendarFeedDoesNotExist):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	rw.Header().Set("Cache-Control", "private, max-age=300")
+	rw.WriteHeader(http.StatusOK)
+	rw.Write([]byte(EncodeCalendar(result.User, result.Reminders, h.now())))
+}
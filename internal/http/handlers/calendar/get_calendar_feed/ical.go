@@ -0,0 +1,259 @@
+package getcalendarfeed
+
+import (
+	"fmt"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	PRODID         = "-//remindme//calendar feed//EN"
+	UID_DOMAIN     = "remindme"
+	CALENDAR_NAME  = "Reminders"
+	MAX_LINE_LEN   = 75
+	UTC_FORMAT     = "20060102T150405Z"
+	LOCAL_FORMAT   = "20060102T150405"
+	REFRESH_PERIOD = "PT1H"
+)
+
+// EncodeCalendar renders reminders as an iCalendar object (RFC 5545). Times of one-off reminders are
+// in the time zone of the user, recurring reminders are anchored in UTC as they are repeated in UTC.
+func EncodeCalendar(u user.User, reminders []reminder.Reminder, now time.Time) string {
+	loc := u.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+	isUTC := loc.String() == time.UTC.String()
+
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", PRODID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeText(CALENDAR_NAME))
+	w.line("X-WR-TIMEZONE", loc.String())
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", REFRESH_PERIOD)
+	w.line("X-PUBLISHED-TTL", REFRESH_PERIOD)
+	if !isUTC {
+		writeTimeZone(w, loc, now)
+	}
+	for _, rem := range reminders {
+		writeEvent(w, rem, loc, isUTC)
+	}
+	w.line("END", "VCALENDAR")
+	return w.String()
+}
+
+func writeEvent(w *icalWriter, rem reminder.Reminder, loc *time.Location, isUTC bool) {
+	summary, _, _ := strings.Cut(rem.Body, "\n")
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("%d@%s", rem.ID, UID_DOMAIN))
+	w.line("DTSTAMP", rem.CreatedAt.UTC().Format(UTC_FORMAT))
+	isRecurring := rem.IsActive() && rem.Every.IsPresent
+	if isUTC || isRecurring {
+		w.line("DTSTART", rem.At.UTC().Format(UTC_FORMAT))
+	} else {
+		w.line("DTSTART;TZID="+loc.String(), rem.At.In(loc).Format(LOCAL_FORMAT))
+	}
+	if isRecurring {
+		w.line("RRULE", encodeEvery(rem.Every.Value))
+	}
+	w.line("SUMMARY", escapeText(summary))
+	if summary != rem.Body {
+		w.line("DESCRIPTION", escapeText(rem.Body))
+	}
+	if rem.IsActive() {
+		w.line("STATUS", "CONFIRMED")
+		w.line("BEGIN", "VALARM")
+		w.line("ACTION", "DISPLAY")
+		w.line("DESCRIPTION", escapeText(summary))
+		w.line("TRIGGER", "PT0S")
+		w.line("END", "VALARM")
+	}
+	w.line("END", "VEVENT")
+}
+
+func encodeEvery(every reminder.Every) string {
+	freq := ""
+	switch every.Period() {
+	case reminder.PeriodMinute:
+		freq = "MINUTELY"
+	case reminder.PeriodHour:
+		freq = "HOURLY"
+	case reminder.PeriodDay:
+		freq = "DAILY"
+	case reminder.PeriodWeek:
+		freq = "WEEKLY"
+	case reminder.PeriodMonth:
+		freq = "MONTHLY"
+	case reminder.PeriodYear:
+		freq = "YEARLY"
+	}
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, every.N())
+}
+
+// transition is a change of the UTC offset of a time zone.
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	isDST      bool
+}
+
+// writeTimeZone writes a VTIMEZONE with yearly rules derived from transitions of the current year,
+// the rules start a year earlier, so that recently sent reminders are covered too.
+func writeTimeZone(w *icalWriter, loc *time.Location, now time.Time) {
+	year := now.In(loc).Year()
+	transitions := findTransitions(loc, year)
+
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+	if len(transitions) != 2 {
+		name, offset := now.In(loc).Zone()
+		w.line("BEGIN", "STANDARD")
+		w.line("DTSTART", "19700101T000000")
+		w.line("TZOFFSETFROM", formatOffset(offset))
+		w.line("TZOFFSETTO", formatOffset(offset))
+		w.line("TZNAME", escapeText(name))
+		w.line("END", "STANDARD")
+		w.line("END", "VTIMEZONE")
+		return
+	}
+	for _, t := range transitions {
+		component := "STANDARD"
+		if t.isDST {
+			component = "DAYLIGHT"
+		}
+		// The onset of an observance is the local time before the transition.
+		onset := t.at.In(time.FixedZone("", t.offsetFrom))
+		nth := (onset.Day()-1)/7 + 1
+		if onset.Day()+7 > daysIn(onset.Month(), onset.Year()) {
+			nth = -1
+		}
+		start := nthWeekday(year-1, onset.Month(), onset.Weekday(), nth)
+		start = start.Add(time.Duration(onset.Hour())*time.Hour +
+			time.Duration(onset.Minute())*time.Minute +
+			time.Duration(onset.Second())*time.Second)
+
+		w.line("BEGIN", component)
+		w.line("DTSTART", start.Format(LOCAL_FORMAT))
+		w.line("RRULE", fmt.Sprintf(
+			"FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s",
+			onset.Month(),
+			nth,
+			strings.ToUpper(onset.Weekday().String()[:2]),
+		))
+		w.line("TZOFFSETFROM", formatOffset(t.offsetFrom))
+		w.line("TZOFFSETTO", formatOffset(t.offsetTo))
+		w.line("TZNAME", escapeText(t.name))
+		w.line("END", component)
+	}
+	w.line("END", "VTIMEZONE")
+}
+
+// findTransitions returns offset changes of the location during the year.
+func findTransitions(loc *time.Location, year int) []transition {
+	transitions := make([]transition, 0, 2)
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	_, prevOffset := start.Zone()
+	for day := start; day.Before(end); {
+		next := day.Add(24 * time.Hour)
+		_, offset := next.Zone()
+		if offset == prevOffset {
+			day = next
+			continue
+		}
+		// The transition is in (day, next], it is searched with a second precision.
+		lo, hi := day.Unix(), next.Unix()
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if _, midOffset := time.Unix(mid, 0).In(loc).Zone(); midOffset == prevOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := time.Unix(hi, 0).In(loc)
+		name, _ := at.Zone()
+		transitions = append(transitions, transition{
+			at:         at,
+			offsetFrom: prevOffset,
+			offsetTo:   offset,
+			name:       name,
+			isDST:      at.IsDST(),
+		})
+		prevOffset = offset
+		day = next
+	}
+	return transitions
+}
+
+// nthWeekday returns the midnight of the nth weekday of the month, a negative n counts from the end.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month, daysIn(month, year), 0, 0, 0, 0, time.UTC)
+		diff := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -diff+(n+1)*7)
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	diff := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, diff+(n-1)*7)
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, offset%3600/60, offset%60
+	if seconds != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// icalWriter writes content lines terminated by CRLF and folded at 75 octets.
+type icalWriter struct {
+	strings.Builder
+}
+
+func (w *icalWriter) line(name, value string) {
+	content := name + ":" + value
+	lineLen := 0
+	for len(content) > 0 {
+		_, size := utf8.DecodeRuneInString(content)
+		if lineLen+size > MAX_LINE_LEN {
+			w.WriteString("\r\n ")
+			lineLen = 1
+		}
+		w.WriteString(content[:size])
+		lineLen += size
+		content = content[size:]
+	}
+	w.WriteString("\r\n")
+}
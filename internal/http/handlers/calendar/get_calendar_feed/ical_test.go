@@ -0,0 +1,177 @@
+package getcalendarfeed
+
+import (
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var NOW = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type testSuite struct {
+	suite.Suite
+}
+
+func TestEncodeCalendar(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestUTC() {
+	at := time.Date(2020, 6, 2, 9, 30, 0, 0, time.UTC)
+	reminders := []reminder.Reminder{
+		{ID: 1, Body: "one-off", At: at, CreatedAt: NOW, Status: reminder.StatusCreated},
+		{
+			ID:        2,
+			Body:      "daily; standup, again\nwith notes",
+			At:        at,
+			Every:     c.NewOptional(reminder.NewEvery(2, reminder.PeriodDay), true),
+			CreatedAt: NOW,
+			Status:    reminder.StatusScheduled,
+		},
+		{
+			ID:        3,
+			Body:      "sent",
+			At:        NOW.Add(-time.Hour),
+			Every:     c.NewOptional(reminder.EveryWeek, true),
+			CreatedAt: NOW.Add(-2 * time.Hour),
+			Status:    reminder.StatusSentSuccess,
+			SentAt:    c.NewOptional(NOW.Add(-time.Hour), true),
+		},
+	}
+
+	ics := EncodeCalendar(user.User{TimeZone: time.UTC}, reminders, NOW)
+
+	s.Equal(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//remindme//calendar feed//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Reminders",
+		"X-WR-TIMEZONE:UTC",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"BEGIN:VEVENT",
+		"UID:1@remindme",
+		"DTSTAMP:20200601T000000Z",
+		"DTSTART:20200602T093000Z",
+		"SUMMARY:one-off",
+		"STATUS:CONFIRMED",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:one-off",
+		"TRIGGER:PT0S",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:2@remindme",
+		"DTSTAMP:20200601T000000Z",
+		"DTSTART:20200602T093000Z",
+		"RRULE:FREQ=DAILY;INTERVAL=2",
+		`SUMMARY:daily\; standup\, again`,
+		`DESCRIPTION:daily\; standup\, again\nwith notes`,
+		"STATUS:CONFIRMED",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		`DESCRIPTION:daily\; standup\, again`,
+		"TRIGGER:PT0S",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:3@remindme",
+		"DTSTAMP:20200531T220000Z",
+		"DTSTART:20200531T230000Z",
+		"SUMMARY:sent",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), ics)
+}
+
+func (s *testSuite) TestTimeZoneWithDST() {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	s.Require().Nil(err)
+	at := time.Date(2020, 6, 2, 9, 30, 0, 0, loc)
+	reminders := []reminder.Reminder{
+		{ID: 1, Body: "one-off", At: at, CreatedAt: NOW, Status: reminder.StatusCreated},
+		{
+			ID:        2,
+			Body:      "monthly",
+			At:        at,
+			Every:     c.NewOptional(reminder.EveryMonth, true),
+			CreatedAt: NOW,
+			Status:    reminder.StatusCreated,
+		},
+	}
+
+	ics := EncodeCalendar(user.User{TimeZone: loc}, reminders, NOW)
+
+	s.Contains(ics, strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20190331T020000",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"TZNAME:CEST",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20191027T030000",
+		"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"TZNAME:CET",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+	}, "\r\n"))
+	s.Contains(ics, "X-WR-TIMEZONE:Europe/Berlin\r\n")
+	s.Contains(ics, "DTSTART;TZID=Europe/Berlin:20200602T093000\r\n")
+	s.Contains(ics, "DTSTART:20200602T073000Z\r\nRRULE:FREQ=MONTHLY;INTERVAL=1\r\n")
+}
+
+func (s *testSuite) TestNthWeekdayRule() {
+	loc, err := time.LoadLocation("America/New_York")
+	s.Require().Nil(err)
+
+	ics := EncodeCalendar(user.User{TimeZone: loc}, nil, NOW)
+
+	s.Contains(ics, "DTSTART:20190310T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n")
+	s.Contains(ics, "DTSTART:20191103T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\n")
+}
+
+func (s *testSuite) TestTimeZoneWithoutDST() {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	s.Require().Nil(err)
+
+	ics := EncodeCalendar(user.User{TimeZone: loc}, nil, NOW)
+
+	s.Contains(ics, strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Kolkata",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:+0530",
+		"TZOFFSETTO:+0530",
+		"TZNAME:IST",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+	}, "\r\n"))
+}
+
+func (s *testSuite) TestLineFolding() {
+	body := strings.Repeat("ä", 50)
+	reminders := []reminder.Reminder{{ID: 1, Body: body, At: NOW, CreatedAt: NOW, Status: reminder.StatusCanceled}}
+
+	ics := EncodeCalendar(user.User{TimeZone: time.UTC}, reminders, NOW)
+
+	s.Contains(ics, "SUMMARY:"+strings.Repeat("ä", 33)+"\r\n "+strings.Repeat("ä", 17)+"\r\n")
+	for _, line := range strings.Split(ics, "\r\n") {
+		s.LessOrEqual(len(line), MAX_LINE_LEN)
+	}
+}
@@ -2,12 +2,16 @@ package listuserchannels
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/user"
 	"remindme/internal/core/services"
 	service "remindme/internal/core/services/list_user_channels"
 	"remindme/internal/http/handlers/response"
+	"strconv"
 )
 
 type Handler struct {
@@ -24,15 +28,54 @@ func New(
 }
 
 type Result struct {
-	Channels []response.Channel `json:"channels"`
+	Channels   []response.Channel `json:"channels"`
+	NextCursor *string            `json:"next_cursor"`
 }
 
 func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	result, err := h.service.Run(r.Context(), service.Input{})
+	orderBy, err := parseOrderBy(r.URL.Query().Get("order_by"))
+	if err != nil {
+		response.RenderError(rw, "invalid order_by query parameter", http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		response.RenderError(rw, "invalid limit query parameter", http.StatusBadRequest)
+		return
+	}
+
+	offset, err := parseOffset(r.URL.Query().Get("offset"))
+	if err != nil {
+		response.RenderError(rw, "invalid offset query parameter", http.StatusBadRequest)
+		return
+	}
+
+	cursor, err := parseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		response.RenderError(rw, "invalid cursor query parameter", http.StatusBadRequest)
+		return
+	}
+	if cursor.IsPresent && offset > 0 {
+		response.RenderError(rw, "cursor and offset query parameters can not be used together", http.StatusBadRequest)
+		return
+	}
+
+	input := service.Input{
+		OrderBy: orderBy,
+		Limit:   limit,
+		Offset:  offset,
+		After:   cursor,
+	}
+	result, err := h.service.Run(r.Context(), input)
 	if err != nil {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, channel.ErrCursorNotValid):
+			response.RenderError(rw, "invalid cursor query parameter", http.StatusBadRequest)
 		default:
 			response.RenderInternalError(rw)
 		}
@@ -45,5 +88,53 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		respChannel.FromDomainChannel(channel)
 		respChannels[ix] = respChannel
 	}
-	response.Render(rw, Result{Channels: respChannels}, http.StatusOK)
+	res := Result{Channels: respChannels}
+	if result.NextCursor.IsPresent {
+		nextCursor := result.NextCursor.Value.String()
+		res.NextCursor = &nextCursor
+	}
+	response.Render(rw, res, http.StatusOK)
+}
+
+func parseOrderBy(raw string) (orderBy channel.OrderBy, err error) {
+	if raw == "" {
+		return orderBy, nil
+	}
+	return channel.ParseOrderBy(raw)
+}
+
+func parseLimit(raw string) (limit c.Optional[uint], err error) {
+	if raw == "" {
+		return limit, nil
+	}
+	l, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return limit, err
+	}
+	if l > service.MAX_LIMIT {
+		return limit, fmt.Errorf("limit must be less than or equal to %v", service.MAX_LIMIT)
+	}
+	return c.NewOptional(uint(l), true), nil
+}
+
+func parseOffset(raw string) (offset uint, err error) {
+	if raw == "" {
+		return offset, nil
+	}
+	o, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return offset, err
+	}
+	return uint(o), nil
+}
+
+func parseCursor(raw string) (cursor c.Optional[channel.Cursor], err error) {
+	if raw == "" {
+		return cursor, nil
+	}
+	value, err := channel.ParseCursor(raw)
+	if err != nil {
+		return cursor, err
+	}
+	return c.NewOptional(value, true), nil
 }
@@ -78,6 +78,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
 		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
 			response.RenderRateLimitExceeded(rw)
 		case errors.Is(err, channel.ErrChannelDoesNotExist):
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"remindme/internal/http/handlers/response"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check reports whether a dependency the instance relies on is available.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type Result struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// LivenessHandler reports the process is running, dependencies are not checked.
+type LivenessHandler struct{}
+
+func NewLiveness() *LivenessHandler {
+	return &LivenessHandler{}
+}
+
+func (h *LivenessHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	response.Render(rw, Result{Status: StatusOK}, http.StatusOK)
+}
+
+// ReadinessHandler runs all checks concurrently
+// and responds with 503 if any of them fails or does not finish in time.
+type ReadinessHandler struct {
+	timeout time.Duration
+	checks  []Check
+}
+
+func NewReadiness(timeout time.Duration, checks ...Check) *ReadinessHandler {
+	if timeout <= 0 {
+		panic("readiness timeout must be positive")
+	}
+	return &ReadinessHandler{timeout: timeout, checks: checks}
+}
+
+func (h *ReadinessHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	result := Result{Status: StatusOK, Checks: make(map[string]string, len(h.checks))}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(len(h.checks))
+	for _, check := range h.checks {
+		check := check
+		go func() {
+			defer wg.Done()
+			status := StatusOK
+			if err := check.Check(ctx); err != nil {
+				status = err.Error()
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			result.Checks[check.Name] = status
+			if status != StatusOK {
+				result.Status = StatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	if result.Status != StatusOK {
+		response.Render(rw, result, http.StatusServiceUnavailable)
+		return
+	}
+	response.Render(rw, result, http.StatusOK)
+}
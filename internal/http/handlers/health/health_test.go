@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testSuite struct {
+	suite.Suite
+}
+
+func TestHealthHandlers(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) serve(handler http.Handler) (int, Result) {
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+	result := Result{}
+	s.Nil(json.Unmarshal(rw.Body.Bytes(), &result))
+	return rw.Code, result
+}
+
+func (s *testSuite) TestLiveness() {
+	code, result := s.serve(NewLiveness())
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(Result{Status: StatusOK}, result)
+}
+
+func (s *testSuite) TestReadinessOK() {
+	code, result := s.serve(NewReadiness(
+		time.Second,
+		Check{Name: "db", Check: func(ctx context.Context) error { return nil }},
+		Check{Name: "redis", Check: func(ctx context.Context) error { return nil }},
+	))
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(Result{Status: StatusOK, Checks: map[string]string{"db": StatusOK, "redis": StatusOK}}, result)
+}
+
+func (s *testSuite) TestReadinessFailed() {
+	code, result := s.serve(NewReadiness(
+		time.Second,
+		Check{Name: "db", Check: func(ctx context.Context) error { return nil }},
+		Check{Name: "redis", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+	))
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(
+		Result{Status: StatusUnavailable, Checks: map[string]string{"db": StatusOK, "redis": "connection refused"}},
+		result,
+	)
+}
+
+func (s *testSuite) TestReadinessTimeout() {
+	code, result := s.serve(NewReadiness(
+		10*time.Millisecond,
+		Check{Name: "db", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	))
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(context.DeadlineExceeded.Error(), result.Checks["db"])
+}
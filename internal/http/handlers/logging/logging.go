@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"remindme/internal/core/domain/logging"
+)
+
+const REQUEST_ID_HEADER = "X-Request-ID"
+
+var requestIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9\-_.]{1,64}$`)
+
+// WithRequestContext assigns a request ID to the request context, the ID of a proxy
+// is reused if valid. The user ID is assigned to the same context once the user is authenticated,
+// so every log entry of the request is attached to both.
+func WithRequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(REQUEST_ID_HEADER)
+		if !requestIDRegexp.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		rw.Header().Set(REQUEST_ID_HEADER, requestID)
+		next.ServeHTTP(rw, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"remindme/internal/core/domain/logging"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testSuite struct {
+	suite.Suite
+	requestID string
+	userID    int64
+	handler   http.Handler
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.requestID = ""
+	suite.userID = 0
+	authenticated := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		logging.WithUserID(r.Context(), 42)
+	})
+	suite.handler = WithRequestContext(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		authenticated.ServeHTTP(rw, r)
+		suite.requestID, _ = logging.RequestIDFromContext(r.Context())
+		suite.userID, _ = logging.UserIDFromContext(r.Context())
+	}))
+}
+
+func TestWithRequestContext(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestNewRequestID() {
+	rw := httptest.NewRecorder()
+	s.handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Len(s.requestID, 32)
+	s.Equal(s.requestID, rw.Header().Get(REQUEST_ID_HEADER))
+	s.Equal(int64(42), s.userID, "user ID set downstream is visible to middleware")
+}
+
+func (s *testSuite) TestIncomingRequestID() {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(REQUEST_ID_HEADER, "proxy-request-1")
+	s.handler.ServeHTTP(httptest.NewRecorder(), r)
+	s.Equal("proxy-request-1", s.requestID)
+}
+
+func (s *testSuite) TestInvalidIncomingRequestID() {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(REQUEST_ID_HEADER, "bad id\nwith newline")
+	s.handler.ServeHTTP(httptest.NewRecorder(), r)
+	s.Len(s.requestID, 32)
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"remindme/internal/http/handlers/route"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type HTTPMetrics interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// MeasureRequests observes latency of requests by chi route pattern,
+// secrets are hidden from route patterns.
+func MeasureRequests(metrics HTTPMetrics, secrets ...string) func(next http.Handler) http.Handler {
+	namer := route.NewNamer(secrets...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			startedAt := time.Now()
+			ww := middleware.NewWrapResponseWriter(rw, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			metrics.ObserveHTTPRequest(r.Method, namer.Name(r), status, time.Since(startedAt))
+		})
+	}
+}
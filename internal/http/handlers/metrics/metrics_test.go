@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"remindme/internal/http/handlers/route"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type observation struct {
+	method string
+	route  string
+	status int
+}
+
+type fakeMetrics struct {
+	observations []observation
+}
+
+func (m *fakeMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.observations = append(m.observations, observation{method: method, route: route, status: status})
+}
+
+type testSuite struct {
+	suite.Suite
+	metrics *fakeMetrics
+	router  *chi.Mux
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.metrics = &fakeMetrics{}
+
+	subrouter := chi.NewRouter()
+	subrouter.Get("/{reminderID:[0-9]+}", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	subrouter.Post("/updates/{bot}/top-secret", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("ok"))
+	})
+
+	suite.router = chi.NewRouter()
+	suite.router.Use(MeasureRequests(suite.metrics, "top-secret"))
+	suite.router.Mount("/reminders", subrouter)
+}
+
+func TestMeasureRequests(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) serve(method, path string) {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+}
+
+func (s *testSuite) TestRoutePattern() {
+	s.serve(http.MethodGet, "/reminders/1")
+	s.serve(http.MethodGet, "/reminders/2")
+
+	s.Equal(
+		[]observation{
+			{method: http.MethodGet, route: "/reminders/{reminderID:[0-9]+}", status: http.StatusNoContent},
+			{method: http.MethodGet, route: "/reminders/{reminderID:[0-9]+}", status: http.StatusNoContent},
+		},
+		s.metrics.observations,
+	)
+}
+
+func (s *testSuite) TestSecretIsHidden() {
+	s.serve(http.MethodPost, "/reminders/updates/bot/top-secret")
+
+	s.Equal(
+		[]observation{{method: http.MethodPost, route: "/reminders/updates/{bot}/{secret}", status: http.StatusOK}},
+		s.metrics.observations,
+	)
+}
+
+func (s *testSuite) TestUnmatchedRoute() {
+	s.serve(http.MethodGet, "/unknown/path")
+
+	s.Equal(
+		[]observation{{method: http.MethodGet, route: route.Unmatched, status: http.StatusNotFound}},
+		s.metrics.observations,
+	)
+}
@@ -0,0 +1,192 @@
+package bulkupdatereminders
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/bulk_update_reminders"
+	"remindme/internal/http/handlers/response"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Filter has the filters of listing reminders, times are in RFC 3339.
+type Filter struct {
+	StatusIn      []string   `json:"status_in"`
+	Search        *string    `json:"search"`
+	AtAfter       *time.Time `json:"at_after"`
+	AtBefore      *time.Time `json:"at_before"`
+	Periodic      *bool      `json:"periodic"`
+	ChannelID     *int64     `json:"channel_id"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+}
+
+func (f Filter) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Search, validation.RuneLength(0, reminder.MAX_BODY_LEN)),
+	)
+}
+
+type Input struct {
+	ReminderIDs []int64 `json:"reminder_ids"`
+	Filter      *Filter `json:"filter"`
+	Action      string  `json:"action"`
+	// Shift is a duration like "-1h30m".
+	Shift      *string `json:"shift"`
+	ChannelIDs []int64 `json:"channel_ids"`
+}
+
+type Item struct {
+	ReminderID int64                          `json:"reminder_id"`
+	Error      *string                        `json:"error"`
+	Reminder   *response.ReminderWithChannels `json:"reminder"`
+}
+
+type Result struct {
+	UpdatedCount uint   `json:"updated_count"`
+	Items        []Item `json:"items"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.ReminderIDs, validation.Length(0, service.MAX_REMINDER_COUNT)),
+		validation.Field(&i.Filter),
+		validation.Field(&i.Action, validation.Required),
+		validation.Field(&i.Shift, validation.Length(0, 64)),
+		validation.Field(&i.ChannelIDs, validation.Length(0, reminder.MAX_CHANNEL_COUNT)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	serviceInput := service.Input{Action: service.Action(input.Action)}
+	for _, reminderID := range input.ReminderIDs {
+		serviceInput.ReminderIDs = append(serviceInput.ReminderIDs, reminder.ID(reminderID))
+	}
+	if input.Filter != nil {
+		filter := service.Filter{}
+		if input.Filter.StatusIn != nil {
+			statusIn := make([]reminder.Status, 0, len(input.Filter.StatusIn))
+			for _, rawStatus := range input.Filter.StatusIn {
+				status, err := reminder.ParseStatus(rawStatus)
+				if err != nil {
+					response.RenderError(rw, "invalid filter status_in", http.StatusBadRequest)
+					return
+				}
+				statusIn = append(statusIn, status)
+			}
+			filter.StatusIn = c.NewOptional(statusIn, true)
+		}
+		if input.Filter.Search != nil && strings.TrimSpace(*input.Filter.Search) != "" {
+			filter.BodySearch = c.NewOptional(strings.TrimSpace(*input.Filter.Search), true)
+		}
+		filter.AtAfter = optionalTime(input.Filter.AtAfter)
+		filter.AtBefore = optionalTime(input.Filter.AtBefore)
+		if input.Filter.Periodic != nil {
+			filter.IsPeriodic = c.NewOptional(*input.Filter.Periodic, true)
+		}
+		if input.Filter.ChannelID != nil {
+			filter.ChannelIDEquals = c.NewOptional(channel.ID(*input.Filter.ChannelID), true)
+		}
+		filter.CreatedAfter = optionalTime(input.Filter.CreatedAfter)
+		filter.CreatedBefore = optionalTime(input.Filter.CreatedBefore)
+		serviceInput.Filter = c.NewOptional(filter, true)
+	}
+	if input.Shift != nil {
+		shift, err := time.ParseDuration(*input.Shift)
+		if err != nil {
+			response.RenderError(rw, "invalid shift", http.StatusBadRequest)
+			return
+		}
+		serviceInput.Shift = shift
+	}
+	channelIDs := make([]channel.ID, len(input.ChannelIDs))
+	for ix, channelID := range input.ChannelIDs {
+		channelIDs[ix] = channel.ID(channelID)
+	}
+	serviceInput.ChannelIDs = reminder.NewChannelIDs(channelIDs...)
+
+	result, err := h.service.Run(r.Context(), serviceInput)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case isExpectedError(err):
+			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{UpdatedCount: result.UpdatedCount, Items: make([]Item, len(result.Items))}
+	for ix, item := range result.Items {
+		res.Items[ix] = Item{ReminderID: int64(item.ReminderID)}
+		if item.Error != nil {
+			msg := item.Error.Error()
+			res.Items[ix].Error = &msg
+		}
+		if item.Reminder.IsPresent {
+			res.Items[ix].Reminder = &response.ReminderWithChannels{}
+			res.Items[ix].Reminder.FromDomainType(item.Reminder.Value)
+		}
+	}
+	response.Render(rw, res, http.StatusOK)
+}
+
+func optionalTime(t *time.Time) c.Optional[time.Time] {
+	if t == nil {
+		return c.Optional[time.Time]{}
+	}
+	return c.NewOptional(t.UTC(), true)
+}
+
+func isExpectedError(err error) bool {
+	return (errors.Is(err, reminder.ErrBulkActionNotValid) ||
+		errors.Is(err, reminder.ErrBulkSelectionNotValid) ||
+		errors.Is(err, reminder.ErrBulkTooManyReminders) ||
+		errors.Is(err, reminder.ErrBulkShiftNotSet) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotSet) ||
+		errors.Is(err, reminder.ErrReminderTooManyChannels) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotValid) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotVerified))
+}
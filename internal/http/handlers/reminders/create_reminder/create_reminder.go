@@ -98,6 +98,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
 		case isExpectedError(err):
 			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
 		default:
@@ -67,6 +67,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
 		case errors.Is(err, reminder.ErrNaturalQueryParsing):
 			response.RenderError(rw, reminder.ErrNaturalQueryParsing.Error(), http.StatusUnprocessableEntity)
 		case isExpectedError(err):
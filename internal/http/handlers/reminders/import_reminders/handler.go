@@ -0,0 +1,189 @@
+package importreminders
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"remindme/internal/core/domain/channel"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/reminder"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/import_reminders"
+	"remindme/internal/http/handlers/response"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const MAX_FILE_SIZE = 1 << 20
+
+const (
+	RowStatusValid   = "valid"
+	RowStatusSkipped = "skipped"
+	RowStatusError   = "error"
+	RowStatusCreated = "created"
+)
+
+// Handler imports reminders from the request body, which is an ICS or a CSV file.
+// The format is read from the format query parameter or from the content type.
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Row struct {
+	Line     int                            `json:"line"`
+	Status   string                         `json:"status"`
+	At       *time.Time                     `json:"at"`
+	Body     string                         `json:"body"`
+	Every    *string                        `json:"every"`
+	Error    *string                        `json:"error"`
+	Reminder *response.ReminderWithChannels `json:"reminder"`
+}
+
+type Result struct {
+	DryRun    bool  `json:"dry_run"`
+	IsCreated bool  `json:"is_created"`
+	Rows      []Row `json:"rows"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	format, err := parseFormat(r)
+	if err != nil {
+		response.RenderError(rw, "format must be ics or csv", http.StatusBadRequest)
+		return
+	}
+	channelIDs, err := parseChannelIDs(r.URL.Query().Get("channel_ids"))
+	if err != nil {
+		response.RenderError(rw, "invalid channel_ids query parameter", http.StatusBadRequest)
+		return
+	}
+	dryRun := false
+	if rawDryRun := r.URL.Query().Get("dry_run"); rawDryRun != "" {
+		dryRun, err = strconv.ParseBool(rawDryRun)
+		if err != nil {
+			response.RenderError(rw, "invalid dry_run query parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, MAX_FILE_SIZE))
+	if err != nil {
+		response.RenderError(rw, "file is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), service.Input{
+		Format:     format,
+		Data:       data,
+		ChannelIDs: reminder.NewChannelIDs(channelIDs...),
+		DryRun:     dryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case isExpectedError(err):
+			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{DryRun: dryRun, IsCreated: result.IsCreated, Rows: make([]Row, len(result.Rows))}
+	for ix, row := range result.Rows {
+		res.Rows[ix] = fromServiceRow(row)
+	}
+	status := http.StatusOK
+	switch {
+	case result.IsCreated:
+		status = http.StatusCreated
+	case result.HasErrors():
+		status = http.StatusUnprocessableEntity
+	}
+	response.Render(rw, res, status)
+}
+
+func fromServiceRow(row service.Row) Row {
+	res := Row{Line: row.Line, Status: RowStatusValid, Body: row.Input.Body}
+	if !row.Input.At.IsZero() {
+		at := row.Input.At
+		res.At = &at
+	}
+	if row.Input.Every.IsPresent {
+		every := row.Input.Every.Value.String()
+		res.Every = &every
+	}
+	switch {
+	case row.Error != nil:
+		res.Status = RowStatusError
+		msg := row.Error.Error()
+		res.Error = &msg
+	case row.IsSkipped:
+		res.Status = RowStatusSkipped
+	case row.Reminder.IsPresent:
+		res.Status = RowStatusCreated
+		res.Reminder = &response.ReminderWithChannels{}
+		res.Reminder.FromDomainType(row.Reminder.Value)
+	}
+	return res
+}
+
+func parseFormat(r *http.Request) (service.Format, error) {
+	rawFormat := r.URL.Query().Get("format")
+	if rawFormat == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/calendar":
+			rawFormat = string(service.FormatICS)
+		case "text/csv":
+			rawFormat = string(service.FormatCSV)
+		}
+	}
+	switch format := service.Format(rawFormat); format {
+	case service.FormatICS, service.FormatCSV:
+		return format, nil
+	default:
+		return format, reminder.ErrImportInvalidFile
+	}
+}
+
+func parseChannelIDs(raw string) ([]channel.ID, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	rawIDs := strings.SplitN(raw, ",", reminder.MAX_CHANNEL_COUNT+1)
+	channelIDs := make([]channel.ID, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := strconv.ParseInt(strings.TrimSpace(rawID), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		channelIDs = append(channelIDs, channel.ID(id))
+	}
+	return channelIDs, nil
+}
+
+func isExpectedError(err error) bool {
+	return (errors.Is(err, reminder.ErrImportInvalidFile) ||
+		errors.Is(err, reminder.ErrImportTooManyRows) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotSet) ||
+		errors.Is(err, reminder.ErrReminderTooManyChannels) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotValid) ||
+		errors.Is(err, reminder.ErrReminderChannelsNotVerified))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"remindme/internal/core/domain/channel"
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/reminder"
@@ -13,6 +14,8 @@ import (
 	"remindme/internal/http/handlers/response"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type Handler struct {
@@ -31,6 +34,7 @@ func New(
 type Result struct {
 	Reminders  []response.ReminderWithChannels `json:"reminders"`
 	TotalCount uint                            `json:"total_count"`
+	NextCursor *string                         `json:"next_cursor"`
 }
 
 func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
@@ -41,6 +45,51 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	raw_search := r.URL.Query().Get("search")
+	search, err := parseSearch(raw_search)
+	if err != nil {
+		response.RenderError(rw, "invalid search query parameter", http.StatusBadRequest)
+		return
+	}
+
+	at_after, err := parseTime(r.URL.Query().Get("at_after"))
+	if err != nil {
+		response.RenderError(rw, "invalid at_after query parameter", http.StatusBadRequest)
+		return
+	}
+
+	at_before, err := parseTime(r.URL.Query().Get("at_before"))
+	if err != nil {
+		response.RenderError(rw, "invalid at_before query parameter", http.StatusBadRequest)
+		return
+	}
+
+	raw_periodic := r.URL.Query().Get("periodic")
+	periodic, err := parsePeriodic(raw_periodic)
+	if err != nil {
+		response.RenderError(rw, "invalid periodic query parameter", http.StatusBadRequest)
+		return
+	}
+
+	raw_channel_id := r.URL.Query().Get("channel_id")
+	channel_id, err := parseChannelID(raw_channel_id)
+	if err != nil {
+		response.RenderError(rw, "invalid channel_id query parameter", http.StatusBadRequest)
+		return
+	}
+
+	created_after, err := parseTime(r.URL.Query().Get("created_after"))
+	if err != nil {
+		response.RenderError(rw, "invalid created_after query parameter", http.StatusBadRequest)
+		return
+	}
+
+	created_before, err := parseTime(r.URL.Query().Get("created_before"))
+	if err != nil {
+		response.RenderError(rw, "invalid created_before query parameter", http.StatusBadRequest)
+		return
+	}
+
 	raw_order_by := r.URL.Query().Get("order_by")
 	order_by, err := parseOrderBy(raw_order_by)
 	if err != nil {
@@ -62,17 +111,40 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	raw_cursor := r.URL.Query().Get("cursor")
+	cursor, err := parseCursor(raw_cursor)
+	if err != nil {
+		response.RenderError(rw, "invalid cursor query parameter", http.StatusBadRequest)
+		return
+	}
+	if cursor.IsPresent && offset > 0 {
+		response.RenderError(rw, "cursor and offset query parameters can not be used together", http.StatusBadRequest)
+		return
+	}
+
 	input := service.Input{
-		StatusIn: status_in,
-		OrderBy:  order_by,
-		Limit:    limit,
-		Offset:   offset,
+		StatusIn:        status_in,
+		BodySearch:      search,
+		AtAfter:         at_after,
+		AtBefore:        at_before,
+		IsPeriodic:      periodic,
+		ChannelIDEquals: channel_id,
+		CreatedAfter:    created_after,
+		CreatedBefore:   created_before,
+		OrderBy:         order_by,
+		Limit:           limit,
+		Offset:          offset,
+		After:           cursor,
 	}
 	result, err := h.service.Run(r.Context(), input)
 	if err != nil {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, reminder.ErrCursorNotValid):
+			response.RenderError(rw, "invalid cursor query parameter", http.StatusBadRequest)
 		default:
 			response.RenderInternalError(rw)
 		}
@@ -85,14 +157,19 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		respReminder.FromDomainType(reminder)
 		respReminders = append(respReminders, respReminder)
 	}
-	response.Render(rw, Result{Reminders: respReminders, TotalCount: result.TotalCount}, http.StatusOK)
+	res := Result{Reminders: respReminders, TotalCount: result.TotalCount}
+	if result.NextCursor.IsPresent {
+		nextCursor := result.NextCursor.Value.String()
+		res.NextCursor = &nextCursor
+	}
+	response.Render(rw, res, http.StatusOK)
 }
 
 func parseStatusIn(raw string) (result c.Optional[[]reminder.Status], err error) {
 	if raw == "" {
 		return result, nil
 	}
-	raw_statuses := strings.SplitN(raw, ",", 6)
+	raw_statuses := strings.SplitN(raw, ",", 8)
 	statuses := make([]reminder.Status, 0, len(raw_statuses))
 	for _, raw_status := range raw_statuses {
 		status, err := reminder.ParseStatus(raw_status)
@@ -107,6 +184,61 @@ func parseStatusIn(raw string) (result c.Optional[[]reminder.Status], err error)
 	return result, err
 }
 
+func parseSearch(raw string) (search c.Optional[string], err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return search, nil
+	}
+	if utf8.RuneCountInString(raw) > reminder.MAX_BODY_LEN {
+		return search, fmt.Errorf("search must be at most %v characters long", reminder.MAX_BODY_LEN)
+	}
+	return c.NewOptional(raw, true), nil
+}
+
+func parseTime(raw string) (t c.Optional[time.Time], err error) {
+	if raw == "" {
+		return t, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return t, err
+	}
+	return c.NewOptional(value.UTC(), true), nil
+}
+
+func parsePeriodic(raw string) (periodic c.Optional[bool], err error) {
+	if raw == "" {
+		return periodic, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return periodic, err
+	}
+	return c.NewOptional(value, true), nil
+}
+
+func parseChannelID(raw string) (channelID c.Optional[channel.ID], err error) {
+	if raw == "" {
+		return channelID, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return channelID, err
+	}
+	return c.NewOptional(channel.ID(value), true), nil
+}
+
+func parseCursor(raw string) (cursor c.Optional[reminder.Cursor], err error) {
+	if raw == "" {
+		return cursor, nil
+	}
+	value, err := reminder.ParseCursor(raw)
+	if err != nil {
+		return cursor, err
+	}
+	return c.NewOptional(value, true), nil
+}
+
 func parseOrderBy(raw string) (orderBy reminder.OrderBy, err error) {
 	if raw == "" {
 		return orderBy, nil
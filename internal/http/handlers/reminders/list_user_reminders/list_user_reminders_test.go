@@ -9,6 +9,7 @@ import (
 	"remindme/internal/core/domain/reminder"
 	"remindme/internal/core/domain/user"
 	service "remindme/internal/core/services/list_user_reminders"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,9 +39,16 @@ var Reminders []reminder.ReminderWithChannels = []reminder.ReminderWithChannels{
 	},
 }
 
+var atAscCursor = reminder.Cursor{
+	OrderBy: reminder.OrderByAtAsc,
+	At:      time.Date(2020, 1, 2, 1, 1, 1, 0, time.UTC),
+	ID:      reminder.ID(2),
+}
+
 type stubService struct {
 	reminders  []reminder.ReminderWithChannels
 	totalCount uint
+	nextCursor c.Optional[reminder.Cursor]
 	err        error
 	input      *service.Input
 }
@@ -59,6 +67,7 @@ func (s *stubService) Run(ctx context.Context, input service.Input) (result serv
 	s.input = &input
 	result.Reminders = s.reminders
 	result.TotalCount = s.totalCount
+	result.NextCursor = s.nextCursor
 	return result, nil
 }
 
@@ -183,6 +192,97 @@ func TestListUserRemindersHandler(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedInput:  nil,
 		},
+		{
+			url:            "/reminders?status_in=paused",
+			expectedStatus: http.StatusOK,
+			expectedInput: &service.Input{
+				StatusIn: c.NewOptional([]reminder.Status{reminder.StatusPaused}, true),
+			},
+		},
+		{
+			url:            "/reminders?search=call%20-mom",
+			expectedStatus: http.StatusOK,
+			expectedInput:  &service.Input{BodySearch: c.NewOptional("call -mom", true)},
+		},
+		{
+			url:            "/reminders?search=%20",
+			expectedStatus: http.StatusOK,
+			expectedInput:  &service.Input{},
+		},
+		{
+			url:            "/reminders?search=" + strings.Repeat("a", reminder.MAX_BODY_LEN+1),
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?at_after=2020-01-01T00:00:00Z&at_before=2020-01-02T03:00:00%2B03:00",
+			expectedStatus: http.StatusOK,
+			expectedInput: &service.Input{
+				AtAfter:  c.NewOptional(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true),
+				AtBefore: c.NewOptional(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), true),
+			},
+		},
+		{
+			url:            "/reminders?at_after=2020-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?created_after=2020-01-01T00:00:00Z&created_before=2020-02-01T00:00:00Z",
+			expectedStatus: http.StatusOK,
+			expectedInput: &service.Input{
+				CreatedAfter:  c.NewOptional(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true),
+				CreatedBefore: c.NewOptional(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), true),
+			},
+		},
+		{
+			url:            "/reminders?created_before=yesterday",
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?periodic=true",
+			expectedStatus: http.StatusOK,
+			expectedInput:  &service.Input{IsPeriodic: c.NewOptional(true, true)},
+		},
+		{
+			url:            "/reminders?periodic=false",
+			expectedStatus: http.StatusOK,
+			expectedInput:  &service.Input{IsPeriodic: c.NewOptional(false, true)},
+		},
+		{
+			url:            "/reminders?periodic=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?channel_id=300",
+			expectedStatus: http.StatusOK,
+			expectedInput:  &service.Input{ChannelIDEquals: c.NewOptional(channel.ID(300), true)},
+		},
+		{
+			url:            "/reminders?channel_id=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?order_by=at_asc&cursor=" + atAscCursor.String(),
+			expectedStatus: http.StatusOK,
+			expectedInput: &service.Input{
+				OrderBy: reminder.OrderByAtAsc,
+				After:   c.NewOptional(atAscCursor, true),
+			},
+		},
+		{
+			url:            "/reminders?cursor=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
+		{
+			url:            "/reminders?offset=10&cursor=" + atAscCursor.String(),
+			expectedStatus: http.StatusBadRequest,
+			expectedInput:  nil,
+		},
 		{
 			url:            "/reminders?status_in=created,scheduled&order_by=at_asc&limit=20&offset=40",
 			expectedStatus: http.StatusOK,
@@ -212,3 +312,18 @@ func TestListUserRemindersHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestListUserRemindersHandlerNextCursor(t *testing.T) {
+	req, err := http.NewRequest("GET", "/reminders?order_by=at_asc&limit=2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := newStubService()
+	service.nextCursor = c.NewOptional(atAscCursor, true)
+	rr := httptest.NewRecorder()
+	New(service).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"next_cursor":"`+atAscCursor.String()+`"`)
+}
@@ -111,6 +111,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
 		case errors.Is(err, reminder.ErrReminderDoesNotExist):
 			response.RenderError(rw, err.Error(), http.StatusNotFound)
 		case errors.Is(err, reminder.ErrReminderPermission):
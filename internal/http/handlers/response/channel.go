@@ -61,7 +61,8 @@ func (c *Channel) FromDomainChannel(dc channel.Channel) {
 		c.VerifiedAt = &dc.VerifiedAt.Value
 	}
 
-	if dc.Type == channel.Telegram {
+	// Stored channels only keep the token hash, so the token is known right after creation only.
+	if dc.Type == channel.Telegram && dc.VerificationToken.Value != "" {
 		c.VerificationToken = (*string)(&dc.VerificationToken.Value)
 	}
 
@@ -0,0 +1,83 @@
+package response
+
+import (
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"time"
+)
+
+// Limits holds the limits of a user or a plan, null means unlimited.
+type Limits struct {
+	EmailChannelCount            *uint32  `json:"email_channel_count"`
+	TelegramChannelCount         *uint32  `json:"telegram_channel_count"`
+	ActiveReminderCount          *uint32  `json:"active_reminder_count"`
+	MonthlySentReminderCount     *uint32  `json:"monthly_sent_reminder_count"`
+	ReminderEveryPerDayCount     *float64 `json:"reminder_every_per_day_count"`
+	SentReminderQuotaWindow      string   `json:"sent_reminder_quota_window"`
+	PausedRemindersCountAsActive bool     `json:"paused_reminders_count_as_active"`
+}
+
+func (l *Limits) FromDomainType(dl user.Limits) {
+	l.EmailChannelCount = optionalToPointer(dl.EmailChannelCount)
+	l.TelegramChannelCount = optionalToPointer(dl.TelegramChannelCount)
+	l.ActiveReminderCount = optionalToPointer(dl.ActiveReminderCount)
+	l.MonthlySentReminderCount = optionalToPointer(dl.MonthlySentReminderCount)
+	l.ReminderEveryPerDayCount = optionalToPointer(dl.ReminderEveryPerDayCount)
+	l.SentReminderQuotaWindow = string(dl.SentReminderQuotaWindow)
+	l.PausedRemindersCountAsActive = dl.PausedRemindersCountAsActive
+}
+
+type Plan struct {
+	Name   string `json:"name"`
+	Limits Limits `json:"limits"`
+}
+
+func (p *Plan) FromDomainType(dp user.Plan) {
+	p.Name = string(dp.Name)
+	p.Limits.FromDomainType(dp.Limits)
+}
+
+type UserPlan struct {
+	UserID int64   `json:"user_id"`
+	Plan   *string `json:"plan"`
+	Limits Limits  `json:"limits"`
+}
+
+func (p *UserPlan) FromDomainType(dp user.UserPlan) {
+	p.UserID = int64(dp.UserID)
+	if dp.Plan.IsPresent {
+		plan := string(dp.Plan.Value)
+		p.Plan = &plan
+	}
+	p.Limits.FromDomainType(dp.Limits)
+}
+
+type LimitsAuditEntry struct {
+	ID        int64     `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Plan      *string   `json:"plan"`
+	Before    Limits    `json:"before"`
+	After     Limits    `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *LimitsAuditEntry) FromDomainType(de user.LimitsAuditEntry) {
+	e.ID = int64(de.ID)
+	e.Actor = de.Actor
+	e.Action = string(de.Action)
+	if de.Plan.IsPresent {
+		plan := string(de.Plan.Value)
+		e.Plan = &plan
+	}
+	e.Before.FromDomainType(de.Before)
+	e.After.FromDomainType(de.After)
+	e.CreatedAt = de.CreatedAt
+}
+
+func optionalToPointer[T any](o c.Optional[T]) *T {
+	if !o.IsPresent {
+		return nil
+	}
+	return &o.Value
+}
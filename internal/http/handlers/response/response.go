@@ -13,6 +13,10 @@ func RenderUnauthorized(rw http.ResponseWriter) {
 	RenderError(rw, "invalid authentication token", http.StatusUnauthorized)
 }
 
+func RenderForbidden(rw http.ResponseWriter) {
+	RenderError(rw, "insufficient token scope", http.StatusForbidden)
+}
+
 func RenderInternalError(rw http.ResponseWriter) {
 	RenderError(rw, "internal error", http.StatusInternalServerError)
 }
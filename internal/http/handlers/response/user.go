@@ -28,3 +28,81 @@ func (u *User) FromDomainUser(du user.User) {
 	u.ActivatedAt = du.ActivatedAt.Value
 	u.TimeZone = du.TimeZone.String()
 }
+
+type Session struct {
+	ID         int64     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+func (s *Session) FromDomainSession(ds user.Session) {
+	s.ID = int64(ds.ID)
+	s.CreatedAt = ds.CreatedAt
+	s.LastSeenAt = ds.LastSeenAt
+	s.UserAgent = ds.UserAgent
+	s.IP = ds.IP
+	s.IsCurrent = ds.IsCurrent
+}
+
+type APIToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+func (t *APIToken) FromDomainAPIToken(dt user.APIToken) {
+	t.ID = int64(dt.ID)
+	t.Name = dt.Name
+	t.Scopes = make([]string, len(dt.Scopes))
+	for ix, scope := range dt.Scopes {
+		t.Scopes[ix] = string(scope)
+	}
+	t.CreatedAt = dt.CreatedAt
+	t.ExpiresAt = dt.ExpiresAt
+	if dt.LastUsedAt.IsPresent {
+		lastUsedAt := dt.LastUsedAt.Value
+		t.LastUsedAt = &lastUsedAt
+	}
+}
+
+type ExternalIdentity struct {
+	ID        int64     `json:"id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (i *ExternalIdentity) FromDomainExternalIdentity(di user.ExternalIdentity) {
+	i.ID = int64(di.ID)
+	i.Provider = string(di.Provider)
+	i.Subject = di.Subject
+	i.CreatedAt = di.CreatedAt
+}
+
+type AccountDeletion struct {
+	RequestedAt time.Time `json:"requested_at"`
+	PurgeAt     time.Time `json:"purge_at"`
+}
+
+func (d *AccountDeletion) FromDomainAccountDeletion(dd user.AccountDeletion) {
+	d.RequestedAt = dd.RequestedAt
+	d.PurgeAt = dd.PurgeAt
+}
+
+type EmailChange struct {
+	NewEmail  string    `json:"new_email"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (ch *EmailChange) FromDomainEmailChange(dch user.EmailChange) {
+	ch.NewEmail = string(dch.NewEmail)
+	ch.CreatedAt = dch.CreatedAt
+	ch.ExpiresAt = dch.ExpiresAt
+}
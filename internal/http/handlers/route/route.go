@@ -0,0 +1,35 @@
+package route
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const Unmatched = "unmatched"
+
+// Namer names requests by their chi route pattern, so path parameters do not produce
+// distinct names. Secrets found in route patterns are replaced with "{secret}".
+type Namer struct {
+	replacer *strings.Replacer
+}
+
+func NewNamer(secrets ...string) *Namer {
+	replacements := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			replacements = append(replacements, secret, "{secret}")
+		}
+	}
+	return &Namer{replacer: strings.NewReplacer(replacements...)}
+}
+
+// Name must be called after the request has been routed.
+func (n *Namer) Name(r *http.Request) string {
+	routeCtx := chi.RouteContext(r.Context())
+	if routeCtx == nil || routeCtx.RoutePattern() == "" {
+		return Unmatched
+	}
+	return n.replacer.Replace(routeCtx.RoutePattern())
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	leaderelection "remindme/internal/db/leader_election"
+	"remindme/internal/http/handlers/response"
+)
+
+type Elector interface {
+	Status() leaderelection.Status
+}
+
+type Handler struct {
+	elector Elector
+}
+
+func New(elector Elector) *Handler {
+	if elector == nil {
+		panic(e.NewNilArgumentError("elector"))
+	}
+	return &Handler{elector: elector}
+}
+
+type Result struct {
+	InstanceID string  `json:"instance_id"`
+	IsLeader   bool    `json:"is_leader"`
+	Leader     *string `json:"leader"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	status := h.elector.Status()
+	result := Result{InstanceID: status.InstanceID, IsLeader: status.IsLeader}
+	if status.Leader != "" {
+		result.Leader = &status.Leader
+	}
+	response.Render(rw, result, http.StatusOK)
+}
@@ -48,7 +48,7 @@ func New(
 }
 
 type user struct {
-	ID int64 `json:"id"`
+	ID channel.TelegramChatID `json:"id"`
 }
 
 type message struct {
@@ -130,10 +130,10 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	)
 }
 
-func (h *Handler) sendBotMessage(ctx context.Context, b channel.TelegramBot, chatID int64, text string) {
+func (h *Handler) sendBotMessage(ctx context.Context, b channel.TelegramBot, chatID channel.TelegramChatID, text string) {
 	err := h.botMessageSender.SendTelegramBotMessage(ctx, bot.TelegramBotMessage{
 		Bot:    b,
-		ChatID: channel.TelegramChatID(chatID),
+		ChatID: chatID,
 		Text:   text,
 	})
 	if err != nil {
@@ -176,6 +176,6 @@ func parseVerificationData(u update) (d channelVerificationData, ok bool) {
 	return channelVerificationData{
 		channelID:      channel.ID(channelID),
 		token:          channel.VerificationToken(parts[2]),
-		telegramChatID: channel.TelegramChatID(u.Message.From.ID),
+		telegramChatID: u.Message.From.ID,
 	}, true
 }
@@ -11,7 +11,7 @@ import (
 func TestParseVerificationDataSuccess(t *testing.T) {
 	cases := []struct {
 		id       string
-		userID   int64
+		userID   channel.TelegramChatID
 		text     string
 		expected channelVerificationData
 	}{
@@ -101,7 +101,7 @@ func TestParseVerificationDataFail(t *testing.T) {
 	}
 }
 
-func createUpdate(userID int64, text string) update {
+func createUpdate(userID channel.TelegramChatID, text string) update {
 	return update{
 		ID: 1,
 		Message: &message{
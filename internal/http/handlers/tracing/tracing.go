@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	dt "remindme/internal/core/domain/tracing"
+	"remindme/internal/http/handlers/route"
+	"remindme/internal/tracing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceRequests continues the trace found in the request headers or starts a new one.
+// The server span is named by the chi route pattern, secrets are hidden from route patterns.
+func TraceRequests(tracer *tracing.Tracer, secrets ...string) func(next http.Handler) http.Handler {
+	namer := route.NewNamer(secrets...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			ctx := tracing.ExtractHTTP(r.Context(), r.Header)
+			ctx, span := tracer.StartSpan(
+				ctx,
+				"HTTP "+r.Method,
+				trace.SpanKindServer,
+				dt.Attribute("http.request.method", r.Method),
+			)
+			defer span.End()
+
+			ww := middleware.NewWrapResponseWriter(rw, r.ProtoMajor)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(ww, r)
+
+			routeName := namer.Name(r)
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetName(r.Method + " " + routeName)
+			span.SetAttributes(
+				dt.Attribute("http.route", routeName),
+				dt.Attribute("http.response.status_code", status),
+			)
+			if status >= http.StatusInternalServerError {
+				span.RecordError(errors.New(http.StatusText(status)))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"remindme/internal/tracing"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	TRACEPARENT = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	TRACE_ID    = "4bf92f3577b34da6a3ce929d0e0e4736"
+	SPAN_ID     = "00f067aa0ba902b7"
+)
+
+type testSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	router   *chi.Mux
+	seen     trace.SpanContext
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	tracer := tracing.NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+
+	suite.router = chi.NewRouter()
+	suite.router.Use(TraceRequests(tracer, "top-secret"))
+	suite.router.Get("/reminders/{reminderID:[0-9]+}", func(rw http.ResponseWriter, r *http.Request) {
+		suite.seen = trace.SpanContextFromContext(r.Context())
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	suite.router.Post("/updates/top-secret", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	})
+}
+
+func TestTraceRequests(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestSpanNamedByRoute() {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/reminders/1", nil))
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("GET /reminders/{reminderID:[0-9]+}", spans[0].Name())
+	s.Equal(trace.SpanKindServer, spans[0].SpanKind())
+	s.Equal(spans[0].SpanContext(), s.seen)
+}
+
+func (s *testSuite) TestContinuesIncomingTrace() {
+	r := httptest.NewRequest(http.MethodGet, "/reminders/1", nil)
+	r.Header.Set("traceparent", TRACEPARENT)
+	s.router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(TRACE_ID, s.seen.TraceID().String())
+	s.Equal(SPAN_ID, spans[0].Parent().SpanID().String())
+	s.NotEqual(SPAN_ID, s.seen.SpanID().String())
+}
+
+func (s *testSuite) TestSecretHidden() {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/updates/top-secret", nil))
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.NotContains(spans[0].Name(), "top-secret")
+}
@@ -0,0 +1,43 @@
+package cancelaccountdeletion
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/cancel_account_deletion"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	_, err := h.service.Run(r.Context(), service.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, user.ErrAccountDeletionNotRequested):
+			response.RenderError(rw, "account deletion is not requested", http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	response.Render(rw, struct{}{}, http.StatusOK)
+}
@@ -66,6 +66,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, user.ErrUserDoesNotExist):
 			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
 		case errors.Is(err, user.ErrInvalidCredentials):
 			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
 		default:
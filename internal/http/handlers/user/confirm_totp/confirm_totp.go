@@ -0,0 +1,87 @@
+package confirmtotp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	confirmtotp "remindme/internal/core/services/confirm_totp"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[confirmtotp.Input, confirmtotp.Result]
+}
+
+func New(
+	service services.Service[confirmtotp.Input, confirmtotp.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	Code string `json:"code"`
+}
+
+type Result struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Code, validation.Required, validation.Length(1, 32)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), confirmtotp.Input{Code: user.TwoFactorCode(input.Code)})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case errors.Is(err, user.ErrTOTPNotEnrolled):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		case errors.Is(err, user.ErrTOTPAlreadyEnabled):
+			response.RenderError(rw, err.Error(), http.StatusConflict)
+		case errors.Is(err, user.ErrInvalidTwoFactorCode):
+			response.RenderError(rw, "invalid code", http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	recoveryCodes := make([]string, 0, len(result.RecoveryCodes))
+	for _, code := range result.RecoveryCodes {
+		recoveryCodes = append(recoveryCodes, string(code))
+	}
+	response.Render(rw, Result{RecoveryCodes: recoveryCodes}, http.StatusOK)
+}
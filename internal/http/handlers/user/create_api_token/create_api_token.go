@@ -0,0 +1,100 @@
+package createapitoken
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	createapitoken "remindme/internal/core/services/create_api_token"
+	"remindme/internal/http/handlers/response"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[createapitoken.Input, createapitoken.Result]
+}
+
+func New(
+	service services.Service[createapitoken.Input, createapitoken.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Input struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// Result contains the token, which can not be read again.
+type Result struct {
+	Token    string            `json:"token"`
+	APIToken response.APIToken `json:"api_token"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	scopes := make([]interface{}, len(user.Scopes))
+	for ix, scope := range user.Scopes {
+		scopes[ix] = string(scope)
+	}
+	maxDays := int(user.API_TOKEN_MAX_LIFETIME / (24 * time.Hour))
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 64)),
+		validation.Field(&i.Scopes, validation.Required, validation.Each(validation.In(scopes...))),
+		validation.Field(&i.ExpiresInDays, validation.Required, validation.Min(1), validation.Max(maxDays)),
+	)
+}
+
+func (i Input) scopes() []user.Scope {
+	scopes := make([]user.Scope, 0, len(i.Scopes))
+	for _, scope := range i.Scopes {
+		scopes = append(scopes, user.Scope(scope))
+	}
+	return scopes
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), createapitoken.Input{
+		Name:      input.Name,
+		Scopes:    input.scopes(),
+		ExpiresIn: time.Duration(input.ExpiresInDays) * 24 * time.Hour,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{Token: string(result.Secret)}
+	res.APIToken.FromDomainAPIToken(result.Token)
+	response.Render(rw, res, http.StatusCreated)
+}
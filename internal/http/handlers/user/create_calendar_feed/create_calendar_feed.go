@@ -0,0 +1,52 @@
+package createcalendarfeed
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	createcalendarfeed "remindme/internal/core/services/create_calendar_feed"
+	"remindme/internal/http/handlers/response"
+	"time"
+)
+
+type Handler struct {
+	service services.Service[createcalendarfeed.Input, createcalendarfeed.Result]
+	baseURL url.URL
+}
+
+func New(
+	service services.Service[createcalendarfeed.Input, createcalendarfeed.Result],
+	baseURL url.URL,
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service, baseURL: baseURL}
+}
+
+// Result contains the subscription URL with the token, which can not be read again.
+type Result struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Run(r.Context(), createcalendarfeed.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	feedURL := h.baseURL.JoinPath(string(result.Token) + ".ics")
+	response.Render(rw, Result{URL: feedURL.String(), CreatedAt: result.Feed.CreatedAt}, http.StatusCreated)
+}
@@ -0,0 +1,78 @@
+package disabletotp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	disabletotp "remindme/internal/core/services/disable_totp"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[disabletotp.Input, disabletotp.Result]
+}
+
+func New(
+	service services.Service[disabletotp.Input, disabletotp.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Input has a TOTP code or a recovery code.
+type Input struct {
+	Code string `json:"code"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Code, validation.Required, validation.Length(1, 32)),
+	)
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.service.Run(r.Context(), disabletotp.Input{Code: user.TwoFactorCode(input.Code)})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case errors.Is(err, user.ErrTOTPNotEnrolled):
+			response.RenderError(rw, "TOTP is not enabled", http.StatusNotFound)
+		case errors.Is(err, user.ErrInvalidTwoFactorCode):
+			response.RenderError(rw, "invalid code", http.StatusUnprocessableEntity)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	response.Render(rw, struct{}{}, http.StatusOK)
+}
@@ -0,0 +1,48 @@
+package enabletotp
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	enabletotp "remindme/internal/core/services/enable_totp"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[enabletotp.Input, enabletotp.Result]
+}
+
+func New(
+	service services.Service[enabletotp.Input, enabletotp.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Run(r.Context(), enabletotp.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, user.ErrTOTPAlreadyEnabled):
+			response.RenderError(rw, err.Error(), http.StatusConflict)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	response.Render(rw, Result{Secret: string(result.Secret), URI: result.URI}, http.StatusCreated)
+}
@@ -0,0 +1,155 @@
+package exportuserdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/export_user_data"
+	"remindme/internal/http/handlers/response"
+)
+
+const (
+	FORMAT_JSON = "json"
+	FORMAT_ZIP  = "zip"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Result is the exported data, deliveries are the reminders which have been sent.
+// Plan is null for users who are not activated yet.
+type Result struct {
+	Profile            response.User                   `json:"profile"`
+	Channels           []response.Channel              `json:"channels"`
+	Reminders          []response.ReminderWithChannels `json:"reminders"`
+	Deliveries         []response.ReminderWithChannels `json:"deliveries"`
+	Sessions           []response.Session              `json:"sessions"`
+	APITokens          []response.APIToken             `json:"api_tokens"`
+	ExternalIdentities []response.ExternalIdentity     `json:"external_identities"`
+	Plan               *response.UserPlan              `json:"plan"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FORMAT_JSON
+	}
+	if format != FORMAT_JSON && format != FORMAT_ZIP {
+		response.RenderError(rw, "format must be json or zip", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), service.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{
+		Channels:           make([]response.Channel, len(result.Channels)),
+		Reminders:          make([]response.ReminderWithChannels, len(result.Reminders)),
+		Deliveries:         make([]response.ReminderWithChannels, 0),
+		Sessions:           make([]response.Session, len(result.Sessions)),
+		APITokens:          make([]response.APIToken, len(result.APITokens)),
+		ExternalIdentities: make([]response.ExternalIdentity, len(result.ExternalIdentities)),
+	}
+	res.Profile.FromDomainUser(result.User)
+	for ix, channel := range result.Channels {
+		res.Channels[ix].FromDomainChannel(channel)
+	}
+	for ix, reminder := range result.Reminders {
+		res.Reminders[ix].FromDomainType(reminder)
+		if reminder.SentAt.IsPresent {
+			res.Deliveries = append(res.Deliveries, res.Reminders[ix])
+		}
+	}
+	for ix, session := range result.Sessions {
+		res.Sessions[ix].FromDomainSession(session)
+	}
+	for ix, token := range result.APITokens {
+		res.APITokens[ix].FromDomainAPIToken(token)
+	}
+	for ix, identity := range result.ExternalIdentities {
+		res.ExternalIdentities[ix].FromDomainExternalIdentity(identity)
+	}
+	if result.Plan.IsPresent {
+		res.Plan = &response.UserPlan{}
+		res.Plan.FromDomainType(result.Plan.Value)
+	}
+
+	filename := fmt.Sprintf("remindme-export-%d.%s", result.User.ID, format)
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == FORMAT_JSON {
+		response.Render(rw, res, http.StatusOK)
+		return
+	}
+
+	content, err := toZip(res)
+	if err != nil {
+		rw.Header().Del("Content-Disposition")
+		response.RenderInternalError(rw)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/zip")
+	rw.WriteHeader(http.StatusOK)
+	rw.Write(content)
+}
+
+// toZip puts every part of the export into its own JSON file of the archive.
+func toZip(res Result) ([]byte, error) {
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{name: "profile.json", content: res.Profile},
+		{name: "channels.json", content: res.Channels},
+		{name: "reminders.json", content: res.Reminders},
+		{name: "deliveries.json", content: res.Deliveries},
+		{name: "sessions.json", content: res.Sessions},
+		{name: "api_tokens.json", content: res.APITokens},
+		{name: "external_identities.json", content: res.ExternalIdentities},
+		{name: "plan.json", content: res.Plan},
+	}
+
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.NewEncoder(w).Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -37,6 +37,10 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		response.RenderUnauthorized(rw)
 		return
 	}
+	if errors.Is(err, user.ErrInsufficientScope) {
+		response.RenderForbidden(rw)
+		return
+	}
 	if err != nil {
 		response.RenderInternalError(rw)
 		return
@@ -8,6 +8,7 @@ import (
 	"remindme/internal/core/services"
 	service "remindme/internal/core/services/get_limit_for_sent_reminders"
 	"remindme/internal/http/handlers/response"
+	"time"
 )
 
 type Handler struct {
@@ -24,7 +25,9 @@ func New(
 }
 
 type Result struct {
-	Limit *response.Limit `json:"limit"`
+	Limit   *response.Limit `json:"limit"`
+	Window  *string         `json:"window"`
+	ResetAt *time.Time      `json:"reset_at"`
 }
 
 func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
@@ -36,6 +39,10 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		response.RenderUnauthorized(rw)
 		return
 	}
+	if errors.Is(err, user.ErrInsufficientScope) {
+		response.RenderForbidden(rw)
+		return
+	}
 	if err != nil {
 		response.RenderInternalError(rw)
 		return
@@ -46,6 +53,9 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		limit := &response.Limit{}
 		limit.FromDomain(result.Limit.Value)
 		res.Limit = limit
+		window := string(result.Window)
+		res.Window = &window
+		res.ResetAt = &result.ResetAt
 	}
 
 	response.Render(rw, res, http.StatusOK)
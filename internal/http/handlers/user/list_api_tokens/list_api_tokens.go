@@ -0,0 +1,49 @@
+package listapitokens
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/list_api_tokens"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	Tokens []response.APIToken `json:"tokens"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Run(r.Context(), service.Input{})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	respTokens := make([]response.APIToken, len(result.Tokens))
+	for ix, token := range result.Tokens {
+		respTokens[ix].FromDomainAPIToken(token)
+	}
+	response.Render(rw, Result{Tokens: respTokens}, http.StatusOK)
+}
@@ -0,0 +1,55 @@
+package listusersessions
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/list_user_sessions"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+type Result struct {
+	Sessions []response.Session `json:"sessions"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token, ok := auth.ParseToken(r)
+	if !ok {
+		response.RenderUnauthorized(rw)
+		return
+	}
+	result, err := h.service.Run(r.Context(), service.Input{Token: token})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	respSessions := make([]response.Session, len(result.Sessions))
+	for ix, session := range result.Sessions {
+		respSessions[ix].FromDomainSession(session)
+	}
+	response.Render(rw, Result{Sessions: respSessions}, http.StatusOK)
+}
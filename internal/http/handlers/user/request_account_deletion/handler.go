@@ -0,0 +1,96 @@
+package requestaccountdeletion
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	requestaccountdeletion "remindme/internal/core/services/request_account_deletion"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Handler struct {
+	service services.Service[requestaccountdeletion.Input, requestaccountdeletion.Result]
+}
+
+func New(
+	service services.Service[requestaccountdeletion.Input, requestaccountdeletion.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Input confirms the deletion with the current password, users without a password
+// omit it and must have logged in recently instead.
+type Input struct {
+	Password string `json:"password"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Password, validation.Length(0, 256)),
+	)
+}
+
+type Result struct {
+	Deletion response.AccountDeletion `json:"deletion"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token, ok := auth.ParseToken(r)
+	if !ok {
+		response.RenderUnauthorized(rw)
+		return
+	}
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		requestaccountdeletion.Input{Password: user.RawPassword(input.Password), Token: token},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case errors.Is(err, user.ErrInvalidCredentials):
+			response.RenderError(rw, "invalid password", http.StatusUnprocessableEntity)
+		case errors.Is(err, user.ErrReauthenticationRequired):
+			response.RenderError(rw, "log in again to confirm the deletion", http.StatusForbidden)
+		case errors.Is(err, user.ErrAccountDeletionAlreadyRequested):
+			response.RenderError(rw, "account deletion is already requested", http.StatusConflict)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{}
+	res.Deletion.FromDomainAccountDeletion(result.Deletion)
+	response.Render(rw, res, http.StatusAccepted)
+}
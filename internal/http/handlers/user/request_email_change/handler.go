@@ -0,0 +1,96 @@
+package requestemailchange
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	requestemailchange "remindme/internal/core/services/request_email_change"
+	"remindme/internal/http/handlers/response"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+type Handler struct {
+	service services.Service[requestemailchange.Input, requestemailchange.Result]
+}
+
+func New(
+	service services.Service[requestemailchange.Input, requestemailchange.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+// Input confirms the change with the current password, users without a password may omit it.
+type Input struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (i *Input) FromJSON(r io.Reader) error {
+	e := json.NewDecoder(r)
+	return e.Decode(i)
+}
+
+func (i Input) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Email, validation.Required, is.Email, validation.Length(0, 512)),
+		validation.Field(&i.Password, validation.Length(0, 256)),
+	)
+}
+
+type Result struct {
+	Change response.EmailChange `json:"email_change"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	input := Input{}
+	if err := input.FromJSON(r.Body); err != nil {
+		response.RenderError(rw, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		response.Render(rw, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(
+		r.Context(),
+		requestemailchange.Input{
+			Email:    c.NewEmail(input.Email),
+			Password: user.RawPassword(input.Password),
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+			response.RenderRateLimitExceeded(rw)
+		case errors.Is(err, user.ErrInvalidCredentials):
+			response.RenderError(rw, "invalid password", http.StatusUnprocessableEntity)
+		case errors.Is(err, user.ErrEmailIsNotSet), errors.Is(err, user.ErrEmailIsNotChanged):
+			response.RenderError(rw, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, user.ErrEmailAlreadyExists):
+			response.RenderError(rw, "email already exists", http.StatusConflict)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+
+	res := Result{}
+	res.Change.FromDomainEmailChange(result.Change)
+	response.Render(rw, res, http.StatusAccepted)
+}
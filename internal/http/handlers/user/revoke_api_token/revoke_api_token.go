@@ -0,0 +1,52 @@
+package revokeapitoken
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/revoke_api_token"
+	"remindme/internal/http/handlers/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rawTokenID := chi.URLParam(r, "tokenID")
+	tokenID, err := strconv.ParseInt(rawTokenID, 10, 64)
+	if err != nil {
+		response.RenderError(rw, "invalid token ID", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.service.Run(r.Context(), service.Input{APITokenID: user.APITokenID(tokenID)})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		case errors.Is(err, user.ErrAPITokenDoesNotExist):
+			response.RenderError(rw, err.Error(), http.StatusNotFound)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+	response.Render(rw, struct{}{}, http.StatusOK)
+}
@@ -0,0 +1,46 @@
+package revokeothersessions
+
+import (
+	"errors"
+	"net/http"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"remindme/internal/core/services"
+	service "remindme/internal/core/services/revoke_other_sessions"
+	"remindme/internal/http/handlers/auth"
+	"remindme/internal/http/handlers/response"
+)
+
+type Handler struct {
+	service services.Service[service.Input, service.Result]
+}
+
+func New(
+	service services.Service[service.Input, service.Result],
+) *Handler {
+	if service == nil {
+		panic(e.NewNilArgumentError("service"))
+	}
+	return &Handler{service: service}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token, ok := auth.ParseToken(r)
+	if !ok {
+		response.RenderUnauthorized(rw)
+		return
+	}
+	_, err := h.service.Run(r.Context(), service.Input{Token: token})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDoesNotExist):
+			response.RenderUnauthorized(rw)
+		case errors.Is(err, user.ErrInsufficientScope):
+			response.RenderForbidden(rw)
+		default:
+			response.RenderInternalError(rw)
+		}
+		return
+	}
+	response.Render(rw, struct{}{}, http.StatusOK)
+}
@@ -23,6 +23,10 @@ type EmailSender struct {
 	passwordResetTemplate     string
 	passwordResetBaseUrl      url.URL
 	channelActivationTemplate string
+	emailChangeTemplate       string
+	emailChangeBaseUrl        url.URL
+	emailChangedTemplate      string
+	emailRevertBaseUrl        url.URL
 }
 
 func NewEmailSender(
@@ -33,6 +37,10 @@ func NewEmailSender(
 	passwordResetTemplate string,
 	passwordResetBaseUrl url.URL,
 	channelActivationTemplate string,
+	emailChangeTemplate string,
+	emailChangeBaseUrl url.URL,
+	emailChangedTemplate string,
+	emailRevertBaseUrl url.URL,
 ) *EmailSender {
 	return &EmailSender{
 		ses:                       ses.NewFromConfig(awsConfig),
@@ -42,6 +50,10 @@ func NewEmailSender(
 		passwordResetTemplate:     passwordResetTemplate,
 		passwordResetBaseUrl:      passwordResetBaseUrl,
 		channelActivationTemplate: channelActivationTemplate,
+		emailChangeTemplate:       emailChangeTemplate,
+		emailChangeBaseUrl:        emailChangeBaseUrl,
+		emailChangedTemplate:      emailChangedTemplate,
+		emailRevertBaseUrl:        emailRevertBaseUrl,
 	}
 }
 
@@ -147,6 +159,70 @@ func (s *EmailSender) SendVerificationToken(
 	return err
 }
 
+func (s *EmailSender) SendEmailChangeConfirmation(
+	ctx context.Context,
+	change user.EmailChange,
+	token user.EmailChangeToken,
+) error {
+	templateParamsBytes, err := json.Marshal(
+		emailChangeTemplateParams{
+			Email:           string(change.NewEmail),
+			ConfirmationUrl: s.emailChangeBaseUrl.JoinPath(string(token)).String(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	templateParams := string(templateParamsBytes)
+
+	email := string(change.NewEmail)
+	_, err = s.ses.SendTemplatedEmail(
+		ctx,
+		&ses.SendTemplatedEmailInput{
+			Source: &s.sender,
+			Destination: &types.Destination{
+				CcAddresses: []string{},
+				ToAddresses: []string{email},
+			},
+			Template:     &s.emailChangeTemplate,
+			TemplateData: &templateParams,
+		},
+	)
+	return err
+}
+
+func (s *EmailSender) SendEmailChangeNotification(
+	ctx context.Context,
+	change user.EmailChange,
+	revertToken user.EmailChangeToken,
+) error {
+	templateParamsBytes, err := json.Marshal(
+		emailChangedTemplateParams{
+			Email:     string(change.NewEmail),
+			RevertUrl: s.emailRevertBaseUrl.JoinPath(string(revertToken)).String(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	templateParams := string(templateParamsBytes)
+
+	email := string(change.OldEmail)
+	_, err = s.ses.SendTemplatedEmail(
+		ctx,
+		&ses.SendTemplatedEmailInput{
+			Source: &s.sender,
+			Destination: &types.Destination{
+				CcAddresses: []string{},
+				ToAddresses: []string{email},
+			},
+			Template:     &s.emailChangedTemplate,
+			TemplateData: &templateParams,
+		},
+	)
+	return err
+}
+
 type accountActivationTemplateParams struct {
 	ActivationCode string `json:"activationCode"`
 	ActivationUrl  string `json:"activationUrl"`
@@ -159,3 +235,13 @@ type passwordResetTemplateParams struct {
 type channelActivationTemplateParams struct {
 	ActivationCode string `json:"activationCode"`
 }
+
+type emailChangeTemplateParams struct {
+	Email           string `json:"email"`
+	ConfirmationUrl string `json:"confirmationUrl"`
+}
+
+type emailChangedTemplateParams struct {
+	Email     string `json:"email"`
+	RevertUrl string `json:"revertUrl"`
+}
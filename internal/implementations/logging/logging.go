@@ -4,23 +4,101 @@ import (
 	"context"
 	"fmt"
 	"remindme/internal/core/domain/logging"
+	"sort"
+	"strings"
 
 	"github.com/getsentry/sentry-go"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+type Options struct {
+	// Level is one of debug, info, warn and error.
+	Level string
+	// PackageLevels override Level for loggers named by a package path relative to the module,
+	// e.g. "internal/rabbitmq". The longest matching path wins and also applies to its subpackages.
+	PackageLevels map[string]string
+	// Development switches JSON output to colored console output.
+	Development bool
+}
+
+type packageLevel struct {
+	path  string
+	level zapcore.Level
+}
+
+// ZapLogger writes entries of its level and above. Loggers of packages are created
+// with Named, so that the package level is resolved once instead of on every call.
 type ZapLogger struct {
-	logger *zap.Logger
-	sugar  *zap.SugaredLogger
+	logger        *zap.Logger
+	level         zapcore.Level
+	packageLevels []packageLevel
+	minLevel      zapcore.Level
 }
 
-func NewZapLogger() *ZapLogger {
-	logger, err := zap.NewProduction(zap.AddCallerSkip(1))
+func NewZapLogger(options Options) (*ZapLogger, error) {
+	level, packageLevels, err := parseLevels(options)
 	if err != nil {
-		panic("Could not create Zap logger.")
+		return nil, err
 	}
-	sugar := logger.Sugar()
-	return &ZapLogger{logger: logger, sugar: sugar}
+
+	config := zap.NewProductionConfig()
+	if options.Development {
+		config = zap.NewDevelopmentConfig()
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+	logger := newZapLogger(nil, level, packageLevels)
+	config.Level = zap.NewAtomicLevelAt(logger.minLevel)
+	logger.logger, err = config.Build(zap.AddCallerSkip(2))
+	if err != nil {
+		return nil, err
+	}
+	return logger, nil
+}
+
+func newZapLogger(logger *zap.Logger, level zapcore.Level, packageLevels []packageLevel) *ZapLogger {
+	l := &ZapLogger{
+		logger:        logger,
+		level:         level,
+		packageLevels: packageLevels,
+		minLevel:      level,
+	}
+	for _, pl := range packageLevels {
+		if pl.level < l.minLevel {
+			l.minLevel = pl.level
+		}
+	}
+	return l
+}
+
+// Named returns a logger of the package, path is relative to the module like in Options.PackageLevels.
+func (l *ZapLogger) Named(path string) *ZapLogger {
+	path = strings.Trim(path, "/")
+	return &ZapLogger{
+		logger:        l.logger.Named(path),
+		level:         l.packageLevel(path),
+		packageLevels: l.packageLevels,
+		minLevel:      l.minLevel,
+	}
+}
+
+func parseLevels(options Options) (zapcore.Level, []packageLevel, error) {
+	level, err := zapcore.ParseLevel(options.Level)
+	if err != nil {
+		return level, nil, err
+	}
+	packageLevels := make([]packageLevel, 0, len(options.PackageLevels))
+	for path, rawLevel := range options.PackageLevels {
+		l, err := zapcore.ParseLevel(rawLevel)
+		if err != nil {
+			return level, nil, fmt.Errorf("invalid log level of package %s: %w", path, err)
+		}
+		packageLevels = append(packageLevels, packageLevel{path: strings.Trim(path, "/"), level: l})
+	}
+	sort.Slice(packageLevels, func(i, j int) bool {
+		return len(packageLevels[i].path) > len(packageLevels[j].path)
+	})
+	return level, packageLevels, nil
 }
 
 func (l *ZapLogger) Sync() {
@@ -28,19 +106,19 @@ func (l *ZapLogger) Sync() {
 }
 
 func (l *ZapLogger) Debug(ctx context.Context, msg string, entries ...logging.LogEntry) {
-	l.sugar.Debugw(msg, prepareArgs(entries...)...)
+	l.log(ctx, zapcore.DebugLevel, msg, entries)
 }
 
 func (l *ZapLogger) Info(ctx context.Context, msg string, entries ...logging.LogEntry) {
-	l.sugar.Infow(msg, prepareArgs(entries...)...)
+	l.log(ctx, zapcore.InfoLevel, msg, entries)
 }
 
 func (l *ZapLogger) Warning(ctx context.Context, msg string, entries ...logging.LogEntry) {
-	l.sugar.Warnw(msg, prepareArgs(entries...)...)
+	l.log(ctx, zapcore.WarnLevel, msg, entries)
 }
 
 func (l *ZapLogger) Error(ctx context.Context, msg string, entries ...logging.LogEntry) {
-	l.sugar.Errorw(msg, prepareArgs(entries...)...)
+	l.log(ctx, zapcore.ErrorLevel, msg, entries)
 	hub := sentry.GetHubFromContext(ctx)
 	if hub == nil {
 		hub = sentry.CurrentHub().Clone()
@@ -49,18 +127,54 @@ func (l *ZapLogger) Error(ctx context.Context, msg string, entries ...logging.Lo
 		hub.WithScope(func(scope *sentry.Scope) {
 			e := make(map[string]interface{}, len(entries))
 			for _, entry := range entries {
-				e[entry.Key] = fmt.Sprintf("%+v", entry.Value)
+				e[entry.Key] = fmt.Sprintf("%+v", redact(entry.Key, entry.Value))
 			}
 			scope.SetContext("entries", e)
+			for _, entry := range logging.ContextEntries(ctx) {
+				scope.SetTag(entry.Key, fmt.Sprintf("%v", entry.Value))
+			}
 			hub.CaptureMessage(msg)
 		})
 	}
 }
 
-func prepareArgs(entries ...logging.LogEntry) []interface{} {
-	args := make([]interface{}, 0, len(entries)*2)
+func (l *ZapLogger) log(ctx context.Context, level zapcore.Level, msg string, entries []logging.LogEntry) {
+	if level < l.level {
+		return
+	}
+	if ce := l.logger.Check(level, msg); ce != nil {
+		ce.Write(prepareFields(ctx, entries)...)
+	}
+}
+
+func (l *ZapLogger) packageLevel(path string) zapcore.Level {
+	for _, pl := range l.packageLevels {
+		if path == pl.path || strings.HasPrefix(path, pl.path+"/") {
+			return pl.level
+		}
+	}
+	return l.level
+}
+
+func prepareFields(ctx context.Context, entries []logging.LogEntry) []zap.Field {
+	contextEntries := logging.ContextEntries(ctx)
+	fields := make([]zap.Field, 0, len(contextEntries)+len(entries))
+	for _, ce := range contextEntries {
+		if !hasKey(entries, ce.Key) {
+			fields = append(fields, zap.Any(ce.Key, ce.Value))
+		}
+	}
+	for _, e := range entries {
+		fields = append(fields, zap.Any(e.Key, redact(e.Key, e.Value)))
+	}
+	return fields
+}
+
+func hasKey(entries []logging.LogEntry, key string) bool {
 	for _, e := range entries {
-		args = append(args, e.Key, e.Value)
+		if e.Key == key {
+			return true
+		}
 	}
-	return args
+	return false
 }
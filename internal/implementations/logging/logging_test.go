@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/channel"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/user"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testSuite struct {
+	suite.Suite
+}
+
+func TestZapLogger(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) newLogger(level string, packageLevels map[string]string) (*ZapLogger, *observer.ObservedLogs) {
+	l, pls, err := parseLevels(Options{Level: level, PackageLevels: packageLevels})
+	s.Require().Nil(err)
+	logger := newZapLogger(nil, l, pls)
+	core, logs := observer.New(logger.minLevel)
+	logger.logger = zap.New(core, zap.AddCallerSkip(2))
+	return logger, logs
+}
+
+func (s *testSuite) TestContextEntries() {
+	logger, logs := s.newLogger("info", nil)
+
+	ctx := logging.WithRequestID(context.Background(), "request-1")
+	ctx = logging.WithReminderID(logging.WithUserID(ctx, 42), 7)
+	logger.Info(ctx, "Test.", logging.Entry("foo", "bar"))
+
+	s.Require().Equal(1, logs.Len())
+	s.Equal(
+		map[string]interface{}{"requestID": "request-1", "userID": int64(42), "reminderID": int64(7), "foo": "bar"},
+		logs.All()[0].ContextMap(),
+	)
+}
+
+func (s *testSuite) TestExplicitEntryWins() {
+	logger, logs := s.newLogger("info", nil)
+
+	logger.Info(logging.WithUserID(context.Background(), 42), "Test.", logging.Entry("userID", 1))
+
+	s.Require().Equal(1, logs.Len())
+	s.Len(logs.All()[0].Context, 1)
+	s.Equal(int64(1), logs.All()[0].ContextMap()["userID"])
+}
+
+func (s *testSuite) TestRedaction() {
+	logger, logs := s.newLogger("info", nil)
+
+	type input struct {
+		Email        c.Email
+		OptionalMail c.Optional[c.Email]
+		Token        user.SessionToken
+		Password     user.RawPassword
+		Settings     channel.Settings
+		Description  string
+	}
+	logger.Info(
+		context.Background(),
+		"Test.",
+		logging.Entry("input", input{
+			Email:        c.NewEmail("john@example.com"),
+			OptionalMail: c.NewOptional(c.NewEmail("jane@example.com"), true),
+			Token:        user.SessionToken("session-token"),
+			Password:     user.RawPassword("password"),
+			Settings:     channel.NewTelegramSettings(channel.TelegramBot("bot"), channel.TelegramChatID(123456789)),
+			Description:  "write to bob@example.com",
+		}),
+		logging.Entry("err", errors.New("Post \"https://api.telegram.org/bot123:secret-token/sendMessage\": timeout")),
+		logging.Entry("chatID", int64(987654321)),
+		logging.Entry("activationToken", "activation-token"),
+	)
+
+	s.Require().Equal(1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	s.Equal(
+		map[string]interface{}{
+			"Email":        "j***@example.com",
+			"OptionalMail": map[string]interface{}{"Value": "j***@example.com", "IsPresent": true},
+			"Token":        logging.Redacted,
+			"Password":     logging.Redacted,
+			"Settings":     map[string]interface{}{"Bot": "bot", "ChatID": "***789"},
+			"Description":  "write to b***@example.com",
+		},
+		fields["input"],
+	)
+	s.Equal("Post \"https://api.telegram.org/bot***/sendMessage\": timeout", fields["err"])
+	s.Equal("***321", fields["chatID"])
+	s.Equal(logging.Redacted, fields["activationToken"])
+}
+
+func (s *testSuite) TestPackageLevels() {
+	logger, logs := s.newLogger("info", map[string]string{"internal/implementations": "debug"})
+	logger.Debug(context.Background(), "Not logged, the root logger has the default level.")
+	logger.Named("internal/implementations/logging").Debug(context.Background(), "Logged.")
+	s.Equal(1, logs.Len())
+	s.Equal("internal/implementations/logging", logs.All()[0].LoggerName)
+
+	logger, logs = s.newLogger("info", map[string]string{
+		"internal/implementations":         "debug",
+		"internal/implementations/logging": "warn",
+	})
+	named := logger.Named("internal/implementations/logging")
+	named.Debug(context.Background(), "Not logged.")
+	named.Info(context.Background(), "Not logged.")
+	logging.Error(context.Background(), named, errors.New("logged"))
+	s.Equal(1, logs.Len())
+	s.Equal(zapcore.ErrorLevel, logs.All()[0].Level)
+
+	logger, logs = s.newLogger("info", map[string]string{"internal/implementations/log": "debug"})
+	logger.Named("internal/implementations/logging").Debug(
+		context.Background(),
+		"Not logged, only whole path elements match.",
+	)
+	s.Equal(0, logs.Len())
+}
+
+func (s *testSuite) TestInvalidLevel() {
+	_, err := NewZapLogger(Options{Level: "verbose"})
+	s.NotNil(err)
+	_, err = NewZapLogger(Options{Level: "info", PackageLevels: map[string]string{"internal/db": "verbose"}})
+	s.NotNil(err)
+}
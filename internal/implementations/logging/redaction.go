@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"remindme/internal/core/domain/logging"
+	"strings"
+	"time"
+)
+
+const maxRedactionDepth = 10
+
+var (
+	emailRegexp = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	// Telegram bot tokens are part of Bot API URLs, which show up in HTTP client errors.
+	telegramBotTokenRegexp = regexp.MustCompile(`bot\d+:[\w\-]+`)
+)
+
+// redact returns a copy of a log entry value safe to be written: values implementing
+// logging.Redactable are replaced by their redacted form, values under secret looking keys are hidden,
+// emails and Telegram bot tokens are masked in strings and errors. Structs, maps and slices
+// are walked and returned as maps and slices.
+func redact(key string, value interface{}) interface{} {
+	return redactField(key, reflect.ValueOf(value), 0)
+}
+
+func redactField(key string, v reflect.Value, depth int) interface{} {
+	k := strings.ToLower(key)
+	if isSecretKey(k) {
+		if isZero(v) {
+			return nil
+		}
+		return logging.Redacted
+	}
+	if strings.Contains(k, "chatid") || strings.Contains(k, "chat_id") {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			return logging.MaskID(v.Int())
+		}
+	}
+	return redactValue(v, depth)
+}
+
+func isSecretKey(key string) bool {
+	return strings.Contains(key, "password") || strings.Contains(key, "token") || strings.Contains(key, "secret")
+}
+
+func isZero(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
+
+func redactText(s string) string {
+	s = emailRegexp.ReplaceAllStringFunc(s, logging.MaskEmail)
+	return telegramBotTokenRegexp.ReplaceAllString(s, "bot"+logging.Redacted)
+}
+
+func redactValue(v reflect.Value, depth int) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > maxRedactionDepth {
+		return logging.Redacted
+	}
+	if v.CanInterface() {
+		switch value := v.Interface().(type) {
+		case logging.Redactable:
+			if v.Kind() == reflect.Pointer && v.IsNil() {
+				return nil
+			}
+			return value.Redact()
+		case error:
+			if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+				return nil
+			}
+			return redactText(value.Error())
+		case time.Time, time.Duration:
+			return value
+		case fmt.Stringer:
+			if !isWalkable(v) && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+				return redactText(value.String())
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() {
+				fields[field.Name] = redactField(field.Name, v.Field(i), depth+1)
+			}
+		}
+		return fields
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		items := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprintf("%v", redactValue(iter.Key(), depth+1))
+			items[key] = redactField(key, iter.Value(), depth+1)
+		}
+		return items
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			return redactText(string(v.Bytes()))
+		}
+		items := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = redactValue(v.Index(i), depth+1)
+		}
+		return items
+	case reflect.String:
+		return redactText(v.String())
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return nil
+}
+
+// isWalkable reports if a value is a struct, or a pointer to it, with exported fields.
+// Such values are walked field by field instead of being formatted with String.
+func isWalkable(v reflect.Value) bool {
+	t := v.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"remindme/internal/core/domain/channel"
+	e "remindme/internal/core/domain/errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "remindme"
+
+// Prometheus implements domain metrics and the infrastructure ones:
+// HTTP requests latency and queue lag.
+type Prometheus struct {
+	httpRequestDuration *prometheus.HistogramVec
+	remindersScheduled  prometheus.Counter
+	schedulingFailed    prometheus.Counter
+	remindersSent       *prometheus.CounterVec
+	remindersFailed     *prometheus.CounterVec
+	rateLimitRejections *prometheus.CounterVec
+	queueLag            *prometheus.HistogramVec
+}
+
+func NewPrometheus(registerer prometheus.Registerer) *Prometheus {
+	if registerer == nil {
+		panic(e.NewNilArgumentError("registerer"))
+	}
+	m := &Prometheus{
+		httpRequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "http_request_duration_seconds",
+				Help:      "HTTP requests latency by chi route pattern.",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"method", "route", "status"},
+		),
+		remindersScheduled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reminders_scheduled_total",
+			Help:      "Reminders published to the queue for sending.",
+		}),
+		schedulingFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reminders_scheduling_failed_total",
+			Help:      "Reminders which could not be published to the queue.",
+		}),
+		remindersSent: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reminders_sent_total",
+				Help:      "Reminders sent by channel type.",
+			},
+			[]string{"channel_type"},
+		),
+		remindersFailed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reminders_failed_total",
+				Help:      "Reminders which could not be sent by channel type.",
+			},
+			[]string{"channel_type"},
+		),
+		rateLimitRejections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limit_rejections_total",
+				Help:      "Requests rejected by rate limits.",
+			},
+			[]string{"limit"},
+		),
+		queueLag: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "queue_lag_seconds",
+				Help:      "Delay between the time a message is due and the time it is taken for processing.",
+				Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+			},
+			[]string{"queue"},
+		),
+	}
+	registerer.MustRegister(
+		m.httpRequestDuration,
+		m.remindersScheduled,
+		m.schedulingFailed,
+		m.remindersSent,
+		m.remindersFailed,
+		m.rateLimitRejections,
+		m.queueLag,
+	)
+	return m
+}
+
+func (m *Prometheus) RemindersScheduled(scheduled, failed int) {
+	m.remindersScheduled.Add(float64(scheduled))
+	m.schedulingFailed.Add(float64(failed))
+}
+
+func (m *Prometheus) ReminderSent(channelType channel.Type, err error) {
+	if err != nil {
+		m.remindersFailed.WithLabelValues(string(channelType)).Inc()
+		return
+	}
+	m.remindersSent.WithLabelValues(string(channelType)).Inc()
+}
+
+func (m *Prometheus) RateLimitExceeded(limit string) {
+	m.rateLimitRejections.WithLabelValues(limit).Inc()
+}
+
+func (m *Prometheus) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (m *Prometheus) ObserveQueueLag(queue string, lag time.Duration) {
+	if lag < 0 {
+		lag = 0
+	}
+	m.queueLag.WithLabelValues(queue).Observe(lag.Seconds())
+}
@@ -0,0 +1,120 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GitHubOptions struct {
+	ClientID         string
+	ClientSecret     string
+	AuthorizationURL url.URL
+	TokenURL         url.URL
+	APIURL           url.URL
+	RedirectURL      url.URL
+}
+
+// GitHubProvider implements the same flow for GitHub, which supports OAuth2 with PKCE but
+// does not issue ID tokens. The user is read from the API with the access token instead,
+// so there is no nonce to check, the state and the code verifier protect the flow.
+type GitHubProvider struct {
+	options    GitHubOptions
+	httpClient *http.Client
+}
+
+func NewGitHub(options GitHubOptions, timeout time.Duration, transport http.RoundTripper) *GitHubProvider {
+	return &GitHubProvider{
+		options:    options,
+		httpClient: &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+func (p *GitHubProvider) AuthorizationURL(state user.OIDCState, nonce user.OIDCNonce, codeChallenge string) string {
+	query := url.Values{}
+	query.Set("client_id", p.options.ClientID)
+	query.Set("redirect_uri", p.options.RedirectURL.String())
+	query.Set("scope", "read:user user:email")
+	query.Set("state", string(state))
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authorizationURL := p.options.AuthorizationURL
+	authorizationURL.RawQuery = query.Encode()
+	return authorizationURL.String()
+}
+
+type gitHubTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+}
+
+type gitHubUser struct {
+	ID int64 `json:"id"`
+}
+
+type gitHubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+func (p *GitHubProvider) Exchange(
+	ctx context.Context,
+	code user.OIDCAuthorizationCode,
+	codeVerifier user.OIDCCodeVerifier,
+	nonce user.OIDCNonce,
+) (claims user.OIDCClaims, err error) {
+	form := url.Values{}
+	form.Set("code", string(code))
+	form.Set("redirect_uri", p.options.RedirectURL.String())
+	form.Set("client_id", p.options.ClientID)
+	form.Set("client_secret", p.options.ClientSecret)
+	form.Set("code_verifier", string(codeVerifier))
+
+	token := gitHubTokenResponse{}
+	if err := postForm(ctx, p.httpClient, p.options.TokenURL, form, &token); err != nil {
+		return claims, err
+	}
+	// GitHub responds with 200 to invalid codes as well.
+	if token.Error != "" || token.AccessToken == "" {
+		return claims, fmt.Errorf("%w: %s", user.ErrOIDCAuthenticationFailed, token.Error)
+	}
+
+	u := gitHubUser{}
+	if err := p.get(ctx, "user", token.AccessToken, &u); err != nil {
+		return claims, err
+	}
+	if u.ID == 0 {
+		return claims, fmt.Errorf("%w: GitHub user has no ID", user.ErrOIDCAuthenticationFailed)
+	}
+	emails := []gitHubEmail{}
+	if err := p.get(ctx, "user/emails", token.AccessToken, &emails); err != nil {
+		return claims, err
+	}
+
+	claims.Subject = strconv.FormatInt(u.ID, 10)
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			claims.Email = c.NewOptional(c.NewEmail(email.Email), true)
+		}
+	}
+	return claims, nil
+}
+
+func (p *GitHubProvider) get(ctx context.Context, path string, accessToken string, v interface{}) error {
+	endpoint := p.options.APIURL
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/" + path
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+	return doJSON(p.httpClient, request, v)
+}
@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ACCESS_TOKEN = "test-access-token"
+
+func newFakeGitHub(t *testing.T, emails []gitHubEmail) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// GitHub rejects invalid codes with 200.
+		if r.PostForm.Get("code") != CODE || r.PostForm.Get("code_verifier") != CODE_VERIFIER {
+			json.NewEncoder(rw).Encode(gitHubTokenResponse{Error: "bad_verification_code"})
+			return
+		}
+		json.NewEncoder(rw).Encode(gitHubTokenResponse{AccessToken: ACCESS_TOKEN})
+	})
+	authorized := func(handler func(rw http.ResponseWriter)) http.HandlerFunc {
+		return func(rw http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+ACCESS_TOKEN {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler(rw)
+		}
+	}
+	mux.HandleFunc("/api/user", authorized(func(rw http.ResponseWriter) {
+		json.NewEncoder(rw).Encode(gitHubUser{ID: 123})
+	}))
+	mux.HandleFunc("/api/user/emails", authorized(func(rw http.ResponseWriter) {
+		json.NewEncoder(rw).Encode(emails)
+	}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newGitHubProvider(t *testing.T, server *httptest.Server) *GitHubProvider {
+	parse := func(path string) url.URL {
+		u, err := url.Parse(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *u
+	}
+	return NewGitHub(
+		GitHubOptions{
+			ClientID:         CLIENT_ID,
+			ClientSecret:     CLIENT_SECRET,
+			AuthorizationURL: parse("/login/oauth/authorize"),
+			TokenURL:         parse("/login/oauth/access_token"),
+			APIURL:           parse("/api"),
+			RedirectURL:      url.URL{Scheme: "https", Host: "remindme.test", Path: "/oidc/github"},
+		},
+		time.Second,
+		nil,
+	)
+}
+
+func TestGitHubExchange(t *testing.T) {
+	server := newFakeGitHub(t, []gitHubEmail{
+		{Email: "other@test.test", Primary: false, Verified: true},
+		{Email: "test@test.test", Primary: true, Verified: true},
+	})
+	provider := newGitHubProvider(t, server)
+
+	claims, err := provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	assert.Nil(t, err)
+	assert.Equal(t, user.OIDCClaims{Subject: "123", Email: c.NewOptional(c.NewEmail("test@test.test"), true)}, claims)
+}
+
+func TestGitHubUnverifiedEmailIgnored(t *testing.T) {
+	server := newFakeGitHub(t, []gitHubEmail{{Email: "test@test.test", Primary: true, Verified: false}})
+	provider := newGitHubProvider(t, server)
+
+	claims, err := provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "123", claims.Subject)
+	assert.False(t, claims.Email.IsPresent)
+}
+
+func TestGitHubInvalidCode(t *testing.T) {
+	server := newFakeGitHub(t, nil)
+	provider := newGitHubProvider(t, server)
+
+	_, err := provider.Exchange(context.Background(), "other-code", CODE_VERIFIER, NONCE)
+
+	assert.ErrorIs(t, err, user.ErrOIDCAuthenticationFailed)
+}
+
+func TestGitHubAuthorizationURL(t *testing.T) {
+	provider := newGitHubProvider(t, newFakeGitHub(t, nil))
+
+	authorizationURL, err := url.Parse(provider.AuthorizationURL("test-state", NONCE, "test-challenge"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "test-state", authorizationURL.Query().Get("state"))
+	assert.Equal(t, "test-challenge", authorizationURL.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", authorizationURL.Query().Get("code_challenge_method"))
+	assert.Equal(t, "https://remindme.test/oidc/github", authorizationURL.Query().Get("redirect_uri"))
+}
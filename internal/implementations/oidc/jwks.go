@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"remindme/internal/core/domain/user"
+	"sync"
+	"time"
+)
+
+// KEYS_REFRESH_INTERVAL limits how often keys are fetched for an unknown key ID,
+// so tokens with made up key IDs do not make us hammer the issuer.
+const KEYS_REFRESH_INTERVAL = time.Minute
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// keySet caches RSA keys of the issuer, keys are fetched again when a token
+// is signed with an unknown key, i.e. after the issuer rotated its keys.
+type keySet struct {
+	httpClient *http.Client
+	url        url.URL
+	now        func() time.Time
+	keys       map[string]*rsa.PublicKey
+	fetchedAt  time.Time
+	lock       sync.Mutex
+}
+
+func newKeySet(httpClient *http.Client, url url.URL, now func() time.Time) *keySet {
+	return &keySet{httpClient: httpClient, url: url, now: now}
+}
+
+func (s *keySet) get(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	if s.keys != nil && s.now().Sub(s.fetchedAt) < KEYS_REFRESH_INTERVAL {
+		return nil, fmt.Errorf("%w: unknown ID token key %q", user.ErrOIDCAuthenticationFailed, kid)
+	}
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown ID token key %q", user.ErrOIDCAuthenticationFailed, kid)
+}
+
+func (s *keySet) fetch(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url.String(), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	set := jsonWebKeySet{}
+	if err := doJSON(s.httpClient, request, &set); err != nil {
+		return fmt.Errorf("could not fetch JWKS: %v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := decodeRSAKey(jwk)
+		if err != nil {
+			return err
+		}
+		keys[jwk.Kid] = key
+	}
+	s.keys = keys
+	s.fetchedAt = s.now()
+	return nil
+}
+
+func decodeRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus of JWK %q: %w", jwk.Kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent of JWK %q: %w", jwk.Kid, err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent of JWK %q", jwk.Kid)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
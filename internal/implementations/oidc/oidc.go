@@ -0,0 +1,280 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	c "remindme/internal/core/domain/common"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/user"
+	"strings"
+	"time"
+)
+
+// CLOCK_SKEW is tolerated when expiration and issue times of ID tokens are checked.
+const CLOCK_SKEW = time.Minute
+
+type Options struct {
+	ClientID         string
+	ClientSecret     string
+	Issuer           string
+	AuthorizationURL url.URL
+	TokenURL         url.URL
+	JWKSURL          url.URL
+	RedirectURL      url.URL
+	Scopes           []string
+}
+
+// Provider implements the authorization code flow with PKCE of OpenID Connect,
+// ID tokens are verified with the keys published by the issuer.
+type Provider struct {
+	options    Options
+	httpClient *http.Client
+	keys       *keySet
+	now        func() time.Time
+}
+
+func New(
+	options Options,
+	timeout time.Duration,
+	transport http.RoundTripper,
+	now func() time.Time,
+) *Provider {
+	if now == nil {
+		panic(e.NewNilArgumentError("now"))
+	}
+	httpClient := &http.Client{Timeout: timeout, Transport: transport}
+	return &Provider{
+		options:    options,
+		httpClient: httpClient,
+		keys:       newKeySet(httpClient, options.JWKSURL, now),
+		now:        now,
+	}
+}
+
+func (p *Provider) AuthorizationURL(state user.OIDCState, nonce user.OIDCNonce, codeChallenge string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.options.ClientID)
+	query.Set("redirect_uri", p.options.RedirectURL.String())
+	query.Set("scope", strings.Join(p.options.Scopes, " "))
+	query.Set("state", string(state))
+	query.Set("nonce", string(nonce))
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authorizationURL := p.options.AuthorizationURL
+	authorizationURL.RawQuery = query.Encode()
+	return authorizationURL.String()
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+func (p *Provider) Exchange(
+	ctx context.Context,
+	code user.OIDCAuthorizationCode,
+	codeVerifier user.OIDCCodeVerifier,
+	nonce user.OIDCNonce,
+) (claims user.OIDCClaims, err error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", string(code))
+	form.Set("redirect_uri", p.options.RedirectURL.String())
+	form.Set("client_id", p.options.ClientID)
+	form.Set("client_secret", p.options.ClientSecret)
+	form.Set("code_verifier", string(codeVerifier))
+
+	response := tokenResponse{}
+	if err := postForm(ctx, p.httpClient, p.options.TokenURL, form, &response); err != nil {
+		return claims, err
+	}
+	if response.IDToken == "" {
+		return claims, fmt.Errorf("%w: token response has no ID token", user.ErrOIDCAuthenticationFailed)
+	}
+	return p.verifyIDToken(ctx, response.IDToken, nonce)
+}
+
+type idTokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Subject       string          `json:"sub"`
+	Audience      json.RawMessage `json:"aud"`
+	AuthorizedBy  string          `json:"azp"`
+	ExpiresAt     int64           `json:"exp"`
+	IssuedAt      int64           `json:"iat"`
+	Nonce         string          `json:"nonce"`
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"`
+}
+
+func (p *Provider) verifyIDToken(
+	ctx context.Context,
+	idToken string,
+	nonce user.OIDCNonce,
+) (claims user.OIDCClaims, err error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("%w: malformed ID token", user.ErrOIDCAuthenticationFailed)
+	}
+
+	header := idTokenHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return claims, err
+	}
+	if header.Alg != "RS256" {
+		return claims, fmt.Errorf("%w: unsupported ID token algorithm %q", user.ErrOIDCAuthenticationFailed, header.Alg)
+	}
+	key, err := p.keys.get(ctx, header.Kid)
+	if err != nil {
+		return claims, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, fmt.Errorf("%w: malformed ID token signature", user.ErrOIDCAuthenticationFailed)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return claims, fmt.Errorf("%w: invalid ID token signature", user.ErrOIDCAuthenticationFailed)
+	}
+
+	tokenClaims := idTokenClaims{}
+	if err := decodeSegment(parts[1], &tokenClaims); err != nil {
+		return claims, err
+	}
+	if err := p.validateClaims(tokenClaims, nonce); err != nil {
+		return claims, err
+	}
+
+	claims.Subject = tokenClaims.Subject
+	if tokenClaims.Email != "" && isTrue(tokenClaims.EmailVerified) {
+		claims.Email = c.NewOptional(c.NewEmail(tokenClaims.Email), true)
+	}
+	return claims, nil
+}
+
+func (p *Provider) validateClaims(claims idTokenClaims, nonce user.OIDCNonce) error {
+	now := p.now()
+	switch {
+	case claims.Issuer != p.options.Issuer:
+		return fmt.Errorf("%w: unexpected ID token issuer %q", user.ErrOIDCAuthenticationFailed, claims.Issuer)
+	case !hasAudience(claims.Audience, p.options.ClientID):
+		return fmt.Errorf("%w: ID token is issued for another audience", user.ErrOIDCAuthenticationFailed)
+	case !isAuthorizedParty(claims, p.options.ClientID):
+		return fmt.Errorf("%w: ID token is authorized for another party", user.ErrOIDCAuthenticationFailed)
+	case !now.Before(time.Unix(claims.ExpiresAt, 0).Add(CLOCK_SKEW)):
+		return fmt.Errorf("%w: ID token is expired", user.ErrOIDCAuthenticationFailed)
+	case time.Unix(claims.IssuedAt, 0).After(now.Add(CLOCK_SKEW)):
+		return fmt.Errorf("%w: ID token is issued in the future", user.ErrOIDCAuthenticationFailed)
+	case claims.Nonce != string(nonce):
+		return fmt.Errorf("%w: ID token nonce does not match", user.ErrOIDCAuthenticationFailed)
+	case claims.Subject == "":
+		return fmt.Errorf("%w: ID token has no subject", user.ErrOIDCAuthenticationFailed)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed ID token segment", user.ErrOIDCAuthenticationFailed)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed ID token segment", user.ErrOIDCAuthenticationFailed)
+	}
+	return nil
+}
+
+// hasAudience reports if aud, which is a string or an array of strings, contains clientID.
+func hasAudience(aud json.RawMessage, clientID string) bool {
+	for _, audience := range parseAudience(aud) {
+		if audience == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// isAuthorizedParty checks azp like OpenID Connect Core 3.1.3.7 requires: it must be set
+// if the token has several audiences and be the client ID if it is set.
+func isAuthorizedParty(claims idTokenClaims, clientID string) bool {
+	if claims.AuthorizedBy == "" {
+		return len(parseAudience(claims.Audience)) <= 1
+	}
+	return claims.AuthorizedBy == clientID
+}
+
+func parseAudience(aud json.RawMessage) []string {
+	var single string
+	if err := json.Unmarshal(aud, &single); err == nil {
+		return []string{single}
+	}
+	var multiple []string
+	if err := json.Unmarshal(aud, &multiple); err != nil {
+		return nil
+	}
+	return multiple
+}
+
+// isTrue accepts booleans and strings, some issuers encode email_verified as "true".
+func isTrue(value json.RawMessage) bool {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s == "true"
+	}
+	return false
+}
+
+// postForm posts the form and decodes the JSON response, rejected requests
+// (e.g. an invalid or already used code) fail with ErrOIDCAuthenticationFailed.
+func postForm(ctx context.Context, client *http.Client, endpoint url.URL, form url.Values, v interface{}) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		endpoint.String(),
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	return doJSON(client, request, v)
+}
+
+func doJSON(client *http.Client, request *http.Request, v interface{}) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 400 && response.StatusCode < 500 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf(
+			"%w: %s responded with %d: %s",
+			user.ErrOIDCAuthenticationFailed,
+			request.URL.Host,
+			response.StatusCode,
+			body,
+		)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with %d", request.URL.Host, response.StatusCode)
+	}
+	return json.NewDecoder(response.Body).Decode(v)
+}
@@ -0,0 +1,413 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	c "remindme/internal/core/domain/common"
+	"remindme/internal/core/domain/user"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	CLIENT_ID     = "test-client-id"
+	CLIENT_SECRET = "test-client-secret"
+	CODE          = "test-code"
+	CODE_VERIFIER = "test-code-verifier"
+	NONCE         = "test-nonce"
+	KEY_ID        = "test-key"
+)
+
+var NOW = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeIssuer serves the token and JWKS endpoints, the token endpoint returns IDToken
+// for CODE and CODE_VERIFIER only.
+type fakeIssuer struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	keyID         string
+	IDToken       string
+	JWKSRequests  int
+	TokenRequests []url.Values
+}
+
+func newFakeIssuer(key *rsa.PrivateKey) *fakeIssuer {
+	issuer := &fakeIssuer{key: key, keyID: KEY_ID}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	issuer.server = httptest.NewServer(mux)
+	return issuer
+}
+
+func (i *fakeIssuer) token(rw http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	i.TokenRequests = append(i.TokenRequests, r.PostForm)
+	if r.PostForm.Get("code") != CODE || r.PostForm.Get("code_verifier") != CODE_VERIFIER {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+	json.NewEncoder(rw).Encode(map[string]string{"id_token": i.IDToken})
+}
+
+func (i *fakeIssuer) jwks(rw http.ResponseWriter, r *http.Request) {
+	i.JWKSRequests++
+	json.NewEncoder(rw).Encode(jsonWebKeySet{Keys: []jsonWebKey{
+		{
+			Kty: "RSA",
+			Kid: i.keyID,
+			N:   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		},
+	}})
+}
+
+func (i *fakeIssuer) URL(path string) url.URL {
+	u, err := url.Parse(i.server.URL + path)
+	if err != nil {
+		panic(err)
+	}
+	return *u
+}
+
+func (i *fakeIssuer) Sign(header map[string]interface{}, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	payload := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+type testSuite struct {
+	suite.Suite
+	key      *rsa.PrivateKey
+	otherKey *rsa.PrivateKey
+	issuer   *fakeIssuer
+	now      time.Time
+	provider *Provider
+}
+
+func (suite *testSuite) SetupSuite() {
+	var err error
+	suite.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().Nil(err)
+	suite.otherKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().Nil(err)
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.issuer = newFakeIssuer(suite.key)
+	suite.now = NOW
+	suite.provider = New(
+		Options{
+			ClientID:         CLIENT_ID,
+			ClientSecret:     CLIENT_SECRET,
+			Issuer:           suite.issuer.server.URL,
+			AuthorizationURL: suite.issuer.URL("/authorize"),
+			TokenURL:         suite.issuer.URL("/token"),
+			JWKSURL:          suite.issuer.URL("/jwks"),
+			RedirectURL:      url.URL{Scheme: "https", Host: "remindme.test", Path: "/oidc/test"},
+			Scopes:           []string{"openid", "email"},
+		},
+		time.Second,
+		nil,
+		func() time.Time { return suite.now },
+	)
+}
+
+func (suite *testSuite) TearDownTest() {
+	suite.issuer.server.Close()
+}
+
+func TestProvider(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestAuthorizationURL() {
+	authorizationURL, err := url.Parse(s.provider.AuthorizationURL("test-state", NONCE, "test-challenge"))
+
+	s.Require().Nil(err)
+	s.Equal(s.issuer.server.URL+"/authorize", authorizationURL.Scheme+"://"+authorizationURL.Host+authorizationURL.Path)
+	s.Equal(
+		url.Values{
+			"response_type":         {"code"},
+			"client_id":             {CLIENT_ID},
+			"redirect_uri":          {"https://remindme.test/oidc/test"},
+			"scope":                 {"openid email"},
+			"state":                 {"test-state"},
+			"nonce":                 {NONCE},
+			"code_challenge":        {"test-challenge"},
+			"code_challenge_method": {"S256"},
+		},
+		authorizationURL.Query(),
+	)
+}
+
+func (s *testSuite) TestExchangeSuccess() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(nil))
+
+	claims, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	s.Require().Nil(err)
+	s.Equal(
+		user.OIDCClaims{Subject: "test-subject", Email: c.NewOptional(c.NewEmail("test@test.test"), true)},
+		claims,
+	)
+	s.Require().Len(s.issuer.TokenRequests, 1)
+	s.Equal("authorization_code", s.issuer.TokenRequests[0].Get("grant_type"))
+	s.Equal(CLIENT_ID, s.issuer.TokenRequests[0].Get("client_id"))
+	s.Equal(CLIENT_SECRET, s.issuer.TokenRequests[0].Get("client_secret"))
+	s.Equal("https://remindme.test/oidc/test", s.issuer.TokenRequests[0].Get("redirect_uri"))
+}
+
+func (s *testSuite) TestUnverifiedEmailIgnored() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"email_verified": false}))
+
+	claims, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	s.Require().Nil(err)
+	s.False(claims.Email.IsPresent)
+}
+
+func (s *testSuite) TestStringEmailVerified() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"email_verified": "true"}))
+
+	claims, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	s.Require().Nil(err)
+	s.True(claims.Email.IsPresent)
+}
+
+func (s *testSuite) TestAudienceArray() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(map[string]interface{}{
+		"aud": []string{"other-client-id", CLIENT_ID},
+		"azp": CLIENT_ID,
+	}))
+
+	_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	s.Nil(err)
+}
+
+func (s *testSuite) TestAuthorizedParty() {
+	cases := []struct {
+		id            string
+		claims        map[string]interface{}
+		expectedError error
+	}{
+		{id: "single audience without azp", claims: map[string]interface{}{"aud": CLIENT_ID}},
+		{
+			id:     "single audience in array without azp",
+			claims: map[string]interface{}{"aud": []string{CLIENT_ID}},
+		},
+		{
+			id:            "several audiences without azp",
+			claims:        map[string]interface{}{"aud": []string{"other-client-id", CLIENT_ID}},
+			expectedError: user.ErrOIDCAuthenticationFailed,
+		},
+		{
+			id: "several audiences with other azp",
+			claims: map[string]interface{}{
+				"aud": []string{"other-client-id", CLIENT_ID},
+				"azp": "other-client-id",
+			},
+			expectedError: user.ErrOIDCAuthenticationFailed,
+		},
+		{
+			id:            "single audience with other azp",
+			claims:        map[string]interface{}{"aud": CLIENT_ID, "azp": "other-client-id"},
+			expectedError: user.ErrOIDCAuthenticationFailed,
+		},
+	}
+
+	for _, testcase := range cases {
+		s.Run(testcase.id, func() {
+			s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(testcase.claims))
+
+			_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+			s.ErrorIs(err, testcase.expectedError)
+		})
+	}
+}
+
+func (s *testSuite) TestKeysCached() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(nil))
+
+	for i := 0; i < 3; i++ {
+		_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+		s.Require().Nil(err)
+	}
+
+	s.Equal(1, s.issuer.JWKSRequests)
+}
+
+func (s *testSuite) TestRotatedKeysFetched() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(nil))
+	_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+	s.Require().Nil(err)
+	s.issuer.key = s.otherKey
+	s.issuer.keyID = "rotated-key"
+	s.issuer.IDToken = s.issuer.Sign(map[string]interface{}{"alg": "RS256", "kid": "rotated-key"}, s.claims(nil))
+
+	_, err = s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+	s.ErrorIs(err, user.ErrOIDCAuthenticationFailed, "keys must not be fetched again too soon")
+
+	s.now = s.now.Add(KEYS_REFRESH_INTERVAL)
+	_, err = s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+	s.Nil(err)
+	s.Equal(2, s.issuer.JWKSRequests)
+}
+
+func (s *testSuite) TestInvalidIDTokens() {
+	cases := []struct {
+		id      string
+		idToken func() string
+	}{
+		{
+			id:      "malformed",
+			idToken: func() string { return "not-a-token" },
+		},
+		{
+			id: "signed by other key",
+			idToken: func() string {
+				issuer := &fakeIssuer{key: s.otherKey}
+				return issuer.Sign(s.header(), s.claims(nil))
+			},
+		},
+		{
+			id: "tampered claims",
+			idToken: func() string {
+				parts := strings.Split(s.issuer.Sign(s.header(), s.claims(nil)), ".")
+				data, _ := json.Marshal(s.claims(map[string]interface{}{"sub": "other-subject"}))
+				return parts[0] + "." + base64.RawURLEncoding.EncodeToString(data) + "." + parts[2]
+			},
+		},
+		{
+			id: "none algorithm",
+			idToken: func() string {
+				token := s.issuer.Sign(map[string]interface{}{"alg": "none", "kid": KEY_ID}, s.claims(nil))
+				return token[:strings.LastIndex(token, ".")+1]
+			},
+		},
+		{
+			id: "unknown key",
+			idToken: func() string {
+				return s.issuer.Sign(map[string]interface{}{"alg": "RS256", "kid": "unknown"}, s.claims(nil))
+			},
+		},
+		{
+			id: "other issuer",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"iss": "https://other.test"}))
+			},
+		},
+		{
+			id: "other audience",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"aud": "other-client-id"}))
+			},
+		},
+		{
+			id: "expired",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{
+					"exp": NOW.Add(-CLOCK_SKEW).Unix(),
+				}))
+			},
+		},
+		{
+			id: "issued in the future",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{
+					"iat": NOW.Add(2 * CLOCK_SKEW).Unix(),
+				}))
+			},
+		},
+		{
+			id: "other nonce",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"nonce": "other-nonce"}))
+			},
+		},
+		{
+			id: "no subject",
+			idToken: func() string {
+				return s.issuer.Sign(s.header(), s.claims(map[string]interface{}{"sub": ""}))
+			},
+		},
+	}
+
+	for _, testcase := range cases {
+		s.issuer.IDToken = testcase.idToken()
+
+		_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+		s.ErrorIs(err, user.ErrOIDCAuthenticationFailed, testcase.id)
+	}
+}
+
+func (s *testSuite) TestInvalidCode() {
+	s.issuer.IDToken = s.issuer.Sign(s.header(), s.claims(nil))
+
+	_, err := s.provider.Exchange(context.Background(), "other-code", CODE_VERIFIER, NONCE)
+	s.ErrorIs(err, user.ErrOIDCAuthenticationFailed)
+
+	_, err = s.provider.Exchange(context.Background(), CODE, "other-code-verifier", NONCE)
+	s.ErrorIs(err, user.ErrOIDCAuthenticationFailed)
+}
+
+func (s *testSuite) TestIssuerUnavailable() {
+	s.issuer.server.Close()
+
+	_, err := s.provider.Exchange(context.Background(), CODE, CODE_VERIFIER, NONCE)
+
+	s.NotNil(err)
+	s.NotErrorIs(err, user.ErrOIDCAuthenticationFailed)
+}
+
+func (s *testSuite) header() map[string]interface{} {
+	return map[string]interface{}{"alg": "RS256", "kid": KEY_ID}
+}
+
+func (s *testSuite) claims(overrides map[string]interface{}) map[string]interface{} {
+	claims := map[string]interface{}{
+		"iss":            s.issuer.server.URL,
+		"sub":            "test-subject",
+		"aud":            CLIENT_ID,
+		"exp":            NOW.Add(time.Hour).Unix(),
+		"iat":            NOW.Unix(),
+		"nonce":          NONCE,
+		"email":          "test@test.test",
+		"email_verified": true,
+	}
+	for key, value := range overrides {
+		claims[key] = value
+	}
+	return claims
+}
@@ -8,13 +8,17 @@ import (
 )
 
 type Generator struct {
-	chars []rune
+	chars             []rune
+	base32Chars       []rune
+	recoveryCodeChars []rune
 }
 
 func NewGenerator() *Generator {
 	rand.Seed(time.Now().UnixNano())
 	return &Generator{
-		chars: []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"),
+		chars:             []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"),
+		base32Chars:       []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"),
+		recoveryCodeChars: []rune("abcdefghijklmnopqrstuvwxyz0123456789"),
 	}
 }
 
@@ -49,3 +53,78 @@ func (g *Generator) GenerateVerificationToken() channel.VerificationToken {
 	}
 	return channel.VerificationToken(b)
 }
+
+func (g *Generator) GenerateOIDCState() user.OIDCState {
+	b := make([]rune, 32)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.OIDCState(b)
+}
+
+func (g *Generator) GenerateOIDCNonce() user.OIDCNonce {
+	b := make([]rune, 32)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.OIDCNonce(b)
+}
+
+// GenerateOIDCCodeVerifier returns a PKCE code verifier, RFC 7636 requires 43 to 128 characters.
+func (g *Generator) GenerateOIDCCodeVerifier() user.OIDCCodeVerifier {
+	b := make([]rune, 64)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.OIDCCodeVerifier(b)
+}
+
+// GenerateTOTPSecret returns 160 bits encoded with base32, the key size recommended by RFC 4226.
+func (g *Generator) GenerateTOTPSecret() user.TOTPSecret {
+	b := make([]rune, 32)
+	for i := range b {
+		b[i] = g.base32Chars[rand.Intn(len(g.base32Chars))]
+	}
+	return user.TOTPSecret(b)
+}
+
+// GenerateRecoveryCode returns lower case codes, they are typed in by users.
+func (g *Generator) GenerateRecoveryCode() user.RecoveryCode {
+	b := make([]rune, 10)
+	for i := range b {
+		b[i] = g.recoveryCodeChars[rand.Intn(len(g.recoveryCodeChars))]
+	}
+	return user.RecoveryCode(b)
+}
+
+func (g *Generator) GenerateTwoFactorChallengeToken() user.TwoFactorChallengeToken {
+	b := make([]rune, 32)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.TwoFactorChallengeToken(b)
+}
+
+func (g *Generator) GenerateAPITokenSecret() user.APITokenSecret {
+	b := make([]rune, 40)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.APITokenSecret(user.API_TOKEN_PREFIX + string(b))
+}
+
+func (g *Generator) GenerateEmailChangeToken() user.EmailChangeToken {
+	b := make([]rune, 32)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.EmailChangeToken(b)
+}
+
+func (g *Generator) GenerateCalendarFeedToken() user.CalendarFeedToken {
+	b := make([]rune, 40)
+	for i := range b {
+		b[i] = g.chars[rand.Intn(len(g.chars))]
+	}
+	return user.CalendarFeedToken(b)
+}
@@ -1,6 +1,7 @@
 package randomstringgenerator
 
 import (
+	"encoding/base32"
 	"remindme/internal/core/domain/user"
 	"testing"
 )
@@ -49,3 +50,48 @@ func TestSessionTokenGenerator(t *testing.T) {
 		sessionTokens[sessionToken] = struct{}{}
 	}
 }
+
+func TestOIDCCodeVerifierGenerator(t *testing.T) {
+	generator := NewGenerator()
+	codeVerifiers := make(map[user.OIDCCodeVerifier]struct{})
+	for i := 0; i < 100; i++ {
+		codeVerifier := generator.GenerateOIDCCodeVerifier()
+		if len(codeVerifier) < 43 || len(codeVerifier) > 128 {
+			t.Fatalf("codeVerifier %v must be 43 to 128 characters long", codeVerifier)
+		}
+		if _, ok := codeVerifiers[codeVerifier]; ok {
+			t.Fatalf("codeVerifier %v already exists (%v)", codeVerifier, codeVerifiers)
+		}
+		codeVerifiers[codeVerifier] = struct{}{}
+	}
+}
+
+func TestTOTPSecretGenerator(t *testing.T) {
+	generator := NewGenerator()
+	secrets := make(map[user.TOTPSecret]struct{})
+	for i := 0; i < 100; i++ {
+		secret := generator.GenerateTOTPSecret()
+		if _, err := base32.StdEncoding.DecodeString(string(secret)); err != nil {
+			t.Fatalf("secret %v must be base32 encoded: %v", secret, err)
+		}
+		if _, ok := secrets[secret]; ok {
+			t.Fatalf("secret %v already exists (%v)", secret, secrets)
+		}
+		secrets[secret] = struct{}{}
+	}
+}
+
+func TestAPITokenSecretGenerator(t *testing.T) {
+	generator := NewGenerator()
+	secrets := make(map[user.APITokenSecret]struct{})
+	for i := 0; i < 100; i++ {
+		secret := generator.GenerateAPITokenSecret()
+		if !user.IsAPIToken(user.SessionToken(secret)) {
+			t.Fatalf("secret %v must start with %v", secret, user.API_TOKEN_PREFIX)
+		}
+		if _, ok := secrets[secret]; ok {
+			t.Fatalf("secret %v already exists (%v)", secret, secrets)
+		}
+		secrets[secret] = struct{}{}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/metrics"
 	ratelimiter "remindme/internal/core/domain/rate_limiter"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v9"
@@ -15,20 +17,29 @@ import (
 type Redis struct {
 	redisClient *redis.Client
 	log         logging.Logger
+	metrics     metrics.Metrics
 	now         func() time.Time
 }
 
-func NewRedis(redisClient *redis.Client, log logging.Logger, now func() time.Time) *Redis {
+func NewRedis(
+	redisClient *redis.Client,
+	log logging.Logger,
+	metrics metrics.Metrics,
+	now func() time.Time,
+) *Redis {
 	if redisClient == nil {
 		panic(e.NewNilArgumentError("redisClient"))
 	}
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
 	}
+	if metrics == nil {
+		panic(e.NewNilArgumentError("metrics"))
+	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
-	return &Redis{redisClient: redisClient, log: log, now: now}
+	return &Redis{redisClient: redisClient, log: log, metrics: metrics, now: now}
 }
 
 func (r *Redis) CheckLimit(ctx context.Context, key string, limit ratelimiter.Limit) ratelimiter.Result {
@@ -60,7 +71,14 @@ func (r *Redis) CheckLimit(ctx context.Context, key string, limit ratelimiter.Li
 	}
 	intCmd := cmds[0].(*redis.IntCmd)
 	if intCmd.Val() > int64(limit.Value) {
+		r.metrics.RateLimitExceeded(limitName(key))
 		return ratelimiter.NotAllowed()
 	}
 	return ratelimiter.Allowed()
 }
+
+// limitName strips the user specific part of the key, e.g. "log-in-with-email::user@example.com".
+func limitName(key string) string {
+	name, _, _ := strings.Cut(key, "::")
+	return name
+}
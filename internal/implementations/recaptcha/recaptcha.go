@@ -37,6 +37,7 @@ func New(
 	secretKey string,
 	scoreThreshold float64,
 	timeout time.Duration,
+	transport http.RoundTripper,
 ) *GoogleRecaptchaValidator {
 	if log == nil {
 		panic(e.NewNilArgumentError("validator"))
@@ -45,7 +46,7 @@ func New(
 		log:            log,
 		scoreThreshold: scoreThreshold,
 		secretKey:      secretKey,
-		httpClient:     http.Client{Timeout: timeout},
+		httpClient:     http.Client{Timeout: timeout, Transport: transport},
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	c "remindme/internal/core/domain/common"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/metrics"
 	"remindme/internal/core/domain/reminder"
 
 	"github.com/r3labs/sse/v2"
@@ -15,6 +16,7 @@ import (
 
 type Sender struct {
 	log            logging.Logger
+	metrics        metrics.Metrics
 	channelRepo    channel.Repository
 	sseServer      *sse.Server
 	emailSender    reminder.EmailSender
@@ -24,6 +26,7 @@ type Sender struct {
 
 func New(
 	log logging.Logger,
+	metrics metrics.Metrics,
 	channelRepo channel.Repository,
 	sseServer *sse.Server,
 	emailSender reminder.EmailSender,
@@ -33,6 +36,9 @@ func New(
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
 	}
+	if metrics == nil {
+		panic(e.NewNilArgumentError("metrics"))
+	}
 	if channelRepo == nil {
 		panic(e.NewNilArgumentError("channelRepo"))
 	}
@@ -50,6 +56,7 @@ func New(
 	}
 	return &Sender{
 		log:            log,
+		metrics:        metrics,
 		channelRepo:    channelRepo,
 		sseServer:      sseServer,
 		emailSender:    emailSender,
@@ -79,6 +86,7 @@ func (s *Sender) SendReminder(ctx context.Context, rem reminder.ReminderWithChan
 			s.internalSender,
 		)
 		err := channelSender.SendReminder(c.Settings)
+		s.metrics.ReminderSent(c.Type, err)
 		if err != nil {
 			s.log.Error(
 				ctx,
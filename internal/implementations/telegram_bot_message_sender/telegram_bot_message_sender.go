@@ -28,11 +28,12 @@ func New(
 	baseURL url.URL,
 	tokenByBot map[channel.TelegramBot]string,
 	timeout time.Duration,
+	transport http.RoundTripper,
 ) *TelegramBotMessageSender {
 	return &TelegramBotMessageSender{
 		baseURL:    baseURL,
 		tokenByBot: tokenByBot,
-		httpClient: http.Client{Timeout: timeout},
+		httpClient: http.Client{Timeout: timeout, Transport: transport},
 	}
 }
 
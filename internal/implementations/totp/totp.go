@@ -0,0 +1,83 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"remindme/internal/core/domain/user"
+	"strings"
+	"time"
+)
+
+const (
+	PERIOD = 30 * time.Second
+	DIGITS = 6
+	// SKEW steps before and after the current one are accepted to tolerate clock drift.
+	SKEW = 1
+)
+
+// Authenticator implements RFC 6238 with the parameters every authenticator app
+// supports: HMAC-SHA1, 6 digits and 30 second steps.
+type Authenticator struct {
+	issuer string
+}
+
+func New(issuer string) *Authenticator {
+	return &Authenticator{issuer: issuer}
+}
+
+func (a *Authenticator) URI(secret user.TOTPSecret, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", string(secret))
+	query.Set("issuer", a.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(DIGITS))
+	query.Set("period", fmt.Sprint(int(PERIOD.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + a.issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func (a *Authenticator) Validate(secret user.TOTPSecret, code user.TwoFactorCode, at time.Time) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != DIGITS {
+		return 0, false
+	}
+	current := at.Unix() / int64(PERIOD.Seconds())
+	for step := current - SKEW; step <= current+SKEW; step++ {
+		expected := generate(key, step)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func decodeSecret(secret user.TOTPSecret) ([]byte, error) {
+	normalized := strings.ToUpper(strings.TrimRight(string(secret), "="))
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+}
+
+// generate computes the HOTP value (RFC 4226) of the step.
+func generate(key []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < DIGITS; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", DIGITS, value%modulo)
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"remindme/internal/core/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The RFC 6238 SHA1 test key "12345678901234567890".
+var SECRET = user.TOTPSecret(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890")))
+
+func TestValidateRFCVectors(t *testing.T) {
+	authenticator := New("RemindMe")
+	// RFC 6238 vectors are 8 digits long, the last 6 digits are the 6 digit codes.
+	vectors := []struct {
+		at   int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, vector := range vectors {
+		step, ok := authenticator.Validate(SECRET, user.TwoFactorCode(vector.code), time.Unix(vector.at, 0))
+		assert.True(t, ok, vector.code)
+		assert.Equal(t, vector.at/30, step)
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	authenticator := New("RemindMe")
+	at := time.Unix(59, 0)
+
+	step, ok := authenticator.Validate(SECRET, "287082", at.Add(PERIOD))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	_, ok = authenticator.Validate(SECRET, "287082", at.Add(2*PERIOD))
+	assert.False(t, ok)
+}
+
+func TestValidateInvalidCode(t *testing.T) {
+	authenticator := New("RemindMe")
+	at := time.Unix(59, 0)
+
+	for _, code := range []user.TwoFactorCode{"", "287083", "2870820", "abcdef"} {
+		_, ok := authenticator.Validate(SECRET, code, at)
+		assert.False(t, ok, code)
+	}
+	_, ok := authenticator.Validate("not base32!", "287082", at)
+	assert.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(New("RemindMe").URI(SECRET, "test@test.test"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/RemindMe:test@test.test", uri.Path)
+	assert.Equal(t, string(SECRET), uri.Query().Get("secret"))
+	assert.Equal(t, "RemindMe", uri.Query().Get("issuer"))
+}
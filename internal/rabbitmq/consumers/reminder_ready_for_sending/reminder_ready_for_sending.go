@@ -2,33 +2,86 @@ package reminderreadyforsending
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/reminder"
+	dt "remindme/internal/core/domain/tracing"
 	"remindme/internal/core/services"
 	sendreminder "remindme/internal/core/services/send_reminder"
 	"remindme/internal/rabbitmq"
 	"remindme/internal/rabbitmq/schema"
+	"remindme/internal/tracing"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
+type Options struct {
+	// Number of deliveries processed concurrently.
+	Workers int
+	// Number of unacknowledged deliveries the broker pushes to the consumer.
+	Prefetch int
+	// Maximum duration of processing a single delivery.
+	DeliveryTimeout time.Duration
+	// How often consumer statistics are logged, zero disables logging.
+	StatsInterval time.Duration
+}
+
+type Metrics interface {
+	ObserveQueueLag(queue string, lag time.Duration)
+}
+
+type Stats struct {
+	Workers       int
+	InFlight      int64
+	MaxInFlight   int64
+	Processed     uint64
+	Failed        uint64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	MaxLag        time.Duration
+}
+
 type Consumer struct {
 	log     logging.Logger
+	tracer  *tracing.Tracer
 	channel *rabbitmq.Channel
 	queue   string
 	service services.Service[sendreminder.Input, sendreminder.Result]
+	metrics Metrics
+	options Options
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	wg     sync.WaitGroup
+
+	inFlight int64
+	isActive int32
+	stats    Stats
+	lock     sync.Mutex
 }
 
 func New(
 	log logging.Logger,
+	tracer *tracing.Tracer,
 	channel *rabbitmq.Channel,
 	queue string,
 	service services.Service[sendreminder.Input, sendreminder.Result],
+	metrics Metrics,
+	options Options,
 ) *Consumer {
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
 	}
+	if tracer == nil {
+		panic(e.NewNilArgumentError("tracer"))
+	}
 	if channel == nil {
 		panic(e.NewNilArgumentError("channel"))
 	}
@@ -38,62 +91,306 @@ func New(
 	if service == nil {
 		panic(e.NewNilArgumentError("service"))
 	}
+	if metrics == nil {
+		panic(e.NewNilArgumentError("metrics"))
+	}
+	if options.Workers <= 0 {
+		panic("workers count must be positive")
+	}
+	if options.Prefetch < options.Workers {
+		panic("prefetch count must not be less than workers count")
+	}
+	if options.DeliveryTimeout <= 0 {
+		panic("delivery timeout must be positive")
+	}
 
-	return &Consumer{log: log, channel: channel, queue: queue, service: service}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Consumer{
+		log:     log,
+		tracer:  tracer,
+		channel: channel,
+		queue:   queue,
+		service: service,
+		metrics: metrics,
+		options: options,
+		ctx:     ctx,
+		cancel:  cancel,
+		stop:    make(chan struct{}),
+	}
 }
 
 func (c *Consumer) Consume() error {
+	if err := c.channel.Qos(c.options.Prefetch, 0, false); err != nil {
+		c.log.Error(context.Background(), "Could not set channel QoS.", logging.Entry("err", err))
+		return err
+	}
+
 	deliveries, err := c.channel.Consume(c.queue, "", false, false, false, false, nil)
 	if err != nil {
 		c.log.Error(context.Background(), "Could not start cosuming.", logging.Entry("err", err))
 		return err
 	}
+	c.start(deliveries)
+	return nil
+}
+
+func (c *Consumer) start(deliveries <-chan amqp091.Delivery) {
+	c.wg.Add(c.options.Workers)
+	for i := 0; i < c.options.Workers; i++ {
+		go c.work(deliveries)
+	}
+	if c.options.StatsInterval > 0 {
+		go c.reportStats()
+	}
+	atomic.StoreInt32(&c.isActive, 1)
+
+	c.log.Info(
+		context.Background(),
+		"Reminder sending consumer has started.",
+		logging.Entry("queue", c.queue),
+		logging.Entry("workers", c.options.Workers),
+		logging.Entry("prefetch", c.options.Prefetch),
+	)
+}
 
-	c.log.Info(context.Background(), "Reminder sending consumer has started.", logging.Entry("queue", c.queue))
+// Shutdown stops taking new deliveries and waits for in-flight ones to be processed.
+// If ctx is done before that, in-flight deliveries are canceled.
+// Prefetched but not yet processed deliveries are requeued by the broker once the channel is closed.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&c.isActive, 1, 0) {
+		return nil
+	}
+	close(c.stop)
+
+	done := make(chan struct{})
 	go func() {
-		for delivery := range deliveries {
-			c.processDelivery(delivery)
-		}
-		c.log.Info(context.Background(), "Reminder sending consumer has stopped.", logging.Entry("queue", c.queue))
+		c.wg.Wait()
+		close(done)
 	}()
 
-	return nil
+	c.log.Info(
+		ctx,
+		"Reminder sending consumer is draining in-flight deliveries.",
+		logging.Entry("queue", c.queue),
+		logging.Entry("inFlight", atomic.LoadInt64(&c.inFlight)),
+	)
+	select {
+	case <-done:
+		c.cancel()
+		c.log.Info(ctx, "Reminder sending consumer has stopped.", logging.Entry("stats", c.Stats()))
+		return nil
+	case <-ctx.Done():
+		c.cancel()
+		<-done
+		c.log.Warning(
+			ctx,
+			"Reminder sending consumer has stopped, in-flight deliveries were canceled.",
+			logging.Entry("stats", c.Stats()),
+		)
+		return ctx.Err()
+	}
+}
+
+func (c *Consumer) IsActive() bool {
+	return atomic.LoadInt32(&c.isActive) == 1
+}
+
+// Stats returns statistics gathered since the last stats report.
+func (c *Consumer) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats := c.stats
+	stats.Workers = c.options.Workers
+	stats.InFlight = atomic.LoadInt64(&c.inFlight)
+	return stats
+}
+
+func (c *Consumer) work(deliveries <-chan amqp091.Delivery) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		select {
+		case <-c.stop:
+			return
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			c.processDelivery(delivery)
+		}
+	}
 }
 
 func (c *Consumer) processDelivery(delivery amqp091.Delivery) {
 	// defer c.nack(delivery)
 
-	rem := &schema.Reminder{}
-	if err := rem.Unmarshal(delivery.Body); err != nil {
+	inFlight := atomic.AddInt64(&c.inFlight, 1)
+	defer atomic.AddInt64(&c.inFlight, -1)
+	startedAt := time.Now()
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.options.DeliveryTimeout)
+	defer cancel()
+	ctx, span := c.tracer.StartSpan(
+		rabbitmq.ExtractTraceContext(ctx, delivery.Headers),
+		fmt.Sprintf("process %s", c.queue),
+		trace.SpanKindConsumer,
+		dt.Attribute("messaging.system", "rabbitmq"),
+		dt.Attribute("messaging.source.name", c.queue),
+		dt.Attribute("messaging.message.id", delivery.MessageId),
+	)
+	defer span.End()
+
+	rem, version, err := decodeReminder(delivery)
+	if err != nil {
+		span.RecordError(err)
 		c.log.Error(
-			context.Background(),
+			ctx,
 			"Could not unmarshal reminder.",
 			logging.Entry("err", err),
 			logging.Entry("delivery", delivery),
 		)
 		c.ack(delivery)
+		c.observe(inFlight, time.Since(startedAt), 0, false)
 		return
 	}
 
+	ctx = logging.WithReminderID(ctx, rem.ID)
 	c.log.Info(
-		context.Background(),
+		ctx,
 		"Got ready for sending reminder.",
 		logging.Entry("reminder", rem),
+		logging.Entry("schemaVersion", version),
+		logging.Entry("messageID", rem.MessageID),
+		logging.Entry("correlationID", rem.CorrelationID),
 	)
-	_, err := c.service.Run(
-		context.Background(),
+	result, err := c.service.Run(
+		ctx,
 		sendreminder.Input{ReminderID: reminder.ID(rem.ID), At: rem.At},
 	)
 
-	if err != nil {
+	// Processing interrupted by DeliveryTimeout or by Shutdown is retried later unless
+	// the reminder has already been prepared: a redelivery skips a prepared reminder, so it
+	// would not be sent anyway. Other errors are handled by the service and retrying them does not help.
+	isInterrupted := errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+	switch {
+	case isInterrupted && !result.IsPrepared:
+		span.RecordError(err)
+		c.log.Warning(
+			ctx,
+			"Reminder sending was interrupted, delivery is requeued.",
+			logging.Entry("reminder", rem),
+			logging.Entry("err", err),
+		)
+		c.nack(delivery)
+	case err != nil:
+		span.RecordError(err)
 		c.log.Error(
-			context.Background(),
+			ctx,
 			"Could not send reminder, service returned an error.",
 			logging.Entry("reminder", rem),
 			logging.Entry("err", err),
 		)
+		c.ack(delivery)
+	default:
+		c.ack(delivery)
+	}
+
+	duration := time.Since(startedAt)
+	lag := startedAt.Sub(rem.At)
+	c.metrics.ObserveQueueLag(c.queue, lag)
+	c.observe(inFlight, duration, lag, err == nil)
+	c.log.Info(
+		ctx,
+		"Reminder delivery processed.",
+		logging.Entry("reminderID", rem.ID),
+		logging.Entry("correlationID", rem.CorrelationID),
+		logging.Entry("duration", duration),
+		logging.Entry("lag", lag),
+		logging.Entry("inFlight", inFlight),
+	)
+}
+
+// decodeReminder accepts every supported schema version, message IDs missing
+// in legacy bodies are taken from AMQP properties.
+func decodeReminder(delivery amqp091.Delivery) (rem schema.Reminder, version int, err error) {
+	if rawType, ok := delivery.Headers[schema.HeaderType]; ok && rawType != schema.ReminderType {
+		return rem, version, fmt.Errorf("%w: %v", schema.ErrUnexpectedType, rawType)
+	}
+	version, err = rem.UnmarshalVersion(delivery.Body)
+	if err != nil {
+		return rem, version, err
+	}
+	if rem.MessageID == "" {
+		rem.MessageID = delivery.MessageId
+	}
+	if rem.CorrelationID == "" {
+		rem.CorrelationID = delivery.CorrelationId
+	}
+	if rem.CorrelationID == "" {
+		rem.CorrelationID = schema.ReminderCorrelationID(rem.ID, rem.At)
+	}
+	return rem, version, nil
+}
+
+func (c *Consumer) observe(inFlight int64, duration time.Duration, lag time.Duration, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.stats.Processed++
+	if !ok {
+		c.stats.Failed++
+	}
+	c.stats.TotalDuration += duration
+	if duration > c.stats.MaxDuration {
+		c.stats.MaxDuration = duration
+	}
+	if lag > c.stats.MaxLag {
+		c.stats.MaxLag = lag
+	}
+	if inFlight > c.stats.MaxInFlight {
+		c.stats.MaxInFlight = inFlight
+	}
+}
+
+// reportStats logs statistics gathered during the last interval and resets them.
+func (c *Consumer) reportStats() {
+	ticker := time.NewTicker(c.options.StatsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.lock.Lock()
+			stats := c.stats
+			c.stats = Stats{}
+			c.lock.Unlock()
+
+			if stats.Processed == 0 {
+				continue
+			}
+			stats.Workers = c.options.Workers
+			stats.InFlight = atomic.LoadInt64(&c.inFlight)
+			c.log.Info(
+				context.Background(),
+				"Reminder sending consumer stats.",
+				logging.Entry("queue", c.queue),
+				logging.Entry("interval", c.options.StatsInterval),
+				logging.Entry("workers", stats.Workers),
+				logging.Entry("inFlight", stats.InFlight),
+				logging.Entry("maxInFlight", stats.MaxInFlight),
+				logging.Entry("processed", stats.Processed),
+				logging.Entry("failed", stats.Failed),
+				logging.Entry("avgDuration", stats.TotalDuration/time.Duration(stats.Processed)),
+				logging.Entry("maxDuration", stats.MaxDuration),
+				logging.Entry("maxLag", stats.MaxLag),
+			)
+		}
 	}
-	c.ack(delivery)
 }
 
 func (c *Consumer) ack(delivery amqp091.Delivery) {
@@ -0,0 +1,246 @@
+package reminderreadyforsending
+
+import (
+	"context"
+	"errors"
+	"remindme/internal/core/domain/logging"
+	"remindme/internal/core/domain/reminder"
+	sendreminder "remindme/internal/core/services/send_reminder"
+	"remindme/internal/rabbitmq"
+	"remindme/internal/rabbitmq/schema"
+	"remindme/internal/tracing"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const WAIT_TIMEOUT = 5 * time.Second
+
+type fakeService struct {
+	started    chan reminder.ID
+	release    chan struct{}
+	err        error
+	isPrepared bool
+	lock       sync.Mutex
+	running    int
+	maxRunning int
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{started: make(chan reminder.ID, 10), release: make(chan struct{})}
+}
+
+// Run blocks until the service is released or ctx is done.
+func (s *fakeService) Run(ctx context.Context, input sendreminder.Input) (result sendreminder.Result, err error) {
+	s.lock.Lock()
+	s.running++
+	if s.running > s.maxRunning {
+		s.maxRunning = s.running
+	}
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		s.running--
+		s.lock.Unlock()
+	}()
+
+	result.IsPrepared = s.isPrepared
+	s.started <- input.ReminderID
+	select {
+	case <-s.release:
+		return result, s.err
+	case <-ctx.Done():
+		return result, ctx.Err()
+	}
+}
+
+type fakeAcknowledger struct {
+	lock   sync.Mutex
+	acked  []uint64
+	nacked []uint64
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if requeue {
+		a.nacked = append(a.nacked, tag)
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+type fakeMetrics struct{}
+
+func (m fakeMetrics) ObserveQueueLag(queue string, lag time.Duration) {}
+
+type testSuite struct {
+	suite.Suite
+	Service      *fakeService
+	Acknowledger *fakeAcknowledger
+	Deliveries   chan amqp091.Delivery
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.Service = newFakeService()
+	suite.Acknowledger = &fakeAcknowledger{}
+	suite.Deliveries = make(chan amqp091.Delivery, 10)
+}
+
+func TestReminderReadyForSendingConsumer(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestConcurrentProcessing() {
+	consumer := s.start(Options{Workers: 3, Prefetch: 3, DeliveryTimeout: WAIT_TIMEOUT})
+	s.deliver(1, 2, 3)
+	s.waitStarted(3)
+
+	close(s.Service.release)
+	err := consumer.Shutdown(context.Background())
+
+	s.Nil(err)
+	s.Equal(3, s.Service.maxRunning)
+	s.ElementsMatch([]uint64{1, 2, 3}, s.Acknowledger.acked)
+	s.Empty(s.Acknowledger.nacked)
+	s.Equal(uint64(3), consumer.Stats().Processed)
+}
+
+func (s *testSuite) TestShutdownDrainsInFlightDeliveries() {
+	consumer := s.start(Options{Workers: 2, Prefetch: 2, DeliveryTimeout: WAIT_TIMEOUT})
+	s.deliver(1, 2)
+	s.waitStarted(2)
+
+	done := make(chan error)
+	go func() { done <- consumer.Shutdown(context.Background()) }()
+	select {
+	case <-done:
+		s.FailNow("Shutdown returned before in-flight deliveries were processed.")
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.False(consumer.IsActive())
+	close(s.Service.release)
+
+	s.Nil(<-done)
+	s.ElementsMatch([]uint64{1, 2}, s.Acknowledger.acked)
+	s.Empty(s.Acknowledger.nacked)
+}
+
+func (s *testSuite) TestShutdownTimeoutRequeuesInFlightDeliveries() {
+	consumer := s.start(Options{Workers: 1, Prefetch: 1, DeliveryTimeout: WAIT_TIMEOUT})
+	s.deliver(1)
+	s.waitStarted(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := consumer.Shutdown(ctx)
+
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Equal([]uint64{1}, s.Acknowledger.nacked)
+	s.Empty(s.Acknowledger.acked)
+}
+
+func (s *testSuite) TestDeliveryTimeoutRequeuesDelivery() {
+	consumer := s.start(Options{Workers: 1, Prefetch: 1, DeliveryTimeout: 10 * time.Millisecond})
+	s.deliver(1)
+	s.waitStarted(1)
+
+	err := consumer.Shutdown(context.Background())
+
+	s.Nil(err)
+	s.Equal([]uint64{1}, s.Acknowledger.nacked)
+	s.Empty(s.Acknowledger.acked)
+	s.Equal(uint64(1), consumer.Stats().Failed)
+}
+
+func (s *testSuite) TestDeliveryTimeoutAfterPrepareAcksDelivery() {
+	s.Service.isPrepared = true
+	consumer := s.start(Options{Workers: 1, Prefetch: 1, DeliveryTimeout: 10 * time.Millisecond})
+	s.deliver(1)
+	s.waitStarted(1)
+
+	err := consumer.Shutdown(context.Background())
+
+	s.Nil(err)
+	s.Equal([]uint64{1}, s.Acknowledger.acked)
+	s.Empty(s.Acknowledger.nacked)
+	s.Equal(uint64(1), consumer.Stats().Failed)
+}
+
+func (s *testSuite) TestServiceErrorAcked() {
+	s.Service.err = errors.New("test error")
+	consumer := s.start(Options{Workers: 1, Prefetch: 1, DeliveryTimeout: WAIT_TIMEOUT})
+	s.deliver(1)
+	s.waitStarted(1)
+
+	close(s.Service.release)
+	err := consumer.Shutdown(context.Background())
+
+	s.Nil(err)
+	s.Equal([]uint64{1}, s.Acknowledger.acked)
+	s.Empty(s.Acknowledger.nacked)
+}
+
+func (s *testSuite) TestInvalidDeliveryAcked() {
+	consumer := s.start(Options{Workers: 1, Prefetch: 1, DeliveryTimeout: WAIT_TIMEOUT})
+	s.Deliveries <- amqp091.Delivery{Acknowledger: s.Acknowledger, DeliveryTag: 1, Body: []byte("{")}
+
+	s.Require().Eventually(
+		func() bool { return consumer.Stats().Processed == 1 },
+		WAIT_TIMEOUT,
+		time.Millisecond,
+	)
+	err := consumer.Shutdown(context.Background())
+
+	s.Nil(err)
+	s.Equal([]uint64{1}, s.Acknowledger.acked)
+	s.Empty(s.Acknowledger.nacked)
+}
+
+func (s *testSuite) start(options Options) *Consumer {
+	consumer := New(
+		logging.NewFakeLogger(),
+		tracing.NewTracer(trace.NewNoopTracerProvider()),
+		&rabbitmq.Channel{},
+		"test-queue",
+		s.Service,
+		fakeMetrics{},
+		options,
+	)
+	consumer.start(s.Deliveries)
+	return consumer
+}
+
+// deliver publishes a reminder with every ID, IDs are used as delivery tags.
+func (s *testSuite) deliver(ids ...int64) {
+	for _, id := range ids {
+		rem := schema.Reminder{ID: id, At: time.Now().UTC(), MessageID: schema.NewMessageID()}
+		body, err := rem.Marshal()
+		s.Require().Nil(err)
+		s.Deliveries <- amqp091.Delivery{Acknowledger: s.Acknowledger, DeliveryTag: uint64(id), Body: body}
+	}
+}
+
+func (s *testSuite) waitStarted(count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.Service.started:
+		case <-time.After(WAIT_TIMEOUT):
+			s.FailNow("Delivery processing has not started.")
+		}
+	}
+}
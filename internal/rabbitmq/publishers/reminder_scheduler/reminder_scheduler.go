@@ -2,41 +2,63 @@ package reminderscheduler
 
 import (
 	"context"
+	"fmt"
 	e "remindme/internal/core/domain/errors"
 	"remindme/internal/core/domain/logging"
 	"remindme/internal/core/domain/reminder"
+	dt "remindme/internal/core/domain/tracing"
 	"remindme/internal/rabbitmq"
 	"remindme/internal/rabbitmq/schema"
+	"remindme/internal/tracing"
 	"time"
 
 	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type RabbitMQ struct {
-	log        logging.Logger
-	channel    *rabbitmq.Channel
-	exchange   string
-	routingKey string
-	now        func() time.Time
+	log           logging.Logger
+	tracer        *tracing.Tracer
+	channel       *rabbitmq.Channel
+	exchange      string
+	routingKey    string
+	schemaVersion int
+	now           func() time.Time
 }
 
 func NewRabbitMQ(
 	log logging.Logger,
+	tracer *tracing.Tracer,
 	channel *rabbitmq.Channel,
 	exchange string,
 	routingKey string,
+	schemaVersion int,
 	now func() time.Time,
 ) *RabbitMQ {
 	if log == nil {
 		panic(e.NewNilArgumentError("log"))
 	}
+	if tracer == nil {
+		panic(e.NewNilArgumentError("tracer"))
+	}
 	if channel == nil {
 		panic(e.NewNilArgumentError("channel"))
 	}
 	if now == nil {
 		panic(e.NewNilArgumentError("now"))
 	}
-	return &RabbitMQ{log: log, channel: channel, exchange: exchange, routingKey: routingKey, now: now}
+	if schemaVersion < schema.ReminderV1 || schemaVersion > schema.ReminderLatestVersion {
+		panic(fmt.Sprintf("unsupported reminder schema version: %d", schemaVersion))
+	}
+	return &RabbitMQ{
+		log:           log,
+		tracer:        tracer,
+		channel:       channel,
+		exchange:      exchange,
+		routingKey:    routingKey,
+		schemaVersion: schemaVersion,
+		now:           now,
+	}
 }
 
 func (s *RabbitMQ) ScheduleReminder(ctx context.Context, r reminder.Reminder) error {
@@ -44,21 +66,45 @@ func (s *RabbitMQ) ScheduleReminder(ctx context.Context, r reminder.Reminder) er
 	delay := r.At.Sub(now).Milliseconds()
 
 	reminder := &schema.Reminder{
-		ID: int64(r.ID),
-		At: r.At,
+		ID:            int64(r.ID),
+		At:            r.At,
+		MessageID:     schema.NewMessageID(),
+		CorrelationID: schema.ReminderCorrelationID(int64(r.ID), r.At),
 	}
-	data, err := reminder.Marshal()
+	data, err := reminder.MarshalVersion(s.schemaVersion)
 	if err != nil {
 		s.log.Error(ctx, "Could not marshal reminder.", logging.Entry("err", err))
 		return err
 	}
 
+	ctx, span := s.tracer.StartSpan(
+		ctx,
+		fmt.Sprintf("publish %s", s.exchange),
+		trace.SpanKindProducer,
+		dt.Attribute("messaging.system", "rabbitmq"),
+		dt.Attribute("messaging.destination.name", s.exchange),
+		dt.Attribute("messaging.rabbitmq.destination.routing_key", s.routingKey),
+		dt.Attribute("messaging.message.id", reminder.MessageID),
+		dt.Attribute("messaging.message.conversation_id", reminder.CorrelationID),
+	)
+	defer span.End()
+
+	headers := amqp091.Table{
+		"x-delay":            delay,
+		schema.HeaderType:    schema.ReminderType,
+		schema.HeaderVersion: int32(s.schemaVersion),
+	}
+	rabbitmq.InjectTraceContext(ctx, headers)
 	err = s.channel.PublishWithContext(ctx, s.exchange, s.routingKey, false, false, amqp091.Publishing{
-		Headers:     amqp091.Table{"x-delay": delay},
-		ContentType: "application/json",
-		Body:        data,
+		Headers:       headers,
+		ContentType:   "application/json",
+		MessageId:     reminder.MessageID,
+		CorrelationId: reminder.CorrelationID,
+		Timestamp:     now,
+		Body:          data,
 	})
 	if err != nil {
+		span.RecordError(err)
 		logging.Error(ctx, s.log, err)
 		return err
 	}
@@ -69,6 +115,9 @@ func (s *RabbitMQ) ScheduleReminder(ctx context.Context, r reminder.Reminder) er
 		logging.Entry("RK", s.routingKey),
 		logging.Entry("reminderID", reminder.ID),
 		logging.Entry("reminderAt", reminder.At),
+		logging.Entry("messageID", reminder.MessageID),
+		logging.Entry("correlationID", reminder.CorrelationID),
+		logging.Entry("schemaVersion", s.schemaVersion),
 	)
 	return nil
 }
@@ -47,6 +47,7 @@ func (c *Connection) Channel() (*Channel, error) {
 				if err == nil {
 					c.log.Info(context.Background(), "Channel recreate success.")
 					channel.Channel = ch
+					channel.restoreQos()
 					break
 				}
 
@@ -104,6 +105,28 @@ type Channel struct {
 	*amqp.Channel
 	closed int32
 	log    logging.Logger
+	qos    *qos
+}
+
+type qos struct {
+	prefetchCount int
+	prefetchSize  int
+	global        bool
+}
+
+// Qos wrap amqp.Channel.Qos, the settings are applied again after the channel is recreated
+func (ch *Channel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	ch.qos = &qos{prefetchCount: prefetchCount, prefetchSize: prefetchSize, global: global}
+	return ch.Channel.Qos(prefetchCount, prefetchSize, global)
+}
+
+func (ch *Channel) restoreQos() {
+	if ch.qos == nil {
+		return
+	}
+	if err := ch.Channel.Qos(ch.qos.prefetchCount, ch.qos.prefetchSize, ch.qos.global); err != nil {
+		ch.log.Error(context.Background(), "Could not restore channel QoS.", logging.Entry("err", err))
+	}
 }
 
 // IsClosed indicate closed by developer
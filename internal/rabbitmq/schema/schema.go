@@ -1,19 +1,143 @@
 package schema
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 )
 
+// AMQP headers describing a message body.
+const (
+	HeaderType    = "x-message-type"
+	HeaderVersion = "x-message-version"
+)
+
+const (
+	ReminderType = "reminder"
+
+	// ReminderV1 is the legacy unversioned `{ID, At}` body without an envelope.
+	ReminderV1 = 1
+	// ReminderV2 wraps the reminder into an envelope with type, version and message IDs.
+	ReminderV2 = 2
+
+	ReminderLatestVersion = ReminderV2
+)
+
+var (
+	ErrUnexpectedType     = errors.New("unexpected message type")
+	ErrUnsupportedVersion = errors.New("unsupported message version")
+)
+
+type envelope struct {
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	MessageID     string          `json:"message_id,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
 type Reminder struct {
+	ID            int64
+	At            time.Time
+	MessageID     string
+	CorrelationID string
+}
+
+type reminderV1 struct {
 	ID int64
 	At time.Time
 }
 
+type reminderV2 struct {
+	ID int64     `json:"id"`
+	At time.Time `json:"at"`
+}
+
+// Marshal encodes the reminder with the latest schema version.
 func (r *Reminder) Marshal() ([]byte, error) {
-	return json.Marshal(r)
+	return r.MarshalVersion(ReminderLatestVersion)
+}
+
+// MarshalVersion encodes the reminder with the given schema version,
+// older versions are kept so producers can be rolled out before consumers.
+func (r *Reminder) MarshalVersion(version int) ([]byte, error) {
+	switch version {
+	case ReminderV1:
+		return json.Marshal(reminderV1{ID: r.ID, At: r.At})
+	case ReminderV2:
+		payload, err := json.Marshal(reminderV2{ID: r.ID, At: r.At})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(envelope{
+			Type:          ReminderType,
+			Version:       ReminderV2,
+			MessageID:     r.MessageID,
+			CorrelationID: r.CorrelationID,
+			Payload:       payload,
+		})
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
 }
 
+// Unmarshal decodes the reminder of any supported schema version.
 func (r *Reminder) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, r)
+	_, err := r.UnmarshalVersion(data)
+	return err
+}
+
+// UnmarshalVersion decodes the reminder of any supported schema version
+// and returns the version of the decoded message.
+func (r *Reminder) UnmarshalVersion(data []byte) (version int, err error) {
+	env := envelope{}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return version, err
+	}
+
+	if env.Type == "" && env.Version == 0 {
+		legacy := reminderV1{}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return version, err
+		}
+		r.ID = legacy.ID
+		r.At = legacy.At
+		return ReminderV1, nil
+	}
+
+	if env.Type != ReminderType {
+		return version, fmt.Errorf("%w: %s", ErrUnexpectedType, env.Type)
+	}
+	switch env.Version {
+	case ReminderV2:
+		payload := reminderV2{}
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			return version, err
+		}
+		r.ID = payload.ID
+		r.At = payload.At
+		r.MessageID = env.MessageID
+		r.CorrelationID = env.CorrelationID
+		return ReminderV2, nil
+	default:
+		return version, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+}
+
+// NewMessageID returns a random message identifier.
+func NewMessageID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("could not generate message ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// ReminderCorrelationID returns an identifier shared by all messages
+// related to the same occurrence of a reminder.
+func ReminderCorrelationID(id int64, at time.Time) string {
+	return fmt.Sprintf("reminder-%d-%d", id, at.Unix())
 }
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var goldenReminder = Reminder{
+	ID:            42,
+	At:            time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC),
+	MessageID:     "0f8fad5bd9cb469fa16570867728950e",
+	CorrelationID: "reminder-42-1677661200",
+}
+
+func TestReminderMarshalGolden(t *testing.T) {
+	cases := []struct {
+		golden  string
+		version int
+	}{
+		{golden: "reminder_v1.json", version: ReminderV1},
+		{golden: "reminder_v2.json", version: ReminderV2},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.golden, func(t *testing.T) {
+			data, err := goldenReminder.MarshalVersion(testcase.version)
+			assert.Nil(t, err)
+
+			path := filepath.Join("testdata", testcase.golden)
+			if *update {
+				assert.Nil(t, os.WriteFile(path, data, 0644))
+			}
+			expected, err := os.ReadFile(path)
+			assert.Nil(t, err)
+			assert.JSONEq(t, string(expected), string(data))
+		})
+	}
+}
+
+func TestReminderUnmarshalGolden(t *testing.T) {
+	cases := []struct {
+		golden   string
+		version  int
+		expected Reminder
+	}{
+		{
+			golden:   "reminder_v1.json",
+			version:  ReminderV1,
+			expected: Reminder{ID: goldenReminder.ID, At: goldenReminder.At},
+		},
+		{
+			golden:   "reminder_v2.json",
+			version:  ReminderV2,
+			expected: goldenReminder,
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.golden, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", testcase.golden))
+			assert.Nil(t, err)
+
+			rem := Reminder{}
+			version, err := rem.UnmarshalVersion(data)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.version, version)
+			assert.Equal(t, testcase.expected, rem)
+		})
+	}
+}
+
+func TestReminderMarshalLatestVersion(t *testing.T) {
+	data, err := goldenReminder.Marshal()
+	assert.Nil(t, err)
+
+	rem := Reminder{}
+	version, err := rem.UnmarshalVersion(data)
+	assert.Nil(t, err)
+	assert.Equal(t, ReminderLatestVersion, version)
+	assert.Equal(t, goldenReminder, rem)
+}
+
+func TestReminderUnmarshalError(t *testing.T) {
+	cases := []struct {
+		id   string
+		data string
+		err  error
+	}{
+		{id: "unexpected type", data: `{"type":"user","version":2,"payload":{}}`, err: ErrUnexpectedType},
+		{id: "unsupported version", data: `{"type":"reminder","version":3,"payload":{}}`, err: ErrUnsupportedVersion},
+		{id: "missing type", data: `{"version":2,"payload":{}}`, err: ErrUnexpectedType},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.id, func(t *testing.T) {
+			rem := Reminder{}
+			err := rem.Unmarshal([]byte(testcase.data))
+			assert.ErrorIs(t, err, testcase.err)
+		})
+	}
+}
+
+func TestReminderMarshalUnsupportedVersion(t *testing.T) {
+	_, err := goldenReminder.MarshalVersion(0)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
@@ -0,0 +1,38 @@
+package rabbitmq
+
+import (
+	"context"
+	"remindme/internal/tracing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// headersCarrier reads and writes the trace context in message headers.
+type headersCarrier amqp.Table
+
+func (c headersCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c headersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectTraceContext puts the trace context of ctx into message headers.
+func InjectTraceContext(ctx context.Context, headers amqp.Table) {
+	tracing.Inject(ctx, headersCarrier(headers))
+}
+
+// ExtractTraceContext returns a context carrying the trace context found in message headers.
+func ExtractTraceContext(ctx context.Context, headers amqp.Table) context.Context {
+	return tracing.Extract(ctx, headersCarrier(headers))
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"net/http"
+	dt "remindme/internal/core/domain/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type transport struct {
+	tracer *Tracer
+	base   http.RoundTripper
+}
+
+// NewTransport wraps base, every outgoing request is traced in a client span
+// and carries the trace context in its headers. Only the host is recorded
+// as URL paths of third party APIs may contain credentials, e.g. Telegram bot tokens.
+func NewTransport(tracer *Tracer, base http.RoundTripper) http.RoundTripper {
+	if tracer == nil {
+		panic("tracer must not be nil")
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{tracer: tracer, base: base}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.StartSpan(
+		r.Context(),
+		"HTTP "+r.Method,
+		trace.SpanKindClient,
+		dt.Attribute("http.request.method", r.Method),
+		dt.Attribute("server.address", r.URL.Host),
+	)
+	defer span.End()
+
+	r = r.Clone(ctx)
+	InjectHTTP(ctx, r.Header)
+	response, err := t.base.RoundTrip(r)
+	if err != nil {
+		span.RecordError(err)
+		return response, err
+	}
+	span.SetAttributes(dt.Attribute("http.response.status_code", response.StatusCode))
+	return response, nil
+}
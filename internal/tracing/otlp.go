@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	e "remindme/internal/core/domain/errors"
+	"remindme/internal/core/domain/logging"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+)
+
+type OTLPExporterOptions struct {
+	// Collector traces endpoint, e.g. http://localhost:4318/v1/traces.
+	Endpoint url.URL
+	Timeout  time.Duration
+}
+
+// NewOTLPExporter creates an exporter sending spans to an OTLP/HTTP collector.
+func NewOTLPExporter(ctx context.Context, options OTLPExporterOptions) (*otlptrace.Exporter, error) {
+	exporterOptions := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(options.Endpoint.Host),
+		otlptracehttp.WithURLPath(options.Endpoint.Path),
+		otlptracehttp.WithTimeout(options.Timeout),
+	}
+	if options.Endpoint.Scheme == "http" {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, exporterOptions...)
+}
+
+type TracerProviderOptions struct {
+	ServiceName string
+	// Ratio of sampled root spans, child spans follow the decision of their parent.
+	SampleRatio float64
+	// Maximum number of spans sent in a single request.
+	BatchSize int
+	// Spans are dropped if this many of them wait for export.
+	QueueSize     int
+	FlushInterval time.Duration
+	Timeout       time.Duration
+}
+
+// NewTracerProvider creates a provider exporting sampled spans in batches. If exporter is nil,
+// spans are not exported but the trace context is still propagated. Errors of OpenTelemetry,
+// e.g. failed exports, are logged with log.
+func NewTracerProvider(
+	log logging.Logger,
+	exporter sdktrace.SpanExporter,
+	options TracerProviderOptions,
+) *sdktrace.TracerProvider {
+	if log == nil {
+		panic(e.NewNilArgumentError("log"))
+	}
+	if options.SampleRatio < 0 || options.SampleRatio > 1 {
+		panic("sample ratio must be in [0, 1]")
+	}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Error(context.Background(), "OpenTelemetry error.", logging.Entry("err", err))
+	}))
+
+	providerOptions := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(options.ServiceName),
+		)),
+	}
+	if exporter != nil {
+		if options.BatchSize <= 0 {
+			panic("batch size must be positive")
+		}
+		if options.QueueSize < options.BatchSize {
+			panic("queue size must not be less than batch size")
+		}
+		if options.FlushInterval <= 0 {
+			panic("flush interval must be positive")
+		}
+		providerOptions = append(providerOptions, sdktrace.WithBatcher(
+			exporter,
+			sdktrace.WithMaxExportBatchSize(options.BatchSize),
+			sdktrace.WithMaxQueueSize(options.QueueSize),
+			sdktrace.WithBatchTimeout(options.FlushInterval),
+			sdktrace.WithExportTimeout(options.Timeout),
+		))
+	}
+	return sdktrace.NewTracerProvider(providerOptions...)
+}
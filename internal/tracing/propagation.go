@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// propagator carries W3C trace context (https://www.w3.org/TR/trace-context/) and baggage.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Inject writes the trace context of ctx to carrier, nothing is written if ctx has no span.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	propagator.Inject(ctx, carrier)
+}
+
+// Extract returns a context carrying the remote trace context read from carrier.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return propagator.Extract(ctx, carrier)
+}
+
+func InjectHTTP(ctx context.Context, header http.Header) {
+	Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,113 @@
+// Package tracing adapts OpenTelemetry to the tracing interfaces of the domain:
+// spans are created by an OpenTelemetry tracer provider, the trace context is propagated
+// with the standard propagators and finished spans are exported over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	dt "remindme/internal/core/domain/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "remindme"
+
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a tracer of the provider, use trace.NewNoopTracerProvider
+// if spans must be neither recorded nor propagated.
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		panic("provider must not be nil")
+	}
+	return &Tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+func (t *Tracer) Start(ctx context.Context, name string, attributes ...dt.SpanAttribute) (context.Context, dt.Span) {
+	return t.StartSpan(ctx, name, trace.SpanKindInternal, attributes...)
+}
+
+func (t *Tracer) StartSpan(
+	ctx context.Context,
+	name string,
+	kind trace.SpanKind,
+	attributes ...dt.SpanAttribute,
+) (context.Context, *Span) {
+	ctx, span := t.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(convertAttributes(attributes)...),
+	)
+	return ctx, &Span{span: span}
+}
+
+// HasSpan reports if ctx carries a local or remote span.
+func HasSpan(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
+
+type Span struct {
+	span trace.Span
+}
+
+func (s *Span) SpanContext() trace.SpanContext {
+	return s.span.SpanContext()
+}
+
+func (s *Span) SetName(name string) {
+	s.span.SetName(name)
+}
+
+func (s *Span) SetAttributes(attributes ...dt.SpanAttribute) {
+	s.span.SetAttributes(convertAttributes(attributes)...)
+}
+
+// RecordError adds an exception event and marks the span as failed.
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *Span) End() {
+	s.span.End()
+}
+
+func convertAttributes(attributes []dt.SpanAttribute) []attribute.KeyValue {
+	converted := make([]attribute.KeyValue, 0, len(attributes))
+	for _, a := range attributes {
+		converted = append(converted, convertAttribute(a))
+	}
+	return converted
+}
+
+func convertAttribute(a dt.SpanAttribute) attribute.KeyValue {
+	switch v := a.Value.(type) {
+	case string:
+		return attribute.String(a.Key, v)
+	case bool:
+		return attribute.Bool(a.Key, v)
+	case int:
+		return attribute.Int(a.Key, v)
+	case int32:
+		return attribute.Int64(a.Key, int64(v))
+	case int64:
+		return attribute.Int64(a.Key, v)
+	case uint:
+		return attribute.Int64(a.Key, int64(v))
+	case float64:
+		return attribute.Float64(a.Key, v)
+	case fmt.Stringer:
+		return attribute.Stringer(a.Key, v)
+	default:
+		return attribute.String(a.Key, fmt.Sprintf("%v", v))
+	}
+}
@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	dt "remindme/internal/core/domain/tracing"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACEPARENT = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type testSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	tracer   *Tracer
+}
+
+func (suite *testSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	suite.tracer = suite.newTracer(1)
+}
+
+func (suite *testSuite) newTracer(sampleRatio float64) *Tracer {
+	return NewTracer(sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithSpanProcessor(suite.recorder),
+	))
+}
+
+func TestTracing(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) TestChildSpan() {
+	ctx, parent := s.tracer.StartSpan(context.Background(), "parent", trace.SpanKindServer)
+	_, child := s.tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 2)
+	childSpan, parentSpan := spans[0], spans[1]
+	s.Equal(parentSpan.SpanContext().TraceID(), childSpan.SpanContext().TraceID())
+	s.Equal(parentSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+	s.Equal(trace.SpanKindInternal, childSpan.SpanKind())
+	s.False(parentSpan.Parent().IsValid())
+}
+
+func (s *testSuite) TestNotSampled() {
+	tracer := s.newTracer(0)
+
+	ctx, span := tracer.StartSpan(context.Background(), "parent", trace.SpanKindServer)
+	span.End()
+
+	s.Empty(s.recorder.Ended())
+	s.True(HasSpan(ctx))
+	s.False(span.SpanContext().IsSampled())
+}
+
+func (s *testSuite) TestRemoteParentDecidesSampling() {
+	tracer := s.newTracer(0)
+	ctx := Extract(context.Background(), propagation.MapCarrier{"traceparent": TRACEPARENT})
+
+	_, span := tracer.StartSpan(ctx, "consume", trace.SpanKindConsumer)
+	span.End()
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	s.Equal("00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func (s *testSuite) TestInject() {
+	ctx, span := s.tracer.StartSpan(context.Background(), "span", trace.SpanKindProducer)
+	carrier := propagation.MapCarrier{}
+	Inject(ctx, carrier)
+	span.End()
+
+	sc := span.SpanContext()
+	s.Equal(fmt.Sprintf("00-%s-%s-01", sc.TraceID(), sc.SpanID()), carrier.Get("traceparent"))
+
+	carrier = propagation.MapCarrier{}
+	Inject(context.Background(), carrier)
+	s.Empty(carrier.Keys())
+}
+
+func (s *testSuite) TestAttributesAndError() {
+	_, span := s.tracer.Start(
+		context.Background(),
+		"span",
+		dt.Attribute("name", "value"),
+		dt.Attribute("count", 3),
+		dt.Attribute("id", int64(4)),
+	)
+	span.RecordError(errors.New("an error occured"))
+	span.End()
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(
+		[]attribute.KeyValue{attribute.String("name", "value"), attribute.Int("count", 3), attribute.Int64("id", 4)},
+		spans[0].Attributes(),
+	)
+	s.Equal(sdktrace.Status{Code: codes.Error, Description: "an error occured"}, spans[0].Status())
+	s.Require().Len(spans[0].Events(), 1)
+	s.Equal("exception", spans[0].Events()[0].Name)
+}
+
+func (s *testSuite) TestTransport() {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		rw.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+	client := http.Client{Transport: NewTransport(s.tracer, nil)}
+	ctx, parent := s.tracer.StartSpan(context.Background(), "parent", trace.SpanKindInternal)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/bot-secret/send", nil)
+	s.Require().Nil(err)
+	response, err := client.Do(request)
+	s.Require().Nil(err)
+	response.Body.Close()
+	parent.End()
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 2)
+	clientSpan := spans[0]
+	sc := clientSpan.SpanContext()
+	s.Equal(fmt.Sprintf("00-%s-%s-01", sc.TraceID(), sc.SpanID()), traceparent)
+	s.Equal(parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+	s.Equal("HTTP POST", clientSpan.Name())
+	s.Equal(trace.SpanKindClient, clientSpan.SpanKind())
+	s.Contains(clientSpan.Attributes(), attribute.Int("http.response.status_code", http.StatusTeapot))
+	for _, attribute := range clientSpan.Attributes() {
+		s.NotContains(attribute.Value.Emit(), "secret")
+	}
+}